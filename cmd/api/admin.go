@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// runAdmin выполняет подкоманду admin: bookapi admin [flags] EMAIL [--revoke].
+// Через API права администратора выдаёт только администратор, поэтому
+// первого назначают из командной строки.
+func runAdmin(ctx context.Context, repos storage.Repositories, args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("missing reader email\n\n" + usage)
+	}
+	email, args := args[0], args[1:]
+
+	fs := flag.NewFlagSet("bookapi admin", flag.ContinueOnError)
+	revoke := fs.Bool("revoke", false, "снять права администратора вместо выдачи")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	reader, err := repos.Readers.GetReaderByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("reader %s: %w", email, err)
+	}
+	reader.Admin = !*revoke
+	if err := repos.Readers.Update(ctx, reader); err != nil {
+		return err
+	}
+	state := "granted to"
+	if *revoke {
+		state = "revoked from"
+	}
+	fmt.Fprintf(os.Stdout, "administrator rights %s reader %d (%s)\n", state, reader.ID, reader.Email)
+	return nil
+}
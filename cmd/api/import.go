@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+	"github.com/0sokrat0/BookAPI/internal/service/catalog"
+	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+)
+
+// runImport выполняет подкоманду import: bookapi import [flags] FILE [--format F]
+// [--batch-size N] [--offset N]. Вместо FILE можно передать "-" для stdin.
+func runImport(ctx context.Context, repos storage.Repositories, counter *genid.IDcounter, args []string) error {
+	if len(args) == 0 || args[0] == "" {
+		return errors.New("missing import file\n\n" + usage)
+	}
+	path, args := args[0], args[1:]
+
+	opts := catalog.ImportOptions{Format: catalog.FormatFromName(path)}
+	fs := flag.NewFlagSet("bookapi import", flag.ContinueOnError)
+	fs.StringVar(&opts.Format, "format", opts.Format, "формат файла: csv, jsonl, marc или marcxml (по умолчанию по расширению)")
+	fs.IntVar(&opts.BatchSize, "batch-size", 0, "строк в одной транзакции; 0 — весь файл в одной транзакции")
+	fs.IntVar(&opts.Offset, "offset", 0, "пропустить первые N строк данных (resume_offset прерванного импорта)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	report, err := catalog.NewCatalogService(repos.CatalogTx(), counter, nil).ImportBooks(ctx, in, opts)
+	if report != nil {
+		printImportReport(report)
+	}
+	if err != nil {
+		return err
+	}
+	if !report.Committed {
+		return errors.New(report.Error)
+	}
+	return nil
+}
+
+func printImportReport(report *catalog.ImportReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROW\tSTATUS\tBOOK\tISBN\tTITLE\tREASON")
+	for _, row := range report.Rows {
+		book := "-"
+		if row.BookID != 0 {
+			book = fmt.Sprint(row.BookID)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", row.Row, row.Status, book, row.ISBN, row.Title, row.Reason)
+		if len(row.Unmapped) > 0 {
+			fmt.Fprintf(w, "\t\t\t\t\tunmapped: %s\n", strings.Join(row.Unmapped, ", "))
+		}
+	}
+	w.Flush()
+
+	fmt.Printf("\ncreated: %d, updated: %d, skipped: %d, failed: %d\n",
+		report.Created, report.Updated, report.Skipped, report.Failed)
+	if report.Committed {
+		fmt.Println("committed")
+	} else {
+		fmt.Printf("not committed; resume with --offset %d\n", report.ResumeOffset)
+	}
+}
@@ -2,45 +2,170 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 
 	"github.com/0sokrat0/BookAPI/internal/application/http"
 	"github.com/0sokrat0/BookAPI/internal/config"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
 	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
 	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/0sokrat0/BookAPI/pkg/db/sqlite"
 	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
 
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 )
 
 var idCounter = genid.NewCounter(0)
 
+// idSeeder — хранилище, по которому счётчик ID восстанавливается после
+// перезапуска.
+type idSeeder interface {
+	MaxID(ctx context.Context) (int64, error)
+}
+
 // @title Book API
 // @version 1.0
 // @host 62.113.37.155:8080
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	cfg := config.GetConfig()
-	if cfg == nil {
-		panic("Configuration is nil")
+	// Первый аргумент без дефиса — подкоманда; по умолчанию запускается сервер.
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+	if command != "serve" && command != "migrate" && command != "import" && command != "admin" {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+
+	cfg, opts, err := config.Load("bookapi "+command, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Ошибка загрузки конфигурации:\n%v\n", err)
+		os.Exit(2)
+	}
+	if opts.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "print config: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
 	lg := logger.NewLogger(cfg)
 	defer lg.Sync()
 	ctx = logger.WithLogger(ctx, lg)
 
-	pool, err := postgres.NewPG(ctx, cfg)
-	if err != nil {
-		lg.Fatalf("Error connecting to PostgreSQL: %v", err)
+	if cfg.Tracing.Enabled {
+		shutdownTracing, err := tracing.Init(ctx, tracing.Options{
+			ServiceName: cfg.Tracing.ServiceName,
+			Environment: cfg.App.Env,
+			Exporter:    cfg.Tracing.Exporter,
+			Endpoint:    cfg.Tracing.Endpoint,
+			Insecure:    cfg.Tracing.Insecure,
+			SampleRatio: cfg.Tracing.SampleRatio,
+		})
+		if err != nil {
+			lg.Fatalf("Error initializing tracing: %v", err)
+		}
+		defer func() {
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(flushCtx); err != nil {
+				lg.Errorf("Error flushing traces: %v", err)
+			}
+		}()
+	}
+
+	if command != "serve" && cfg.Storage != config.StorageDatabase {
+		lg.Fatalf("%s: storage %q is not persistent", command, cfg.Storage)
+	}
+	if command == "serve" && len(opts.Args) > 0 {
+		lg.Fatalf("unexpected arguments: %v", opts.Args)
 	}
-	defer pool.Close()
 
-	server := http.NewServer(ctx, cfg, pool, idCounter)
+	var (
+		db    http.Database
+		sqlDB migratable
+		repos storage.Repositories
+	)
+	switch {
+	case cfg.Storage == config.StorageMemory:
+		lg.Warn("Используется хранилище в памяти: данные будут потеряны при перезапуске")
+		repos = storage.NewMemory()
+	case cfg.Database.Driver == config.DriverSQLite:
+		lite, err := sqlite.New(ctx, cfg)
+		if err != nil {
+			lg.Fatalf("Error opening SQLite: %v", err)
+		}
+		defer lite.Close()
+		db, sqlDB, repos = lite, lite, storage.NewSQLite(lite.DB)
+	default:
+		pool, err := postgres.NewPG(ctx, cfg)
+		if err != nil {
+			lg.Fatalf("Error connecting to PostgreSQL: %v", err)
+		}
+		defer pool.Close()
+		db, sqlDB, repos = pool, pool, storage.NewPostgres(pool.DB)
+	}
+
+	if command == "migrate" {
+		if err := runMigrate(ctx, sqlDB, opts.Args); err != nil {
+			lg.Errorf("migrate: %v", err)
+			lg.Sync()
+			os.Exit(1)
+		}
+		return
+	}
+	if sqlDB != nil && cfg.Database.AutoMigrate {
+		if err := migrateUp(ctx, sqlDB); err != nil {
+			lg.Fatalf("Error applying migrations: %v", err)
+		}
+	}
+
+	if seeder, ok := db.(idSeeder); ok {
+		maxID, err := seeder.MaxID(ctx)
+		if err != nil {
+			lg.Warnf("Не удалось прочитать наибольший ID: %v", err)
+		}
+		idCounter.Advance(maxID)
+	}
+
+	if command == "import" {
+		if err := runImport(ctx, repos, idCounter, opts.Args); err != nil {
+			lg.Errorf("import: %v", err)
+			lg.Sync()
+			os.Exit(1)
+		}
+		return
+	}
+
+	if command == "admin" {
+		if err := runAdmin(ctx, repos, opts.Args); err != nil {
+			lg.Errorf("admin: %v", err)
+			lg.Sync()
+			os.Exit(1)
+		}
+		return
+	}
+
+	server := http.NewServer(ctx, cfg, repos, db, idCounter)
+	server.RunWorkers(ctx)
 
 	go func() {
 		if err := server.Start(); err != nil {
@@ -56,8 +181,13 @@ func gracefulShutdown(ctx context.Context, server *http.Server, lg *logger.Logge
 
 	lg.Info("Сигнал завершения получен, начинается graceful shutdown...")
 
-	// Используем ctx, который содержит логгер, вместо context.Background()
-	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	// Сначала /readyz начинает отвечать 503, и только после паузы сервер
+	// перестаёт принимать соединения — оркестратор успевает снять трафик.
+	server.Drain()
+	time.Sleep(server.Config.App.DrainDelay)
+
+	// ctx уже отменён сигналом: сохраняем его значения (логгер), но не отмену.
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), server.Config.App.ShutdownTimeout)
 	defer cancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
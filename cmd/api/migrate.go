@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/0sokrat0/BookAPI/pkg/db/dbmigrate"
+)
+
+const usage = `Usage:
+  bookapi [flags]                         запустить сервер
+  bookapi migrate [flags] up              применить все миграции
+  bookapi migrate [flags] down N          откатить N последних миграций
+  bookapi migrate [flags] status          показать версию схемы и список миграций
+  bookapi migrate [flags] goto V          перейти на версию V
+  bookapi migrate [flags] force V         записать версию V без выполнения миграций
+  bookapi import [flags] FILE [--format csv|jsonl|marc|marcxml] [--batch-size N] [--offset N]
+                                          импортировать книги из CSV, JSON Lines или MARC 21
+  bookapi admin [flags] EMAIL [--revoke]  выдать читателю права администратора или снять их
+
+Флаги конфигурации: bookapi -h
+`
+
+// migratable — SQL-хранилище со встроенными миграциями.
+type migratable interface {
+	NewMigrator(ctx context.Context) (*dbmigrate.Migrator, error)
+}
+
+// migrateUp применяет миграции при старте сервера.
+func migrateUp(ctx context.Context, db migratable) error {
+	migrator, err := db.NewMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+	return migrator.Up(ctx)
+}
+
+// runMigrate выполняет подкоманду migrate.
+func runMigrate(ctx context.Context, db migratable, args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing migrate operation\n\n" + usage)
+	}
+	op, args := args[0], args[1:]
+
+	wantArgs := 0
+	switch op {
+	case "up", "status":
+	case "down", "goto", "force":
+		wantArgs = 1
+	default:
+		return fmt.Errorf("unknown migrate operation %q\n\n%s", op, usage)
+	}
+	if len(args) != wantArgs {
+		return fmt.Errorf("migrate %s expects %d argument(s), got %d", op, wantArgs, len(args))
+	}
+
+	migrator, err := db.NewMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch op {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("down: invalid number of steps %q", args[0])
+		}
+		return migrator.Down(ctx, n)
+	case "goto":
+		v, err := strconv.ParseUint(args[0], 10, 0)
+		if err != nil {
+			return fmt.Errorf("goto: invalid version %q", args[0])
+		}
+		return migrator.Goto(ctx, uint(v))
+	case "force":
+		v, err := strconv.Atoi(args[0])
+		if err != nil || v < -1 {
+			return fmt.Errorf("force: invalid version %q", args[0])
+		}
+		return migrator.Force(ctx, v)
+	default:
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(status)
+		return nil
+	}
+}
+
+func printStatus(status *dbmigrate.Status) {
+	fmt.Printf("current: %d", status.Current)
+	if status.Dirty {
+		fmt.Print(" (dirty)")
+	}
+	fmt.Printf("\nlatest:  %d\n\n", status.Latest)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE")
+	for _, m := range status.Migrations {
+		state := "pending"
+		if m.Applied {
+			state = "applied"
+		}
+		if status.Dirty && m.Version == status.Current {
+			state = "dirty"
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\n", m.Version, m.Identifier, state)
+	}
+	w.Flush()
+}
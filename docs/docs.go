@@ -15,9 +15,89 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "Сверяет state с cookie браузера, начавшего вход, обменивает код авторизации на токены, проверяет ID token и сопоставляет subject/email с читателем (создавая его при первом входе). Дальше действуют те же правила второго фактора, что и при входе по паролю: читатель с включённым TOTP получает challenge для POST /login/2fa.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "OIDC callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Код авторизации",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State из запроса авторизации",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешная аутентификация или требуется второй фактор",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Провайдер отклонил вход, state не совпал с cookie или токен не прошёл проверку",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Читатель не может быть сопоставлен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Перенаправляет на страницу авторизации провайдера OpenID Connect (authorization code flow с PKCE). Параметры входа сохраняются в подписанной cookie bookapi_oidc_state, которую проверяет callback.",
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Start OIDC login",
+                "responses": {
+                    "302": {
+                        "description": "Перенаправление на IdP"
+                    },
+                    "503": {
+                        "description": "Провайдер недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/author": {
             "post": {
-                "description": "Создаёт нового автора с указанными данными. Принимает JSON-представление автора и возвращает созданную запись.",
+                "description": "Создаёт нового автора с указанными данными. Принимает JSON-представление автора и возвращает созданную запись. aliases — другие написания имени (транслитерации, инициалы, псевдонимы), по ним автор находится в поиске и при импорте. Даты жизни — в формате YYYY-MM-DD.",
                 "consumes": [
                     "application/json"
                 ],
@@ -48,7 +128,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос",
+                        "description": "Неверный запрос или дата",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -116,7 +196,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Обновляет данные существующего автора по его уникальному идентификатору.",
+                "description": "Обновляет данные существующего автора по его уникальному идентификатору. Пустое name оставляет прежнее имя; страна, синонимы, даты жизни и биография заменяются.",
                 "consumes": [
                     "application/json"
                 ],
@@ -154,7 +234,16 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос или ID",
+                        "description": "Неверный запрос, ID или дата",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Автор не найден",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -222,9 +311,91 @@ const docTemplate = `{
                 }
             }
         },
+        "/author/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сливает авторов source_ids в автора из пути атомарно: их участие в книгах переносится на него (с сохранением ролей и порядка), их имена и синонимы становятся его синонимами, незаполненные страна, даты жизни и биография берутся у дубликатов, сами дубликаты удаляются. Только для администраторов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authors"
+                ],
+                "summary": "Merge duplicate authors",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID основного автора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Сливаемые дубликаты. Пример: {\\",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_authors.MergeAuthorsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Автор с новыми синонимами",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_authors.Author"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или список авторов",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Автор не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/authors": {
             "get": {
-                "description": "Возвращает список всех авторов, зарегистрированных в системе.",
+                "description": "Возвращает список всех авторов, зарегистрированных в системе. С параметром q — только авторов, в имени или синониме которых есть q (без учёта регистра, точек и «ё»).",
                 "produces": [
                     "application/json"
                 ],
@@ -232,6 +403,14 @@ const docTemplate = `{
                     "authors"
                 ],
                 "summary": "List all authors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Часть имени или синонима, например \\",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Массив объектов авторов",
@@ -257,7 +436,7 @@ const docTemplate = `{
         },
         "/book": {
             "post": {
-                "description": "Создаёт новую книгу в системе. Принимает данные книги в формате JSON и возвращает созданную запись.",
+                "description": "Создаёт новую книгу в системе. Принимает данные книги в формате JSON и возвращает созданную запись. Книга — издание произведения work_id; без work_id она становится единственным изданием нового произведения с тем же названием. publisher_id должен ссылаться на существующее издательство (0 — не указано). contributors задаёт участников с ролями (author, translator, editor, illustrator) в нужном порядке; author_ids — краткая запись для авторов, они идут первыми.",
                 "consumes": [
                     "application/json"
                 ],
@@ -287,7 +466,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверный формат запроса или отсутствуют обязательные поля",
+                        "description": "Неверный формат запроса, отсутствуют обязательные поля, неизвестное произведение либо издательство или неверная роль участника",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
@@ -301,9 +480,96 @@ const docTemplate = `{
                 }
             }
         },
+        "/book/from-isbn": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ищет метаданные книги по ISBN во внешнем каталоге (API Open Library) и возвращает черновик книги: название, год, ID авторов и ID жанров каталога, совпавших с рубриками (новые жанры не создаются). Недостающие авторы создаются и перечислены в created_author_ids. Сама книга не создаётся: после проверки её сохраняют через POST /book. Если книга с этим ISBN уже есть, её ID возвращается в existing_book_id. Ответы внешнего каталога кешируются. Только для администраторов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Prefill a book from its ISBN",
+                "parameters": [
+                    {
+                        "description": "ISBN-10 или ISBN-13, дефисы допускаются",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_catalog.BookFromISBNRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Черновик книги",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_catalog.BookDraft"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ISBN",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Внешний каталог не знает такого ISBN",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Внешний каталог недоступен или ответил ошибкой",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Поиск по ISBN выключен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/book/{id}": {
             "get": {
-                "description": "Возвращает данные книги по её уникальному идентификатору.",
+                "description": "Возвращает данные книги по её уникальному идентификатору. Rating — средняя оценка и число одобренных отзывов читателей.",
                 "produces": [
                     "application/json"
                 ],
@@ -342,7 +608,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Обновляет данные книги по её уникальному идентификатору. Принимает новые данные книги в формате JSON.",
+                "description": "Обновляет данные книги по её уникальному идентификатору. Принимает новые данные книги в формате JSON. work_id переносит книгу в другое произведение, 0 оставляет прежнее. author_ids и contributors заменяют список участников целиком.",
                 "consumes": [
                     "application/json"
                 ],
@@ -379,7 +645,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос или ID",
+                        "description": "Неверный запрос, ID, неизвестное произведение либо издательство или неверная роль участника",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
@@ -432,143 +698,198 @@ const docTemplate = `{
                 }
             }
         },
-        "/books": {
+        "/book/{id}/cover": {
             "get": {
-                "description": "Возвращает список всех книг, хранящихся в системе. Если указан параметр \"author\", возвращаются книги только этого автора. Дополнительно можно задать параметры сортировки: \"sort\" (поле сортировки) и \"order\" (asc или desc).",
+                "description": "Отдаёт изображение обложки. С параметром width — наименьшую миниатюру не уже width (или оригинал, если такой нет). Ответ кешируется; ссылки из данных книги содержат версию обложки.",
                 "produces": [
-                    "application/json"
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/webp"
                 ],
                 "tags": [
                     "books"
                 ],
-                "summary": "List all books",
+                "summary": "Get a book cover image",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID автора для фильтрации (например, 5)",
-                        "name": "author",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Поле для сортировки (например, 'title', 'year')",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "Уникальный ID книги",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Порядок сортировки: 'asc' или 'desc' (по умолчанию: asc)",
-                        "name": "order",
+                        "type": "integer",
+                        "description": "Желаемая ширина миниатюры",
+                        "name": "width",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Массив книг",
+                        "description": "Изображение",
                         "schema": {
-                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                            "type": "file"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
+                    "304": {
+                        "description": "Не изменилось (If-None-Match)"
+                    },
+                    "400": {
+                        "description": "Неверный ID или ширина",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Обложки нет",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Аутентифицирует пользователя по email и паролю. При неверном пароле возвращает ошибку Unauthorized.",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет обложку книги. Файл передаётся в поле file формы multipart/form-data. Тип определяется по содержимому: JPEG, PNG, GIF или WebP. Помимо оригинала сохраняются JPEG-миниатюры настроенных ширин (не шире оригинала). Только для администраторов.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "readers"
+                    "books"
                 ],
-                "summary": "Authenticate reader",
+                "summary": "Upload a book cover",
                 "parameters": [
                     {
-                        "description": "Данные для аутентификации. Пример: {\\",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_application_http_handlers_readers.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Уникальный ID книги",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Изображение обложки",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешная аутентификация: данные пользователя",
+                        "description": "Обложка со ссылками на оригинал и миниатюры",
                         "schema": {
-                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.Cover"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Неверный формат запроса",
+                        "description": "Неверный ID, нет файла или файл не является изображением",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Неверный пароль или пользователь не найден",
+                        "description": "Требуется аутентификация",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Книга не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл или изображение слишком большие",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Неподдерживаемый тип изображения",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/reader": {
-            "post": {
-                "description": "Создаёт нового читателя с предоставленными данными.",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Удаляет обложку книги вместе с миниатюрами. Только для администраторов.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "readers"
+                    "books"
                 ],
-                "summary": "Create a new reader",
+                "summary": "Delete a book cover",
                 "parameters": [
                     {
-                        "description": "Параметры для создания читателя. Пример: {\\",
-                        "name": "reader",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_application_http_handlers_readers.CreateReaderRequest"
-                        }
+                        "type": "integer",
+                        "description": "Уникальный ID книги",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Созданный читатель с уникальным ID",
+                        "description": "Обложка удалена",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос",
+                        "description": "Неверный ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Обложки нет",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
@@ -576,48 +897,74 @@ const docTemplate = `{
                 }
             }
         },
-        "/reader/{id}": {
+        "/book/{id}/reviews": {
             "get": {
-                "description": "Возвращает данные читателя по его уникальному идентификатору.",
+                "description": "Возвращает одобренные отзывы о книге. sort=recent (по умолчанию) — сначала новые, sort=helpful — сначала отмеченные полезными большим числом читателей.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "readers"
+                    "reviews"
                 ],
-                "summary": "Get a reader by ID",
+                "summary": "List reviews of a book",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Уникальный ID читателя",
+                        "description": "Уникальный ID книги",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: recent или helpful",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные читателя",
+                        "description": "Отзывы",
                         "schema": {
-                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Review"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Неверный ID",
+                        "description": "Неверный ID или порядок",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Читатель не найден",
+                        "description": "Книга не найдена",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Обновляет данные существующего читателя.",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет оценку книги от 1 до 5 и необязательный текст от имени читателя сессии. Оставить отзыв можно только о книге, бронирование которой у читателя уже закончилось, и только один. Оценка без текста публикуется сразу, отзыв с текстом ждёт модерации (status pending).",
                 "consumes": [
                     "application/json"
                 ],
@@ -625,104 +972,137 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "readers"
+                    "reviews"
                 ],
-                "summary": "Update a reader",
+                "summary": "Review a book",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Уникальный ID читателя",
+                        "description": "Уникальный ID книги",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Новые данные читателя. Пример: {\\",
-                        "name": "reader",
+                        "description": "Оценка и текст. Пример: {\\",
+                        "name": "review",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_application_http_handlers_readers.UpdateReaderRequest"
+                            "$ref": "#/definitions/internal_application_http_handlers_reviews.CreateReviewRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновлённые данные читателя",
+                        "description": "Созданный отзыв",
                         "schema": {
-                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Review"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос",
+                        "description": "Неверный ID, оценка или слишком длинный текст",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет завершённого бронирования книги",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Книга не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Читатель уже оставил отзыв о книге",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет читателя по его уникальному идентификатору.",
+            }
+        },
+        "/book/{id}/similar": {
+            "get": {
+                "description": "«Читатели, бравшие эту книгу, брали и эти»: книги по убыванию косинусной близости множеств их читателей (score от 0 до 1), readers — число общих читателей. Соседи пересчитываются фоновой задачей по всей истории бронирований, поэтому новые бронирования учитываются не сразу.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "readers"
+                    "recommendations"
                 ],
-                "summary": "Delete a reader",
+                "summary": "Books borrowed together with this one",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Уникальный ID читателя",
+                        "description": "Уникальный ID книги",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько книг вернуть, от 1 до 50; по умолчанию 10",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Читатель успешно удалён",
+                        "description": "Похожие книги",
                         "schema": {
-                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_recommendations.Suggestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Неверный ID",
+                        "description": "Неверный ID или limit",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера",
+                    "404": {
+                        "description": "Книга не найдена",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/readers": {
-            "get": {
-                "description": "Возвращает список всех читателей.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "readers"
-                ],
-                "summary": "List all readers",
-                "responses": {
-                    "200": {
-                        "description": "Список читателей",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
-                        }
                     },
                     "500": {
                         "description": "Ошибка сервера",
@@ -733,45 +1113,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservation": {
-            "put": {
-                "description": "Обновляет данные существующего бронирования.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/book/{id}/tags": {
+            "get": {
+                "description": "Возвращает теги книги по имени.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "tags"
                 ],
-                "summary": "Update reservation",
+                "summary": "List tags of a book",
                 "parameters": [
                     {
-                        "description": "Reservation update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_application_http_handlers_reservations.UpdateReservationRequestDTO"
-                        }
+                        "type": "integer",
+                        "description": "Уникальный ID книги",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Бронирование обновлено успешно",
+                        "description": "Теги книги",
                         "schema": {
-                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_tags.Tag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Неверный ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Книга не найдена",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
@@ -779,7 +1169,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Создаёт новое бронирование в системе.",
+                "description": "Ставит книге теги по именам. Имена нормализуются: ведущий «#» отбрасывается, регистр и повторные пробелы не различаются. Синоним заменяется основным тегом, недостающие теги создаются. Возвращает все теги книги.",
                 "consumes": [
                     "application/json"
                 ],
@@ -787,35 +1177,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "tags"
                 ],
-                "summary": "Create a new reservation",
+                "summary": "Tag a book",
                 "parameters": [
                     {
-                        "description": "Reservation creation request",
-                        "name": "request",
+                        "type": "integer",
+                        "description": "Уникальный ID книги",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Теги. Пример: {\\",
+                        "name": "tags",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_application_http_handlers_reservations.CreateReservationRequestDTO"
+                            "$ref": "#/definitions/internal_application_http_handlers_tags.TagBookRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Бронирование создано успешно",
+                        "description": "Теги книги",
                         "schema": {
-                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_tags.Tag"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Неверный ID или имя тега",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Книга не найдена",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
@@ -823,79 +1241,286 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservation/{id}": {
-            "get": {
-                "description": "Возвращает бронирование по его уникальному идентификатору.",
+        "/book/{id}/tags/{tagID}": {
+            "delete": {
+                "description": "Снимает тег с книги. Если тега у книги нет, запрос всё равно успешен.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "tags"
                 ],
-                "summary": "Get reservation by ID",
+                "summary": "Remove a tag from a book",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Reservation ID",
+                        "description": "Уникальный ID книги",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID тега",
+                        "name": "tagID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные бронирования",
+                        "description": "Тег снят",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid reservation ID",
+                        "description": "Неверный ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not found",
+                        "description": "Книга не найдена",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет бронирование по его идентификатору.",
+            }
+        },
+        "/books": {
+            "get": {
+                "description": "Возвращает список всех книг, хранящихся в системе. Если указан параметр \"author\", возвращаются книги только этого автора (с \"role\" — только те, где он участвует в этой роли), \"work\" — только издания этого произведения, \"publisher\" — только книги этого издательства; фильтры сочетаются. Дополнительно можно задать параметры сортировки: \"sort\" (поле сортировки) и \"order\" (asc или desc).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "books"
                 ],
-                "summary": "Delete reservation",
+                "summary": "List all books",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Reservation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ID автора для фильтрации (например, 5)",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Роль автора из параметра author: author, translator, editor или illustrator",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID произведения для фильтрации",
+                        "name": "work",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID издательства для фильтрации",
+                        "name": "publisher",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поле для сортировки (например, 'title', 'year')",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок сортировки: 'asc' или 'desc' (по умолчанию: asc)",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Бронирование удалено успешно",
+                        "description": "Массив книг",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid ID",
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Массовый импорт книг из CSV (колонки title, year, isbn, publisher, language, translator, genre или genres, authors; авторы и жанры через \";\"), JSON Lines (объекты {\"title\",\"year\",\"isbn\",\"publisher\",\"language\",\"translator\",\"genres\":[...],\"authors\":[...]}; поле genre со строкой тоже принимается) или MARC 21 (ISO 2709 и MARCXML: 245 — название, 020 — ISBN, 100/700 — авторы, 264/260 $c — год, 650 — жанры; поля, не перенесённые в книгу, перечислены в unmapped строки отчёта). Книги сопоставляются по ISBN и обновляются; новая книга становится единственным изданием нового произведения. Издательство находится по названию без учёта регистра или создаётся. Авторы и жанры находятся по имени (жанры — также по локализованным названиям) или создаются; новые жанры создаются верхнего уровня. Без batch_size импорт выполняется в одной транзакции и откатывается целиком, если хоть одна строка не прошла (422). С batch_size каждый пакет сохраняется отдельно; после сбоя импорт продолжают, передав resume_offset из отчёта в offset. Только для администраторов.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/marc",
+                    "application/marcxml+xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Import books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Формат: csv, jsonl, marc или marcxml (по умолчанию по Content-Type)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Строк в одной транзакции; 0 — весь импорт в одной транзакции",
+                        "name": "batch_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Пропустить первые N строк данных",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт об импорте",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_catalog.ImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат или параметры",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
+                    "422": {
+                        "description": "Импорт откатан из-за ошибочных строк",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_catalog.ImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Импорт остановлен ошибкой хранилища",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_catalog.ImportReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/export/authors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выгружает авторов потоком в CSV или NDJSON. Колонки: id, name, country, aliases (через «;» в CSV), birth_date, death_date, bio. Только для администраторов.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Export authors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Формат: csv (по умолчанию) или ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Колонки через запятую в нужном порядке (по умолчанию все)",
+                        "name": "columns",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл выгрузки",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат или колонка",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
@@ -903,103 +1528,4348 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations": {
+        "/export/books": {
             "get": {
-                "description": "Возвращает список бронирований в указанном диапазоне дат.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выгружает книги потоком в CSV, NDJSON или MARC 21 (marc — ISO 2709, marcxml — MARCXML). Колонки: id, title, year, isbn, work_id, publisher_id, language, translator, author_ids, contributors («автор:роль» в порядке книги), genre_ids (списки в CSV через \";\"); для MARC колонки не задаются, запись содержит 001 (ID), 020, 100/700, 245, 264 и 650. Фильтры author, role, work и publisher — как у списка книг. Только для администраторов.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/marc",
+                    "application/marcxml+xml"
                 ],
                 "tags": [
-                    "reservations"
+                    "export"
                 ],
-                "summary": "List reservations",
+                "summary": "Export books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Формат: csv (по умолчанию), ndjson, marc или marcxml",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Колонки через запятую в нужном порядке (по умолчанию все)",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID автора для фильтрации",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Роль автора из параметра author",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID произведения для фильтрации",
+                        "name": "work",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID издательства для фильтрации",
+                        "name": "publisher",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл выгрузки",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат, колонка или фильтр",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/readers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выгружает читателей потоком в CSV или NDJSON. Колонки: id, name, phone, email, admin, totp_enabled; пароли и секреты второго фактора не выгружаются. Только для администраторов.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Export readers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Формат: csv (по умолчанию) или ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Колонки через запятую в нужном порядке (по умолчанию все)",
+                        "name": "columns",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл выгрузки",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат или колонка",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/reservations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выгружает бронирования потоком в CSV или NDJSON. Колонки: id, book_id, reader_id, start_date, end_date, returned_date (пустая строка, пока книга не возвращена). Фильтр startDate/endDate — как у списка бронирований, но каждая граница необязательна. Только для администраторов.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Export reservations",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Формат: csv (по умолчанию) или ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Колонки через запятую в нужном порядке (по умолчанию все)",
+                        "name": "columns",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Start date (YYYY-MM-DD)",
                         "name": "startDate",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
                         "description": "End date (YYYY-MM-DD)",
                         "name": "endDate",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список бронирований",
+                        "description": "Файл выгрузки",
                         "schema": {
-                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Неверный формат, колонка или дата",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
                         "schema": {
                             "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_0sokrat0_BookAPI_pkg_response.BaseResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 200
-                },
-                "data": {},
-                "message": {
-                    "type": "string",
-                    "example": "Operation successful"
-                }
-            }
         },
-        "github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 400
-                },
-                "message": {
-                    "type": "string",
-                    "example": "Bad Request"
+        "/genre": {
+            "post": {
+                "description": "Создаёт жанр. parent_id — родительский жанр, 0 — жанр верхнего уровня. names — локализованные названия по коду языка. Названия сравниваются без учёта регистра и не должны совпадать с названиями других жанров.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Create a genre",
+                "parameters": [
+                    {
+                        "description": "Параметры жанра. Пример: {\\",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_genres.CreateGenreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный жанр",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_genres.Genre"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос, занятое название или неизвестный родитель",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/genre/{id}": {
+            "get": {
+                "description": "Возвращает жанр по его уникальному идентификатору.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Get a genre by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID жанра",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Жанр",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_genres.Genre"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Жанр не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет жанр. Пустое name оставляет прежнее название; parent_id и names заменяются. Жанр нельзя перенести в его собственное поддерево.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Update a genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID жанра",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные жанра. Пример: {\\",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_genres.UpdateGenreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённый жанр",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_genres.Genre"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос, ID, название или родитель",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Жанр не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет жанр. Жанр с дочерними жанрами или книгами удалить нельзя.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Delete a genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID жанра",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Жанр удалён",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Жанр не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "У жанра есть дочерние жанры или книги",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/genre/{id}/books": {
+            "get": {
+                "description": "Возвращает книги жанра и всех его дочерних жанров на любой глубине.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "List books of a genre",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID жанра",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Книги жанра",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Жанр не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres": {
+            "get": {
+                "description": "Возвращает все жанры по возрастанию ID; иерархия задаётся полем ParentID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "List genres",
+                "responses": {
+                    "200": {
+                        "description": "Список жанров",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_genres.Genre"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Сообщает, что процесс запущен и обрабатывает запросы. Зависимости не проверяются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Процесс жив",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Аутентифицирует пользователя по email и паролю. Если у читателя включена двухфакторная аутентификация, вместо токена возвращается challenge для шага /login/2fa. Администратор без второго фактора при AUTH_REQUIRE_ADMIN_2FA получает вместо сессии токен подключения второго фактора и two_factor_enrollment_required: его принимают только маршруты /reader/{id}/2fa, остальные считают запрос анонимным. При неверном пароле возвращает ошибку Unauthorized.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Authenticate reader",
+                "parameters": [
+                    {
+                        "description": "Данные для аутентификации. Пример: {\\",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readers.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешная аутентификация: данные пользователя и токен либо challenge второго фактора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный пароль или пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/2fa": {
+            "post": {
+                "description": "Второй шаг входа: принимает challenge, выданный /login, и TOTP-код либо код восстановления. Возвращает токен сессии.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Complete two-factor login",
+                "parameters": [
+                    {
+                        "description": "Challenge и код",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readers.TwoFactorLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешная аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный или просроченный challenge либо код",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publisher": {
+            "post": {
+                "description": "Создаёт издательство. Книги ссылаются на него полем publisher_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Create a new publisher",
+                "parameters": [
+                    {
+                        "description": "Параметры издательства. Пример: {\\",
+                        "name": "publisher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_publishers.CreatePublisherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданное издательство",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_publishers.Publisher"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или пустое название",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publisher/{id}": {
+            "get": {
+                "description": "Возвращает издательство по его уникальному идентификатору.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Get a publisher by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID издательства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Издательство",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_publishers.Publisher"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Издательство не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет издательство. Пустое name оставляет прежнее название; city и country заменяются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Update a publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID издательства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные издательства. Пример: {\\",
+                        "name": "publisher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_publishers.UpdatePublisherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённое издательство",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_publishers.Publisher"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Издательство не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет издательство. Издательство, у которого есть книги, удалить нельзя.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Delete a publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID издательства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Издательство удалено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Издательство не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "У издательства есть книги",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publisher/{id}/books": {
+            "get": {
+                "description": "Возвращает книги издательства по возрастанию ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "List books of a publisher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID издательства",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Книги издательства",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Издательство не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers": {
+            "get": {
+                "description": "Возвращает все издательства по возрастанию ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "List all publishers",
+                "responses": {
+                    "200": {
+                        "description": "Список издательств",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_publishers.Publisher"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reader": {
+            "post": {
+                "description": "Создаёт нового читателя с предоставленными данными. Флаг admin может выставить только администратор.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Create a new reader",
+                "parameters": [
+                    {
+                        "description": "Параметры для создания читателя. Пример: {\\",
+                        "name": "reader",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readers.CreateReaderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный читатель с уникальным ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Флаг admin без прав администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reader/{id}": {
+            "get": {
+                "description": "Возвращает данные читателя по его уникальному идентификатору.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Get a reader by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID читателя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные читателя",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Читатель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Обновляет данные существующего читателя. Флаг admin может выставить только администратор.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Update a reader",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID читателя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные читателя. Пример: {\\",
+                        "name": "reader",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readers.UpdateReaderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённые данные читателя",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Флаг admin без прав администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет читателя по его уникальному идентификатору.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Delete a reader",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID читателя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Читатель успешно удалён",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reader/{id}/2fa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Включает двухфакторную аутентификацию после проверки первого кода из приложения. Доступно только самому читателю.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Confirm TOTP enrollment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID читателя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Код из приложения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readers.TOTPConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Двухфакторная аутентификация включена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нет сессии, неверный код",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Другой читатель",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Нет незавершённой настройки",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reader/{id}/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отключает двухфакторную аутентификацию и удаляет коды восстановления. Требует пароль и действующий код. Доступно только самому читателю.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Disable TOTP",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID читателя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Пароль и код",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readers.TOTPVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Двухфакторная аутентификация отключена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нет сессии, неверный пароль или код",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Другой читатель",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Двухфакторная аутентификация не включена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reader/{id}/2fa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Генерирует секрет TOTP, otpauth:// URI для QR-кода и коды восстановления. Второй фактор включается только после подтверждения кодом. Доступно только самому читателю.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Start TOTP enrollment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID читателя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текущий пароль",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readers.TOTPEnrollRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Секрет, URI и коды восстановления",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нет сессии, неверный пароль",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Другой читатель",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Двухфакторная аутентификация уже включена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reader/{id}/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выпускает новый набор кодов восстановления; прежние коды перестают действовать. Доступно только самому читателю.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "Regenerate recovery codes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID читателя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Пароль и код",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readers.TOTPVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Новые коды восстановления",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нет сессии, неверный пароль или код",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Другой читатель",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Двухфакторная аутентификация не включена",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reader/{id}/recommendations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Книги, которые брали вместе с книгами из истории бронирований читателя, кроме тех, что он уже брал. score — сумма близостей к его книгам, because — ID книг читателя, по которым подобрана рекомендация. Свои рекомендации видит читатель сессии, чужие — только администратор.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recommendations"
+                ],
+                "summary": "Personal recommendations for a reader",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID читателя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько книг вернуть, от 1 до 50; по умолчанию 10",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рекомендации",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_recommendations.Suggestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или limit",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нет сессии",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Рекомендации другого читателя",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Читатель не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readers": {
+            "get": {
+                "description": "Возвращает список всех читателей.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "readers"
+                ],
+                "summary": "List all readers",
+                "responses": {
+                    "200": {
+                        "description": "Список читателей",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reading-list": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт пустой список книг читателя сессии. Публичный список сразу получает ссылку Slug для общего доступа.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "Create a reading list",
+                "parameters": [
+                    {
+                        "description": "Название и публичность",
+                        "name": "list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readinglists.CreateReadingListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный список",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_readinglists.ReadingList"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Пустое или слишком длинное название",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reading-list/shared/{slug}": {
+            "get": {
+                "description": "Возвращает публичный список по ссылке без аутентификации. После закрытия списка ссылка перестаёт работать.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "Get a shared reading list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ссылка списка",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список с книгами",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_readinglists.ListView"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Список не найден или закрыт",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reading-list/{id}": {
+            "get": {
+                "description": "Возвращает список с книгами в его порядке; Available — книга сегодня не забронирована. Приватный список видят только владелец и администраторы.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "Get a reading list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID списка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список с книгами",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_readinglists.ListView"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Список не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет название и публичность своего списка. Закрытие списка отзывает ссылку, повторное открытие выдаёт новую.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "Update a reading list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID списка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Название и публичность",
+                        "name": "list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readinglists.UpdateReadingListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённый список",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_readinglists.ReadingList"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или название",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Чужой список",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Список не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет список. Чужой список может удалить только администратор.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "Delete a reading list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID списка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список удалён",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Чужой список",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Список не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reading-list/{id}/books": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Задаёт новый порядок книг своего списка; book_ids должен содержать ровно книги списка.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "Reorder books of a reading list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID списка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Книги в новом порядке",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readinglists.ReorderBooksRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список с книгами",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_readinglists.ListView"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Порядок не совпадает с книгами списка",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Чужой список",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Список не найден или книги нет в списке",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет книгу в конец своего списка; в списке не больше 200 книг.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "Add a book to a reading list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID списка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Книга",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readinglists.AddBookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список с книгами",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_readinglists.ListView"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или список заполнен",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Чужой список",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Список или книга не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Книга уже в списке",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reading-list/{id}/books/{bookID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Убирает книгу из своего списка; порядок остальных сохраняется.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "Remove a book from a reading list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID списка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID книги",
+                        "name": "bookID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список с книгами",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_readinglists.ListView"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Чужой список",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Список не найден или книги нет в списке",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reading-list/{id}/reserve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Бронирует на владельца списка первую по порядку книгу, свободную на весь срок. Без дат срок — с сегодняшнего дня на 14 дней. Книга остаётся в списке.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "Reserve the first available book of a reading list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID списка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Срок бронирования",
+                        "name": "period",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_readinglists.ReserveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданное бронирование",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или даты, начало в прошлом",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Чужой список",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Список не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Все книги списка заняты",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reading-lists": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает списки читателя сессии по возрастанию ID с ID книг в их порядке.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reading-lists"
+                ],
+                "summary": "List own reading lists",
+                "responses": {
+                    "200": {
+                        "description": "Списки",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_readinglists.ReadingList"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Проверяет зависимости (база данных, версия миграций, фоновые задачи) и возвращает детали по каждой. Во время graceful shutdown отвечает 503.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Сервис готов принимать трафик",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Одна из проверок не прошла или сервер останавливается",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservation": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет данные существующего бронирования. Читатель меняет только своё бронирование и не может перенести начало в прошлое; уже начавшееся можно продлить, не меняя start_date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Update reservation",
+                "parameters": [
+                    {
+                        "description": "Reservation update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_reservations.UpdateReservationRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Бронирование обновлено успешно",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Бронирование другого читателя",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Бронирование не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт новое бронирование в системе. Вместо book_id можно передать work_id — тогда бронируется издание произведения с наименьшим ID, свободное на весь срок. Читатель бронирует только на себя и не раньше сегодняшнего дня; администратор может записать бронирование любого читателя, в том числе прошедшее.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Create a new reservation",
+                "parameters": [
+                    {
+                        "description": "Reservation creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_reservations.CreateReservationRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Бронирование создано успешно",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Бронирование на другого читателя",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Нет свободного издания произведения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservation/{id}": {
+            "get": {
+                "description": "Возвращает бронирование по его уникальному идентификатору.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get reservation by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные бронирования",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет бронирование по его идентификатору. Читатель удаляет только свои бронирования.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Delete reservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Бронирование удалено успешно",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Бронирование другого читателя",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Бронирование не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservation/{id}/return": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Записывает возврат книги по бронированию сегодняшним днём. Невозвращённые бронирования с истёкшим сроком считаются просроченными (метрика bookapi_loans_overdue).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Return reserved book",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Возврат записан",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Бронирование не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Возврат уже записан",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "get": {
+                "description": "Возвращает список бронирований в указанном диапазоне дат.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List reservations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "endDate",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список бронирований",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/{id}": {
+            "get": {
+                "description": "Возвращает отзыв. Отзыв на модерации или отклонённый видят только его автор и администраторы, остальным отвечает 404.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get a review by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отзыв",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет оценку и текст своего отзыва. Изменённый текст снова отправляется на модерацию; смена одной оценки статус не меняет.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Update own review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые оценка и текст. Пример: {\\",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_reviews.UpdateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённый отзыв",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID, оценка или слишком длинный текст",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Отзыв другого читателя",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет отзыв. Читатель удаляет свой отзыв, администратор — любой.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Delete a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отзыв удалён",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Отзыв другого читателя",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/{id}/helpful": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отмечает чужой одобренный отзыв полезным от имени читателя сессии. Повторная отметка не ошибка. Число отметок определяет порядок sort=helpful.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Mark a review as helpful",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отзыв с новым числом отметок",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или свой отзыв",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снимает отметку «полезен», поставленную читателем сессии. Если отметки нет, запрос всё равно успешен.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Remove a helpful mark",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отзыв с новым числом отметок",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или свой отзыв",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/{id}/moderate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Одобряет (approved) или отклоняет (rejected) отзыв. В списки отзывов книги и в её рейтинг входят только одобренные отзывы. Только для администраторов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Moderate a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID отзыва",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Решение. Пример: {\\",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_reviews.ModerateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отзыв с новым статусом",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Review"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или статус",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Отзыв не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает отзывы любых статусов по фильтру; очередь модерации — status=pending. Только для администраторов.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "List reviews for moderation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Статус: pending, approved или rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID книги",
+                        "name": "book",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID читателя",
+                        "name": "reader",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок: recent или helpful",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отзывы",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Review"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный параметр",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag/{id}": {
+            "get": {
+                "description": "Возвращает тег с его синонимами.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get a tag by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID тега",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тег",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_tags.Tag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тег не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет тег вместе с его синонимами и снимает его со всех книг. Только для администраторов.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete a tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID тега",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тег удалён",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тег не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag/{id}/books": {
+            "get": {
+                "description": "Возвращает книги с тегом по возрастанию ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List books with a tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID тега",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Книги с тегом",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.Book"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тег не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tag/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сливает теги source_ids в тег из пути: их книги получают этот тег, их имена и синонимы становятся его синонимами, сами теги удаляются. После слияния пометка книги синонимом ставит основной тег. Только для администраторов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Merge synonym tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тега, в который сливаются синонимы",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Сливаемые теги. Пример: {\\",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_tags.MergeTagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тег с новыми синонимами",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_tags.Tag"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или список тегов",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуются права администратора",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Тег не найден",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Возвращает теги с числом книг по убыванию числа, при равенстве — по имени. Теги без книг не выдаются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Tag cloud",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Сколько самых частых тегов вернуть; 0 или без параметра — все",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Теги с числом книг",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_tags.TagCount"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный limit",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/work": {
+            "post": {
+                "description": "Создаёт произведение — группу изданий одной книги (переводов, переизданий). Издания привязываются к нему полем work_id книги.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "Create a work",
+                "parameters": [
+                    {
+                        "description": "Параметры произведения. Пример: {\\",
+                        "name": "work",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_works.CreateWorkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданное произведение",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_works.Work"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или пустое название",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/work/{id}": {
+            "get": {
+                "description": "Возвращает произведение по его уникальному идентификатору.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "Get a work by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Произведение",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_works.Work"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Произведение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Переименовывает произведение. Названия изданий не меняются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "Update a work",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные произведения. Пример: {\\",
+                        "name": "work",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_application_http_handlers_works.UpdateWorkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённое произведение",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_works.Work"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос, ID или пустое название",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Произведение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет произведение. Произведение с изданиями удалить нельзя: сначала удалите или перенесите их.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "Delete a work",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Произведение удалено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Произведение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "У произведения есть издания",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/work/{id}/editions": {
+            "get": {
+                "description": "Возвращает издания произведения — книги с его work_id — по возрастанию ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "List editions of a work",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Уникальный ID произведения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Издания произведения",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Произведение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/works": {
+            "get": {
+                "description": "Возвращает все произведения по возрастанию ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "List works",
+                "responses": {
+                    "200": {
+                        "description": "Список произведений",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.BaseResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_entity_works.Work"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_0sokrat0_BookAPI_internal_application_commands.ContributorRequest": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "role": {
+                    "type": "string",
+                    "example": "translator"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.Book": {
+            "type": "object",
+            "properties": {
+                "cover": {
+                    "description": "Cover хранится отдельно (CoverRepo) и заполняется при выдаче книги.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.Cover"
+                        }
+                    ]
+                },
+                "genres": {
+                    "description": "Genres — жанры книги с названиями; заполняются при выдаче книги.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.GenreRef"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "language": {
+                    "description": "Language и Translator описывают конкретное издание.",
+                    "type": "string"
+                },
+                "publisherID": {
+                    "description": "PublisherID — издательство; 0 — не указано.",
+                    "type": "integer"
+                },
+                "rating": {
+                    "description": "Rating — сводка одобренных отзывов; заполняется при выдаче книги.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.Rating"
+                        }
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "translator": {
+                    "type": "string"
+                },
+                "workID": {
+                    "description": "WorkID — произведение, изданием которого является книга; 0 — без\nпроизведения.",
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.Cover": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "thumbnails": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "description": "URL и Thumbnails (ширина → URL) заполняет сервис обложек.",
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.GenreRef": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.Rating": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_aggregate_readinglists.ReadingList": {
+            "type": "object",
+            "properties": {
+                "bookIDs": {
+                    "description": "BookIDs — книги в порядке списка.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "public": {
+                    "type": "boolean"
+                },
+                "readerID": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "Slug — случайная часть ссылки на публичный список; у приватного\nпустая.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Review": {
+            "type": "object",
+            "properties": {
+                "bookID": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "helpful": {
+                    "description": "Helpful — число читателей, отметивших отзыв полезным; заполняется\nрепозиторием.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "readerID": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Status"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_aggregate_reviews.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusApproved",
+                "StatusRejected"
+            ]
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_entity_authors.Author": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "Aliases — другие написания имени: транслитерации («Lev Tolstoi»),\nинициалы («Толстой Л. Н.»), псевдонимы. Поиск находит автора и по ним.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "birthDate": {
+                    "description": "BirthDate и DeathDate — даты жизни; nil — дата неизвестна.",
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "deathDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_entity_genres.Genre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "names": {
+                    "description": "Names — локализованные названия: код языка (ru, en, ...) → название.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "parentID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_entity_publishers.Publisher": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_entity_tags.Tag": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "Aliases — синонимы: имена тегов, слитых в этот. Поиск по синониму\nнаходит этот тег.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_entity_tags.TagCount": {
+            "type": "object",
+            "properties": {
+                "books": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_domain_entity_works.Work": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_service_catalog.BookDraft": {
+            "type": "object",
+            "properties": {
+                "author_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_author_ids": {
+                    "description": "CreatedAuthorIDs — авторы, которых не было в каталоге и которые\nсозданы при поиске.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "existing_book_id": {
+                    "description": "ExistingBookID — книга с этим ISBN уже есть в каталоге.",
+                    "type": "integer",
+                    "example": 0
+                },
+                "genre_ids": {
+                    "description": "GenreIDs — жанры каталога, совпавшие с рубриками внешнего каталога.\nНовые жанры не создаются: рубрик обычно много и они мельче жанров.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "9785170000000"
+                },
+                "subjects": {
+                    "description": "Subjects — все рубрики внешнего каталога.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Война и мир"
+                },
+                "year": {
+                    "type": "integer",
+                    "example": 1869
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_service_catalog.ImportReport": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "description": "Committed — все обработанные строки сохранены.",
+                    "type": "boolean"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error — причина, по которой импорт остановлен.",
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "resume_offset": {
+                    "description": "ResumeOffset — сколько строк от начала файла уже сохранено; передаётся\nв Offset, чтобы продолжить прерванный импорт.",
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_catalog.RowResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_service_catalog.RowResult": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "isbn": {
+                    "type": "string",
+                    "example": "9785170000000"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "invalid isbn"
+                },
+                "row": {
+                    "description": "Row — номер строки данных, начиная с 1 (заголовок CSV и пустые\nстроки JSON Lines не считаются).",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_catalog.RowStatus"
+                        }
+                    ],
+                    "example": "created"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Война и мир"
+                },
+                "unmapped": {
+                    "description": "Unmapped — поля записи MARC, которые не перенесены в книгу:\n\"tag\" для поля целиком, \"tag$code\" для подполя.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "245$c",
+                        "500"
+                    ]
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_service_catalog.RowStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "updated",
+                "skipped",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "RowCreated",
+                "RowUpdated",
+                "RowSkipped",
+                "RowFailed"
+            ]
+        },
+        "github_com_0sokrat0_BookAPI_internal_service_readinglists.ListView": {
+            "type": "object",
+            "properties": {
+                "bookIDs": {
+                    "description": "BookIDs — книги в порядке списка.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "books": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_service_readinglists.ListedBook"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "public": {
+                    "type": "boolean"
+                },
+                "readerID": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "Slug — случайная часть ссылки на публичный список; у приватного\nпустая.",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_service_readinglists.ListedBook": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "Available — у книги нет бронирования, пересекающегося с сегодняшним\nднём.",
+                    "type": "boolean"
+                },
+                "book": {
+                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.Book"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_internal_service_recommendations.Suggestion": {
+            "type": "object",
+            "properties": {
+                "because": {
+                    "description": "Because — книги читателя, по которым подобрана эта; только у\nперсональных рекомендаций.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "book": {
+                    "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_domain_aggregate_books.Book"
+                },
+                "readers": {
+                    "description": "Readers — сколько читателей брали и исходную книгу, и эту; только у\nпохожих книг.",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "Score — близость к исходной книге или, для читателя, сумма близостей\nк книгам, которые он брал.",
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_pkg_response.BaseResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {},
+                "message": {
+                    "type": "string",
+                    "example": "Operation successful"
+                }
+            }
+        },
+        "github_com_0sokrat0_BookAPI_pkg_response.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f1c2a9e0b7d4e3f8a6b5c4d3e2f1a0b"
                 }
             }
         },
         "internal_application_http_handlers_authors.CreateAuthorRequest": {
             "type": "object",
             "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Лев Толстой",
+                        "Lev Tolstoi"
+                    ]
+                },
+                "bio": {
+                    "type": "string",
+                    "example": "Русский писатель."
+                },
+                "birth_date": {
+                    "type": "string",
+                    "example": "1828-09-09"
+                },
                 "country": {
                     "type": "string",
                     "example": "Russia"
                 },
+                "death_date": {
+                    "type": "string",
+                    "example": "1910-11-20"
+                },
                 "name": {
                     "type": "string",
                     "example": "Leo Tolstoy"
                 }
             }
         },
+        "internal_application_http_handlers_authors.MergeAuthorsRequest": {
+            "type": "object",
+            "properties": {
+                "source_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
         "internal_application_http_handlers_authors.UpdateAuthorRequest": {
             "type": "object",
             "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Антон Чехов",
+                        "Anton Tchekhov"
+                    ]
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "birth_date": {
+                    "type": "string",
+                    "example": "1860-01-29"
+                },
                 "country": {
                     "type": "string",
                     "example": "Russia"
                 },
+                "death_date": {
+                    "type": "string",
+                    "example": "1904-07-15"
+                },
                 "name": {
                     "type": "string",
                     "example": "Anton Chekhov"
@@ -1015,18 +5885,42 @@ const docTemplate = `{
                         "type": "integer"
                     }
                 },
-                "genre": {
-                    "type": "string",
-                    "example": "Programming"
+                "contributors": {
+                    "description": "Contributors — участники с ролями; авторы из author_ids идут первыми.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_application_commands.ContributorRequest"
+                    }
+                },
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
                 "isbn": {
                     "type": "string",
                     "example": "1234567890"
                 },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "publisher_id": {
+                    "type": "integer",
+                    "example": 0
+                },
                 "title": {
                     "type": "string",
                     "example": "Go Programming"
                 },
+                "translator": {
+                    "type": "string"
+                },
+                "work_id": {
+                    "type": "integer",
+                    "example": 0
+                },
                 "year": {
                     "type": "integer",
                     "example": 2025
@@ -1042,24 +5936,129 @@ const docTemplate = `{
                         "type": "integer"
                     }
                 },
-                "genre": {
-                    "type": "string",
-                    "example": "Programming"
+                "contributors": {
+                    "description": "Contributors — участники с ролями; авторы из author_ids идут первыми.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_0sokrat0_BookAPI_internal_application_commands.ContributorRequest"
+                    }
+                },
+                "genre_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
                 "isbn": {
                     "type": "string",
                     "example": "0987654321"
                 },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "publisher_id": {
+                    "type": "integer",
+                    "example": 0
+                },
                 "title": {
                     "type": "string",
                     "example": "Advanced Go"
                 },
+                "translator": {
+                    "type": "string"
+                },
+                "work_id": {
+                    "type": "integer",
+                    "example": 0
+                },
                 "year": {
                     "type": "integer",
                     "example": 2025
                 }
             }
         },
+        "internal_application_http_handlers_catalog.BookFromISBNRequest": {
+            "type": "object",
+            "properties": {
+                "isbn": {
+                    "type": "string",
+                    "example": "9780306406157"
+                }
+            }
+        },
+        "internal_application_http_handlers_genres.CreateGenreRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Programming"
+                },
+                "names": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "internal_application_http_handlers_genres.UpdateGenreRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Go"
+                },
+                "names": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "internal_application_http_handlers_publishers.CreatePublisherRequest": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "London"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "United Kingdom"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Penguin Books"
+                }
+            }
+        },
+        "internal_application_http_handlers_publishers.UpdatePublisherRequest": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "New York"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "USA"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Vintage"
+                }
+            }
+        },
         "internal_application_http_handlers_readers.CreateReaderRequest": {
             "type": "object",
             "properties": {
@@ -1098,6 +6097,50 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_application_http_handlers_readers.TOTPConfirmRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "internal_application_http_handlers_readers.TOTPEnrollRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "internal_application_http_handlers_readers.TOTPVerifyRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "internal_application_http_handlers_readers.TwoFactorLoginRequest": {
+            "type": "object",
+            "properties": {
+                "challenge": {
+                    "type": "string",
+                    "example": "eyJzdWIiOjF9.c2lnbmF0dXJl"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
         "internal_application_http_handlers_readers.UpdateReaderRequest": {
             "type": "object",
             "properties": {
@@ -1123,6 +6166,63 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_application_http_handlers_readinglists.AddBookRequest": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "internal_application_http_handlers_readinglists.CreateReadingListRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Прочитать летом"
+                },
+                "public": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "internal_application_http_handlers_readinglists.ReorderBooksRequest": {
+            "type": "object",
+            "properties": {
+                "book_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "internal_application_http_handlers_readinglists.ReserveRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_application_http_handlers_readinglists.UpdateReadingListRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Прочитать летом"
+                },
+                "public": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "internal_application_http_handlers_reservations.CreateReservationRequestDTO": {
             "type": "object",
             "properties": {
@@ -1145,6 +6245,10 @@ const docTemplate = `{
                 "start_date": {
                     "description": "Начало бронирования",
                     "type": "string"
+                },
+                "work_id": {
+                    "description": "Любое свободное издание произведения, если book_id не задан",
+                    "type": "integer"
                 }
             }
         },
@@ -1167,6 +6271,92 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "internal_application_http_handlers_reviews.CreateReviewRequest": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Перечитываю каждый год."
+                }
+            }
+        },
+        "internal_application_http_handlers_reviews.ModerateReviewRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "approved"
+                }
+            }
+        },
+        "internal_application_http_handlers_reviews.UpdateReviewRequest": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Со второго раза понравилась меньше."
+                }
+            }
+        },
+        "internal_application_http_handlers_tags.MergeTagsRequest": {
+            "type": "object",
+            "properties": {
+                "source_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "internal_application_http_handlers_tags.TagBookRequest": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "concurrency",
+                        "soviet sci-fi"
+                    ]
+                }
+            }
+        },
+        "internal_application_http_handlers_works.CreateWorkRequest": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "example": "War and Peace"
+                }
+            }
+        },
+        "internal_application_http_handlers_works.UpdateWorkRequest": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "example": "War and Peace"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
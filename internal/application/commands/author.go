@@ -2,12 +2,22 @@ package commands
 
 // CreateAuthorRequest содержит данные для создания автора.
 type CreateAuthorRequest struct {
-	Name    string `json:"name" example:"Leo Tolstoy"`
-	Country string `json:"country" example:"Russia"`
+	Name    string   `json:"name" example:"Leo Tolstoy"`
+	Country string   `json:"country" example:"Russia"`
+	Aliases []string `json:"aliases"`
+	// BirthDate и DeathDate — даты в формате YYYY-MM-DD; пустая строка —
+	// дата неизвестна.
+	BirthDate string `json:"birth_date" example:"1828-09-09"`
+	DeathDate string `json:"death_date" example:"1910-11-20"`
+	Bio       string `json:"bio"`
 }
 
 // UpdateAuthorRequest содержит данные для обновления автора.
 type UpdateAuthorRequest struct {
-	Name    string `json:"name" example:"Leo Tolstoy"`
-	Country string `json:"country" example:"Russia"`
+	Name      string   `json:"name" example:"Leo Tolstoy"`
+	Country   string   `json:"country" example:"Russia"`
+	Aliases   []string `json:"aliases"`
+	BirthDate string   `json:"birth_date" example:"1828-09-09"`
+	DeathDate string   `json:"death_date" example:"1910-11-20"`
+	Bio       string   `json:"bio"`
 }
@@ -1,13 +1,29 @@
 package commands
 
+// ContributorRequest — участник книги из справочника авторов и его роль:
+// author (по умолчанию), translator, editor или illustrator.
+type ContributorRequest struct {
+	AuthorID int    `json:"author_id" example:"1"`
+	Role     string `json:"role" example:"translator"`
+}
+
 // CreateBookRequest содержит данные для создания книги.
 // swagger:parameters CreateBookRequest
 type CreateBookRequest struct {
 	Title     string `json:"title" example:"Go Programming"`
 	Year      int    `json:"year" example:"2025"`
 	ISBN      string `json:"isbn" example:"1234567890"`
-	Genre     string `json:"genre" example:"Programming"`
 	AuthorIDs []int  `json:"author_ids" `
+	GenreIDs  []int  `json:"genre_ids" `
+	// Contributors — участники в порядке следования; авторы из AuthorIDs
+	// идут перед ними.
+	Contributors []ContributorRequest `json:"contributors"`
+	// WorkID — произведение, изданием которого станет книга; 0 — создать
+	// для книги новое произведение.
+	WorkID      int    `json:"work_id" example:"0"`
+	PublisherID int    `json:"publisher_id" example:"0"`
+	Language    string `json:"language" example:"en"`
+	Translator  string `json:"translator"`
 }
 
 // UpdateBookRequest содержит данные для обновления книги.
@@ -15,6 +31,14 @@ type UpdateBookRequest struct {
 	Title     string `json:"title" example:"Advanced Go"`
 	Year      int    `json:"year" example:"2025"`
 	ISBN      string `json:"isbn" example:"0987654321"`
-	Genre     string `json:"genre" example:"Programming"`
 	AuthorIDs []int  `json:"author_ids" `
+	GenreIDs  []int  `json:"genre_ids" `
+	// Contributors — участники в порядке следования; авторы из AuthorIDs
+	// идут перед ними.
+	Contributors []ContributorRequest `json:"contributors"`
+	// WorkID переносит книгу в другое произведение; 0 — оставить прежнее.
+	WorkID      int    `json:"work_id" example:"0"`
+	PublisherID int    `json:"publisher_id" example:"0"`
+	Language    string `json:"language" example:"en"`
+	Translator  string `json:"translator"`
 }
@@ -0,0 +1,15 @@
+package commands
+
+// CreateGenreRequest содержит данные для создания жанра.
+type CreateGenreRequest struct {
+	ParentID int               `json:"parent_id" example:"0"`
+	Name     string            `json:"name" example:"Programming"`
+	Names    map[string]string `json:"names"`
+}
+
+// UpdateGenreRequest содержит данные для обновления жанра.
+type UpdateGenreRequest struct {
+	ParentID int               `json:"parent_id" example:"0"`
+	Name     string            `json:"name" example:"Programming"`
+	Names    map[string]string `json:"names"`
+}
@@ -0,0 +1,15 @@
+package commands
+
+// CreatePublisherRequest содержит данные для создания издательства.
+type CreatePublisherRequest struct {
+	Name    string `json:"name" example:"Penguin Books"`
+	City    string `json:"city" example:"London"`
+	Country string `json:"country" example:"United Kingdom"`
+}
+
+// UpdatePublisherRequest содержит данные для обновления издательства.
+type UpdatePublisherRequest struct {
+	Name    string `json:"name" example:"Penguin Books"`
+	City    string `json:"city" example:"London"`
+	Country string `json:"country" example:"United Kingdom"`
+}
@@ -0,0 +1,14 @@
+package commands
+
+// CreateReadingListRequest содержит название нового списка и признак
+// публичности.
+type CreateReadingListRequest struct {
+	Name   string `json:"name" example:"Прочитать летом"`
+	Public bool   `json:"public" example:"false"`
+}
+
+// UpdateReadingListRequest заменяет название и публичность списка.
+type UpdateReadingListRequest struct {
+	Name   string `json:"name" example:"Прочитать летом"`
+	Public bool   `json:"public" example:"true"`
+}
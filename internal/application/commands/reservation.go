@@ -6,6 +6,7 @@ import "time"
 type CreateReservationRequestDTO struct {
 	ID        int       `json:"id" example:"0"`
 	BookID    int       `json:"book_id" example:"1"`
+	WorkID    int       `json:"work_id" example:"0"`
 	ReaderID  int       `json:"reader_id" example:"2"`
 	StartDate time.Time `json:"start_date" example:"2025-03-15"`
 	EndDate   time.Time `json:"end_date" example:"2025-03-20"`
@@ -0,0 +1,13 @@
+package commands
+
+// CreateReviewRequest содержит оценку книги и необязательный текст отзыва.
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" example:"5"`
+	Text   string `json:"text" example:"Перечитываю каждый год."`
+}
+
+// UpdateReviewRequest заменяет оценку и текст отзыва.
+type UpdateReviewRequest struct {
+	Rating int    `json:"rating" example:"4"`
+	Text   string `json:"text" example:"Со второго раза понравилась меньше."`
+}
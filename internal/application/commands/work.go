@@ -0,0 +1,11 @@
+package commands
+
+// CreateWorkRequest содержит данные для создания произведения.
+type CreateWorkRequest struct {
+	Title string `json:"title" example:"War and Peace"`
+}
+
+// UpdateWorkRequest содержит данные для обновления произведения.
+type UpdateWorkRequest struct {
+	Title string `json:"title" example:"War and Peace"`
+}
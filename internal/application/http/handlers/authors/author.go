@@ -1,9 +1,12 @@
 package authors
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	authorentity "github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
 	"github.com/0sokrat0/BookAPI/internal/service/authors"
 	"github.com/0sokrat0/BookAPI/pkg/response"
 	"github.com/gofiber/fiber/v2"
@@ -14,15 +17,29 @@ import (
 // CreateAuthorRequest содержит данные для создания автора.
 // swagger:model CreateAuthorRequest
 type CreateAuthorRequest struct {
-	Name    string `json:"name" example:"Leo Tolstoy"`
-	Country string `json:"country" example:"Russia"`
+	Name      string   `json:"name" example:"Leo Tolstoy"`
+	Country   string   `json:"country" example:"Russia"`
+	Aliases   []string `json:"aliases" example:"Лев Толстой,Lev Tolstoi"`
+	BirthDate string   `json:"birth_date" example:"1828-09-09"`
+	DeathDate string   `json:"death_date" example:"1910-11-20"`
+	Bio       string   `json:"bio" example:"Русский писатель."`
 }
 
 // UpdateAuthorRequest содержит данные для обновления автора.
 // swagger:model UpdateAuthorRequest
 type UpdateAuthorRequest struct {
-	Name    string `json:"name" example:"Anton Chekhov"`
-	Country string `json:"country" example:"Russia"`
+	Name      string   `json:"name" example:"Anton Chekhov"`
+	Country   string   `json:"country" example:"Russia"`
+	Aliases   []string `json:"aliases" example:"Антон Чехов,Anton Tchekhov"`
+	BirthDate string   `json:"birth_date" example:"1860-01-29"`
+	DeathDate string   `json:"death_date" example:"1904-07-15"`
+	Bio       string   `json:"bio"`
+}
+
+// MergeAuthorsRequest — дубликаты, сливаемые в автора из пути.
+// swagger:model MergeAuthorsRequest
+type MergeAuthorsRequest struct {
+	SourceIDs []int `json:"source_ids"`
 }
 
 // Handler представляет обработчик для операций с авторами.
@@ -35,35 +52,52 @@ func NewHandler(service authors.AuthorService) *Handler {
 	return &Handler{authorService: service}
 }
 
+func authorError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, authorentity.ErrNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, authors.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
 // CreateAuthorHandler godoc
 // @Summary      Create a new author
-// @Description  Создаёт нового автора с указанными данными. Принимает JSON-представление автора и возвращает созданную запись.
+// @Description  Создаёт нового автора с указанными данными. Принимает JSON-представление автора и возвращает созданную запись. aliases — другие написания имени (транслитерации, инициалы, псевдонимы), по ним автор находится в поиске и при импорте. Даты жизни — в формате YYYY-MM-DD.
 // @Tags         authors
 // @Accept       json
 // @Produce      json
-// @Param        author  body      authors.CreateAuthorRequest  true  "Параметры для создания автора. Пример: {\"name\":\"Leo Tolstoy\", \"country\":\"Russia\"}"
+// @Param        author  body      authors.CreateAuthorRequest  true  "Параметры для создания автора. Пример: {\"name\":\"Leo Tolstoy\", \"country\":\"Russia\", \"aliases\":[\"Лев Толстой\"], \"birth_date\":\"1828-09-09\"}"
 // @Success      200     {object}  map[string]interface{}  "Новый автор с уникальным ID"
-// @Failure      400     {object}  map[string]string       "Неверный запрос"
+// @Failure      400     {object}  map[string]string       "Неверный запрос или дата"
 // @Failure      500     {object}  map[string]string       "Ошибка сервера"
 // @Router       /author [post]
 func (h *Handler) CreateAuthorHandler(c *fiber.Ctx) error {
 	var req CreateAuthorRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid request: " + err.Error(),
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	cmdReq := commands.CreateAuthorRequest{
-		Name:    req.Name,
-		Country: req.Country,
+		Name:      req.Name,
+		Country:   req.Country,
+		Aliases:   req.Aliases,
+		BirthDate: req.BirthDate,
+		DeathDate: req.DeathDate,
+		Bio:       req.Bio,
 	}
 	author, err := h.authorService.CreateAuthor(c.UserContext(), cmdReq)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
-		})
+		return authorError(c, err)
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Code:    fiber.StatusOK,
@@ -86,15 +120,17 @@ func (h *Handler) GetAuthorHandler(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid author ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid author ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	author, err := h.authorService.GetAuthor(c.UserContext(), id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(response.ErrorResponse{
-			Code:    fiber.StatusNotFound,
-			Message: "Author not found",
+			Code:      fiber.StatusNotFound,
+			Message:   "Author not found",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -106,41 +142,45 @@ func (h *Handler) GetAuthorHandler(c *fiber.Ctx) error {
 
 // UpdateAuthorHandler godoc
 // @Summary      Update an author
-// @Description  Обновляет данные существующего автора по его уникальному идентификатору.
+// @Description  Обновляет данные существующего автора по его уникальному идентификатору. Пустое name оставляет прежнее имя; страна, синонимы, даты жизни и биография заменяются.
 // @Tags         authors
 // @Accept       json
 // @Produce      json
 // @Param        id      path      int  true  "Уникальный ID автора"
 // @Param        author  body      authors.UpdateAuthorRequest  true  "Новые данные автора. Пример: {\"name\":\"Anton Chekhov\", \"country\":\"Russia\"}"
 // @Success      200     {object}  map[string]interface{}  "Обновлённые данные автора"
-// @Failure      400     {object}  map[string]string       "Неверный запрос или ID"
+// @Failure      400     {object}  map[string]string       "Неверный запрос, ID или дата"
+// @Failure      404     {object}  map[string]string       "Автор не найден"
 // @Failure      500     {object}  map[string]string       "Ошибка сервера"
 // @Router       /author/{id} [put]
 func (h *Handler) UpdateAuthorHandler(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid author ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid author ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	var req UpdateAuthorRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid request: " + err.Error(),
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	cmdReq := commands.UpdateAuthorRequest{
-		Name:    req.Name,
-		Country: req.Country,
+		Name:      req.Name,
+		Country:   req.Country,
+		Aliases:   req.Aliases,
+		BirthDate: req.BirthDate,
+		DeathDate: req.DeathDate,
+		Bio:       req.Bio,
 	}
 	updatedAuthor, err := h.authorService.UpdateAuthor(c.UserContext(), id, cmdReq)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
-		})
+		return authorError(c, err)
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Code:    fiber.StatusOK,
@@ -163,14 +203,16 @@ func (h *Handler) DeleteAuthorHandler(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid author ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid author ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	if err := h.authorService.DeleteAuthor(c.UserContext(), id); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -181,18 +223,26 @@ func (h *Handler) DeleteAuthorHandler(c *fiber.Ctx) error {
 
 // ListAuthorsHandler godoc
 // @Summary      List all authors
-// @Description  Возвращает список всех авторов, зарегистрированных в системе.
+// @Description  Возвращает список всех авторов, зарегистрированных в системе. С параметром q — только авторов, в имени или синониме которых есть q (без учёта регистра, точек и «ё»).
 // @Tags         authors
 // @Produce      json
+// @Param        q    query     string  false  "Часть имени или синонима, например \"толстой\""
 // @Success      200  {array}   map[string]interface{}  "Массив объектов авторов"
 // @Failure      500  {object}  map[string]string       "Ошибка сервера"
 // @Router       /authors [get]
 func (h *Handler) ListAuthorsHandler(c *fiber.Ctx) error {
-	authorsList, err := h.authorService.ListAuthors(c.UserContext())
+	var authorsList []authorentity.Author
+	var err error
+	if q := c.Query("q"); q != "" {
+		authorsList, err = h.authorService.SearchAuthors(c.UserContext(), q)
+	} else {
+		authorsList, err = h.authorService.ListAuthors(c.UserContext())
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -201,3 +251,46 @@ func (h *Handler) ListAuthorsHandler(c *fiber.Ctx) error {
 		Data:    authorsList,
 	})
 }
+
+// MergeAuthorsHandler godoc
+// @Summary      Merge duplicate authors
+// @Description  Сливает авторов source_ids в автора из пути атомарно: их участие в книгах переносится на него (с сохранением ролей и порядка), их имена и синонимы становятся его синонимами, незаполненные страна, даты жизни и биография берутся у дубликатов, сами дубликаты удаляются. Только для администраторов.
+// @Tags         authors
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int  true  "ID основного автора"
+// @Param        merge  body      authors.MergeAuthorsRequest  true  "Сливаемые дубликаты. Пример: {\"source_ids\":[12,15]}"
+// @Success      200    {object}  response.BaseResponse{data=authorentity.Author} "Автор с новыми синонимами"
+// @Failure      400    {object}  response.ErrorResponse "Неверный ID или список авторов"
+// @Failure      401    {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403    {object}  response.ErrorResponse "Требуются права администратора"
+// @Failure      404    {object}  response.ErrorResponse "Автор не найден"
+// @Router       /author/{id}/merge [post]
+func (h *Handler) MergeAuthorsHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid author ID",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	var req MergeAuthorsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	author, err := h.authorService.MergeAuthors(c.UserContext(), id, req.SourceIDs)
+	if err != nil {
+		return authorError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Authors merged successfully",
+		Data:    author,
+	})
+}
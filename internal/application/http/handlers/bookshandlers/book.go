@@ -1,9 +1,11 @@
 package bookshandlers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
 	"github.com/0sokrat0/BookAPI/pkg/response"
 
 	"github.com/0sokrat0/BookAPI/internal/service/books"
@@ -15,8 +17,14 @@ type CreateBookRequest struct {
 	Title     string `json:"title" example:"Go Programming"`
 	Year      int    `json:"year" example:"2025"`
 	ISBN      string `json:"isbn" example:"1234567890"`
-	Genre     string `json:"genre" example:"Programming"`
 	AuthorIDs []int  `json:"author_ids"`
+	GenreIDs  []int  `json:"genre_ids"`
+	// Contributors — участники с ролями; авторы из author_ids идут первыми.
+	Contributors []commands.ContributorRequest `json:"contributors"`
+	WorkID       int                           `json:"work_id" example:"0"`
+	PublisherID  int                           `json:"publisher_id" example:"0"`
+	Language     string                        `json:"language" example:"en"`
+	Translator   string                        `json:"translator"`
 }
 
 // swagger:model UpdateBookRequest
@@ -24,8 +32,14 @@ type UpdateBookRequest struct {
 	Title     string `json:"title" example:"Advanced Go"`
 	Year      int    `json:"year" example:"2025"`
 	ISBN      string `json:"isbn" example:"0987654321"`
-	Genre     string `json:"genre" example:"Programming"`
 	AuthorIDs []int  `json:"author_ids"`
+	GenreIDs  []int  `json:"genre_ids"`
+	// Contributors — участники с ролями; авторы из author_ids идут первыми.
+	Contributors []commands.ContributorRequest `json:"contributors"`
+	WorkID       int                           `json:"work_id" example:"0"`
+	PublisherID  int                           `json:"publisher_id" example:"0"`
+	Language     string                        `json:"language" example:"en"`
+	Translator   string                        `json:"translator"`
 }
 
 type Handler struct {
@@ -38,28 +52,34 @@ func NewHandler(bookService books.BookService) *Handler {
 
 // CreateBookHandler godoc
 // @Summary      Create a new book
-// @Description  Создаёт новую книгу в системе. Принимает данные книги в формате JSON и возвращает созданную запись.
+// @Description  Создаёт новую книгу в системе. Принимает данные книги в формате JSON и возвращает созданную запись. Книга — издание произведения work_id; без work_id она становится единственным изданием нового произведения с тем же названием. publisher_id должен ссылаться на существующее издательство (0 — не указано). contributors задаёт участников с ролями (author, translator, editor, illustrator) в нужном порядке; author_ids — краткая запись для авторов, они идут первыми.
 // @Tags         books
 // @Accept       json
 // @Produce      json
-// @Param        book  body       bookshandlers.CreateBookRequest  true  "Параметры для создания книги. Пример: {\"title\":\"Go Programming\",\"year\":2025,\"isbn\":\"1234567890\",\"genre\":\"Programming\",\"author_ids\":[1,2]}"
+// @Param        book  body       bookshandlers.CreateBookRequest  true  "Параметры для создания книги. Пример: {\"title\":\"Go Programming\",\"year\":2025,\"isbn\":\"1234567890\",\"author_ids\":[1,2],\"genre_ids\":[1]}"
 // @Success      200   {object}   response.BaseResponse "Созданная книга с её уникальным ID"
-// @Failure      400   {object}   response.ErrorResponse "Неверный формат запроса или отсутствуют обязательные поля"
+// @Failure      400   {object}   response.ErrorResponse "Неверный формат запроса, отсутствуют обязательные поля, неизвестное произведение либо издательство или неверная роль участника"
 // @Failure      500   {object}   response.ErrorResponse "Ошибка сервера"
 // @Router       /book [post]
 func (h *Handler) CreateBookHandler(c *fiber.Ctx) error {
 	var req commands.CreateBookRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid request: " + err.Error(),
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	book, err := h.bookService.CreateBook(c.UserContext(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, books.ErrInvalidInput) {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -71,7 +91,7 @@ func (h *Handler) CreateBookHandler(c *fiber.Ctx) error {
 
 // GetBookHandler godoc
 // @Summary      Retrieve a book by ID
-// @Description  Возвращает данные книги по её уникальному идентификатору.
+// @Description  Возвращает данные книги по её уникальному идентификатору. Rating — средняя оценка и число одобренных отзывов читателей.
 // @Tags         books
 // @Produce      json
 // @Param        id   path      int  true  "Уникальный ID книги"
@@ -84,15 +104,17 @@ func (h *Handler) GetBookHandler(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid book ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid book ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	book, err := h.bookService.GetBook(c.UserContext(), id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(response.ErrorResponse{
-			Code:    fiber.StatusNotFound,
-			Message: "Book not found",
+			Code:      fiber.StatusNotFound,
+			Message:   "Book not found",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -104,14 +126,14 @@ func (h *Handler) GetBookHandler(c *fiber.Ctx) error {
 
 // UpdateBookHandler godoc
 // @Summary      Update a book
-// @Description  Обновляет данные книги по её уникальному идентификатору. Принимает новые данные книги в формате JSON.
+// @Description  Обновляет данные книги по её уникальному идентификатору. Принимает новые данные книги в формате JSON. work_id переносит книгу в другое произведение, 0 оставляет прежнее. author_ids и contributors заменяют список участников целиком.
 // @Tags         books
 // @Accept       json
 // @Produce      json
 // @Param        id    path      int  true  "Уникальный ID книги"
-// @Param        book  body       bookshandlers.UpdateBookRequest  true  "Данные для обновления книги. Пример: {\"title\":\"Advanced Go\",\"year\":2025,\"isbn\":\"0987654321\",\"genre\":\"Programming\",\"author_ids\":[3,4]}"
+// @Param        book  body       bookshandlers.UpdateBookRequest  true  "Данные для обновления книги. Пример: {\"title\":\"Advanced Go\",\"year\":2025,\"isbn\":\"0987654321\",\"author_ids\":[3,4],\"genre_ids\":[1,5]}"
 // @Success      200   {object}  response.BaseResponse "Обновлённые данные книги"
-// @Failure      400   {object}  response.ErrorResponse "Неверный запрос или ID"
+// @Failure      400   {object}  response.ErrorResponse "Неверный запрос, ID, неизвестное произведение либо издательство или неверная роль участника"
 // @Failure      500   {object}  response.ErrorResponse "Ошибка сервера"
 // @Router       /book/{id} [put]
 func (h *Handler) UpdateBookHandler(c *fiber.Ctx) error {
@@ -119,22 +141,29 @@ func (h *Handler) UpdateBookHandler(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid book ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid book ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	var req commands.UpdateBookRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid request: " + err.Error(),
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	updatedBook, err := h.bookService.UpdateBook(c.UserContext(), id, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, books.ErrInvalidInput) {
+			status = fiber.StatusBadRequest
+		}
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -159,14 +188,16 @@ func (h *Handler) DeleteBookHandler(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid book ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid book ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	if err := h.bookService.DeleteBook(c.UserContext(), id); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -177,25 +208,50 @@ func (h *Handler) DeleteBookHandler(c *fiber.Ctx) error {
 
 // ListBooksHandler godoc
 // @Summary      List all books
-// @Description  Возвращает список всех книг, хранящихся в системе. Если указан параметр "author", возвращаются книги только этого автора. Дополнительно можно задать параметры сортировки: "sort" (поле сортировки) и "order" (asc или desc).
+// @Description  Возвращает список всех книг, хранящихся в системе. Если указан параметр "author", возвращаются книги только этого автора (с "role" — только те, где он участвует в этой роли), "work" — только издания этого произведения, "publisher" — только книги этого издательства; фильтры сочетаются. Дополнительно можно задать параметры сортировки: "sort" (поле сортировки) и "order" (asc или desc).
 // @Tags         books
 // @Produce      json
 // @Param        author  query     int     false  "ID автора для фильтрации (например, 5)"
+// @Param        role    query     string  false  "Роль автора из параметра author: author, translator, editor или illustrator"
+// @Param        work    query     int     false  "ID произведения для фильтрации"
+// @Param        publisher  query  int     false  "ID издательства для фильтрации"
 // @Param        sort    query     string  false  "Поле для сортировки (например, 'title', 'year')"
 // @Param        order   query     string  false  "Порядок сортировки: 'asc' или 'desc' (по умолчанию: asc)"
 // @Success      200     {object}  response.BaseResponse "Массив книг"
 // @Failure      500     {object}  response.ErrorResponse  "Ошибка сервера"
 // @Router       /books [get]
 func (h *Handler) ListBooksHandler(c *fiber.Ctx) error {
-	authorParam := c.Query("author")
-	if authorParam != "" {
-		authorID, err := strconv.Atoi(authorParam)
+	filter, err := ParseFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	if filter.WorkID != 0 || filter.PublisherID != 0 || filter.Role != "" {
+		booksList, err := h.bookService.FindBooks(c.UserContext(), filter)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid author parameter"})
+			return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
+				Code:      fiber.StatusInternalServerError,
+				Message:   err.Error(),
+				RequestID: middleware.RequestID(c),
+			})
 		}
-		booksList, err := h.bookService.ListBooksByAuthor(c.UserContext(), authorID)
+		return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+			Code:    fiber.StatusOK,
+			Message: "Books list retrieved successfully",
+			Data:    booksList,
+		})
+	}
+	if filter.AuthorID != 0 {
+		booksList, err := h.bookService.ListBooksByAuthor(c.UserContext(), filter.AuthorID)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
+				Code:      fiber.StatusInternalServerError,
+				Message:   err.Error(),
+				RequestID: middleware.RequestID(c),
+			})
 		}
 		return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 			Code:    fiber.StatusOK,
@@ -207,8 +263,9 @@ func (h *Handler) ListBooksHandler(c *fiber.Ctx) error {
 	booksList, err := h.bookService.ListBooks(c.UserContext())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -0,0 +1,47 @@
+package bookshandlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ParseFilter читает фильтр книг из query-параметров (author, role, work, publisher) — общий для
+// списка и выгрузки. Текст ошибки годится для ответа клиенту.
+func ParseFilter(c *fiber.Ctx) (books.Filter, error) {
+	var filter books.Filter
+	if author := c.Query("author"); author != "" {
+		id, err := strconv.Atoi(author)
+		if err != nil {
+			return filter, errors.New("Invalid author parameter")
+		}
+		filter.AuthorID = id
+	}
+	if role := c.Query("role"); role != "" {
+		if filter.AuthorID == 0 {
+			return filter, errors.New("Role parameter requires author")
+		}
+		parsed, err := books.ParseRole(role)
+		if err != nil {
+			return filter, errors.New("Invalid role parameter")
+		}
+		filter.Role = parsed
+	}
+	if work := c.Query("work"); work != "" {
+		id, err := strconv.Atoi(work)
+		if err != nil {
+			return filter, errors.New("Invalid work parameter")
+		}
+		filter.WorkID = id
+	}
+	if publisher := c.Query("publisher"); publisher != "" {
+		id, err := strconv.Atoi(publisher)
+		if err != nil {
+			return filter, errors.New("Invalid publisher parameter")
+		}
+		filter.PublisherID = id
+	}
+	return filter, nil
+}
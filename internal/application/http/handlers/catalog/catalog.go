@@ -0,0 +1,152 @@
+package cataloghandlers
+
+import (
+	"bytes"
+	"errors"
+	"mime"
+
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/service/catalog"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	catalogService catalog.CatalogService
+}
+
+func NewHandler(catalogService catalog.CatalogService) *Handler {
+	return &Handler{catalogService: catalogService}
+}
+
+// formatFromContentType определяет формат импорта по Content-Type.
+func formatFromContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return catalog.FormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return catalog.FormatJSONL
+	case "application/marc":
+		return catalog.FormatMARC
+	case "application/marcxml+xml", "application/xml", "text/xml":
+		return catalog.FormatMARCXML
+	default:
+		return ""
+	}
+}
+
+// ImportBooksHandler godoc
+// @Summary      Import books
+// @Description  Массовый импорт книг из CSV (колонки title, year, isbn, publisher, language, translator, genre или genres, authors; авторы и жанры через ";"), JSON Lines (объекты {"title","year","isbn","publisher","language","translator","genres":[...],"authors":[...]}; поле genre со строкой тоже принимается) или MARC 21 (ISO 2709 и MARCXML: 245 — название, 020 — ISBN, 100/700 — авторы, 264/260 $c — год, 650 — жанры; поля, не перенесённые в книгу, перечислены в unmapped строки отчёта). Книги сопоставляются по ISBN и обновляются; новая книга становится единственным изданием нового произведения. Издательство находится по названию без учёта регистра или создаётся. Авторы и жанры находятся по имени (жанры — также по локализованным названиям) или создаются; новые жанры создаются верхнего уровня. Без batch_size импорт выполняется в одной транзакции и откатывается целиком, если хоть одна строка не прошла (422). С batch_size каждый пакет сохраняется отдельно; после сбоя импорт продолжают, передав resume_offset из отчёта в offset. Только для администраторов.
+// @Tags         books
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Accept       application/marc
+// @Accept       application/marcxml+xml
+// @Produce      json
+// @Security     BearerAuth
+// @Param        format      query     string  false  "Формат: csv, jsonl, marc или marcxml (по умолчанию по Content-Type)"
+// @Param        batch_size  query     int     false  "Строк в одной транзакции; 0 — весь импорт в одной транзакции"
+// @Param        offset      query     int     false  "Пропустить первые N строк данных"
+// @Success      200  {object}  response.BaseResponse{data=catalog.ImportReport} "Отчёт об импорте"
+// @Failure      400  {object}  response.ErrorResponse "Неверный формат или параметры"
+// @Failure      401  {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403  {object}  response.ErrorResponse "Требуются права администратора"
+// @Failure      422  {object}  response.BaseResponse{data=catalog.ImportReport} "Импорт откатан из-за ошибочных строк"
+// @Failure      500  {object}  response.BaseResponse{data=catalog.ImportReport} "Импорт остановлен ошибкой хранилища"
+// @Router       /books/import [post]
+func (h *Handler) ImportBooksHandler(c *fiber.Ctx) error {
+	opts := catalog.ImportOptions{
+		Format:    c.Query("format"),
+		BatchSize: c.QueryInt("batch_size"),
+		Offset:    c.QueryInt("offset"),
+	}
+	if opts.Format == "" {
+		opts.Format = formatFromContentType(c.Get(fiber.HeaderContentType))
+	}
+
+	report, err := h.catalogService.ImportBooks(c.UserContext(), bytes.NewReader(c.Body()), opts)
+	if errors.Is(err, catalog.ErrInvalidInput) {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.BaseResponse{
+			Code:    fiber.StatusInternalServerError,
+			Message: "Import stopped: " + err.Error(),
+			Data:    report,
+		})
+	}
+	if !report.Committed {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response.BaseResponse{
+			Code:    fiber.StatusUnprocessableEntity,
+			Message: "Import rolled back",
+			Data:    report,
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Books imported successfully",
+		Data:    report,
+	})
+}
+
+// swagger:model BookFromISBNRequest
+type BookFromISBNRequest struct {
+	ISBN string `json:"isbn" example:"9780306406157"`
+}
+
+// BookFromISBNHandler godoc
+// @Summary      Prefill a book from its ISBN
+// @Description  Ищет метаданные книги по ISBN во внешнем каталоге (API Open Library) и возвращает черновик книги: название, год, ID авторов и ID жанров каталога, совпавших с рубриками (новые жанры не создаются). Недостающие авторы создаются и перечислены в created_author_ids. Сама книга не создаётся: после проверки её сохраняют через POST /book. Если книга с этим ISBN уже есть, её ID возвращается в existing_book_id. Ответы внешнего каталога кешируются. Только для администраторов.
+// @Tags         books
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      cataloghandlers.BookFromISBNRequest  true  "ISBN-10 или ISBN-13, дефисы допускаются"
+// @Success      200      {object}  response.BaseResponse{data=catalog.BookDraft} "Черновик книги"
+// @Failure      400      {object}  response.ErrorResponse "Неверный ISBN"
+// @Failure      401      {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403      {object}  response.ErrorResponse "Требуются права администратора"
+// @Failure      404      {object}  response.ErrorResponse "Внешний каталог не знает такого ISBN"
+// @Failure      502      {object}  response.ErrorResponse "Внешний каталог недоступен или ответил ошибкой"
+// @Failure      503      {object}  response.ErrorResponse "Поиск по ISBN выключен"
+// @Router       /book/from-isbn [post]
+func (h *Handler) BookFromISBNHandler(c *fiber.Ctx) error {
+	var req BookFromISBNRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+
+	draft, err := h.catalogService.BookFromISBN(c.UserContext(), req.ISBN)
+	if err != nil {
+		status := fiber.StatusBadGateway
+		switch {
+		case errors.Is(err, catalog.ErrInvalidInput):
+			status = fiber.StatusBadRequest
+		case errors.Is(err, books.ErrMetadataNotFound):
+			status = fiber.StatusNotFound
+		case errors.Is(err, catalog.ErrLookupDisabled):
+			status = fiber.StatusServiceUnavailable
+		}
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Book metadata retrieved successfully",
+		Data:    draft,
+	})
+}
@@ -0,0 +1,170 @@
+package coverhandlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/service/covers"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// coverMaxAge — срок кеширования изображений. Ссылки из ответов содержат
+// версию обложки, поэтому после замены клиент запросит новый адрес.
+const coverMaxAge = "public, max-age=86400"
+
+type Handler struct {
+	coverService covers.CoverService
+}
+
+func NewHandler(coverService covers.CoverService) *Handler {
+	return &Handler{coverService: coverService}
+}
+
+func coverError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, books.ErrNotFound), errors.Is(err, books.ErrCoverNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, covers.ErrTooLarge):
+		status = fiber.StatusRequestEntityTooLarge
+	case errors.Is(err, covers.ErrUnsupportedType):
+		status = fiber.StatusUnsupportedMediaType
+	case errors.Is(err, covers.ErrInvalidImage):
+		status = fiber.StatusBadRequest
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+// UploadCoverHandler godoc
+// @Summary      Upload a book cover
+// @Description  Заменяет обложку книги. Файл передаётся в поле file формы multipart/form-data. Тип определяется по содержимому: JPEG, PNG, GIF или WebP. Помимо оригинала сохраняются JPEG-миниатюры настроенных ширин (не шире оригинала). Только для администраторов.
+// @Tags         books
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int   true  "Уникальный ID книги"
+// @Param        file  formData  file  true  "Изображение обложки"
+// @Success      200   {object}  response.BaseResponse{data=books.Cover} "Обложка со ссылками на оригинал и миниатюры"
+// @Failure      400   {object}  response.ErrorResponse "Неверный ID, нет файла или файл не является изображением"
+// @Failure      401   {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403   {object}  response.ErrorResponse "Требуются права администратора"
+// @Failure      404   {object}  response.ErrorResponse "Книга не найдена"
+// @Failure      413   {object}  response.ErrorResponse "Файл или изображение слишком большие"
+// @Failure      415   {object}  response.ErrorResponse "Неподдерживаемый тип изображения"
+// @Router       /book/{id}/cover [put]
+func (h *Handler) UploadCoverHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid book ID",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Missing file field: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	file, err := header.Open()
+	if err != nil {
+		return coverError(c, err)
+	}
+	defer file.Close()
+
+	cover, err := h.coverService.Upload(c.UserContext(), id, file)
+	if err != nil {
+		return coverError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Cover uploaded successfully",
+		Data:    cover,
+	})
+}
+
+// GetCoverHandler godoc
+// @Summary      Get a book cover image
+// @Description  Отдаёт изображение обложки. С параметром width — наименьшую миниатюру не уже width (или оригинал, если такой нет). Ответ кешируется; ссылки из данных книги содержат версию обложки.
+// @Tags         books
+// @Produce      image/jpeg,image/png,image/gif,image/webp
+// @Param        id     path   int  true   "Уникальный ID книги"
+// @Param        width  query  int  false  "Желаемая ширина миниатюры"
+// @Success      200    {file}    file "Изображение"
+// @Success      304    "Не изменилось (If-None-Match)"
+// @Failure      400    {object}  response.ErrorResponse "Неверный ID или ширина"
+// @Failure      404    {object}  response.ErrorResponse "Обложки нет"
+// @Router       /book/{id}/cover [get]
+func (h *Handler) GetCoverHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid book ID",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	width := c.QueryInt("width")
+	if width < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid width parameter",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+
+	img, err := h.coverService.Open(c.UserContext(), id, width)
+	if err != nil {
+		return coverError(c, err)
+	}
+	c.Set(fiber.HeaderETag, img.ETag)
+	c.Set(fiber.HeaderCacheControl, coverMaxAge)
+	if c.Get(fiber.HeaderIfNoneMatch) == img.ETag {
+		img.Body.Close()
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	c.Set(fiber.HeaderContentType, img.ContentType)
+	// fasthttp закрывает поток после отправки.
+	return c.SendStream(img.Body)
+}
+
+// DeleteCoverHandler godoc
+// @Summary      Delete a book cover
+// @Description  Удаляет обложку книги вместе с миниатюрами. Только для администраторов.
+// @Tags         books
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Уникальный ID книги"
+// @Success      200  {object}  response.BaseResponse "Обложка удалена"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      401  {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403  {object}  response.ErrorResponse "Требуются права администратора"
+// @Failure      404  {object}  response.ErrorResponse "Обложки нет"
+// @Router       /book/{id}/cover [delete]
+func (h *Handler) DeleteCoverHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid book ID",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	if err := h.coverService.Delete(c.UserContext(), id); err != nil {
+		return coverError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Cover deleted successfully",
+	})
+}
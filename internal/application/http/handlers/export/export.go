@@ -0,0 +1,165 @@
+package exporthandlers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/internal/application/http/handlers/bookshandlers"
+	reservationshandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/reservations"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/service/export"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	exportService export.ExportService
+}
+
+func NewHandler(exportService export.ExportService) *Handler {
+	return &Handler{exportService: exportService}
+}
+
+// options читает общие параметры выгрузки: format и columns (через запятую).
+func options(c *fiber.Ctx) export.Options {
+	opts := export.Options{Format: c.Query("format")}
+	if columns := c.Query("columns"); columns != "" {
+		opts.Columns = strings.Split(columns, ",")
+	}
+	return opts
+}
+
+func badRequest(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+		Code:      fiber.StatusBadRequest,
+		Message:   message,
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+// stream отдаёт выгрузку потоком. Заголовки уже отправлены, когда начинается
+// чтение из хранилища, поэтому ошибка посреди выгрузки только логируется,
+// а клиент получает оборванный файл.
+func stream(c *fiber.Ctx, name string, opts export.Options, write export.WriteFunc, err error) error {
+	if errors.Is(err, export.ErrInvalidOptions) {
+		return badRequest(c, err.Error())
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+
+	// Контекст запроса захватывается до выхода из обработчика: поток
+	// пишется уже после того, как fiber вернул Ctx в пул.
+	ctx := c.UserContext()
+	c.Set(fiber.HeaderContentType, export.ContentType(opts.Format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, name, export.FileExtension(opts.Format)))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := write(ctx, w); err != nil {
+			logger.FromContext(ctx).Errorw("export stream failed", "export", name, "error", err)
+		}
+	})
+	return nil
+}
+
+// ExportBooksHandler godoc
+// @Summary      Export books
+// @Description  Выгружает книги потоком в CSV, NDJSON или MARC 21 (marc — ISO 2709, marcxml — MARCXML). Колонки: id, title, year, isbn, work_id, publisher_id, language, translator, author_ids, contributors («автор:роль» в порядке книги), genre_ids (списки в CSV через ";"); для MARC колонки не задаются, запись содержит 001 (ID), 020, 100/700, 245, 264 и 650. Фильтры author, role, work и publisher — как у списка книг. Только для администраторов.
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/marc
+// @Produce      application/marcxml+xml
+// @Security     BearerAuth
+// @Param        format   query     string  false  "Формат: csv (по умолчанию), ndjson, marc или marcxml"
+// @Param        columns  query     string  false  "Колонки через запятую в нужном порядке (по умолчанию все)"
+// @Param        author   query     int     false  "ID автора для фильтрации"
+// @Param        role     query     string  false  "Роль автора из параметра author"
+// @Param        work     query     int     false  "ID произведения для фильтрации"
+// @Param        publisher  query   int     false  "ID издательства для фильтрации"
+// @Success      200      {file}    file    "Файл выгрузки"
+// @Failure      400      {object}  response.ErrorResponse  "Неверный формат, колонка или фильтр"
+// @Failure      401      {object}  response.ErrorResponse  "Требуется аутентификация"
+// @Failure      403      {object}  response.ErrorResponse  "Требуются права администратора"
+// @Router       /export/books [get]
+func (h *Handler) ExportBooksHandler(c *fiber.Ctx) error {
+	filter, err := bookshandlers.ParseFilter(c)
+	if err != nil {
+		return badRequest(c, err.Error())
+	}
+	opts := options(c)
+	write, err := h.exportService.ExportBooks(c.UserContext(), opts, filter)
+	return stream(c, "books", opts, write, err)
+}
+
+// ExportAuthorsHandler godoc
+// @Summary      Export authors
+// @Description  Выгружает авторов потоком в CSV или NDJSON. Колонки: id, name, country, aliases (через «;» в CSV), birth_date, death_date, bio. Только для администраторов.
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Param        format   query     string  false  "Формат: csv (по умолчанию) или ndjson"
+// @Param        columns  query     string  false  "Колонки через запятую в нужном порядке (по умолчанию все)"
+// @Success      200      {file}    file    "Файл выгрузки"
+// @Failure      400      {object}  response.ErrorResponse  "Неверный формат или колонка"
+// @Failure      401      {object}  response.ErrorResponse  "Требуется аутентификация"
+// @Failure      403      {object}  response.ErrorResponse  "Требуются права администратора"
+// @Router       /export/authors [get]
+func (h *Handler) ExportAuthorsHandler(c *fiber.Ctx) error {
+	opts := options(c)
+	write, err := h.exportService.ExportAuthors(c.UserContext(), opts)
+	return stream(c, "authors", opts, write, err)
+}
+
+// ExportReadersHandler godoc
+// @Summary      Export readers
+// @Description  Выгружает читателей потоком в CSV или NDJSON. Колонки: id, name, phone, email, admin, totp_enabled; пароли и секреты второго фактора не выгружаются. Только для администраторов.
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Param        format   query     string  false  "Формат: csv (по умолчанию) или ndjson"
+// @Param        columns  query     string  false  "Колонки через запятую в нужном порядке (по умолчанию все)"
+// @Success      200      {file}    file    "Файл выгрузки"
+// @Failure      400      {object}  response.ErrorResponse  "Неверный формат или колонка"
+// @Failure      401      {object}  response.ErrorResponse  "Требуется аутентификация"
+// @Failure      403      {object}  response.ErrorResponse  "Требуются права администратора"
+// @Router       /export/readers [get]
+func (h *Handler) ExportReadersHandler(c *fiber.Ctx) error {
+	opts := options(c)
+	write, err := h.exportService.ExportReaders(c.UserContext(), opts)
+	return stream(c, "readers", opts, write, err)
+}
+
+// ExportReservationsHandler godoc
+// @Summary      Export reservations
+// @Description  Выгружает бронирования потоком в CSV или NDJSON. Колонки: id, book_id, reader_id, start_date, end_date, returned_date (пустая строка, пока книга не возвращена). Фильтр startDate/endDate — как у списка бронирований, но каждая граница необязательна. Только для администраторов.
+// @Tags         export
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Security     BearerAuth
+// @Param        format     query     string  false  "Формат: csv (по умолчанию) или ndjson"
+// @Param        columns    query     string  false  "Колонки через запятую в нужном порядке (по умолчанию все)"
+// @Param        startDate  query     string  false  "Start date (YYYY-MM-DD)"
+// @Param        endDate    query     string  false  "End date (YYYY-MM-DD)"
+// @Success      200        {file}    file    "Файл выгрузки"
+// @Failure      400        {object}  response.ErrorResponse  "Неверный формат, колонка или дата"
+// @Failure      401        {object}  response.ErrorResponse  "Требуется аутентификация"
+// @Failure      403        {object}  response.ErrorResponse  "Требуются права администратора"
+// @Router       /export/reservations [get]
+func (h *Handler) ExportReservationsHandler(c *fiber.Ctx) error {
+	filter, err := reservationshandlers.ParseFilter(c, false)
+	if err != nil {
+		return badRequest(c, err.Error())
+	}
+	opts := options(c)
+	write, err := h.exportService.ExportReservations(c.UserContext(), opts, filter)
+	return stream(c, "reservations", opts, write, err)
+}
@@ -0,0 +1,236 @@
+package genrehandlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	genresvc "github.com/0sokrat0/BookAPI/internal/service/genres"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateGenreRequest содержит данные для создания жанра.
+// swagger:model CreateGenreRequest
+type CreateGenreRequest struct {
+	ParentID int               `json:"parent_id" example:"0"`
+	Name     string            `json:"name" example:"Programming"`
+	Names    map[string]string `json:"names"`
+}
+
+// UpdateGenreRequest содержит данные для обновления жанра.
+// swagger:model UpdateGenreRequest
+type UpdateGenreRequest struct {
+	ParentID int               `json:"parent_id" example:"1"`
+	Name     string            `json:"name" example:"Go"`
+	Names    map[string]string `json:"names"`
+}
+
+type Handler struct {
+	genreService genresvc.GenreService
+}
+
+func NewHandler(genreService genresvc.GenreService) *Handler {
+	return &Handler{genreService: genreService}
+}
+
+func genreError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, genres.ErrNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, genresvc.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, genresvc.ErrInUse):
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+func invalidID(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+		Code:      fiber.StatusBadRequest,
+		Message:   "Invalid genre ID",
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+// CreateGenreHandler godoc
+// @Summary      Create a genre
+// @Description  Создаёт жанр. parent_id — родительский жанр, 0 — жанр верхнего уровня. names — локализованные названия по коду языка. Названия сравниваются без учёта регистра и не должны совпадать с названиями других жанров.
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Param        genre  body      genrehandlers.CreateGenreRequest  true  "Параметры жанра. Пример: {\"parent_id\":0,\"name\":\"Programming\",\"names\":{\"ru\":\"Программирование\"}}"
+// @Success      200    {object}  response.BaseResponse{data=genres.Genre} "Созданный жанр"
+// @Failure      400    {object}  response.ErrorResponse "Неверный запрос, занятое название или неизвестный родитель"
+// @Failure      500    {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /genre [post]
+func (h *Handler) CreateGenreHandler(c *fiber.Ctx) error {
+	var req CreateGenreRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	genre, err := h.genreService.CreateGenre(c.UserContext(), commands.CreateGenreRequest{
+		ParentID: req.ParentID,
+		Name:     req.Name,
+		Names:    req.Names,
+	})
+	if err != nil {
+		return genreError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Genre created successfully",
+		Data:    genre,
+	})
+}
+
+// GetGenreHandler godoc
+// @Summary      Get a genre by ID
+// @Description  Возвращает жанр по его уникальному идентификатору.
+// @Tags         genres
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID жанра"
+// @Success      200  {object}  response.BaseResponse{data=genres.Genre} "Жанр"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Жанр не найден"
+// @Router       /genre/{id} [get]
+func (h *Handler) GetGenreHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	genre, err := h.genreService.GetGenre(c.UserContext(), id)
+	if err != nil {
+		return genreError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Genre retrieved successfully",
+		Data:    genre,
+	})
+}
+
+// UpdateGenreHandler godoc
+// @Summary      Update a genre
+// @Description  Обновляет жанр. Пустое name оставляет прежнее название; parent_id и names заменяются. Жанр нельзя перенести в его собственное поддерево.
+// @Tags         genres
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int  true  "Уникальный ID жанра"
+// @Param        genre  body      genrehandlers.UpdateGenreRequest  true  "Новые данные жанра. Пример: {\"parent_id\":1,\"name\":\"Go\",\"names\":{\"ru\":\"Go\"}}"
+// @Success      200    {object}  response.BaseResponse{data=genres.Genre} "Обновлённый жанр"
+// @Failure      400    {object}  response.ErrorResponse "Неверный запрос, ID, название или родитель"
+// @Failure      404    {object}  response.ErrorResponse "Жанр не найден"
+// @Failure      500    {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /genre/{id} [put]
+func (h *Handler) UpdateGenreHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	var req UpdateGenreRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	genre, err := h.genreService.UpdateGenre(c.UserContext(), id, commands.UpdateGenreRequest{
+		ParentID: req.ParentID,
+		Name:     req.Name,
+		Names:    req.Names,
+	})
+	if err != nil {
+		return genreError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Genre updated successfully",
+		Data:    genre,
+	})
+}
+
+// DeleteGenreHandler godoc
+// @Summary      Delete a genre
+// @Description  Удаляет жанр. Жанр с дочерними жанрами или книгами удалить нельзя.
+// @Tags         genres
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID жанра"
+// @Success      200  {object}  response.BaseResponse "Жанр удалён"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Жанр не найден"
+// @Failure      409  {object}  response.ErrorResponse "У жанра есть дочерние жанры или книги"
+// @Router       /genre/{id} [delete]
+func (h *Handler) DeleteGenreHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	if err := h.genreService.DeleteGenre(c.UserContext(), id); err != nil {
+		return genreError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Genre deleted successfully",
+	})
+}
+
+// ListGenresHandler godoc
+// @Summary      List genres
+// @Description  Возвращает все жанры по возрастанию ID; иерархия задаётся полем ParentID.
+// @Tags         genres
+// @Produce      json
+// @Success      200  {object}  response.BaseResponse{data=[]genres.Genre} "Список жанров"
+// @Failure      500  {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /genres [get]
+func (h *Handler) ListGenresHandler(c *fiber.Ctx) error {
+	list, err := h.genreService.ListGenres(c.UserContext())
+	if err != nil {
+		return genreError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Genres list retrieved successfully",
+		Data:    list,
+	})
+}
+
+// ListGenreBooksHandler godoc
+// @Summary      List books of a genre
+// @Description  Возвращает книги жанра и всех его дочерних жанров на любой глубине.
+// @Tags         genres
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID жанра"
+// @Success      200  {object}  response.BaseResponse "Книги жанра"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Жанр не найден"
+// @Failure      500  {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /genre/{id}/books [get]
+func (h *Handler) ListGenreBooksHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	list, err := h.genreService.ListBooks(c.UserContext(), id)
+	if err != nil {
+		return genreError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Genre books retrieved successfully",
+		Data:    list,
+	})
+}
@@ -0,0 +1,65 @@
+package healthhandlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/pkg/health"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// readinessTimeout ограничивает суммарное время проверок готовности.
+const readinessTimeout = 2 * time.Second
+
+// Handler отдаёт состояние живости и готовности сервиса.
+type Handler struct {
+	checker *health.Checker
+}
+
+// NewHandler создаёт обработчик проверок здоровья.
+func NewHandler(checker *health.Checker) *Handler {
+	return &Handler{checker: checker}
+}
+
+// LivenessHandler godoc
+// @Summary      Liveness probe
+// @Description  Сообщает, что процесс запущен и обрабатывает запросы. Зависимости не проверяются.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  response.BaseResponse "Процесс жив"
+// @Router       /healthz [get]
+func (h *Handler) LivenessHandler(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "alive",
+		Data:    map[string]string{"status": health.StatusOK},
+	})
+}
+
+// ReadinessHandler godoc
+// @Summary      Readiness probe
+// @Description  Проверяет зависимости (база данных, версия миграций, фоновые задачи) и возвращает детали по каждой. Во время graceful shutdown отвечает 503.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  response.BaseResponse "Сервис готов принимать трафик"
+// @Failure      503  {object}  response.BaseResponse "Одна из проверок не прошла или сервер останавливается"
+// @Router       /readyz [get]
+func (h *Handler) ReadinessHandler(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), readinessTimeout)
+	defer cancel()
+
+	report := h.checker.Run(ctx)
+	if report.Status != health.StatusOK {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(response.BaseResponse{
+			Code:    fiber.StatusServiceUnavailable,
+			Message: "not ready",
+			Data:    report,
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "ready",
+		Data:    report,
+	})
+}
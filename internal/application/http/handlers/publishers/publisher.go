@@ -0,0 +1,238 @@
+package publisherhandlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+	publishersvc "github.com/0sokrat0/BookAPI/internal/service/publishers"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreatePublisherRequest содержит данные для создания издательства.
+// swagger:model CreatePublisherRequest
+type CreatePublisherRequest struct {
+	Name    string `json:"name" example:"Penguin Books"`
+	City    string `json:"city" example:"London"`
+	Country string `json:"country" example:"United Kingdom"`
+}
+
+// UpdatePublisherRequest содержит данные для обновления издательства.
+// swagger:model UpdatePublisherRequest
+type UpdatePublisherRequest struct {
+	Name    string `json:"name" example:"Vintage"`
+	City    string `json:"city" example:"New York"`
+	Country string `json:"country" example:"USA"`
+}
+
+// Handler представляет обработчик для операций с издательствами.
+type Handler struct {
+	publisherService publishersvc.PublisherService
+}
+
+// NewHandler создаёт новый обработчик для издательств.
+func NewHandler(service publishersvc.PublisherService) *Handler {
+	return &Handler{publisherService: service}
+}
+
+func publisherError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, publishers.ErrNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, publishersvc.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, publishersvc.ErrInUse):
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+func invalidID(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+		Code:      fiber.StatusBadRequest,
+		Message:   "Invalid publisher ID",
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+// CreatePublisherHandler godoc
+// @Summary      Create a new publisher
+// @Description  Создаёт издательство. Книги ссылаются на него полем publisher_id.
+// @Tags         publishers
+// @Accept       json
+// @Produce      json
+// @Param        publisher  body      publisherhandlers.CreatePublisherRequest  true  "Параметры издательства. Пример: {\"name\":\"Penguin Books\",\"city\":\"London\",\"country\":\"United Kingdom\"}"
+// @Success      200        {object}  response.BaseResponse{data=publishers.Publisher} "Созданное издательство"
+// @Failure      400        {object}  response.ErrorResponse "Неверный запрос или пустое название"
+// @Failure      500        {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /publisher [post]
+func (h *Handler) CreatePublisherHandler(c *fiber.Ctx) error {
+	var req CreatePublisherRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	publisher, err := h.publisherService.CreatePublisher(c.UserContext(), commands.CreatePublisherRequest{
+		Name:    req.Name,
+		City:    req.City,
+		Country: req.Country,
+	})
+	if err != nil {
+		return publisherError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Publisher created successfully",
+		Data:    publisher,
+	})
+}
+
+// GetPublisherHandler godoc
+// @Summary      Get a publisher by ID
+// @Description  Возвращает издательство по его уникальному идентификатору.
+// @Tags         publishers
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID издательства"
+// @Success      200  {object}  response.BaseResponse{data=publishers.Publisher} "Издательство"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Издательство не найдено"
+// @Router       /publisher/{id} [get]
+func (h *Handler) GetPublisherHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	publisher, err := h.publisherService.GetPublisher(c.UserContext(), id)
+	if err != nil {
+		return publisherError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Publisher retrieved successfully",
+		Data:    publisher,
+	})
+}
+
+// UpdatePublisherHandler godoc
+// @Summary      Update a publisher
+// @Description  Обновляет издательство. Пустое name оставляет прежнее название; city и country заменяются.
+// @Tags         publishers
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true  "Уникальный ID издательства"
+// @Param        publisher  body      publisherhandlers.UpdatePublisherRequest  true  "Новые данные издательства. Пример: {\"name\":\"Vintage\",\"city\":\"New York\",\"country\":\"USA\"}"
+// @Success      200        {object}  response.BaseResponse{data=publishers.Publisher} "Обновлённое издательство"
+// @Failure      400        {object}  response.ErrorResponse "Неверный запрос или ID"
+// @Failure      404        {object}  response.ErrorResponse "Издательство не найдено"
+// @Failure      500        {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /publisher/{id} [put]
+func (h *Handler) UpdatePublisherHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	var req UpdatePublisherRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	publisher, err := h.publisherService.UpdatePublisher(c.UserContext(), id, commands.UpdatePublisherRequest{
+		Name:    req.Name,
+		City:    req.City,
+		Country: req.Country,
+	})
+	if err != nil {
+		return publisherError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Publisher updated successfully",
+		Data:    publisher,
+	})
+}
+
+// DeletePublisherHandler godoc
+// @Summary      Delete a publisher
+// @Description  Удаляет издательство. Издательство, у которого есть книги, удалить нельзя.
+// @Tags         publishers
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID издательства"
+// @Success      200  {object}  response.BaseResponse "Издательство удалено"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Издательство не найдено"
+// @Failure      409  {object}  response.ErrorResponse "У издательства есть книги"
+// @Router       /publisher/{id} [delete]
+func (h *Handler) DeletePublisherHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	if err := h.publisherService.DeletePublisher(c.UserContext(), id); err != nil {
+		return publisherError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Publisher deleted successfully",
+	})
+}
+
+// ListPublishersHandler godoc
+// @Summary      List all publishers
+// @Description  Возвращает все издательства по возрастанию ID.
+// @Tags         publishers
+// @Produce      json
+// @Success      200  {object}  response.BaseResponse{data=[]publishers.Publisher} "Список издательств"
+// @Failure      500  {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /publishers [get]
+func (h *Handler) ListPublishersHandler(c *fiber.Ctx) error {
+	list, err := h.publisherService.ListPublishers(c.UserContext())
+	if err != nil {
+		return publisherError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Publishers list retrieved successfully",
+		Data:    list,
+	})
+}
+
+// ListPublisherBooksHandler godoc
+// @Summary      List books of a publisher
+// @Description  Возвращает книги издательства по возрастанию ID.
+// @Tags         publishers
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID издательства"
+// @Success      200  {object}  response.BaseResponse "Книги издательства"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Издательство не найдено"
+// @Failure      500  {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /publisher/{id}/books [get]
+func (h *Handler) ListPublisherBooksHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	list, err := h.publisherService.ListBooks(c.UserContext(), id)
+	if err != nil {
+		return publisherError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Publisher books retrieved successfully",
+		Data:    list,
+	})
+}
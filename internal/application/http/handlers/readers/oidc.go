@@ -0,0 +1,133 @@
+package readerhandlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/service/readers"
+	"github.com/0sokrat0/BookAPI/pkg/metrics"
+	"github.com/0sokrat0/BookAPI/pkg/oidc"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OIDCHandler обслуживает вход через внешний провайдер OpenID Connect.
+type OIDCHandler struct {
+	readerService readers.ReaderService
+	provider      *oidc.Provider
+	groupsClaim   string
+}
+
+// NewOIDCHandler создаёт обработчик входа через OIDC.
+func NewOIDCHandler(service readers.ReaderService, provider *oidc.Provider, groupsClaim string) *OIDCHandler {
+	return &OIDCHandler{readerService: service, provider: provider, groupsClaim: groupsClaim}
+}
+
+// LoginHandler godoc
+// @Summary      Start OIDC login
+// @Description  Перенаправляет на страницу авторизации провайдера OpenID Connect (authorization code flow с PKCE). Параметры входа сохраняются в подписанной cookie bookapi_oidc_state, которую проверяет callback.
+// @Tags         readers
+// @Success      302  "Перенаправление на IdP"
+// @Failure      503  {object}  response.ErrorResponse "Провайдер недоступен"
+// @Router       /auth/oidc/login [get]
+func (h *OIDCHandler) LoginHandler(c *fiber.Ctx) error {
+	authURL, state, err := h.provider.AuthCodeURL(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(response.ErrorResponse{
+			Code:      fiber.StatusServiceUnavailable,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	c.Cookie(stateCookie(c, state, time.Now().Add(oidc.StateTTL)))
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// stateCookie собирает cookie с параметрами входа. SameSite=Lax: браузер
+// отправит её при возврате с IdP, но не при запросах с чужих страниц.
+func stateCookie(c *fiber.Ctx, value string, expires time.Time) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     oidc.StateCookie,
+		Value:    value,
+		Path:     "/auth/oidc",
+		Expires:  expires,
+		Secure:   c.Secure(),
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	}
+}
+
+// CallbackHandler godoc
+// @Summary      OIDC callback
+// @Description  Сверяет state с cookie браузера, начавшего вход, обменивает код авторизации на токены, проверяет ID token и сопоставляет subject/email с читателем (создавая его при первом входе). Дальше действуют те же правила второго фактора, что и при входе по паролю: читатель с включённым TOTP получает challenge для POST /login/2fa.
+// @Tags         readers
+// @Produce      json
+// @Param        code   query     string  true  "Код авторизации"
+// @Param        state  query     string  true  "State из запроса авторизации"
+// @Success      200    {object}  response.BaseResponse  "Успешная аутентификация или требуется второй фактор"
+// @Failure      400    {object}  response.ErrorResponse "Неверный запрос"
+// @Failure      401    {object}  response.ErrorResponse "Провайдер отклонил вход, state не совпал с cookie или токен не прошёл проверку"
+// @Failure      403    {object}  response.ErrorResponse "Читатель не может быть сопоставлен"
+// @Failure      500    {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /auth/oidc/callback [get]
+func (h *OIDCHandler) CallbackHandler(c *fiber.Ctx) error {
+	cookie := c.Cookies(oidc.StateCookie)
+	c.Cookie(stateCookie(c, "", time.Unix(0, 0)))
+
+	if idpErr := c.Query("error"); idpErr != "" {
+		message := idpErr
+		if desc := c.Query("error_description"); desc != "" {
+			message += ": " + desc
+		}
+		metrics.FailedLogins.WithLabelValues(metrics.LoginOIDC).Inc()
+		return c.Status(fiber.StatusUnauthorized).JSON(response.ErrorResponse{
+			Code:      fiber.StatusUnauthorized,
+			Message:   message,
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	state, code := c.Query("state"), c.Query("code")
+	if state == "" || code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "state and code are required",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+
+	claims, err := h.provider.Exchange(c.UserContext(), cookie, state, code)
+	if err != nil {
+		metrics.FailedLogins.WithLabelValues(metrics.LoginOIDC).Inc()
+		return c.Status(fiber.StatusUnauthorized).JSON(response.ErrorResponse{
+			Code:      fiber.StatusUnauthorized,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+
+	result, err := h.readerService.LoginWithOIDC(c.UserContext(), readers.OIDCIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+		Groups:        claims.StringList(h.groupsClaim),
+		AMR:           claims.AMR,
+		ACR:           claims.ACR,
+	})
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, readers.ErrOIDCReaderNotFound) ||
+			errors.Is(err, readers.ErrOIDCEmailNotVerified) ||
+			errors.Is(err, readers.ErrOIDCEmailMissing) ||
+			errors.Is(err, readers.ErrOIDCSubjectConflict) {
+			status = fiber.StatusForbidden
+		}
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	return loginResponse(c, result)
+}
@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
 	"github.com/0sokrat0/BookAPI/internal/service/readers"
 	"github.com/0sokrat0/BookAPI/pkg/response"
 	"github.com/gofiber/fiber/v2"
@@ -42,21 +43,30 @@ func NewHandler(service readers.ReaderService) *Handler {
 
 // CreateReaderHandler godoc
 // @Summary      Create a new reader
-// @Description  Создаёт нового читателя с предоставленными данными.
+// @Description  Создаёт нового читателя с предоставленными данными. Флаг admin может выставить только администратор.
 // @Tags         readers
 // @Accept       json
 // @Produce      json
 // @Param        reader  body      readerhandlers.CreateReaderRequest  true  "Параметры для создания читателя. Пример: {\"name\":\"Ivan Ivanov\", \"phone\":\"+79111234567\", \"email\":\"ivan@example.com\", \"password\":\"password123\", \"admin\":false}"
 // @Success      200     {object}  response.BaseResponse "Созданный читатель с уникальным ID"
 // @Failure      400     {object}  response.ErrorResponse  "Неверный запрос"
+// @Failure      403     {object}  response.ErrorResponse  "Флаг admin без прав администратора"
 // @Failure      500     {object}  response.ErrorResponse  "Ошибка сервера"
 // @Router       /reader [post]
 func (h *Handler) CreateReaderHandler(c *fiber.Ctx) error {
 	var req CreateReaderRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid request: " + err.Error(),
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	if req.Admin && !middleware.IsAdmin(c) {
+		return c.Status(fiber.StatusForbidden).JSON(response.ErrorResponse{
+			Code:      fiber.StatusForbidden,
+			Message:   "Only administrators can grant administrator rights",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 
@@ -70,8 +80,9 @@ func (h *Handler) CreateReaderHandler(c *fiber.Ctx) error {
 	reader, err := h.readerService.CreateReader(c.UserContext(), cmdReq)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -95,15 +106,17 @@ func (h *Handler) GetReaderHandler(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid reader ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reader ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	reader, err := h.readerService.GetReader(c.UserContext(), id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(response.ErrorResponse{
-			Code:    fiber.StatusNotFound,
-			Message: "Reader not found",
+			Code:      fiber.StatusNotFound,
+			Message:   "Reader not found",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -115,7 +128,7 @@ func (h *Handler) GetReaderHandler(c *fiber.Ctx) error {
 
 // UpdateReaderHandler godoc
 // @Summary      Update a reader
-// @Description  Обновляет данные существующего читателя.
+// @Description  Обновляет данные существующего читателя. Флаг admin может выставить только администратор.
 // @Tags         readers
 // @Accept       json
 // @Produce      json
@@ -123,21 +136,31 @@ func (h *Handler) GetReaderHandler(c *fiber.Ctx) error {
 // @Param        reader  body      readerhandlers.UpdateReaderRequest  true  "Новые данные читателя. Пример: {\"name\":\"Ivan Ivanov\", \"phone\":\"+79111234567\", \"email\":\"ivan@example.com\", \"password\":\"newpassword\", \"admin\":false}"
 // @Success      200     {object}  response.BaseResponse "Обновлённые данные читателя"
 // @Failure      400     {object}  response.ErrorResponse  "Неверный запрос"
+// @Failure      403     {object}  response.ErrorResponse  "Флаг admin без прав администратора"
 // @Failure      500     {object}  response.ErrorResponse  "Ошибка сервера"
 // @Router       /reader/{id} [put]
 func (h *Handler) UpdateReaderHandler(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid reader ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reader ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	var req UpdateReaderRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid request: " + err.Error(),
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	if req.Admin && !middleware.IsAdmin(c) {
+		return c.Status(fiber.StatusForbidden).JSON(response.ErrorResponse{
+			Code:      fiber.StatusForbidden,
+			Message:   "Only administrators can grant administrator rights",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	cmdReq := commands.UpdateReaderRequest{
@@ -150,8 +173,9 @@ func (h *Handler) UpdateReaderHandler(c *fiber.Ctx) error {
 	updatedReader, err := h.readerService.UpdateReader(c.UserContext(), id, cmdReq)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -175,14 +199,16 @@ func (h *Handler) DeleteReaderHandler(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid reader ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reader ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	if err := h.readerService.DeleteReader(c.UserContext(), id); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -203,8 +229,9 @@ func (h *Handler) ListReadersHandler(c *fiber.Ctx) error {
 	readersList, err := h.readerService.ListReaders(c.UserContext())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -218,15 +245,17 @@ func (h *Handler) GetReadersByEmailHandler(c *fiber.Ctx) error {
 	email := c.Query("email")
 	if email == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Email is required",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Email is required",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	reader, err := h.readerService.GetReaderByEmail(c.UserContext(), email)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -238,12 +267,12 @@ func (h *Handler) GetReadersByEmailHandler(c *fiber.Ctx) error {
 
 // AuthenticateReaderHandler godoc
 // @Summary      Authenticate reader
-// @Description  Аутентифицирует пользователя по email и паролю. При неверном пароле возвращает ошибку Unauthorized.
+// @Description  Аутентифицирует пользователя по email и паролю. Если у читателя включена двухфакторная аутентификация, вместо токена возвращается challenge для шага /login/2fa. Администратор без второго фактора при AUTH_REQUIRE_ADMIN_2FA получает вместо сессии токен подключения второго фактора и two_factor_enrollment_required: его принимают только маршруты /reader/{id}/2fa, остальные считают запрос анонимным. При неверном пароле возвращает ошибку Unauthorized.
 // @Tags         readers
 // @Accept       json
 // @Produce      json
 // @Param        credentials  body      readerhandlers.LoginRequest  true  "Данные для аутентификации. Пример: {\"email\":\"ivan@example.com\", \"password\":\"password123\"}"
-// @Success      200          {object}  response.BaseResponse  "Успешная аутентификация: данные пользователя"
+// @Success      200          {object}  response.BaseResponse  "Успешная аутентификация: данные пользователя и токен либо challenge второго фактора"
 // @Failure      400          {object}  response.ErrorResponse "Неверный формат запроса"
 // @Failure      401          {object}  response.ErrorResponse "Неверный пароль или пользователь не найден"
 // @Failure      500          {object}  response.ErrorResponse "Ошибка сервера"
@@ -252,27 +281,55 @@ func (h *Handler) AuthenticateReaderHandler(c *fiber.Ctx) error {
 	var req LoginRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid request: " + err.Error(),
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 
-	reader, err := h.readerService.Authenticate(c.UserContext(), req.Email, req.Password)
+	result, err := h.readerService.Login(c.UserContext(), req.Email, req.Password)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(response.ErrorResponse{
-			Code:    fiber.StatusUnauthorized,
-			Message: err.Error(),
+		status := authErrorStatus(err)
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 
+	return loginResponse(c, result)
+}
+
+// loginResponse отвечает на вход: вызовом второго фактора или токеном сессии.
+func loginResponse(c *fiber.Ctx, result *readers.LoginResult) error {
+	if result.TwoFactorRequired {
+		return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+			Code:    fiber.StatusOK,
+			Message: "Two-factor authentication required",
+			Data: map[string]interface{}{
+				"two_factor_required": true,
+				"challenge":           result.Challenge,
+			},
+		})
+	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Code:    fiber.StatusOK,
 		Message: "Authentication successful",
-		Data: map[string]interface{}{
-			"id":    reader.ID,
-			"name":  reader.Name,
-			"email": reader.Email,
-			"admin": reader.Admin,
-		},
+		Data:    loginData(result),
 	})
 }
+
+func loginData(result *readers.LoginResult) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":         result.Reader.ID,
+		"name":       result.Reader.Name,
+		"email":      result.Reader.Email,
+		"admin":      result.Reader.Admin && !result.TwoFactorEnrollmentRequired,
+		"token":      result.Token,
+		"expires_at": result.ExpiresAt,
+	}
+	if result.TwoFactorEnrollmentRequired {
+		data["two_factor_enrollment_required"] = true
+	}
+	return data
+}
@@ -0,0 +1,284 @@
+package readerhandlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/service/readers"
+	"github.com/0sokrat0/BookAPI/pkg/authtoken"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TwoFactorLoginRequest содержит challenge из /login и код второго фактора.
+// swagger:model TwoFactorLoginRequest
+type TwoFactorLoginRequest struct {
+	Challenge string `json:"challenge" example:"eyJzdWIiOjF9.c2lnbmF0dXJl"`
+	Code      string `json:"code" example:"123456"`
+}
+
+// TOTPEnrollRequest подтверждает владение аккаунтом паролем.
+// swagger:model TOTPEnrollRequest
+type TOTPEnrollRequest struct {
+	Password string `json:"password" example:"password123"`
+}
+
+// TOTPConfirmRequest содержит первый код из приложения-аутентификатора.
+// swagger:model TOTPConfirmRequest
+type TOTPConfirmRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+// TOTPVerifyRequest содержит пароль и код второго фактора (TOTP или код восстановления).
+// swagger:model TOTPVerifyRequest
+type TOTPVerifyRequest struct {
+	Password string `json:"password" example:"password123"`
+	Code     string `json:"code" example:"123456"`
+}
+
+// TwoFactorLoginHandler godoc
+// @Summary      Complete two-factor login
+// @Description  Второй шаг входа: принимает challenge, выданный /login, и TOTP-код либо код восстановления. Возвращает токен сессии.
+// @Tags         readers
+// @Accept       json
+// @Produce      json
+// @Param        request  body      readerhandlers.TwoFactorLoginRequest  true  "Challenge и код"
+// @Success      200      {object}  response.BaseResponse  "Успешная аутентификация"
+// @Failure      400      {object}  response.ErrorResponse "Неверный формат запроса"
+// @Failure      401      {object}  response.ErrorResponse "Неверный или просроченный challenge либо код"
+// @Failure      500      {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /login/2fa [post]
+func (h *Handler) TwoFactorLoginHandler(c *fiber.Ctx) error {
+	var req TwoFactorLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	result, err := h.readerService.CompleteTwoFactorLogin(c.UserContext(), req.Challenge, req.Code)
+	if err != nil {
+		status := authErrorStatus(err)
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Authentication successful",
+		Data:    loginData(result),
+	})
+}
+
+// EnrollTOTPHandler godoc
+// @Summary      Start TOTP enrollment
+// @Description  Генерирует секрет TOTP, otpauth:// URI для QR-кода и коды восстановления. Второй фактор включается только после подтверждения кодом. Доступно только самому читателю.
+// @Tags         readers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true  "Уникальный ID читателя"
+// @Param        request  body      readerhandlers.TOTPEnrollRequest  true  "Текущий пароль"
+// @Success      200      {object}  response.BaseResponse  "Секрет, URI и коды восстановления"
+// @Failure      400      {object}  response.ErrorResponse "Неверный запрос"
+// @Failure      401      {object}  response.ErrorResponse "Нет сессии, неверный пароль"
+// @Failure      403      {object}  response.ErrorResponse "Другой читатель"
+// @Failure      409      {object}  response.ErrorResponse "Двухфакторная аутентификация уже включена"
+// @Failure      500      {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /reader/{id}/2fa/enroll [post]
+func (h *Handler) EnrollTOTPHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reader ID",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	var req TOTPEnrollRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	enrollment, err := h.readerService.EnrollTOTP(c.UserContext(), id, req.Password)
+	if err != nil {
+		status := authErrorStatus(err)
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Scan the provisioning URI and confirm with a code",
+		Data:    enrollment,
+	})
+}
+
+// ConfirmTOTPHandler godoc
+// @Summary      Confirm TOTP enrollment
+// @Description  Включает двухфакторную аутентификацию после проверки первого кода из приложения. Доступно только самому читателю.
+// @Tags         readers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true  "Уникальный ID читателя"
+// @Param        request  body      readerhandlers.TOTPConfirmRequest  true  "Код из приложения"
+// @Success      200      {object}  response.BaseResponse  "Двухфакторная аутентификация включена"
+// @Failure      400      {object}  response.ErrorResponse "Неверный запрос"
+// @Failure      401      {object}  response.ErrorResponse "Нет сессии, неверный код"
+// @Failure      403      {object}  response.ErrorResponse "Другой читатель"
+// @Failure      409      {object}  response.ErrorResponse "Нет незавершённой настройки"
+// @Failure      500      {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /reader/{id}/2fa/confirm [post]
+func (h *Handler) ConfirmTOTPHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reader ID",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	var req TOTPConfirmRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	if err := h.readerService.ConfirmTOTP(c.UserContext(), id, req.Code); err != nil {
+		status := authErrorStatus(err)
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Two-factor authentication enabled",
+	})
+}
+
+// DisableTOTPHandler godoc
+// @Summary      Disable TOTP
+// @Description  Отключает двухфакторную аутентификацию и удаляет коды восстановления. Требует пароль и действующий код. Доступно только самому читателю.
+// @Tags         readers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true  "Уникальный ID читателя"
+// @Param        request  body      readerhandlers.TOTPVerifyRequest  true  "Пароль и код"
+// @Success      200      {object}  response.BaseResponse  "Двухфакторная аутентификация отключена"
+// @Failure      400      {object}  response.ErrorResponse "Неверный запрос"
+// @Failure      401      {object}  response.ErrorResponse "Нет сессии, неверный пароль или код"
+// @Failure      403      {object}  response.ErrorResponse "Другой читатель"
+// @Failure      409      {object}  response.ErrorResponse "Двухфакторная аутентификация не включена"
+// @Failure      500      {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /reader/{id}/2fa/disable [post]
+func (h *Handler) DisableTOTPHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reader ID",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	var req TOTPVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	if err := h.readerService.DisableTOTP(c.UserContext(), id, req.Password, req.Code); err != nil {
+		status := authErrorStatus(err)
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Two-factor authentication disabled",
+	})
+}
+
+// RegenerateRecoveryCodesHandler godoc
+// @Summary      Regenerate recovery codes
+// @Description  Выпускает новый набор кодов восстановления; прежние коды перестают действовать. Доступно только самому читателю.
+// @Tags         readers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true  "Уникальный ID читателя"
+// @Param        request  body      readerhandlers.TOTPVerifyRequest  true  "Пароль и код"
+// @Success      200      {object}  response.BaseResponse  "Новые коды восстановления"
+// @Failure      400      {object}  response.ErrorResponse "Неверный запрос"
+// @Failure      401      {object}  response.ErrorResponse "Нет сессии, неверный пароль или код"
+// @Failure      403      {object}  response.ErrorResponse "Другой читатель"
+// @Failure      409      {object}  response.ErrorResponse "Двухфакторная аутентификация не включена"
+// @Failure      500      {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /reader/{id}/2fa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodesHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reader ID",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	var req TOTPVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	codes, err := h.readerService.RegenerateRecoveryCodes(c.UserContext(), id, req.Password, req.Code)
+	if err != nil {
+		status := authErrorStatus(err)
+		return c.Status(status).JSON(response.ErrorResponse{
+			Code:      status,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Recovery codes regenerated",
+		Data:    map[string]interface{}{"recovery_codes": codes},
+	})
+}
+
+// authErrorStatus сопоставляет ошибки входа и второго фактора с HTTP-статусами.
+func authErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, readers.ErrInvalidCredentials),
+		errors.Is(err, readers.ErrInvalidTwoFactorCode),
+		errors.Is(err, authtoken.ErrInvalidToken),
+		errors.Is(err, authtoken.ErrExpiredToken):
+		return fiber.StatusUnauthorized
+	case errors.Is(err, readers.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, readers.ErrTwoFactorNotEnabled),
+		errors.Is(err, readers.ErrNoPendingEnrollment):
+		return fiber.StatusConflict
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
@@ -0,0 +1,407 @@
+package readinglisthandlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/readinglists"
+	readinglistsvc "github.com/0sokrat0/BookAPI/internal/service/readinglists"
+	"github.com/0sokrat0/BookAPI/internal/service/reservations"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateReadingListRequest содержит название списка и признак публичности.
+// swagger:model CreateReadingListRequest
+type CreateReadingListRequest struct {
+	Name   string `json:"name" example:"Прочитать летом"`
+	Public bool   `json:"public" example:"false"`
+}
+
+// UpdateReadingListRequest заменяет название и публичность списка.
+// swagger:model UpdateReadingListRequest
+type UpdateReadingListRequest struct {
+	Name   string `json:"name" example:"Прочитать летом"`
+	Public bool   `json:"public" example:"true"`
+}
+
+// AddBookRequest — книга, добавляемая в конец списка.
+// swagger:model AddBookRequest
+type AddBookRequest struct {
+	BookID int `json:"book_id" example:"4"`
+}
+
+// ReorderBooksRequest — все книги списка в новом порядке.
+// swagger:model ReorderBooksRequest
+type ReorderBooksRequest struct {
+	BookIDs []int `json:"book_ids"`
+}
+
+// ReserveRequest — срок бронирования; без дат — с сегодняшнего дня на
+// 14 дней.
+// swagger:model ReserveRequest
+type ReserveRequest struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+type Handler struct {
+	listService readinglistsvc.ReadingListService
+}
+
+func NewHandler(listService readinglistsvc.ReadingListService) *Handler {
+	return &Handler{listService: listService}
+}
+
+func listError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, readinglists.ErrNotFound), errors.Is(err, books.ErrNotFound),
+		errors.Is(err, readinglists.ErrNotListed):
+		status = fiber.StatusNotFound
+	case errors.Is(err, readinglistsvc.ErrInvalidInput), errors.Is(err, reservations.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, readinglistsvc.ErrForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, readinglists.ErrAlreadyListed), errors.Is(err, readinglistsvc.ErrNothingAvailable),
+		errors.Is(err, reservations.ErrNoEditionAvailable):
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+func badRequest(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+		Code:      fiber.StatusBadRequest,
+		Message:   message,
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+// viewer описывает читателя сессии; анонимный запрос — нулевой ReaderID.
+func viewer(c *fiber.Ctx) readinglistsvc.Viewer {
+	id, _ := middleware.ReaderID(c)
+	return readinglistsvc.Viewer{ReaderID: id, Admin: middleware.IsAdmin(c)}
+}
+
+// CreateListHandler godoc
+// @Summary      Create a reading list
+// @Description  Создаёт пустой список книг читателя сессии. Публичный список сразу получает ссылку Slug для общего доступа.
+// @Tags         reading-lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        list  body      readinglisthandlers.CreateReadingListRequest  true  "Название и публичность"
+// @Success      200   {object}  response.BaseResponse{data=readinglists.ReadingList} "Созданный список"
+// @Failure      400   {object}  response.ErrorResponse "Пустое или слишком длинное название"
+// @Failure      401   {object}  response.ErrorResponse "Требуется аутентификация"
+// @Router       /reading-list [post]
+func (h *Handler) CreateListHandler(c *fiber.Ctx) error {
+	var req CreateReadingListRequest
+	if err := c.BodyParser(&req); err != nil {
+		return badRequest(c, "Invalid request: "+err.Error())
+	}
+	readerID, _ := middleware.ReaderID(c)
+	list, err := h.listService.CreateList(c.UserContext(), readerID, commands.CreateReadingListRequest{
+		Name:   req.Name,
+		Public: req.Public,
+	})
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reading list created successfully",
+		Data:    list,
+	})
+}
+
+// ReaderListsHandler godoc
+// @Summary      List own reading lists
+// @Description  Возвращает списки читателя сессии по возрастанию ID с ID книг в их порядке.
+// @Tags         reading-lists
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.BaseResponse{data=[]readinglists.ReadingList} "Списки"
+// @Failure      401  {object}  response.ErrorResponse "Требуется аутентификация"
+// @Router       /reading-lists [get]
+func (h *Handler) ReaderListsHandler(c *fiber.Ctx) error {
+	readerID, _ := middleware.ReaderID(c)
+	lists, err := h.listService.ReaderLists(c.UserContext(), readerID)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reading lists retrieved successfully",
+		Data:    lists,
+	})
+}
+
+// GetListHandler godoc
+// @Summary      Get a reading list
+// @Description  Возвращает список с книгами в его порядке; Available — книга сегодня не забронирована. Приватный список видят только владелец и администраторы.
+// @Tags         reading-lists
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID списка"
+// @Success      200  {object}  response.BaseResponse{data=readinglistsvc.ListView} "Список с книгами"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Список не найден"
+// @Router       /reading-list/{id} [get]
+func (h *Handler) GetListHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid reading list ID")
+	}
+	view, err := h.listService.GetList(c.UserContext(), viewer(c), id)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reading list retrieved successfully",
+		Data:    view,
+	})
+}
+
+// SharedListHandler godoc
+// @Summary      Get a shared reading list
+// @Description  Возвращает публичный список по ссылке без аутентификации. После закрытия списка ссылка перестаёт работать.
+// @Tags         reading-lists
+// @Produce      json
+// @Param        slug  path      string  true  "Ссылка списка"
+// @Success      200   {object}  response.BaseResponse{data=readinglistsvc.ListView} "Список с книгами"
+// @Failure      404   {object}  response.ErrorResponse "Список не найден или закрыт"
+// @Router       /reading-list/shared/{slug} [get]
+func (h *Handler) SharedListHandler(c *fiber.Ctx) error {
+	view, err := h.listService.SharedList(c.UserContext(), c.Params("slug"))
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reading list retrieved successfully",
+		Data:    view,
+	})
+}
+
+// UpdateListHandler godoc
+// @Summary      Update a reading list
+// @Description  Меняет название и публичность своего списка. Закрытие списка отзывает ссылку, повторное открытие выдаёт новую.
+// @Tags         reading-lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int  true  "Уникальный ID списка"
+// @Param        list  body      readinglisthandlers.UpdateReadingListRequest  true  "Название и публичность"
+// @Success      200   {object}  response.BaseResponse{data=readinglists.ReadingList} "Обновлённый список"
+// @Failure      400   {object}  response.ErrorResponse "Неверный ID или название"
+// @Failure      401   {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403   {object}  response.ErrorResponse "Чужой список"
+// @Failure      404   {object}  response.ErrorResponse "Список не найден"
+// @Router       /reading-list/{id} [put]
+func (h *Handler) UpdateListHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid reading list ID")
+	}
+	var req UpdateReadingListRequest
+	if err := c.BodyParser(&req); err != nil {
+		return badRequest(c, "Invalid request: "+err.Error())
+	}
+	readerID, _ := middleware.ReaderID(c)
+	list, err := h.listService.UpdateList(c.UserContext(), readerID, id, commands.UpdateReadingListRequest{
+		Name:   req.Name,
+		Public: req.Public,
+	})
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reading list updated successfully",
+		Data:    list,
+	})
+}
+
+// DeleteListHandler godoc
+// @Summary      Delete a reading list
+// @Description  Удаляет список. Чужой список может удалить только администратор.
+// @Tags         reading-lists
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Уникальный ID списка"
+// @Success      200  {object}  response.BaseResponse "Список удалён"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      401  {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403  {object}  response.ErrorResponse "Чужой список"
+// @Failure      404  {object}  response.ErrorResponse "Список не найден"
+// @Router       /reading-list/{id} [delete]
+func (h *Handler) DeleteListHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid reading list ID")
+	}
+	if err := h.listService.DeleteList(c.UserContext(), viewer(c), id); err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reading list deleted successfully",
+	})
+}
+
+// AddBookHandler godoc
+// @Summary      Add a book to a reading list
+// @Description  Добавляет книгу в конец своего списка; в списке не больше 200 книг.
+// @Tags         reading-lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int  true  "Уникальный ID списка"
+// @Param        book  body      readinglisthandlers.AddBookRequest  true  "Книга"
+// @Success      200   {object}  response.BaseResponse{data=readinglistsvc.ListView} "Список с книгами"
+// @Failure      400   {object}  response.ErrorResponse "Неверный запрос или список заполнен"
+// @Failure      401   {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403   {object}  response.ErrorResponse "Чужой список"
+// @Failure      404   {object}  response.ErrorResponse "Список или книга не найдены"
+// @Failure      409   {object}  response.ErrorResponse "Книга уже в списке"
+// @Router       /reading-list/{id}/books [post]
+func (h *Handler) AddBookHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid reading list ID")
+	}
+	var req AddBookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return badRequest(c, "Invalid request: "+err.Error())
+	}
+	readerID, _ := middleware.ReaderID(c)
+	view, err := h.listService.AddBook(c.UserContext(), readerID, id, req.BookID)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Book added to the reading list",
+		Data:    view,
+	})
+}
+
+// RemoveBookHandler godoc
+// @Summary      Remove a book from a reading list
+// @Description  Убирает книгу из своего списка; порядок остальных сохраняется.
+// @Tags         reading-lists
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int  true  "Уникальный ID списка"
+// @Param        bookID  path      int  true  "Уникальный ID книги"
+// @Success      200     {object}  response.BaseResponse{data=readinglistsvc.ListView} "Список с книгами"
+// @Failure      400     {object}  response.ErrorResponse "Неверный ID"
+// @Failure      401     {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403     {object}  response.ErrorResponse "Чужой список"
+// @Failure      404     {object}  response.ErrorResponse "Список не найден или книги нет в списке"
+// @Router       /reading-list/{id}/books/{bookID} [delete]
+func (h *Handler) RemoveBookHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid reading list ID")
+	}
+	bookID, err := strconv.Atoi(c.Params("bookID"))
+	if err != nil {
+		return badRequest(c, "Invalid book ID")
+	}
+	readerID, _ := middleware.ReaderID(c)
+	view, err := h.listService.RemoveBook(c.UserContext(), readerID, id, bookID)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Book removed from the reading list",
+		Data:    view,
+	})
+}
+
+// ReorderBooksHandler godoc
+// @Summary      Reorder books of a reading list
+// @Description  Задаёт новый порядок книг своего списка; book_ids должен содержать ровно книги списка.
+// @Tags         reading-lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int  true  "Уникальный ID списка"
+// @Param        order  body      readinglisthandlers.ReorderBooksRequest  true  "Книги в новом порядке"
+// @Success      200    {object}  response.BaseResponse{data=readinglistsvc.ListView} "Список с книгами"
+// @Failure      400    {object}  response.ErrorResponse "Порядок не совпадает с книгами списка"
+// @Failure      401    {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403    {object}  response.ErrorResponse "Чужой список"
+// @Failure      404    {object}  response.ErrorResponse "Список не найден или книги нет в списке"
+// @Router       /reading-list/{id}/books [put]
+func (h *Handler) ReorderBooksHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid reading list ID")
+	}
+	var req ReorderBooksRequest
+	if err := c.BodyParser(&req); err != nil {
+		return badRequest(c, "Invalid request: "+err.Error())
+	}
+	readerID, _ := middleware.ReaderID(c)
+	view, err := h.listService.ReorderBooks(c.UserContext(), readerID, id, req.BookIDs)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reading list reordered successfully",
+		Data:    view,
+	})
+}
+
+// ReserveHandler godoc
+// @Summary      Reserve the first available book of a reading list
+// @Description  Бронирует на владельца списка первую по порядку книгу, свободную на весь срок. Без дат срок — с сегодняшнего дня на 14 дней. Книга остаётся в списке.
+// @Tags         reading-lists
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int  true   "Уникальный ID списка"
+// @Param        period  body      readinglisthandlers.ReserveRequest  false  "Срок бронирования"
+// @Success      200     {object}  response.BaseResponse "Созданное бронирование"
+// @Failure      400     {object}  response.ErrorResponse "Неверный ID или даты, начало в прошлом"
+// @Failure      401     {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403     {object}  response.ErrorResponse "Чужой список"
+// @Failure      404     {object}  response.ErrorResponse "Список не найден"
+// @Failure      409     {object}  response.ErrorResponse "Все книги списка заняты"
+// @Router       /reading-list/{id}/reserve [post]
+func (h *Handler) ReserveHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid reading list ID")
+	}
+	var req ReserveRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return badRequest(c, "Invalid request: "+err.Error())
+		}
+	}
+	readerID, _ := middleware.ReaderID(c)
+	reservation, err := h.listService.ReserveFirstAvailable(c.UserContext(), readerID, id, req.StartDate, req.EndDate)
+	if err != nil {
+		return listError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reservation created successfully",
+		Data:    reservation,
+	})
+}
@@ -0,0 +1,126 @@
+package recommendationhandlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	recommendsvc "github.com/0sokrat0/BookAPI/internal/service/recommendations"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	recommendService recommendsvc.RecommendationService
+}
+
+func NewHandler(recommendService recommendsvc.RecommendationService) *Handler {
+	return &Handler{recommendService: recommendService}
+}
+
+func recommendationError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, books.ErrNotFound), errors.Is(err, readers.ErrNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, recommendsvc.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, recommendsvc.ErrForbidden):
+		status = fiber.StatusForbidden
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+func badRequest(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+		Code:      fiber.StatusBadRequest,
+		Message:   message,
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+// limit разбирает параметр limit; без параметра — 0, сервис подставит
+// значение по умолчанию.
+func limit(c *fiber.Ctx) (int, bool) {
+	v := c.Query("limit")
+	if v == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(v)
+	return n, err == nil
+}
+
+// SimilarBooksHandler godoc
+// @Summary      Books borrowed together with this one
+// @Description  «Читатели, бравшие эту книгу, брали и эти»: книги по убыванию косинусной близости множеств их читателей (score от 0 до 1), readers — число общих читателей. Соседи пересчитываются фоновой задачей по всей истории бронирований, поэтому новые бронирования учитываются не сразу.
+// @Tags         recommendations
+// @Produce      json
+// @Param        id     path      int  true   "Уникальный ID книги"
+// @Param        limit  query     int  false  "Сколько книг вернуть, от 1 до 50; по умолчанию 10"
+// @Success      200    {object}  response.BaseResponse{data=[]recommendsvc.Suggestion} "Похожие книги"
+// @Failure      400    {object}  response.ErrorResponse "Неверный ID или limit"
+// @Failure      404    {object}  response.ErrorResponse "Книга не найдена"
+// @Failure      500    {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /book/{id}/similar [get]
+func (h *Handler) SimilarBooksHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid book ID")
+	}
+	n, ok := limit(c)
+	if !ok {
+		return badRequest(c, "Invalid limit parameter")
+	}
+	list, err := h.recommendService.SimilarBooks(c.UserContext(), id, n)
+	if err != nil {
+		return recommendationError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Similar books retrieved successfully",
+		Data:    list,
+	})
+}
+
+// ReaderRecommendationsHandler godoc
+// @Summary      Personal recommendations for a reader
+// @Description  Книги, которые брали вместе с книгами из истории бронирований читателя, кроме тех, что он уже брал. score — сумма близостей к его книгам, because — ID книг читателя, по которым подобрана рекомендация. Свои рекомендации видит читатель сессии, чужие — только администратор.
+// @Tags         recommendations
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int  true   "Уникальный ID читателя"
+// @Param        limit  query     int  false  "Сколько книг вернуть, от 1 до 50; по умолчанию 10"
+// @Success      200    {object}  response.BaseResponse{data=[]recommendsvc.Suggestion} "Рекомендации"
+// @Failure      400    {object}  response.ErrorResponse "Неверный ID или limit"
+// @Failure      401    {object}  response.ErrorResponse "Нет сессии"
+// @Failure      403    {object}  response.ErrorResponse "Рекомендации другого читателя"
+// @Failure      404    {object}  response.ErrorResponse "Читатель не найден"
+// @Failure      500    {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /reader/{id}/recommendations [get]
+func (h *Handler) ReaderRecommendationsHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid reader ID")
+	}
+	n, ok := limit(c)
+	if !ok {
+		return badRequest(c, "Invalid limit parameter")
+	}
+	readerID, _ := middleware.ReaderID(c)
+	viewer := recommendsvc.Viewer{ReaderID: readerID, Admin: middleware.IsAdmin(c)}
+	list, err := h.recommendService.ReaderRecommendations(c.UserContext(), viewer, id, n)
+	if err != nil {
+		return recommendationError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Recommendations retrieved successfully",
+		Data:    list,
+	})
+}
@@ -0,0 +1,36 @@
+package reservations
+
+import (
+	"errors"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ParseFilter читает фильтр бронирований из query-параметров startDate и
+// endDate (YYYY-MM-DD) — общий для списка и выгрузки. Если required равен
+// false, отсутствующая дата не ограничивает выборку. Текст ошибки годится
+// для ответа клиенту.
+func ParseFilter(c *fiber.Ctx, required bool) (reservations.Filter, error) {
+	var filter reservations.Filter
+	parse := func(name, message string) (time.Time, error) {
+		value := c.Query(name)
+		if value == "" && !required {
+			return time.Time{}, nil
+		}
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, errors.New(message)
+		}
+		return t, nil
+	}
+	var err error
+	if filter.StartDate, err = parse("startDate", "Invalid startDate format"); err != nil {
+		return filter, err
+	}
+	if filter.EndDate, err = parse("endDate", "Invalid endDate format"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
@@ -1,9 +1,12 @@
 package reservations
 
 import (
+	"errors"
 	"time"
 
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
 	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	domainReservations "github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
 	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
 	"github.com/0sokrat0/BookAPI/internal/service/reservations"
 	"github.com/0sokrat0/BookAPI/pkg/response"
@@ -13,6 +16,7 @@ import (
 type CreateReservationRequestDTO struct {
 	ID        int       `json:"id"`         // Если ID генерируется базой, можно опустить
 	BookID    int       `json:"book_id"`    // Идентификатор книги
+	WorkID    int       `json:"work_id"`    // Любое свободное издание произведения, если book_id не задан
 	ReaderID  int       `json:"reader_id"`  // Идентификатор читателя
 	StartDate time.Time `json:"start_date"` // Начало бронирования
 	EndDate   time.Time `json:"end_date"`   // Окончание бронирования
@@ -34,23 +38,53 @@ func NewHandler(s reservations.ReservationService) *Handler {
 	return &Handler{reservationService: s}
 }
 
+// viewer описывает читателя сессии.
+func viewer(c *fiber.Ctx) reservations.Viewer {
+	id, _ := middleware.ReaderID(c)
+	return reservations.Viewer{ReaderID: id, Admin: middleware.IsAdmin(c)}
+}
+
+func reservationError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, domainReservations.ErrNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, reservations.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, reservations.ErrForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, reservations.ErrNoEditionAvailable), errors.Is(err, reservations.ErrAlreadyReturned):
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
 // CreateReservationHandler godoc
 // @Summary      Create a new reservation
-// @Description  Создаёт новое бронирование в системе.
+// @Description  Создаёт новое бронирование в системе. Вместо book_id можно передать work_id — тогда бронируется издание произведения с наименьшим ID, свободное на весь срок. Читатель бронирует только на себя и не раньше сегодняшнего дня; администратор может записать бронирование любого читателя, в том числе прошедшее.
 // @Tags         reservations
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        request  body      CreateReservationRequestDTO  true  "Reservation creation request"
 // @Success      200      {object}  response.BaseResponse "Бронирование создано успешно"
 // @Failure      400      {object}  response.ErrorResponse  "Invalid request"
+// @Failure      401      {object}  response.ErrorResponse  "Требуется аутентификация"
+// @Failure      403      {object}  response.ErrorResponse  "Бронирование на другого читателя"
+// @Failure      409      {object}  response.ErrorResponse  "Нет свободного издания произведения"
 // @Failure      500      {object}  response.ErrorResponse  "Internal server error"
 // @Router       /reservation [post]
 func (h *Handler) CreateReservationHandler(c *fiber.Ctx) error {
 	var req CreateReservationRequestDTO
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid request",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	book := books.Book{ID: req.BookID}
@@ -58,16 +92,14 @@ func (h *Handler) CreateReservationHandler(c *fiber.Ctx) error {
 	serviceReq := reservations.CreateReservationRequest{
 		ID:        req.ID,
 		Book:      book,
+		WorkID:    req.WorkID,
 		Reader:    reader,
 		StartDate: req.StartDate,
 		EndDate:   req.EndDate,
 	}
-	reservation, err := h.reservationService.CreateReservation(c.UserContext(), serviceReq)
+	reservation, err := h.reservationService.CreateReservation(c.UserContext(), viewer(c), serviceReq)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
-		})
+		return reservationError(c, err)
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Code:    fiber.StatusOK,
@@ -90,15 +122,17 @@ func (h *Handler) GetReservationHandler(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid reservation ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reservation ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	reservation, err := h.reservationService.GetReservationByID(c.UserContext(), id)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(response.ErrorResponse{
-			Code:    fiber.StatusNotFound,
-			Message: err.Error(),
+			Code:      fiber.StatusNotFound,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -110,21 +144,26 @@ func (h *Handler) GetReservationHandler(c *fiber.Ctx) error {
 
 // UpdateReservationHandler godoc
 // @Summary      Update reservation
-// @Description  Обновляет данные существующего бронирования.
+// @Description  Обновляет данные существующего бронирования. Читатель меняет только своё бронирование и не может перенести начало в прошлое; уже начавшееся можно продлить, не меняя start_date.
 // @Tags         reservations
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Param        request  body      UpdateReservationRequestDTO  true  "Reservation update request"
 // @Success      200      {object}  response.BaseResponse "Бронирование обновлено успешно"
 // @Failure      400      {object}  response.ErrorResponse  "Invalid request"
+// @Failure      401      {object}  response.ErrorResponse  "Требуется аутентификация"
+// @Failure      403      {object}  response.ErrorResponse  "Бронирование другого читателя"
+// @Failure      404      {object}  response.ErrorResponse  "Бронирование не найдено"
 // @Failure      500      {object}  response.ErrorResponse  "Internal server error"
 // @Router       /reservation [put]
 func (h *Handler) UpdateReservationHandler(c *fiber.Ctx) error {
 	var req UpdateReservationRequestDTO
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid request",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request",
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	book := books.Book{ID: req.BookID}
@@ -136,11 +175,8 @@ func (h *Handler) UpdateReservationHandler(c *fiber.Ctx) error {
 		StartDate: req.StartDate,
 		EndDate:   req.EndDate,
 	}
-	if err := h.reservationService.UpdateReservation(c.UserContext(), serviceReq); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
-		})
+	if err := h.reservationService.UpdateReservation(c.UserContext(), viewer(c), serviceReq); err != nil {
+		return reservationError(c, err)
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Code:    fiber.StatusOK,
@@ -150,27 +186,29 @@ func (h *Handler) UpdateReservationHandler(c *fiber.Ctx) error {
 
 // DeleteReservationHandler godoc
 // @Summary      Delete reservation
-// @Description  Удаляет бронирование по его идентификатору.
+// @Description  Удаляет бронирование по его идентификатору. Читатель удаляет только свои бронирования.
 // @Tags         reservations
 // @Produce      json
+// @Security     BearerAuth
 // @Param        id   path      int  true  "Reservation ID"
 // @Success      200  {object}  response.BaseResponse "Бронирование удалено успешно"
 // @Failure      400  {object}  response.ErrorResponse  "Invalid ID"
+// @Failure      401  {object}  response.ErrorResponse  "Требуется аутентификация"
+// @Failure      403  {object}  response.ErrorResponse  "Бронирование другого читателя"
+// @Failure      404  {object}  response.ErrorResponse  "Бронирование не найдено"
 // @Failure      500  {object}  response.ErrorResponse  "Internal server error"
 // @Router       /reservation/{id} [delete]
 func (h *Handler) DeleteReservationHandler(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid reservation ID",
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reservation ID",
+			RequestID: middleware.RequestID(c),
 		})
 	}
-	if err := h.reservationService.DeleteReservation(c.UserContext(), id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
-		})
+	if err := h.reservationService.DeleteReservation(c.UserContext(), viewer(c), id); err != nil {
+		return reservationError(c, err)
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
 		Code:    fiber.StatusOK,
@@ -178,6 +216,41 @@ func (h *Handler) DeleteReservationHandler(c *fiber.Ctx) error {
 	})
 }
 
+// ReturnReservationHandler godoc
+// @Summary      Return reserved book
+// @Description  Записывает возврат книги по бронированию сегодняшним днём. Невозвращённые бронирования с истёкшим сроком считаются просроченными (метрика bookapi_loans_overdue).
+// @Tags         reservations
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Reservation ID"
+// @Success      200  {object}  response.BaseResponse "Возврат записан"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid ID"
+// @Failure      401  {object}  response.ErrorResponse  "Требуется аутентификация"
+// @Failure      403  {object}  response.ErrorResponse  "Требуются права администратора"
+// @Failure      404  {object}  response.ErrorResponse  "Бронирование не найдено"
+// @Failure      409  {object}  response.ErrorResponse  "Возврат уже записан"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Router       /reservation/{id}/return [post]
+func (h *Handler) ReturnReservationHandler(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid reservation ID",
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	reservation, err := h.reservationService.ReturnReservation(c.UserContext(), id)
+	if err != nil {
+		return reservationError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reservation returned successfully",
+		Data:    reservation,
+	})
+}
+
 // ListReservationsHandler godoc
 // @Summary      List reservations
 // @Description  Возвращает список бронирований в указанном диапазоне дат.
@@ -190,27 +263,20 @@ func (h *Handler) DeleteReservationHandler(c *fiber.Ctx) error {
 // @Failure      500      {object}  response.ErrorResponse  "Internal server error"
 // @Router       /reservations [get]
 func (h *Handler) ListReservationsHandler(c *fiber.Ctx) error {
-	startDateStr := c.Query("startDate")
-	endDateStr := c.Query("endDate")
-	startDate, err := time.Parse("2006-01-02", startDateStr)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid startDate format",
-		})
-	}
-	endDate, err := time.Parse("2006-01-02", endDateStr)
+	filter, err := ParseFilter(c, true)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
-			Code:    fiber.StatusBadRequest,
-			Message: "Invalid endDate format",
+			Code:      fiber.StatusBadRequest,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
-	resList, err := h.reservationService.ListReservations(c.UserContext(), startDate, endDate)
+	resList, err := h.reservationService.ListReservations(c.UserContext(), filter.StartDate, filter.EndDate)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(response.ErrorResponse{
-			Code:    fiber.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      fiber.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: middleware.RequestID(c),
 		})
 	}
 	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
@@ -0,0 +1,382 @@
+package reviewhandlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reviews"
+	reviewsvc "github.com/0sokrat0/BookAPI/internal/service/reviews"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateReviewRequest содержит оценку от 1 до 5 и необязательный текст.
+// swagger:model CreateReviewRequest
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" example:"5"`
+	Text   string `json:"text" example:"Перечитываю каждый год."`
+}
+
+// UpdateReviewRequest заменяет оценку и текст отзыва.
+// swagger:model UpdateReviewRequest
+type UpdateReviewRequest struct {
+	Rating int    `json:"rating" example:"4"`
+	Text   string `json:"text" example:"Со второго раза понравилась меньше."`
+}
+
+// ModerateReviewRequest — решение модератора: approved или rejected.
+// swagger:model ModerateReviewRequest
+type ModerateReviewRequest struct {
+	Status string `json:"status" example:"approved"`
+}
+
+type Handler struct {
+	reviewService reviewsvc.ReviewService
+}
+
+func NewHandler(reviewService reviewsvc.ReviewService) *Handler {
+	return &Handler{reviewService: reviewService}
+}
+
+func reviewError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, reviews.ErrNotFound), errors.Is(err, books.ErrNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, reviewsvc.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, reviewsvc.ErrNotEligible), errors.Is(err, reviewsvc.ErrForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, reviewsvc.ErrAlreadyReviewed):
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+func badRequest(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+		Code:      fiber.StatusBadRequest,
+		Message:   message,
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+// viewer описывает читателя сессии; анонимный запрос — нулевой ReaderID.
+func viewer(c *fiber.Ctx) reviewsvc.Viewer {
+	id, _ := middleware.ReaderID(c)
+	return reviewsvc.Viewer{ReaderID: id, Admin: middleware.IsAdmin(c)}
+}
+
+// CreateReviewHandler godoc
+// @Summary      Review a book
+// @Description  Сохраняет оценку книги от 1 до 5 и необязательный текст от имени читателя сессии. Оставить отзыв можно только о книге, бронирование которой у читателя уже закончилось, и только один. Оценка без текста публикуется сразу, отзыв с текстом ждёт модерации (status pending).
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int  true  "Уникальный ID книги"
+// @Param        review  body      reviewhandlers.CreateReviewRequest  true  "Оценка и текст. Пример: {\"rating\":5,\"text\":\"Перечитываю каждый год.\"}"
+// @Success      200     {object}  response.BaseResponse{data=reviews.Review} "Созданный отзыв"
+// @Failure      400     {object}  response.ErrorResponse "Неверный ID, оценка или слишком длинный текст"
+// @Failure      401     {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403     {object}  response.ErrorResponse "Нет завершённого бронирования книги"
+// @Failure      404     {object}  response.ErrorResponse "Книга не найдена"
+// @Failure      409     {object}  response.ErrorResponse "Читатель уже оставил отзыв о книге"
+// @Router       /book/{id}/reviews [post]
+func (h *Handler) CreateReviewHandler(c *fiber.Ctx) error {
+	bookID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid book ID")
+	}
+	var req CreateReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return badRequest(c, "Invalid request: "+err.Error())
+	}
+	readerID, _ := middleware.ReaderID(c)
+	review, err := h.reviewService.CreateReview(c.UserContext(), readerID, bookID, commands.CreateReviewRequest{
+		Rating: req.Rating,
+		Text:   req.Text,
+	})
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Review created successfully",
+		Data:    review,
+	})
+}
+
+// BookReviewsHandler godoc
+// @Summary      List reviews of a book
+// @Description  Возвращает одобренные отзывы о книге. sort=recent (по умолчанию) — сначала новые, sort=helpful — сначала отмеченные полезными большим числом читателей.
+// @Tags         reviews
+// @Produce      json
+// @Param        id    path      int     true   "Уникальный ID книги"
+// @Param        sort  query     string  false  "Порядок: recent или helpful"
+// @Success      200   {object}  response.BaseResponse{data=[]reviews.Review} "Отзывы"
+// @Failure      400   {object}  response.ErrorResponse "Неверный ID или порядок"
+// @Failure      404   {object}  response.ErrorResponse "Книга не найдена"
+// @Router       /book/{id}/reviews [get]
+func (h *Handler) BookReviewsHandler(c *fiber.Ctx) error {
+	bookID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid book ID")
+	}
+	sort, err := reviews.ParseSort(c.Query("sort"))
+	if err != nil {
+		return badRequest(c, "Invalid sort parameter")
+	}
+	list, err := h.reviewService.BookReviews(c.UserContext(), bookID, sort)
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reviews retrieved successfully",
+		Data:    list,
+	})
+}
+
+// GetReviewHandler godoc
+// @Summary      Get a review by ID
+// @Description  Возвращает отзыв. Отзыв на модерации или отклонённый видят только его автор и администраторы, остальным отвечает 404.
+// @Tags         reviews
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID отзыва"
+// @Success      200  {object}  response.BaseResponse{data=reviews.Review} "Отзыв"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Отзыв не найден"
+// @Router       /review/{id} [get]
+func (h *Handler) GetReviewHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid review ID")
+	}
+	review, err := h.reviewService.GetReview(c.UserContext(), viewer(c), id)
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Review retrieved successfully",
+		Data:    review,
+	})
+}
+
+// UpdateReviewHandler godoc
+// @Summary      Update own review
+// @Description  Заменяет оценку и текст своего отзыва. Изменённый текст снова отправляется на модерацию; смена одной оценки статус не меняет.
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int  true  "Уникальный ID отзыва"
+// @Param        review  body      reviewhandlers.UpdateReviewRequest  true  "Новые оценка и текст. Пример: {\"rating\":4,\"text\":\"\"}"
+// @Success      200     {object}  response.BaseResponse{data=reviews.Review} "Обновлённый отзыв"
+// @Failure      400     {object}  response.ErrorResponse "Неверный ID, оценка или слишком длинный текст"
+// @Failure      401     {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403     {object}  response.ErrorResponse "Отзыв другого читателя"
+// @Failure      404     {object}  response.ErrorResponse "Отзыв не найден"
+// @Router       /review/{id} [put]
+func (h *Handler) UpdateReviewHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid review ID")
+	}
+	var req UpdateReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return badRequest(c, "Invalid request: "+err.Error())
+	}
+	readerID, _ := middleware.ReaderID(c)
+	review, err := h.reviewService.UpdateReview(c.UserContext(), readerID, id, commands.UpdateReviewRequest{
+		Rating: req.Rating,
+		Text:   req.Text,
+	})
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Review updated successfully",
+		Data:    review,
+	})
+}
+
+// DeleteReviewHandler godoc
+// @Summary      Delete a review
+// @Description  Удаляет отзыв. Читатель удаляет свой отзыв, администратор — любой.
+// @Tags         reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Уникальный ID отзыва"
+// @Success      200  {object}  response.BaseResponse "Отзыв удалён"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      401  {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403  {object}  response.ErrorResponse "Отзыв другого читателя"
+// @Failure      404  {object}  response.ErrorResponse "Отзыв не найден"
+// @Router       /review/{id} [delete]
+func (h *Handler) DeleteReviewHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid review ID")
+	}
+	if err := h.reviewService.DeleteReview(c.UserContext(), viewer(c), id); err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Review deleted successfully",
+	})
+}
+
+// ModerateReviewHandler godoc
+// @Summary      Moderate a review
+// @Description  Одобряет (approved) или отклоняет (rejected) отзыв. В списки отзывов книги и в её рейтинг входят только одобренные отзывы. Только для администраторов.
+// @Tags         reviews
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int  true  "Уникальный ID отзыва"
+// @Param        status  body      reviewhandlers.ModerateReviewRequest  true  "Решение. Пример: {\"status\":\"approved\"}"
+// @Success      200     {object}  response.BaseResponse{data=reviews.Review} "Отзыв с новым статусом"
+// @Failure      400     {object}  response.ErrorResponse "Неверный ID или статус"
+// @Failure      401     {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403     {object}  response.ErrorResponse "Требуются права администратора"
+// @Failure      404     {object}  response.ErrorResponse "Отзыв не найден"
+// @Router       /review/{id}/moderate [post]
+func (h *Handler) ModerateReviewHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid review ID")
+	}
+	var req ModerateReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return badRequest(c, "Invalid request: "+err.Error())
+	}
+	status, err := reviews.ParseStatus(req.Status)
+	if err != nil {
+		return badRequest(c, "Invalid status")
+	}
+	review, err := h.reviewService.ModerateReview(c.UserContext(), id, status)
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Review moderated successfully",
+		Data:    review,
+	})
+}
+
+// MarkHelpfulHandler godoc
+// @Summary      Mark a review as helpful
+// @Description  Отмечает чужой одобренный отзыв полезным от имени читателя сессии. Повторная отметка не ошибка. Число отметок определяет порядок sort=helpful.
+// @Tags         reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Уникальный ID отзыва"
+// @Success      200  {object}  response.BaseResponse{data=reviews.Review} "Отзыв с новым числом отметок"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID или свой отзыв"
+// @Failure      401  {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      404  {object}  response.ErrorResponse "Отзыв не найден"
+// @Router       /review/{id}/helpful [post]
+func (h *Handler) MarkHelpfulHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid review ID")
+	}
+	readerID, _ := middleware.ReaderID(c)
+	review, err := h.reviewService.MarkHelpful(c.UserContext(), readerID, id)
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Review marked as helpful",
+		Data:    review,
+	})
+}
+
+// UnmarkHelpfulHandler godoc
+// @Summary      Remove a helpful mark
+// @Description  Снимает отметку «полезен», поставленную читателем сессии. Если отметки нет, запрос всё равно успешен.
+// @Tags         reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Уникальный ID отзыва"
+// @Success      200  {object}  response.BaseResponse{data=reviews.Review} "Отзыв с новым числом отметок"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID или свой отзыв"
+// @Failure      401  {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      404  {object}  response.ErrorResponse "Отзыв не найден"
+// @Router       /review/{id}/helpful [delete]
+func (h *Handler) UnmarkHelpfulHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return badRequest(c, "Invalid review ID")
+	}
+	readerID, _ := middleware.ReaderID(c)
+	review, err := h.reviewService.UnmarkHelpful(c.UserContext(), readerID, id)
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Helpful mark removed",
+		Data:    review,
+	})
+}
+
+// ListReviewsHandler godoc
+// @Summary      List reviews for moderation
+// @Description  Возвращает отзывы любых статусов по фильтру; очередь модерации — status=pending. Только для администраторов.
+// @Tags         reviews
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query     string  false  "Статус: pending, approved или rejected"
+// @Param        book    query     int     false  "ID книги"
+// @Param        reader  query     int     false  "ID читателя"
+// @Param        sort    query     string  false  "Порядок: recent или helpful"
+// @Success      200     {object}  response.BaseResponse{data=[]reviews.Review} "Отзывы"
+// @Failure      400     {object}  response.ErrorResponse "Неверный параметр"
+// @Failure      401     {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403     {object}  response.ErrorResponse "Требуются права администратора"
+// @Router       /reviews [get]
+func (h *Handler) ListReviewsHandler(c *fiber.Ctx) error {
+	var filter reviews.Filter
+	var err error
+	if s := c.Query("status"); s != "" {
+		if filter.Status, err = reviews.ParseStatus(s); err != nil {
+			return badRequest(c, "Invalid status parameter")
+		}
+	}
+	if s := c.Query("book"); s != "" {
+		if filter.BookID, err = strconv.Atoi(s); err != nil {
+			return badRequest(c, "Invalid book parameter")
+		}
+	}
+	if s := c.Query("reader"); s != "" {
+		if filter.ReaderID, err = strconv.Atoi(s); err != nil {
+			return badRequest(c, "Invalid reader parameter")
+		}
+	}
+	if filter.Sort, err = reviews.ParseSort(c.Query("sort")); err != nil {
+		return badRequest(c, "Invalid sort parameter")
+	}
+	list, err := h.reviewService.ListReviews(c.UserContext(), filter)
+	if err != nil {
+		return reviewError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Reviews retrieved successfully",
+		Data:    list,
+	})
+}
@@ -0,0 +1,301 @@
+package taghandlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/tags"
+	tagsvc "github.com/0sokrat0/BookAPI/internal/service/tags"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TagBookRequest — теги, которые нужно поставить книге.
+// swagger:model TagBookRequest
+type TagBookRequest struct {
+	Tags []string `json:"tags" example:"concurrency,soviet sci-fi"`
+}
+
+// MergeTagsRequest — теги-синонимы, сливаемые в тег из пути.
+// swagger:model MergeTagsRequest
+type MergeTagsRequest struct {
+	SourceIDs []int `json:"source_ids"`
+}
+
+type Handler struct {
+	tagService tagsvc.TagService
+}
+
+func NewHandler(tagService tagsvc.TagService) *Handler {
+	return &Handler{tagService: tagService}
+}
+
+func tagError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, tags.ErrNotFound), errors.Is(err, books.ErrNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, tagsvc.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+func invalidID(c *fiber.Ctx, what string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+		Code:      fiber.StatusBadRequest,
+		Message:   "Invalid " + what + " ID",
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+// TagBookHandler godoc
+// @Summary      Tag a book
+// @Description  Ставит книге теги по именам. Имена нормализуются: ведущий «#» отбрасывается, регистр и повторные пробелы не различаются. Синоним заменяется основным тегом, недостающие теги создаются. Возвращает все теги книги.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true  "Уникальный ID книги"
+// @Param        tags  body      taghandlers.TagBookRequest  true  "Теги. Пример: {\"tags\":[\"concurrency\",\"#Soviet sci-fi\"]}"
+// @Success      200   {object}  response.BaseResponse{data=[]tags.Tag} "Теги книги"
+// @Failure      400   {object}  response.ErrorResponse "Неверный ID или имя тега"
+// @Failure      404   {object}  response.ErrorResponse "Книга не найдена"
+// @Failure      500   {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /book/{id}/tags [post]
+func (h *Handler) TagBookHandler(c *fiber.Ctx) error {
+	bookID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c, "book")
+	}
+	var req TagBookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	list, err := h.tagService.TagBook(c.UserContext(), bookID, req.Tags)
+	if err != nil {
+		return tagError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Book tagged successfully",
+		Data:    list,
+	})
+}
+
+// UntagBookHandler godoc
+// @Summary      Remove a tag from a book
+// @Description  Снимает тег с книги. Если тега у книги нет, запрос всё равно успешен.
+// @Tags         tags
+// @Produce      json
+// @Param        id     path      int  true  "Уникальный ID книги"
+// @Param        tagID  path      int  true  "Уникальный ID тега"
+// @Success      200    {object}  response.BaseResponse "Тег снят"
+// @Failure      400    {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404    {object}  response.ErrorResponse "Книга не найдена"
+// @Router       /book/{id}/tags/{tagID} [delete]
+func (h *Handler) UntagBookHandler(c *fiber.Ctx) error {
+	bookID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c, "book")
+	}
+	tagID, err := strconv.Atoi(c.Params("tagID"))
+	if err != nil {
+		return invalidID(c, "tag")
+	}
+	if err := h.tagService.UntagBook(c.UserContext(), bookID, tagID); err != nil {
+		return tagError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Tag removed successfully",
+	})
+}
+
+// BookTagsHandler godoc
+// @Summary      List tags of a book
+// @Description  Возвращает теги книги по имени.
+// @Tags         tags
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID книги"
+// @Success      200  {object}  response.BaseResponse{data=[]tags.Tag} "Теги книги"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Книга не найдена"
+// @Router       /book/{id}/tags [get]
+func (h *Handler) BookTagsHandler(c *fiber.Ctx) error {
+	bookID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c, "book")
+	}
+	list, err := h.tagService.BookTags(c.UserContext(), bookID)
+	if err != nil {
+		return tagError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Book tags retrieved successfully",
+		Data:    list,
+	})
+}
+
+// TagCountsHandler godoc
+// @Summary      Tag cloud
+// @Description  Возвращает теги с числом книг по убыванию числа, при равенстве — по имени. Теги без книг не выдаются.
+// @Tags         tags
+// @Produce      json
+// @Param        limit  query     int  false  "Сколько самых частых тегов вернуть; 0 или без параметра — все"
+// @Success      200    {object}  response.BaseResponse{data=[]tags.TagCount} "Теги с числом книг"
+// @Failure      400    {object}  response.ErrorResponse "Неверный limit"
+// @Failure      500    {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /tags [get]
+func (h *Handler) TagCountsHandler(c *fiber.Ctx) error {
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+				Code:      fiber.StatusBadRequest,
+				Message:   "Invalid limit parameter",
+				RequestID: middleware.RequestID(c),
+			})
+		}
+		limit = n
+	}
+	counts, err := h.tagService.TagCounts(c.UserContext(), limit)
+	if err != nil {
+		return tagError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Tag counts retrieved successfully",
+		Data:    counts,
+	})
+}
+
+// GetTagHandler godoc
+// @Summary      Get a tag by ID
+// @Description  Возвращает тег с его синонимами.
+// @Tags         tags
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID тега"
+// @Success      200  {object}  response.BaseResponse{data=tags.Tag} "Тег"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Тег не найден"
+// @Router       /tag/{id} [get]
+func (h *Handler) GetTagHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c, "tag")
+	}
+	tag, err := h.tagService.GetTag(c.UserContext(), id)
+	if err != nil {
+		return tagError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Tag retrieved successfully",
+		Data:    tag,
+	})
+}
+
+// DeleteTagHandler godoc
+// @Summary      Delete a tag
+// @Description  Удаляет тег вместе с его синонимами и снимает его со всех книг. Только для администраторов.
+// @Tags         tags
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Уникальный ID тега"
+// @Success      200  {object}  response.BaseResponse "Тег удалён"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      401  {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403  {object}  response.ErrorResponse "Требуются права администратора"
+// @Failure      404  {object}  response.ErrorResponse "Тег не найден"
+// @Router       /tag/{id} [delete]
+func (h *Handler) DeleteTagHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c, "tag")
+	}
+	if err := h.tagService.DeleteTag(c.UserContext(), id); err != nil {
+		return tagError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Tag deleted successfully",
+	})
+}
+
+// MergeTagsHandler godoc
+// @Summary      Merge synonym tags
+// @Description  Сливает теги source_ids в тег из пути: их книги получают этот тег, их имена и синонимы становятся его синонимами, сами теги удаляются. После слияния пометка книги синонимом ставит основной тег. Только для администраторов.
+// @Tags         tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int  true  "ID тега, в который сливаются синонимы"
+// @Param        merge  body      taghandlers.MergeTagsRequest  true  "Сливаемые теги. Пример: {\"source_ids\":[12,15]}"
+// @Success      200    {object}  response.BaseResponse{data=tags.Tag} "Тег с новыми синонимами"
+// @Failure      400    {object}  response.ErrorResponse "Неверный ID или список тегов"
+// @Failure      401    {object}  response.ErrorResponse "Требуется аутентификация"
+// @Failure      403    {object}  response.ErrorResponse "Требуются права администратора"
+// @Failure      404    {object}  response.ErrorResponse "Тег не найден"
+// @Router       /tag/{id}/merge [post]
+func (h *Handler) MergeTagsHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c, "tag")
+	}
+	var req MergeTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	tag, err := h.tagService.MergeTags(c.UserContext(), id, req.SourceIDs)
+	if err != nil {
+		return tagError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Tags merged successfully",
+		Data:    tag,
+	})
+}
+
+// ListTagBooksHandler godoc
+// @Summary      List books with a tag
+// @Description  Возвращает книги с тегом по возрастанию ID.
+// @Tags         tags
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID тега"
+// @Success      200  {object}  response.BaseResponse{data=[]books.Book} "Книги с тегом"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Тег не найден"
+// @Failure      500  {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /tag/{id}/books [get]
+func (h *Handler) ListTagBooksHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c, "tag")
+	}
+	list, err := h.tagService.ListBooks(c.UserContext(), id)
+	if err != nil {
+		return tagError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Tag books retrieved successfully",
+		Data:    list,
+	})
+}
@@ -0,0 +1,224 @@
+package workhandlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+	worksvc "github.com/0sokrat0/BookAPI/internal/service/works"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateWorkRequest содержит данные для создания произведения.
+// swagger:model CreateWorkRequest
+type CreateWorkRequest struct {
+	Title string `json:"title" example:"War and Peace"`
+}
+
+// UpdateWorkRequest содержит данные для обновления произведения.
+// swagger:model UpdateWorkRequest
+type UpdateWorkRequest struct {
+	Title string `json:"title" example:"War and Peace"`
+}
+
+type Handler struct {
+	workService worksvc.WorkService
+}
+
+func NewHandler(workService worksvc.WorkService) *Handler {
+	return &Handler{workService: workService}
+}
+
+func workError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, works.ErrNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, worksvc.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, worksvc.ErrInUse):
+		status = fiber.StatusConflict
+	}
+	return c.Status(status).JSON(response.ErrorResponse{
+		Code:      status,
+		Message:   err.Error(),
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+func invalidID(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+		Code:      fiber.StatusBadRequest,
+		Message:   "Invalid work ID",
+		RequestID: middleware.RequestID(c),
+	})
+}
+
+// CreateWorkHandler godoc
+// @Summary      Create a work
+// @Description  Создаёт произведение — группу изданий одной книги (переводов, переизданий). Издания привязываются к нему полем work_id книги.
+// @Tags         works
+// @Accept       json
+// @Produce      json
+// @Param        work  body      workhandlers.CreateWorkRequest  true  "Параметры произведения. Пример: {\"title\":\"War and Peace\"}"
+// @Success      200   {object}  response.BaseResponse{data=works.Work} "Созданное произведение"
+// @Failure      400   {object}  response.ErrorResponse "Неверный запрос или пустое название"
+// @Failure      500   {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /work [post]
+func (h *Handler) CreateWorkHandler(c *fiber.Ctx) error {
+	var req CreateWorkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	work, err := h.workService.CreateWork(c.UserContext(), commands.CreateWorkRequest{Title: req.Title})
+	if err != nil {
+		return workError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Work created successfully",
+		Data:    work,
+	})
+}
+
+// GetWorkHandler godoc
+// @Summary      Get a work by ID
+// @Description  Возвращает произведение по его уникальному идентификатору.
+// @Tags         works
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID произведения"
+// @Success      200  {object}  response.BaseResponse{data=works.Work} "Произведение"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Произведение не найдено"
+// @Router       /work/{id} [get]
+func (h *Handler) GetWorkHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	work, err := h.workService.GetWork(c.UserContext(), id)
+	if err != nil {
+		return workError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Work retrieved successfully",
+		Data:    work,
+	})
+}
+
+// UpdateWorkHandler godoc
+// @Summary      Update a work
+// @Description  Переименовывает произведение. Названия изданий не меняются.
+// @Tags         works
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true  "Уникальный ID произведения"
+// @Param        work  body      workhandlers.UpdateWorkRequest  true  "Новые данные произведения. Пример: {\"title\":\"War and Peace\"}"
+// @Success      200   {object}  response.BaseResponse{data=works.Work} "Обновлённое произведение"
+// @Failure      400   {object}  response.ErrorResponse "Неверный запрос, ID или пустое название"
+// @Failure      404   {object}  response.ErrorResponse "Произведение не найдено"
+// @Failure      500   {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /work/{id} [put]
+func (h *Handler) UpdateWorkHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	var req UpdateWorkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(response.ErrorResponse{
+			Code:      fiber.StatusBadRequest,
+			Message:   "Invalid request: " + err.Error(),
+			RequestID: middleware.RequestID(c),
+		})
+	}
+	work, err := h.workService.UpdateWork(c.UserContext(), id, commands.UpdateWorkRequest{Title: req.Title})
+	if err != nil {
+		return workError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Work updated successfully",
+		Data:    work,
+	})
+}
+
+// DeleteWorkHandler godoc
+// @Summary      Delete a work
+// @Description  Удаляет произведение. Произведение с изданиями удалить нельзя: сначала удалите или перенесите их.
+// @Tags         works
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID произведения"
+// @Success      200  {object}  response.BaseResponse "Произведение удалено"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Произведение не найдено"
+// @Failure      409  {object}  response.ErrorResponse "У произведения есть издания"
+// @Router       /work/{id} [delete]
+func (h *Handler) DeleteWorkHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	if err := h.workService.DeleteWork(c.UserContext(), id); err != nil {
+		return workError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Work deleted successfully",
+	})
+}
+
+// ListWorksHandler godoc
+// @Summary      List works
+// @Description  Возвращает все произведения по возрастанию ID.
+// @Tags         works
+// @Produce      json
+// @Success      200  {object}  response.BaseResponse{data=[]works.Work} "Список произведений"
+// @Failure      500  {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /works [get]
+func (h *Handler) ListWorksHandler(c *fiber.Ctx) error {
+	list, err := h.workService.ListWorks(c.UserContext())
+	if err != nil {
+		return workError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Works list retrieved successfully",
+		Data:    list,
+	})
+}
+
+// ListEditionsHandler godoc
+// @Summary      List editions of a work
+// @Description  Возвращает издания произведения — книги с его work_id — по возрастанию ID.
+// @Tags         works
+// @Produce      json
+// @Param        id   path      int  true  "Уникальный ID произведения"
+// @Success      200  {object}  response.BaseResponse "Издания произведения"
+// @Failure      400  {object}  response.ErrorResponse "Неверный ID"
+// @Failure      404  {object}  response.ErrorResponse "Произведение не найдено"
+// @Failure      500  {object}  response.ErrorResponse "Ошибка сервера"
+// @Router       /work/{id}/editions [get]
+func (h *Handler) ListEditionsHandler(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return invalidID(c)
+	}
+	list, err := h.workService.ListEditions(c.UserContext(), id)
+	if err != nil {
+		return workError(c, err)
+	}
+	return c.Status(fiber.StatusOK).JSON(response.BaseResponse{
+		Code:    fiber.StatusOK,
+		Message: "Work editions retrieved successfully",
+		Data:    list,
+	})
+}
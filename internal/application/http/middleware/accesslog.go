@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+)
+
+// Recover превращает панику обработчика в ответ 500 вместо падения
+// процесса и пишет в лог её значение со стеком.
+func Recover() fiber.Handler {
+	return recover.New(recover.Config{
+		EnableStackTrace: true,
+		StackTraceHandler: func(c *fiber.Ctx, e interface{}) {
+			logger.FromContext(c.UserContext()).Errorw("panic recovered",
+				"panic", fmt.Sprint(e),
+				"path", c.Path(),
+				"stack", string(debug.Stack()),
+			)
+		},
+	})
+}
+
+// AccessLog пишет одну структурированную запись на запрос. Поля request_id,
+// client_ip, reader_id и trace_id приходят из логгера в контексте.
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		lg := logger.FromContext(c.UserContext())
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			if fe, ok := err.(*fiber.Error); ok {
+				status = fe.Code
+			}
+		}
+		fields := []interface{}{
+			"method", c.Method(),
+			"route", c.Route().Path,
+			"path", c.Path(),
+			"status", status,
+			"duration", time.Since(start),
+			"user_agent", c.Get(fiber.HeaderUserAgent),
+		}
+		// Body() у потокового ответа вычитал бы поток целиком в память,
+		// а размер выгрузки заранее неизвестен.
+		if !c.Response().IsBodyStream() {
+			fields = append(fields, "bytes", len(c.Response().Body()))
+		}
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			lg.Errorw("request", fields...)
+		case status >= fiber.StatusBadRequest:
+			lg.Warnw("request", fields...)
+		default:
+			lg.Infow("request", fields...)
+		}
+		return err
+	}
+}
+
+// ErrorHandler отдаёт ошибки Fiber (неизвестный маршрут, паника в обработчике
+// и т. п.) в общем формате ErrorResponse с идентификатором запроса.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+	message := "Internal server error"
+	if fe, ok := err.(*fiber.Error); ok {
+		code = fe.Code
+		message = fe.Message
+	}
+	return c.Status(code).JSON(response.ErrorResponse{
+		Code:      code,
+		Message:   message,
+		RequestID: RequestID(c),
+	})
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/pkg/authtoken"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/0sokrat0/BookAPI/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	readerIDKey     = "reader_id"
+	adminKey        = "admin"
+	enrollmentIDKey = "enrollment_reader_id"
+)
+
+// Authenticate читает токен сессии из заголовка Authorization: Bearer.
+// Запрос без токена или с недействительным токеном пропускается как анонимный;
+// доступ ограничивают отдельные middleware. Токен подключения второго фактора
+// тоже оставляет запрос анонимным: его принимает только AllowEnrollment.
+func Authenticate(tokens *authtoken.Manager) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(fiber.HeaderAuthorization)
+		raw, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || raw == "" {
+			return c.Next()
+		}
+		if claims, err := tokens.Parse(raw, authtoken.PurposeEnrollment); err == nil {
+			c.Locals(enrollmentIDKey, claims.ReaderID)
+			return c.Next()
+		}
+		claims, err := tokens.Parse(raw, authtoken.PurposeSession)
+		if err != nil {
+			return c.Next()
+		}
+		setReader(c, claims.ReaderID, claims.Admin)
+		return c.Next()
+	}
+}
+
+// AllowEnrollment засчитывает токен подключения второго фактора как сессию
+// читателя без прав администратора. Ставится только на маршруты
+// /reader/:id/2fa/*, перед RequireSelf.
+func AllowEnrollment(c *fiber.Ctx) error {
+	if id, ok := c.Locals(enrollmentIDKey).(int); ok {
+		setReader(c, id, false)
+	}
+	return c.Next()
+}
+
+func setReader(c *fiber.Ctx, readerID int, admin bool) {
+	c.Locals(readerIDKey, readerID)
+	c.Locals(adminKey, admin)
+
+	ctx := c.UserContext()
+	c.SetUserContext(logger.WithLogger(ctx, logger.FromContext(ctx).With("reader_id", readerID)))
+}
+
+// ReaderID возвращает ID аутентифицированного читателя.
+func ReaderID(c *fiber.Ctx) (int, bool) {
+	id, ok := c.Locals(readerIDKey).(int)
+	return id, ok
+}
+
+// IsAdmin сообщает, что запрос выполнен администратором.
+func IsAdmin(c *fiber.Ctx) bool {
+	admin, _ := c.Locals(adminKey).(bool)
+	return admin
+}
+
+// RequireReader пропускает только запросы с сессией читателя; остальные
+// получают 401.
+func RequireReader(c *fiber.Ctx) error {
+	if _, ok := ReaderID(c); !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(response.ErrorResponse{
+			Code:      fiber.StatusUnauthorized,
+			Message:   "Authentication required",
+			RequestID: RequestID(c),
+		})
+	}
+	return c.Next()
+}
+
+// RequireAdmin пропускает только администраторов: запрос без сессии
+// получает 401, сессия обычного читателя — 403.
+func RequireAdmin(c *fiber.Ctx) error {
+	if _, ok := ReaderID(c); !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(response.ErrorResponse{
+			Code:      fiber.StatusUnauthorized,
+			Message:   "Authentication required",
+			RequestID: RequestID(c),
+		})
+	}
+	if !IsAdmin(c) {
+		return c.Status(fiber.StatusForbidden).JSON(response.ErrorResponse{
+			Code:      fiber.StatusForbidden,
+			Message:   "Administrator access required",
+			RequestID: RequestID(c),
+		})
+	}
+	return c.Next()
+}
+
+// RequireSelf пропускает только запросы читателя из параметра :id к самому
+// себе, без исключения для администраторов: запрос без сессии получает 401,
+// чужая сессия — 403. Нечисловой :id проверяет обработчик.
+func RequireSelf(c *fiber.Ctx) error {
+	readerID, ok := ReaderID(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(response.ErrorResponse{
+			Code:      fiber.StatusUnauthorized,
+			Message:   "Authentication required",
+			RequestID: RequestID(c),
+		})
+	}
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil || id == readerID {
+		return c.Next()
+	}
+	return c.Status(fiber.StatusForbidden).JSON(response.ErrorResponse{
+		Code:      fiber.StatusForbidden,
+		Message:   "Access to another reader is not allowed",
+		RequestID: RequestID(c),
+	})
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderRequestID — заголовок с идентификатором запроса.
+const HeaderRequestID = "X-Request-ID"
+
+const (
+	requestIDKey    = "request_id"
+	maxRequestIDLen = 128
+)
+
+// RequestContext присваивает запросу идентификатор (берёт из X-Request-ID
+// или генерирует), возвращает его в ответе и кладёт в контекст дочерний
+// логгер с request_id и IP клиента.
+func RequestContext(lg *logger.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(HeaderRequestID)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Locals(requestIDKey, id)
+		c.Set(HeaderRequestID, id)
+
+		reqLogger := lg.With("request_id", id, "client_ip", c.IP())
+		c.SetUserContext(logger.WithLogger(c.UserContext(), reqLogger))
+		return c.Next()
+	}
+}
+
+// Route дописывает шаблон маршрута в логгер запроса. Подключается
+// к каждому маршруту, так как до сопоставления шаблон неизвестен.
+func Route(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	c.SetUserContext(logger.WithLogger(ctx, logger.FromContext(ctx).With("route", c.Route().Path)))
+	return c.Next()
+}
+
+// RequestID возвращает идентификатор текущего запроса.
+func RequestID(c *fiber.Ctx) string {
+	id, _ := c.Locals(requestIDKey).(string)
+	return id
+}
+
+// validRequestID принимает только короткие печатные ASCII-идентификаторы,
+// чтобы клиент не мог протащить в логи переводы строк.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
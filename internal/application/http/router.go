@@ -4,8 +4,21 @@ import (
 	_ "github.com/0sokrat0/BookAPI/docs"
 	authorhandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/authors"
 	"github.com/0sokrat0/BookAPI/internal/application/http/handlers/bookshandlers"
+	cataloghandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/catalog"
+	coverhandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/covers"
+	exporthandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/export"
+	genrehandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/genres"
+	healthhandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/health"
+	publisherhandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/publishers"
 	readerhandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/readers"
+	readinglisthandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/readinglists"
+	recommendationhandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/recommendations"
 	reservationshandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/reservations"
+	reviewhandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/reviews"
+	taghandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/tags"
+	workhandlers "github.com/0sokrat0/BookAPI/internal/application/http/handlers/works"
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/pkg/metrics"
 
 	"github.com/gofiber/contrib/swagger"
 )
@@ -19,33 +32,127 @@ func (s *Server) registerRouter() {
 	}
 	s.App.Use(swagger.New(cfg))
 
+	handlerHealth := healthhandlers.NewHandler(s.health)
+	s.App.Get("/healthz", middleware.Route, handlerHealth.LivenessHandler)
+	s.App.Get("/readyz", middleware.Route, handlerHealth.ReadinessHandler)
+
+	if s.Config.Metrics.Enabled {
+		s.App.Get(s.Config.Metrics.Path, middleware.Route, metrics.Handler())
+	}
+
 	handlerBooks := bookshandlers.NewHandler(s.bookService)
 	handlerReader := readerhandlers.NewHandler(s.readerService)
 	handlerAuthor := authorhandlers.NewHandler(s.authorService)
+	handlerPublisher := publisherhandlers.NewHandler(s.publisherService)
 	handlerReservation := reservationshandlers.NewHandler(s.reservService)
+	handlerCatalog := cataloghandlers.NewHandler(s.catalogService)
+	handlerExport := exporthandlers.NewHandler(s.exportService)
+	handlerCover := coverhandlers.NewHandler(s.coverService)
+	handlerGenre := genrehandlers.NewHandler(s.genreService)
+	handlerTag := taghandlers.NewHandler(s.tagService)
+	handlerWork := workhandlers.NewHandler(s.workService)
+	handlerReview := reviewhandlers.NewHandler(s.reviewService)
+	handlerRecommendation := recommendationhandlers.NewHandler(s.recommendService)
+	handlerList := readinglisthandlers.NewHandler(s.listService)
+
+	s.App.Post("/book", middleware.Route, handlerBooks.CreateBookHandler)
+	s.App.Post("/book/from-isbn", middleware.Route, middleware.RequireAdmin, handlerCatalog.BookFromISBNHandler)
+	s.App.Get("/book/:id", middleware.Route, handlerBooks.GetBookHandler)
+	s.App.Put("/book/:id", middleware.Route, handlerBooks.UpdateBookHandler)
+	s.App.Delete("/book/:id", middleware.Route, handlerBooks.DeleteBookHandler)
+	s.App.Get("/book/:id/cover", middleware.Route, handlerCover.GetCoverHandler)
+	s.App.Put("/book/:id/cover", middleware.Route, middleware.RequireAdmin, handlerCover.UploadCoverHandler)
+	s.App.Delete("/book/:id/cover", middleware.Route, middleware.RequireAdmin, handlerCover.DeleteCoverHandler)
+	s.App.Get("/book/:id/tags", middleware.Route, handlerTag.BookTagsHandler)
+	s.App.Post("/book/:id/tags", middleware.Route, handlerTag.TagBookHandler)
+	s.App.Delete("/book/:id/tags/:tagID", middleware.Route, handlerTag.UntagBookHandler)
+	s.App.Get("/book/:id/reviews", middleware.Route, handlerReview.BookReviewsHandler)
+	s.App.Post("/book/:id/reviews", middleware.Route, middleware.RequireReader, handlerReview.CreateReviewHandler)
+	s.App.Get("/book/:id/similar", middleware.Route, handlerRecommendation.SimilarBooksHandler)
+	s.App.Get("/books", middleware.Route, handlerBooks.ListBooksHandler)
+	s.App.Post("/books/import", middleware.Route, middleware.RequireAdmin, handlerCatalog.ImportBooksHandler)
+
+	s.App.Post("/reader", middleware.Route, handlerReader.CreateReaderHandler)
+	s.App.Get("/reader/:id", middleware.Route, handlerReader.GetReaderHandler)
+	s.App.Put("/reader/:id", middleware.Route, handlerReader.UpdateReaderHandler)
+	s.App.Delete("/reader/:id", middleware.Route, handlerReader.DeleteReaderHandler)
+	s.App.Get("/reader/:id/recommendations", middleware.Route, middleware.RequireReader, handlerRecommendation.ReaderRecommendationsHandler)
+	s.App.Get("/readers", middleware.Route, handlerReader.ListReadersHandler)
+	s.App.Post("/login", middleware.Route, handlerReader.AuthenticateReaderHandler)
+	s.App.Post("/login/2fa", middleware.Route, handlerReader.TwoFactorLoginHandler)
+	s.App.Post("/reader/:id/2fa/enroll", middleware.Route, middleware.AllowEnrollment, middleware.RequireSelf, handlerReader.EnrollTOTPHandler)
+	s.App.Post("/reader/:id/2fa/confirm", middleware.Route, middleware.AllowEnrollment, middleware.RequireSelf, handlerReader.ConfirmTOTPHandler)
+	s.App.Post("/reader/:id/2fa/disable", middleware.Route, middleware.AllowEnrollment, middleware.RequireSelf, handlerReader.DisableTOTPHandler)
+	s.App.Post("/reader/:id/2fa/recovery-codes", middleware.Route, middleware.AllowEnrollment, middleware.RequireSelf, handlerReader.RegenerateRecoveryCodesHandler)
+
+	if s.oidcProvider != nil {
+		handlerOIDC := readerhandlers.NewOIDCHandler(s.readerService, s.oidcProvider, s.Config.OIDC.GroupsClaim)
+		s.App.Get("/auth/oidc/login", middleware.Route, handlerOIDC.LoginHandler)
+		s.App.Get("/auth/oidc/callback", middleware.Route, handlerOIDC.CallbackHandler)
+	}
+
+	s.App.Post("/author", middleware.Route, handlerAuthor.CreateAuthorHandler)
+	s.App.Get("/author/:id", middleware.Route, handlerAuthor.GetAuthorHandler)
+	s.App.Put("/author/:id", middleware.Route, handlerAuthor.UpdateAuthorHandler)
+	s.App.Delete("/author/:id", middleware.Route, handlerAuthor.DeleteAuthorHandler)
+	s.App.Post("/author/:id/merge", middleware.Route, middleware.RequireAdmin, handlerAuthor.MergeAuthorsHandler)
+	s.App.Get("/authors", middleware.Route, handlerAuthor.ListAuthorsHandler)
+
+	s.App.Post("/publisher", middleware.Route, handlerPublisher.CreatePublisherHandler)
+	s.App.Get("/publisher/:id", middleware.Route, handlerPublisher.GetPublisherHandler)
+	s.App.Put("/publisher/:id", middleware.Route, handlerPublisher.UpdatePublisherHandler)
+	s.App.Delete("/publisher/:id", middleware.Route, handlerPublisher.DeletePublisherHandler)
+	s.App.Get("/publisher/:id/books", middleware.Route, handlerPublisher.ListPublisherBooksHandler)
+	s.App.Get("/publishers", middleware.Route, handlerPublisher.ListPublishersHandler)
+
+	s.App.Post("/work", middleware.Route, handlerWork.CreateWorkHandler)
+	s.App.Get("/work/:id", middleware.Route, handlerWork.GetWorkHandler)
+	s.App.Put("/work/:id", middleware.Route, handlerWork.UpdateWorkHandler)
+	s.App.Delete("/work/:id", middleware.Route, handlerWork.DeleteWorkHandler)
+	s.App.Get("/work/:id/editions", middleware.Route, handlerWork.ListEditionsHandler)
+	s.App.Get("/works", middleware.Route, handlerWork.ListWorksHandler)
+
+	s.App.Post("/genre", middleware.Route, handlerGenre.CreateGenreHandler)
+	s.App.Get("/genre/:id", middleware.Route, handlerGenre.GetGenreHandler)
+	s.App.Put("/genre/:id", middleware.Route, handlerGenre.UpdateGenreHandler)
+	s.App.Delete("/genre/:id", middleware.Route, handlerGenre.DeleteGenreHandler)
+	s.App.Get("/genre/:id/books", middleware.Route, handlerGenre.ListGenreBooksHandler)
+	s.App.Get("/genres", middleware.Route, handlerGenre.ListGenresHandler)
+
+	s.App.Get("/tag/:id", middleware.Route, handlerTag.GetTagHandler)
+	s.App.Delete("/tag/:id", middleware.Route, middleware.RequireAdmin, handlerTag.DeleteTagHandler)
+	s.App.Post("/tag/:id/merge", middleware.Route, middleware.RequireAdmin, handlerTag.MergeTagsHandler)
+	s.App.Get("/tag/:id/books", middleware.Route, handlerTag.ListTagBooksHandler)
+	s.App.Get("/tags", middleware.Route, handlerTag.TagCountsHandler)
+
+	s.App.Post("/reservation", middleware.Route, middleware.RequireReader, handlerReservation.CreateReservationHandler)
+	s.App.Get("/reservation/:id", middleware.Route, handlerReservation.GetReservationHandler)
+	s.App.Put("/reservation/:id", middleware.Route, middleware.RequireReader, handlerReservation.UpdateReservationHandler)
+	s.App.Delete("/reservation/:id", middleware.Route, middleware.RequireReader, handlerReservation.DeleteReservationHandler)
+	s.App.Post("/reservation/:id/return", middleware.Route, middleware.RequireAdmin, handlerReservation.ReturnReservationHandler)
+	s.App.Get("/reservations", middleware.Route, handlerReservation.ListReservationsHandler)
+
+	s.App.Get("/review/:id", middleware.Route, handlerReview.GetReviewHandler)
+	s.App.Put("/review/:id", middleware.Route, middleware.RequireReader, handlerReview.UpdateReviewHandler)
+	s.App.Delete("/review/:id", middleware.Route, middleware.RequireReader, handlerReview.DeleteReviewHandler)
+	s.App.Post("/review/:id/moderate", middleware.Route, middleware.RequireAdmin, handlerReview.ModerateReviewHandler)
+	s.App.Post("/review/:id/helpful", middleware.Route, middleware.RequireReader, handlerReview.MarkHelpfulHandler)
+	s.App.Delete("/review/:id/helpful", middleware.Route, middleware.RequireReader, handlerReview.UnmarkHelpfulHandler)
+	s.App.Get("/reviews", middleware.Route, middleware.RequireAdmin, handlerReview.ListReviewsHandler)
+
+	s.App.Post("/reading-list", middleware.Route, middleware.RequireReader, handlerList.CreateListHandler)
+	s.App.Get("/reading-list/shared/:slug", middleware.Route, handlerList.SharedListHandler)
+	s.App.Get("/reading-list/:id", middleware.Route, handlerList.GetListHandler)
+	s.App.Put("/reading-list/:id", middleware.Route, middleware.RequireReader, handlerList.UpdateListHandler)
+	s.App.Delete("/reading-list/:id", middleware.Route, middleware.RequireReader, handlerList.DeleteListHandler)
+	s.App.Post("/reading-list/:id/books", middleware.Route, middleware.RequireReader, handlerList.AddBookHandler)
+	s.App.Put("/reading-list/:id/books", middleware.Route, middleware.RequireReader, handlerList.ReorderBooksHandler)
+	s.App.Delete("/reading-list/:id/books/:bookID", middleware.Route, middleware.RequireReader, handlerList.RemoveBookHandler)
+	s.App.Post("/reading-list/:id/reserve", middleware.Route, middleware.RequireReader, handlerList.ReserveHandler)
+	s.App.Get("/reading-lists", middleware.Route, middleware.RequireReader, handlerList.ReaderListsHandler)
 
-	s.App.Post("/book", handlerBooks.CreateBookHandler)
-	s.App.Get("/book/:id", handlerBooks.GetBookHandler)
-	s.App.Put("/book/:id", handlerBooks.UpdateBookHandler)
-	s.App.Delete("/book/:id", handlerBooks.DeleteBookHandler)
-	s.App.Get("/books", handlerBooks.ListBooksHandler)
-
-	s.App.Post("/reader", handlerReader.CreateReaderHandler)
-	s.App.Get("/reader/:id", handlerReader.GetReaderHandler)
-	s.App.Put("/reader/:id", handlerReader.UpdateReaderHandler)
-	s.App.Delete("/reader/:id", handlerReader.DeleteReaderHandler)
-	s.App.Get("/readers", handlerReader.ListReadersHandler)
-	s.App.Post("/login", handlerReader.AuthenticateReaderHandler)
-
-	s.App.Post("/author", handlerAuthor.CreateAuthorHandler)
-	s.App.Get("/author/:id", handlerAuthor.GetAuthorHandler)
-	s.App.Put("/author/:id", handlerAuthor.UpdateAuthorHandler)
-	s.App.Delete("/author/:id", handlerAuthor.DeleteAuthorHandler)
-	s.App.Get("/authors", handlerAuthor.ListAuthorsHandler)
-
-	s.App.Post("/reservation", handlerReservation.CreateReservationHandler)
-	s.App.Get("/reservation/:id", handlerReservation.GetReservationHandler)
-	s.App.Put("/reservation/:id", handlerReservation.UpdateReservationHandler)
-	s.App.Delete("/reservation/:id", handlerReservation.DeleteReservationHandler)
-	s.App.Get("/reservations", handlerReservation.ListReservationsHandler)
+	s.App.Get("/export/books", middleware.Route, middleware.RequireAdmin, handlerExport.ExportBooksHandler)
+	s.App.Get("/export/authors", middleware.Route, middleware.RequireAdmin, handlerExport.ExportAuthorsHandler)
+	s.App.Get("/export/readers", middleware.Route, middleware.RequireAdmin, handlerExport.ExportReadersHandler)
+	s.App.Get("/export/reservations", middleware.Route, middleware.RequireAdmin, handlerExport.ExportReservationsHandler)
 }
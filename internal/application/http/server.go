@@ -2,21 +2,40 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/0sokrat0/BookAPI/internal/application/http/middleware"
+	"github.com/0sokrat0/BookAPI/internal/application/workers"
 	"github.com/0sokrat0/BookAPI/internal/config"
-	authorsrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/authorsRepo"
-	"github.com/0sokrat0/BookAPI/internal/infrastructure/booksRepo"
-	readersrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/readersRepo"
-	reservrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/reservations"
+	domainBooks "github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/isbnlookup"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
 	"github.com/0sokrat0/BookAPI/internal/service/authors"
 	"github.com/0sokrat0/BookAPI/internal/service/books"
+	"github.com/0sokrat0/BookAPI/internal/service/catalog"
+	"github.com/0sokrat0/BookAPI/internal/service/covers"
+	"github.com/0sokrat0/BookAPI/internal/service/export"
+	"github.com/0sokrat0/BookAPI/internal/service/genres"
+	"github.com/0sokrat0/BookAPI/internal/service/publishers"
 	"github.com/0sokrat0/BookAPI/internal/service/readers"
+	"github.com/0sokrat0/BookAPI/internal/service/readinglists"
+	"github.com/0sokrat0/BookAPI/internal/service/recommendations"
 	"github.com/0sokrat0/BookAPI/internal/service/reservations"
+	"github.com/0sokrat0/BookAPI/internal/service/reviews"
+	"github.com/0sokrat0/BookAPI/internal/service/tags"
+	"github.com/0sokrat0/BookAPI/internal/service/works"
 	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/authtoken"
+	"github.com/0sokrat0/BookAPI/pkg/blob"
 	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/0sokrat0/BookAPI/pkg/health"
 	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/0sokrat0/BookAPI/pkg/metrics"
+	"github.com/0sokrat0/BookAPI/pkg/oidc"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
@@ -25,65 +44,248 @@ type Server struct {
 	App    *fiber.App
 	Config *config.Config
 
-	bookService   books.BookService
-	authorService authors.AuthorService
-	readerService readers.ReaderService
-	reservService reservations.ReservationService
+	bookService      books.BookService
+	authorService    authors.AuthorService
+	publisherService publishers.PublisherService
+	readerService    readers.ReaderService
+	reservService    reservations.ReservationService
+	reviewService    reviews.ReviewService
+	recommendService recommendations.RecommendationService
+	listService      readinglists.ReadingListService
+	catalogService   catalog.CatalogService
+	exportService    export.ExportService
+	coverService     covers.CoverService
+	genreService     genres.GenreService
+	tagService       tags.TagService
+	workService      works.WorkService
+	oidcProvider     *oidc.Provider
+	workers          []*workers.Worker
+	health           *health.Checker
 }
 
-func NewServer(ctx context.Context, cfg *config.Config, pool *postgres.Postgres, idCounter *genid.IDcounter) *Server {
+// NewServer собирает HTTP-сервер поверх репозиториев. db равен nil,
+// если данные хранятся в памяти.
+func NewServer(ctx context.Context, cfg *config.Config, repos storage.Repositories, db Database, idCounter *genid.IDcounter) *Server {
 	app := fiber.New(fiber.Config{
 		Prefork:       false,
 		CaseSensitive: true,
 		StrictRouting: true,
 		ServerHeader:  "Fiber",
 		AppName:       cfg.App.Name,
+		ErrorHandler:  middleware.ErrorHandler,
+		BodyLimit:     bodyLimit(cfg.Covers.MaxSize),
 	})
 
 	lg := logger.FromContext(ctx)
-	app.Use(func(c *fiber.Ctx) error {
-		c.SetUserContext(logger.WithLogger(c.UserContext(), lg))
-		return c.Next()
-	})
+	if cfg.Auth.TokenSecret == "" {
+		lg.Warn("AUTH_TOKEN_SECRET не задан, токены будут недействительны после перезапуска")
+	}
+	tokens, err := authtoken.NewManager(cfg.Auth.TokenSecret)
+	if err != nil {
+		lg.Fatalf("Error creating token manager: %v", err)
+	}
 
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*", // или задайте нужные источники
-		AllowHeaders: "Origin, Content-Type, Accept",
-	}))
-	app.Use(func(c *fiber.Ctx) error {
-		start := time.Now()
-		err := c.Next()
-		duration := time.Since(start)
-		lg := logger.FromContext(c.UserContext())
-		lg.Infof("Method: %s, URL: %s, Duration: %v", c.Method(), c.OriginalURL(), duration)
-		return err
-	})
+	app.Use(middleware.RequestContext(lg))
+	app.Use(middleware.Recover())
+	app.Use(tracing.Middleware())
 
-	bookRepos := booksRepo.NewBookRepo(pool.DB)
-	bookService := books.NewBookService(bookRepos, idCounter)
+	if cfg.Metrics.Enabled {
+		app.Use(metrics.Middleware())
+		if pg, ok := db.(*postgres.Postgres); ok {
+			if err := metrics.RegisterPool(pg.DB); err != nil {
+				lg.Errorf("failed to register pool metrics: %v", err)
+			}
+		}
+	}
 
-	authorRepos := authorsrepo.NewAuthorRepo(pool.DB)
-	authorService := authors.NewAuthorService(authorRepos, idCounter)
+	app.Use(cors.New(cors.Config{
+		AllowOrigins:  "*", // или задайте нужные источники
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-Request-ID, traceparent, tracestate",
+		ExposeHeaders: "X-Request-ID",
+	}))
+	app.Use(middleware.Authenticate(tokens))
+	app.Use(middleware.AccessLog())
 
-	readerRepos := readersrepo.NewReaderRepo(pool.DB)
-	readerService := readers.NewReaderService(readerRepos, idCounter)
+	coverStore, err := newCoverStore(cfg.Covers)
+	if err != nil {
+		lg.Fatalf("Error creating cover store: %v", err)
+	}
+	coverService := covers.NewCoverService(repos.Books, repos.Covers, coverStore, covers.Options{
+		MaxSize:   cfg.Covers.MaxSize,
+		Widths:    cfg.Covers.Widths,
+		PublicURL: strings.TrimRight(cfg.Covers.PublicURL, "/"),
+	})
+	reviewService := reviews.NewReviewService(repos.Reviews, repos.Books, repos.Reservations, idCounter)
+	bookService := books.NewBookService(repos.Books, repos.Genres, repos.Works, repos.Publishers, idCounter, coverService, reviewService)
+	authorService := authors.NewAuthorService(repos.Authors, idCounter)
+	readerService := readers.NewReaderService(repos.Readers, idCounter, readers.AuthOptions{
+		Tokens:          tokens,
+		TokenTTL:        cfg.Auth.TokenTTL,
+		TOTPIssuer:      cfg.Auth.TOTPIssuer,
+		RequireAdmin2FA: cfg.Auth.RequireAdmin2FA,
+		OIDC: readers.OIDCOptions{
+			AutoProvision: cfg.OIDC.AutoProvision,
+			AdminGroup:    cfg.OIDC.AdminGroup,
+			TrustIdPMFA:   cfg.OIDC.TrustIdPMFA,
+			MFAMethods:    cfg.OIDC.MFAMethods,
+			MFAACR:        cfg.OIDC.MFAACR,
+		},
+	})
 
-	reservationsRepos := reservrepo.NewReservationRepo(pool.DB)
-	reservationService := reservations.NewReservationService(reservationsRepos)
+	reservationService := reservations.NewReservationService(repos.Reservations, repos.Books)
+	recommendService := recommendations.NewRecommendationService(repos.Similarity, repos.Reservations, repos.Readers, bookService, cfg.Recommendations.Neighbours)
 
 	srv := &Server{
-		App:           app,
-		Config:        cfg,
-		bookService:   bookService,
-		authorService: authorService,
-		readerService: readerService,
-		reservService: reservationService,
+		App:              app,
+		Config:           cfg,
+		bookService:      bookService,
+		authorService:    authorService,
+		publisherService: publishers.NewPublisherService(repos.Publishers, idCounter, bookService),
+		readerService:    readerService,
+		reservService:    reservationService,
+		reviewService:    reviewService,
+		recommendService: recommendService,
+		listService:      readinglists.NewReadingListService(repos.ReadingLists, repos.Reservations, bookService, reservationService, idCounter),
+		catalogService:   catalog.NewCatalogService(repos.CatalogTx(), idCounter, newMetadataProvider(cfg.Lookup)),
+		exportService:    export.NewExportService(repos.Books, repos.Authors, repos.Genres, repos.Readers, repos.Reservations),
+		coverService:     coverService,
+		genreService:     genres.NewGenreService(repos.Genres, repos.Books, idCounter, bookService),
+		tagService:       tags.NewTagService(repos.Tags, repos.Books, idCounter, bookService),
+		workService:      works.NewWorkService(repos.Works, idCounter, bookService),
+		health:           health.NewChecker(),
+	}
+	if cfg.Metrics.Enabled {
+		srv.workers = append(srv.workers, workers.New("overdue-loans", cfg.Metrics.OverdueInterval, func(ctx context.Context) error {
+			count, err := reservationService.CountOverdue(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+			metrics.OverdueLoans.Set(float64(count))
+			return nil
+		}))
+	}
+	if cfg.Recommendations.Enabled {
+		srv.workers = append(srv.workers, workers.New("book-similarity", cfg.Recommendations.Interval, recommendService.Recompute))
+	}
+	srv.registerHealthChecks(db)
+	if cfg.OIDC.Enabled {
+		srv.oidcProvider = oidc.NewProvider(oidc.Config{
+			IssuerURL:    cfg.OIDC.IssuerURL,
+			ClientID:     cfg.OIDC.ClientID,
+			ClientSecret: cfg.OIDC.ClientSecret,
+			RedirectURL:  cfg.OIDC.RedirectURL,
+			Scopes:       cfg.OIDC.Scopes,
+			StateKey:     oidcStateKey(cfg.Auth.TokenSecret),
+		}, nil)
 	}
 
 	srv.registerRouter()
 	return srv
 }
 
+// Database — SQL-хранилище, состояние которого проверяет /readyz.
+type Database interface {
+	Ping(ctx context.Context) error
+	Stats() map[string]interface{}
+	MigrationVersion(ctx context.Context) (uint, bool, error)
+	LatestMigration() (uint, error)
+}
+
+// newMetadataProvider собирает провайдер поиска по ISBN с кешем; nil,
+// если поиск выключен.
+func newMetadataProvider(cfg config.LookupConfig) domainBooks.MetadataProvider {
+	if !cfg.Enabled {
+		return nil
+	}
+	return isbnlookup.NewCache(isbnlookup.NewOpenLibrary(cfg.BaseURL, cfg.Timeout), cfg.CacheTTL, cfg.CacheSize)
+}
+
+// newCoverStore создаёт хранилище файлов обложек.
+func newCoverStore(cfg config.CoversConfig) (blob.BlobStore, error) {
+	switch cfg.Store {
+	case config.CoverStoreS3:
+		return blob.NewS3(blob.S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			PathStyle: cfg.S3.PathStyle,
+		}, nil)
+	case config.CoverStoreMemory:
+		return blob.NewMemory(), nil
+	default:
+		return blob.NewLocal(cfg.Dir)
+	}
+}
+
+// bodyLimit оставляет лимит Fiber по умолчанию (4 МБ), если обложка
+// в него помещается, и иначе поднимает его с запасом на разметку multipart.
+func bodyLimit(coverMaxSize int64) int {
+	const defaultLimit = fiber.DefaultBodyLimit
+	if limit := coverMaxSize + 64<<10; limit > defaultLimit {
+		return int(limit)
+	}
+	return defaultLimit
+}
+
+// oidcStateKey выводит ключ подписи cookie OIDC из секрета токенов, чтобы
+// вход, начатый на одной реплике, завершался на любой другой. Без секрета
+// провайдер возьмёт случайный ключ.
+func oidcStateKey(tokenSecret string) []byte {
+	if tokenSecret == "" {
+		return nil
+	}
+	key := sha256.Sum256([]byte("oidc-state\x00" + tokenSecret))
+	return key[:]
+}
+
+// registerHealthChecks подключает проверки готовности для /readyz.
+// Проверки фоновых задач регистрируются после того, как задачи собраны.
+// Без SQL-хранилища проверять базу и миграции нечего.
+func (s *Server) registerHealthChecks(db Database) {
+	for _, w := range s.workers {
+		s.health.Add("worker:"+w.Name(), w.Check)
+	}
+	if db == nil {
+		return
+	}
+	s.health.Add("database", func(ctx context.Context) (map[string]interface{}, error) {
+		return db.Stats(), db.Ping(ctx)
+	})
+	s.health.Add("migrations", func(ctx context.Context) (map[string]interface{}, error) {
+		version, dirty, err := db.MigrationVersion(ctx)
+		if err != nil {
+			return nil, err
+		}
+		latest, err := db.LatestMigration()
+		if err != nil {
+			return nil, err
+		}
+		details := map[string]interface{}{"version": version, "latest": latest, "dirty": dirty}
+		if dirty {
+			return details, fmt.Errorf("migration %d is dirty", version)
+		}
+		if version < latest {
+			return details, fmt.Errorf("schema version %d is behind %d, run migrate up", version, latest)
+		}
+		return details, nil
+	})
+}
+
+// Drain переводит /readyz в отказ; сервер продолжает обслуживать запросы,
+// пока балансировщик не уберёт его из ротации.
+func (s *Server) Drain() {
+	s.health.SetDraining()
+}
+
+// RunWorkers запускает фоновые задачи сервера; они завершаются вместе с ctx.
+func (s *Server) RunWorkers(ctx context.Context) {
+	for _, w := range s.workers {
+		go w.Run(ctx)
+	}
+}
+
 func (s *Server) Start() error {
 	address := fmt.Sprintf(":%d", s.Config.App.Port)
 	return s.App.Listen(address)
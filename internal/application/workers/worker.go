@@ -0,0 +1,109 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+)
+
+// Task — одна итерация фоновой задачи.
+type Task func(ctx context.Context) error
+
+// Worker периодически выполняет задачу и запоминает результат последнего запуска.
+type Worker struct {
+	name     string
+	interval time.Duration
+	task     Task
+
+	mu      sync.RWMutex
+	lastRun time.Time
+	lastErr error
+	running bool
+}
+
+// New создаёт фоновую задачу с указанным интервалом.
+func New(name string, interval time.Duration, task Task) *Worker {
+	return &Worker{name: name, interval: interval, task: task}
+}
+
+// Name возвращает имя задачи.
+func (w *Worker) Name() string {
+	return w.name
+}
+
+// Interval возвращает период запуска задачи.
+func (w *Worker) Interval() time.Duration {
+	return w.interval
+}
+
+// Run выполняет задачу сразу и затем с заданным интервалом до отмены контекста.
+func (w *Worker) Run(ctx context.Context) {
+	lg := logger.FromContext(ctx)
+
+	w.mu.Lock()
+	w.running = true
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		w.running = false
+		w.mu.Unlock()
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		err := w.task(ctx)
+		w.mu.Lock()
+		w.lastRun = time.Now()
+		w.lastErr = err
+		w.mu.Unlock()
+		if err != nil && ctx.Err() == nil {
+			lg.Errorf("worker %s failed: %v", w.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status возвращает время и ошибку последнего запуска и признак работы цикла.
+func (w *Worker) Status() (lastRun time.Time, lastErr error, running bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.lastRun, w.lastErr, w.running
+}
+
+// staleFactor — во сколько интервалов допускается отсутствие запусков,
+// прежде чем задача считается зависшей.
+const staleFactor = 3
+
+// Check — проверка готовности: цикл задачи запущен и выполнялся недавно.
+// Ошибка последнего запуска выводится в деталях, но не валит проверку:
+// недоступность зависимостей отражают их собственные проверки.
+func (w *Worker) Check(ctx context.Context) (map[string]interface{}, error) {
+	lastRun, lastErr, running := w.Status()
+	details := map[string]interface{}{
+		"interval": w.interval.String(),
+	}
+	if !lastRun.IsZero() {
+		details["last_run"] = lastRun
+	}
+	if lastErr != nil {
+		details["last_error"] = lastErr.Error()
+	}
+
+	if !running {
+		return details, errors.New("worker is not running")
+	}
+	if !lastRun.IsZero() && time.Since(lastRun) > staleFactor*w.interval {
+		return details, fmt.Errorf("worker has not run for %s", time.Since(lastRun).Truncate(time.Second))
+	}
+	return details, nil
+}
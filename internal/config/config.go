@@ -1,51 +1,163 @@
 package config
 
 import (
-	"log"
-	"sync"
-
-	"github.com/ilyakaznacheev/cleanenv"
+	"time"
 )
 
+// Config — конфигурация приложения. Значения собираются из нескольких
+// источников (см. Load): теги env-default задают значения по умолчанию,
+// yaml — ключ в файле конфигурации, env — переменную окружения. Поля с тегом
+// secret скрываются при выводе конфигурации.
 type Config struct {
-	App      AppConfig      `yaml:"app"`
-	Database DatabaseConfig `yaml:"database"`
-	Logger   LoggerConfig   `yaml:"logger"`
+	// Storage — где хранятся данные: database (Postgres) или memory
+	// (в памяти процесса, для демонстрации и тестов).
+	Storage         string                `yaml:"storage" env:"STORAGE" env-default:"database"`
+	App             AppConfig             `yaml:"app"`
+	Database        DatabaseConfig        `yaml:"database"`
+	Logger          LoggerConfig          `yaml:"logger"`
+	Auth            AuthConfig            `yaml:"auth"`
+	OIDC            OIDCConfig            `yaml:"oidc"`
+	Metrics         MetricsConfig         `yaml:"metrics"`
+	Tracing         TracingConfig         `yaml:"tracing"`
+	Lookup          LookupConfig          `yaml:"lookup"`
+	Covers          CoversConfig          `yaml:"covers"`
+	Recommendations RecommendationsConfig `yaml:"recommendations"`
 }
 
+// Значения Config.Storage.
+const (
+	StorageDatabase = "database"
+	StorageMemory   = "memory"
+)
+
+// Значения DatabaseConfig.Driver.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
 type AppConfig struct {
 	Name string `yaml:"name" env:"APP_NAME" env-default:"BookCRM"`
 	Env  string `yaml:"env" env:"APP_ENV" env-default:"development"`
 	Port int    `yaml:"port" env:"APP_PORT" env-default:"8080"`
+	// DrainDelay — пауза между переводом /readyz в отказ и остановкой сервера.
+	DrainDelay      time.Duration `yaml:"drain_delay" env:"APP_DRAIN_DELAY" env-default:"5s"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"APP_SHUTDOWN_TIMEOUT" env-default:"5s"`
 }
 
 type DatabaseConfig struct {
+	// Driver — postgres (по умолчанию) или sqlite: локальный файл Path
+	// без отдельного сервера БД.
+	Driver   string `yaml:"driver" env:"DATABASE_DRIVER" env-default:"postgres"`
+	Path     string `yaml:"path" env:"SQLITE_PATH" env-default:"bookapi.db"`
 	Host     string `yaml:"host" env:"POSTGRES_HOST" env-default:"localhost"`
 	Port     uint16 `yaml:"port" env:"POSTGRES_PORT" env-default:"5432"`
 	User     string `yaml:"user" env:"POSTGRES_USER" env-default:"sokrat"`
-	Password string `yaml:"password" env:"POSTGRES_PASSWORD" env-default:"1234"`
+	Password string `yaml:"password" env:"POSTGRES_PASSWORD" env-default:"1234" secret:"true"`
 	Name     string `yaml:"name" env:"POSTGRES_DB" env-default:"bookApi"`
 	Schema   string `yaml:"schema" env:"POSTGRES_SCHEMA" env-default:"public"`
 	SSLMode  string `yaml:"sslmode" env:"POSTGRES_SSLMODE" env-default:"disable"`
 	MaxConn  int32  `yaml:"max_connections" env:"POSTGRES_MAX_CONN" env-default:"5"`
 	MinConn  int32  `yaml:"min_connections" env:"POSTGRES_MIN_CONN" env-default:"1"`
+	// AutoMigrate — применять миграции при старте сервера. При false схему
+	// обновляют отдельно командой migrate.
+	AutoMigrate bool `yaml:"auto_migrate" env:"POSTGRES_AUTO_MIGRATE" env-default:"true"`
 }
 
 type LoggerConfig struct {
-	Level string `yaml:"level" env:"LOGGER_LEVEL" env-default:"development"`
+	// Level — минимальный уровень: debug, info, warn или error.
+	Level string `yaml:"level" env:"LOGGER_LEVEL" env-default:"info"`
+	// Format — console (цветной вывод для разработки) или json.
+	Format string `yaml:"format" env:"LOGGER_FORMAT" env-default:"console"`
+}
+
+type AuthConfig struct {
+	TokenSecret     string        `yaml:"token_secret" env:"AUTH_TOKEN_SECRET" secret:"true"`
+	TokenTTL        time.Duration `yaml:"token_ttl" env:"AUTH_TOKEN_TTL" env-default:"24h"`
+	TOTPIssuer      string        `yaml:"totp_issuer" env:"AUTH_TOTP_ISSUER" env-default:"BookAPI"`
+	RequireAdmin2FA bool          `yaml:"require_admin_2fa" env:"AUTH_REQUIRE_ADMIN_2FA" env-default:"false"`
+}
+
+type OIDCConfig struct {
+	Enabled       bool     `yaml:"enabled" env:"OIDC_ENABLED" env-default:"false"`
+	IssuerURL     string   `yaml:"issuer_url" env:"OIDC_ISSUER_URL"`
+	ClientID      string   `yaml:"client_id" env:"OIDC_CLIENT_ID"`
+	ClientSecret  string   `yaml:"client_secret" env:"OIDC_CLIENT_SECRET" secret:"true"`
+	RedirectURL   string   `yaml:"redirect_url" env:"OIDC_REDIRECT_URL" env-default:"http://localhost:8080/auth/oidc/callback"`
+	Scopes        []string `yaml:"scopes" env:"OIDC_SCOPES" env-separator:"," env-default:"openid,email,profile"`
+	AutoProvision bool     `yaml:"auto_provision" env:"OIDC_AUTO_PROVISION" env-default:"true"`
+	GroupsClaim   string   `yaml:"groups_claim" env:"OIDC_GROUPS_CLAIM" env-default:"groups"`
+	AdminGroup    string   `yaml:"admin_group" env:"OIDC_ADMIN_GROUP"`
+	// TrustIdPMFA засчитывает второй фактор IdP вместо локального TOTP, если
+	// amr или acr ID token совпадает с MFAMethods или MFAACR.
+	TrustIdPMFA bool     `yaml:"trust_idp_mfa" env:"OIDC_TRUST_IDP_MFA" env-default:"false"`
+	MFAMethods  []string `yaml:"mfa_amr" env:"OIDC_MFA_AMR" env-separator:"," env-default:"mfa"`
+	MFAACR      []string `yaml:"mfa_acr" env:"OIDC_MFA_ACR" env-separator:","`
 }
 
-var cfg *Config
-var once sync.Once
+type MetricsConfig struct {
+	Enabled         bool          `yaml:"enabled" env:"METRICS_ENABLED" env-default:"true"`
+	Path            string        `yaml:"path" env:"METRICS_PATH" env-default:"/metrics"`
+	OverdueInterval time.Duration `yaml:"overdue_interval" env:"METRICS_OVERDUE_INTERVAL" env-default:"1m"`
+}
 
-func GetConfig() *Config {
-	once.Do(func() {
-		cfg = &Config{}
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled" env:"TRACING_ENABLED" env-default:"false"`
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"stdout"`
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT" env-default:"localhost:4318"`
+	Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"true"`
+	ServiceName string  `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"bookapi"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
 
-		if err := cleanenv.ReadConfig(".env", cfg); err != nil {
-			log.Fatalf("❌ Ошибка загрузки конфигурации: %v", err)
-		}
+// LookupConfig — поиск метаданных книги по ISBN во внешнем каталоге
+// с API Open Library. BaseURL можно направить на локальный stub.
+type LookupConfig struct {
+	Enabled   bool          `yaml:"enabled" env:"LOOKUP_ENABLED" env-default:"true"`
+	BaseURL   string        `yaml:"base_url" env:"LOOKUP_BASE_URL" env-default:"https://openlibrary.org"`
+	Timeout   time.Duration `yaml:"timeout" env:"LOOKUP_TIMEOUT" env-default:"5s"`
+	CacheTTL  time.Duration `yaml:"cache_ttl" env:"LOOKUP_CACHE_TTL" env-default:"24h"`
+	CacheSize int           `yaml:"cache_size" env:"LOOKUP_CACHE_SIZE" env-default:"1000"`
+}
+
+// CoversConfig — хранение обложек книг. Store — local (каталог Dir),
+// s3 (S3-совместимое хранилище, например MinIO) или memory.
+type CoversConfig struct {
+	Store string `yaml:"store" env:"COVERS_STORE" env-default:"local"`
+	Dir   string `yaml:"dir" env:"COVERS_DIR" env-default:"covers"`
+	// MaxSize — наибольший размер загружаемого файла в байтах.
+	MaxSize int64 `yaml:"max_size" env:"COVERS_MAX_SIZE" env-default:"5242880"`
+	// Widths — ширины миниатюр в пикселях.
+	Widths []int `yaml:"widths" env:"COVERS_WIDTHS" env-separator:"," env-default:"160,320"`
+	// PublicURL — адрес, по которому хранилище отдаёт объекты напрямую
+	// (CDN или публичный бакет). Пусто — изображения отдаёт API.
+	PublicURL string   `yaml:"public_url" env:"COVERS_PUBLIC_URL"`
+	S3        S3Config `yaml:"s3"`
+}
 
-	})
-	return cfg
+// RecommendationsConfig — рекомендации книг по совместным бронированиям.
+// Соседи книг пересчитываются фоновой задачей раз в Interval; при
+// Enabled = false задача не запускается и выдаются последние сохранённые.
+type RecommendationsConfig struct {
+	Enabled  bool          `yaml:"enabled" env:"RECOMMENDATIONS_ENABLED" env-default:"true"`
+	Interval time.Duration `yaml:"interval" env:"RECOMMENDATIONS_INTERVAL" env-default:"1h"`
+	// Neighbours — сколько похожих книг хранится у каждой книги.
+	Neighbours int `yaml:"neighbours" env:"RECOMMENDATIONS_NEIGHBOURS" env-default:"20"`
 }
+
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint" env:"COVERS_S3_ENDPOINT"`
+	Region    string `yaml:"region" env:"COVERS_S3_REGION" env-default:"us-east-1"`
+	Bucket    string `yaml:"bucket" env:"COVERS_S3_BUCKET"`
+	AccessKey string `yaml:"access_key" env:"COVERS_S3_ACCESS_KEY"`
+	SecretKey string `yaml:"secret_key" env:"COVERS_S3_SECRET_KEY" secret:"true"`
+	// PathStyle — адресовать бакет путём, а не поддоменом (нужно для MinIO).
+	PathStyle bool `yaml:"path_style" env:"COVERS_S3_PATH_STYLE" env-default:"true"`
+}
+
+// Значения CoversConfig.Store.
+const (
+	CoverStoreLocal  = "local"
+	CoverStoreS3     = "s3"
+	CoverStoreMemory = "memory"
+)
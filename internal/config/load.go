@@ -0,0 +1,271 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Options — параметры командной строки, которые управляют загрузкой,
+// но сами в конфигурацию не входят.
+type Options struct {
+	// ConfigPath — YAML-файл конфигурации (--config), необязателен.
+	ConfigPath string
+	// EnvFile — файл с переменными окружения (--env-file). Отсутствие файла
+	// по умолчанию (.env) ошибкой не считается.
+	EnvFile string
+	// PrintConfig — вывести итоговую конфигурацию и завершиться.
+	PrintConfig bool
+	// Args — позиционные аргументы после флагов.
+	Args []string
+}
+
+const defaultEnvFile = ".env"
+
+// field — лист дерева конфигурации с его тегами.
+type field struct {
+	path   string // путь в YAML через точку, он же имя флага
+	value  reflect.Value
+	tag    reflect.StructTag
+	secret bool
+}
+
+// Load собирает конфигурацию из источников в порядке возрастания приоритета:
+// значения по умолчанию, YAML-файл, переменные окружения (включая .env),
+// флаги командной строки. Каждое поле доступно флагом по своему YAML-пути,
+// например --app.port или --database.host. Ошибки разбора и валидации
+// возвращаются все вместе.
+func Load(name string, args []string) (*Config, Options, error) {
+	cfg := &Config{}
+	fields := collectFields(reflect.ValueOf(cfg).Elem(), "")
+
+	var opts Options
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&opts.ConfigPath, "config", "", "путь к YAML-файлу конфигурации")
+	fs.StringVar(&opts.EnvFile, "env-file", defaultEnvFile, "файл с переменными окружения")
+	fs.BoolVar(&opts.PrintConfig, "print-config", false, "вывести итоговую конфигурацию (секреты скрыты) и выйти")
+	overrides := make(map[string]*string, len(fields))
+	for _, f := range fields {
+		usage := "переопределяет " + f.path
+		if env := f.tag.Get("env"); env != "" {
+			usage += " (env " + env + ")"
+		}
+		overrides[f.path] = fs.String(f.path, f.tag.Get("env-default"), usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, opts, err
+	}
+	opts.Args = fs.Args()
+
+	var errs []error
+	for _, f := range fields {
+		if def, ok := f.tag.Lookup("env-default"); ok {
+			if err := setValue(f, def); err != nil {
+				errs = append(errs, fmt.Errorf("%s: default %q: %w", f.path, def, err))
+			}
+		}
+	}
+
+	if opts.ConfigPath != "" {
+		if err := readYAML(opts.ConfigPath, cfg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	env, err := readEnv(opts.EnvFile, opts.EnvFile != defaultEnvFile)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, f := range fields {
+		name := f.tag.Get("env")
+		raw, ok := env[name]
+		if name == "" || !ok {
+			continue
+		}
+		if err := setValue(f, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: env %s=%q: %w", f.path, name, raw, err))
+		}
+	}
+
+	byPath := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byPath[f.path] = f
+	}
+	fs.Visit(func(fl *flag.Flag) {
+		f, ok := byPath[fl.Name]
+		if !ok {
+			return
+		}
+		raw := *overrides[fl.Name]
+		if err := setValue(f, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: flag --%s=%q: %w", f.path, fl.Name, raw, err))
+		}
+	})
+
+	errs = append(errs, cfg.Validate()...)
+	if len(errs) > 0 {
+		return nil, opts, errors.Join(errs...)
+	}
+	return cfg, opts, nil
+}
+
+// readYAML накладывает значения из файла поверх уже заполненной структуры.
+// Неизвестные ключи считаются ошибкой, чтобы опечатки не терялись молча.
+func readYAML(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// readEnv возвращает переменные из env-файла, поверх которых записаны
+// переменные процесса. required определяет, ошибка ли отсутствие файла.
+func readEnv(path string, required bool) (map[string]string, error) {
+	env := map[string]string{}
+	if path != "" {
+		fileEnv, err := godotenv.Read(path)
+		switch {
+		case err == nil:
+			env = fileEnv
+		case required || !errors.Is(err, os.ErrNotExist):
+			return env, fmt.Errorf("env file: %w", err)
+		}
+	}
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return env, nil
+}
+
+// collectFields обходит вложенные структуры и возвращает листовые поля.
+func collectFields(v reflect.Value, prefix string) []field {
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Duration(0)) {
+			fields = append(fields, collectFields(fv, path)...)
+			continue
+		}
+		fields = append(fields, field{
+			path:   path,
+			value:  fv,
+			tag:    sf.Tag,
+			secret: sf.Tag.Get("secret") == "true",
+		})
+	}
+	return fields
+}
+
+// setValue разбирает строковое значение в тип поля.
+func setValue(f field, raw string) error {
+	v := f.value
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		if kind := v.Type().Elem().Kind(); kind != reflect.String && kind != reflect.Int {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+		sep := f.tag.Get("env-separator")
+		if sep == "" {
+			sep = ","
+		}
+		items := reflect.Zero(v.Type())
+		for _, item := range strings.Split(raw, sep) {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(field{value: elem}, item); err != nil {
+				return err
+			}
+			items = reflect.Append(items, elem)
+		}
+		v.Set(items)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// Redacted возвращает копию конфигурации, в которой непустые секреты
+// заменены на "******".
+func (c *Config) Redacted() *Config {
+	cp := *c
+	for _, f := range collectFields(reflect.ValueOf(&cp).Elem(), "") {
+		if f.secret && f.value.Kind() == reflect.String && f.value.String() != "" {
+			f.value.SetString("******")
+		}
+	}
+	return &cp
+}
+
+// Print выводит конфигурацию в YAML со скрытыми секретами.
+func (c *Config) Print(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
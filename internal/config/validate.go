@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Validate проверяет согласованность конфигурации и возвращает все
+// найденные ошибки, а не только первую.
+func (c *Config) Validate() []error {
+	var errs []error
+	fail := func(path, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+	oneOf := func(path, value string, allowed ...string) {
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		fail(path, "%q is not one of %s", value, strings.Join(allowed, ", "))
+	}
+
+	if c.App.Name == "" {
+		fail("app.name", "must not be empty")
+	}
+	oneOf("app.env", c.App.Env, "development", "staging", "production")
+	if c.App.Port < 1 || c.App.Port > 65535 {
+		fail("app.port", "%d is out of range 1-65535", c.App.Port)
+	}
+	if c.App.DrainDelay < 0 {
+		fail("app.drain_delay", "must not be negative")
+	}
+	if c.App.ShutdownTimeout <= 0 {
+		fail("app.shutdown_timeout", "must be positive")
+	}
+
+	oneOf("storage", c.Storage, StorageDatabase, StorageMemory)
+	if c.Storage == StorageDatabase {
+		oneOf("database.driver", c.Database.Driver, DriverPostgres, DriverSQLite)
+	}
+	switch {
+	case c.Storage == StorageDatabase && c.Database.Driver == DriverSQLite:
+		if c.Database.Path == "" {
+			fail("database.path", "is required for the sqlite driver")
+		}
+	case c.Storage == StorageDatabase && c.Database.Driver == DriverPostgres:
+		if c.Database.Host == "" {
+			fail("database.host", "must not be empty")
+		}
+		if c.Database.Port == 0 {
+			fail("database.port", "must not be zero")
+		}
+		if c.Database.User == "" {
+			fail("database.user", "must not be empty")
+		}
+		if c.Database.Name == "" {
+			fail("database.name", "must not be empty")
+		}
+		oneOf("database.sslmode", c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+		if c.Database.MaxConn < 1 {
+			fail("database.max_connections", "must be at least 1")
+		}
+		if c.Database.MinConn < 0 || c.Database.MinConn > c.Database.MaxConn {
+			fail("database.min_connections", "must be between 0 and max_connections (%d)", c.Database.MaxConn)
+		}
+	}
+
+	oneOf("logger.level", c.Logger.Level, "debug", "info", "warn", "error")
+	oneOf("logger.format", c.Logger.Format, "console", "json")
+
+	if c.Auth.TokenTTL <= 0 {
+		fail("auth.token_ttl", "must be positive")
+	}
+	if c.Auth.TOTPIssuer == "" {
+		fail("auth.totp_issuer", "must not be empty")
+	}
+	if c.App.Env == "production" && c.Auth.TokenSecret == "" {
+		fail("auth.token_secret", "is required in production")
+	}
+
+	if c.OIDC.Enabled {
+		if !isHTTPURL(c.OIDC.IssuerURL) {
+			fail("oidc.issuer_url", "must be an http(s) URL when OIDC is enabled")
+		}
+		if c.OIDC.ClientID == "" {
+			fail("oidc.client_id", "is required when OIDC is enabled")
+		}
+		if !isHTTPURL(c.OIDC.RedirectURL) {
+			fail("oidc.redirect_url", "must be an http(s) URL when OIDC is enabled")
+		}
+		if c.OIDC.TrustIdPMFA && len(c.OIDC.MFAMethods) == 0 && len(c.OIDC.MFAACR) == 0 {
+			fail("oidc.mfa_amr", "must not be empty when trust_idp_mfa is enabled and oidc.mfa_acr is not set")
+		}
+		// Созданные через IdP читатели не знают локального пароля, а без него
+		// второй фактор не подключить: администратор из группы IdP остался бы
+		// без прав навсегда.
+		if c.Auth.RequireAdmin2FA && c.OIDC.AutoProvision && c.OIDC.AdminGroup != "" && !c.OIDC.TrustIdPMFA {
+			fail("oidc.trust_idp_mfa", "must be enabled when auth.require_admin_2fa is set and auto-provisioned readers get admin rights from oidc.admin_group")
+		}
+	}
+
+	if c.Metrics.Enabled {
+		if !strings.HasPrefix(c.Metrics.Path, "/") {
+			fail("metrics.path", "must start with /")
+		}
+		if c.Metrics.OverdueInterval <= 0 {
+			fail("metrics.overdue_interval", "must be positive")
+		}
+	}
+
+	if c.Tracing.Enabled {
+		oneOf("tracing.exporter", c.Tracing.Exporter, "stdout", "otlp")
+		if c.Tracing.Exporter == "otlp" && c.Tracing.Endpoint == "" {
+			fail("tracing.endpoint", "is required for the otlp exporter")
+		}
+	}
+	if c.Lookup.Enabled {
+		if !isHTTPURL(c.Lookup.BaseURL) {
+			fail("lookup.base_url", "must be an http(s) URL when lookup is enabled")
+		}
+		if c.Lookup.Timeout <= 0 {
+			fail("lookup.timeout", "must be positive")
+		}
+		if c.Lookup.CacheTTL < 0 {
+			fail("lookup.cache_ttl", "must not be negative")
+		}
+		if c.Lookup.CacheSize < 0 {
+			fail("lookup.cache_size", "must not be negative")
+		}
+	}
+
+	oneOf("covers.store", c.Covers.Store, CoverStoreLocal, CoverStoreS3, CoverStoreMemory)
+	switch c.Covers.Store {
+	case CoverStoreLocal:
+		if c.Covers.Dir == "" {
+			fail("covers.dir", "is required for the local store")
+		}
+	case CoverStoreS3:
+		if !isHTTPURL(c.Covers.S3.Endpoint) {
+			fail("covers.s3.endpoint", "must be an http(s) URL for the s3 store")
+		}
+		if c.Covers.S3.Bucket == "" {
+			fail("covers.s3.bucket", "is required for the s3 store")
+		}
+		if c.Covers.S3.AccessKey == "" || c.Covers.S3.SecretKey == "" {
+			fail("covers.s3", "access_key and secret_key are required for the s3 store")
+		}
+	}
+	if c.Covers.MaxSize <= 0 {
+		fail("covers.max_size", "must be positive")
+	}
+	for _, w := range c.Covers.Widths {
+		if w < 16 || w > 4096 {
+			fail("covers.widths", "%d is out of range 16-4096", w)
+		}
+	}
+	if c.Covers.PublicURL != "" && !isHTTPURL(c.Covers.PublicURL) {
+		fail("covers.public_url", "must be an http(s) URL")
+	}
+
+	if c.Recommendations.Enabled && c.Recommendations.Interval <= 0 {
+		fail("recommendations.interval", "must be positive")
+	}
+	if c.Recommendations.Neighbours < 1 || c.Recommendations.Neighbours > 100 {
+		fail("recommendations.neighbours", "%d is out of range 1-100", c.Recommendations.Neighbours)
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		fail("tracing.sample_ratio", "%v is out of range 0-1", c.Tracing.SampleRatio)
+	}
+	return errs
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
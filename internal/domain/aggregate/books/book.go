@@ -2,49 +2,152 @@ package books
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 )
 
+// ErrNotFound возвращается репозиторием, когда книги с таким ID нет.
+var ErrNotFound = errors.New("book not found")
+
 type Book struct {
-	ID        int
-	Title     string
-	Year      int
-	ISBN      string
-	Genre     string
-	authorIDs []int
+	ID    int
+	Title string
+	Year  int
+	ISBN  string
+	// WorkID — произведение, изданием которого является книга; 0 — без
+	// произведения.
+	WorkID int
+	// PublisherID — издательство; 0 — не указано.
+	PublisherID int
+	// Language и Translator описывают конкретное издание.
+	Language   string `json:",omitempty"`
+	Translator string `json:",omitempty"`
+	// Genres — жанры книги с названиями; заполняются при выдаче книги.
+	Genres []GenreRef `json:",omitempty"`
+	// Cover хранится отдельно (CoverRepo) и заполняется при выдаче книги.
+	Cover *Cover `json:",omitempty"`
+	// Rating — сводка одобренных отзывов; заполняется при выдаче книги.
+	Rating *Rating `json:",omitempty"`
+	// contributors — авторы, переводчики, редакторы и иллюстраторы в
+	// порядке, заданном при сохранении книги.
+	contributors []Contributor
+	genreIDs     []int
+}
+
+// Rating — средняя оценка книги читателями и число оценок.
+type Rating struct {
+	Average float64
+	Count   int
+}
+
+// NewRating считает среднюю оценку по сумме и числу оценок и округляет её
+// до сотых.
+func NewRating(sum, count int) Rating {
+	if count == 0 {
+		return Rating{}
+	}
+	return Rating{Average: math.Round(float64(sum)/float64(count)*100) / 100, Count: count}
+}
+
+// GenreRef — жанр в данных книги.
+type GenreRef struct {
+	ID   int
+	Name string
 }
 
 type BookRepo interface {
 	Create(ctx context.Context, book *Book) error
 	GetByID(ctx context.Context, id int) (*Book, error)
+	// GetByISBN ищет книгу по нормализованному ISBN (см. NormalizeISBN).
+	GetByISBN(ctx context.Context, isbn string) (*Book, error)
 	Update(ctx context.Context, book *Book) error
 	Delete(ctx context.Context, id int) error
 	List(ctx context.Context) ([]Book, error)
 	ListBooksByAuthor(ctx context.Context, authorID int) ([]Book, error)
+	// Iterate передаёт fn книги по возрастанию ID, не загружая выборку
+	// целиком; ошибка fn прерывает обход и возвращается.
+	Iterate(ctx context.Context, filter Filter, fn func(*Book) error) error
 }
 
-func NewBook(id int, title string, year int, isbn string, genre string, authorIDs []int) (*Book, error) {
+// Filter — условия выборки книг. Нулевое значение выбирает все книги.
+type Filter struct {
+	// AuthorID — только книги этого автора.
+	AuthorID int
+	// Role вместе с AuthorID оставляет книги, где автор участвует в этой
+	// роли; пустая — в любой.
+	Role Role
+	// GenreIDs — только книги хотя бы с одним из этих жанров.
+	GenreIDs []int
+	// TagID — только книги с этим тегом.
+	TagID int
+	// WorkID — только издания этого произведения.
+	WorkID int
+	// PublisherID — только книги этого издательства.
+	PublisherID int
+}
+
+func NewBook(id int, title string, year int, isbn string, authorIDs, genreIDs []int) (*Book, error) {
 	if title == "" {
 		return nil, fmt.Errorf("title cannot be empty")
 	}
-	return &Book{
-		ID:        id,
-		Title:     title,
-		Year:      year,
-		ISBN:      isbn,
-		Genre:     genre,
-		authorIDs: authorIDs,
-	}, nil
+	book := &Book{
+		ID:       id,
+		Title:    title,
+		Year:     year,
+		ISBN:     isbn,
+		genreIDs: genreIDs,
+	}
+	book.SetAuthorIDs(authorIDs)
+	return book, nil
 }
 
-// AuthorIDs возвращает копию списка идентификаторов авторов.
+// AuthorIDs возвращает идентификаторы участников с ролью автора в порядке
+// их следования.
 func (b *Book) AuthorIDs() []int {
-	ids := make([]int, len(b.authorIDs))
-	copy(ids, b.authorIDs)
+	ids := make([]int, 0, len(b.contributors))
+	for _, c := range b.contributors {
+		if c.Role == RoleAuthor {
+			ids = append(ids, c.AuthorID)
+		}
+	}
 	return ids
 }
 
-// SetAuthorIDs устанавливает список идентификаторов авторов.
+// SetAuthorIDs заменяет авторов книги; участники в других ролях
+// сохраняются и следуют за авторами.
 func (b *Book) SetAuthorIDs(ids []int) {
-	b.authorIDs = ids
+	list := make([]Contributor, 0, len(ids)+len(b.contributors))
+	for _, id := range ids {
+		list = append(list, Contributor{AuthorID: id, Role: RoleAuthor})
+	}
+	for _, c := range b.contributors {
+		if c.Role != RoleAuthor {
+			list = append(list, c)
+		}
+	}
+	b.contributors = list
+}
+
+// GenreIDs возвращает копию списка идентификаторов жанров.
+func (b *Book) GenreIDs() []int {
+	ids := make([]int, len(b.genreIDs))
+	copy(ids, b.genreIDs)
+	return ids
+}
+
+// SetGenreIDs устанавливает список идентификаторов жанров.
+func (b *Book) SetGenreIDs(ids []int) {
+	b.genreIDs = ids
+}
+
+// FillGenres заполняет Genres по GenreIDs; names — названия жанров по ID,
+// неизвестные жанры пропускаются.
+func (b *Book) FillGenres(names map[int]string) {
+	b.Genres = nil
+	for _, id := range b.genreIDs {
+		if name, ok := names[id]; ok {
+			b.Genres = append(b.Genres, GenreRef{ID: id, Name: name})
+		}
+	}
 }
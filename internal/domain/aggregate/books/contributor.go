@@ -0,0 +1,76 @@
+package books
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Role — роль участника в создании книги.
+type Role string
+
+const (
+	RoleAuthor      Role = "author"
+	RoleTranslator  Role = "translator"
+	RoleEditor      Role = "editor"
+	RoleIllustrator Role = "illustrator"
+)
+
+// ParseRole разбирает роль без учёта регистра; пустая строка — автор.
+func ParseRole(s string) (Role, error) {
+	switch role := Role(strings.ToLower(strings.TrimSpace(s))); role {
+	case "":
+		return RoleAuthor, nil
+	case RoleAuthor, RoleTranslator, RoleEditor, RoleIllustrator:
+		return role, nil
+	default:
+		return "", fmt.Errorf("unknown contributor role %q", s)
+	}
+}
+
+// Contributor — участник книги из справочника авторов и его роль. Один
+// человек может участвовать в книге в нескольких ролях.
+type Contributor struct {
+	AuthorID int
+	Role     Role
+}
+
+// ValidateContributors проверяет роли и то, что пара автор—роль не
+// повторяется.
+func ValidateContributors(list []Contributor) error {
+	seen := make(map[Contributor]struct{}, len(list))
+	for _, c := range list {
+		if _, err := ParseRole(string(c.Role)); err != nil || c.Role == "" {
+			return fmt.Errorf("unknown contributor role %q", c.Role)
+		}
+		if _, ok := seen[c]; ok {
+			return fmt.Errorf("author %d is listed twice as %s", c.AuthorID, c.Role)
+		}
+		seen[c] = struct{}{}
+	}
+	return nil
+}
+
+// Contributors возвращает копию списка участников в порядке их следования.
+func (b *Book) Contributors() []Contributor {
+	list := make([]Contributor, len(b.contributors))
+	copy(list, b.contributors)
+	return list
+}
+
+// SetContributors устанавливает участников; порядок списка сохраняется.
+func (b *Book) SetContributors(list []Contributor) {
+	b.contributors = list
+}
+
+// ContributorIDs возвращает идентификаторы всех участников без повторов в
+// порядке первого упоминания.
+func (b *Book) ContributorIDs() []int {
+	ids := make([]int, 0, len(b.contributors))
+	for _, c := range b.contributors {
+		if !slices.Contains(ids, c.AuthorID) {
+			ids = append(ids, c.AuthorID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,36 @@
+package books
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrCoverNotFound — у книги нет обложки.
+var ErrCoverNotFound = errors.New("cover not found")
+
+// Cover — обложка книги. Сами изображения лежат в хранилище файлов,
+// в базе — только версия и параметры, из которых строятся ключи и URL.
+type Cover struct {
+	BookID int `json:"-"`
+	// Version — хеш содержимого. Он входит в ключи и URL, поэтому после
+	// замены обложки кеши не отдают старую картинку.
+	Version     string `json:"-"`
+	ContentType string
+	Width       int
+	Height      int
+	// ThumbnailWidths — ширины сгенерированных миниатюр по возрастанию.
+	ThumbnailWidths []int `json:"-"`
+
+	// URL и Thumbnails (ширина → URL) заполняет сервис обложек.
+	URL        string         `json:",omitempty"`
+	Thumbnails map[int]string `json:",omitempty"`
+}
+
+type CoverRepo interface {
+	// Get возвращает ErrCoverNotFound, если обложки нет.
+	Get(ctx context.Context, bookID int) (*Cover, error)
+	// Save создаёт или заменяет обложку книги.
+	Save(ctx context.Context, cover *Cover) error
+	Delete(ctx context.Context, bookID int) error
+	List(ctx context.Context) ([]Cover, error)
+}
@@ -0,0 +1,48 @@
+package books
+
+import "strings"
+
+// NormalizeISBN убирает дефисы и пробелы и приводит контрольный символ X
+// к верхнему регистру. В таком виде ISBN сравниваются при поиске.
+func NormalizeISBN(isbn string) string {
+	isbn = strings.NewReplacer("-", "", " ", "").Replace(isbn)
+	return strings.ToUpper(isbn)
+}
+
+// ValidISBN проверяет длину и контрольную цифру нормализованного ISBN-10
+// или ISBN-13.
+func ValidISBN(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i := 0; i < 10; i++ {
+			var d int
+			switch c := isbn[i]; {
+			case c >= '0' && c <= '9':
+				d = int(c - '0')
+			case c == 'X' && i == 9:
+				d = 10
+			default:
+				return false
+			}
+			sum += d * (10 - i)
+		}
+		return sum%11 == 0
+	case 13:
+		sum := 0
+		for i := 0; i < 13; i++ {
+			c := isbn[i]
+			if c < '0' || c > '9' {
+				return false
+			}
+			d := int(c - '0')
+			if i%2 == 1 {
+				d *= 3
+			}
+			sum += d
+		}
+		return sum%10 == 0
+	default:
+		return false
+	}
+}
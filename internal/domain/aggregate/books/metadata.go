@@ -0,0 +1,25 @@
+package books
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMetadataNotFound возвращается провайдером, когда внешний каталог
+// не знает такого ISBN.
+var ErrMetadataNotFound = errors.New("no metadata found for isbn")
+
+// Metadata — сведения о книге из внешнего каталога.
+type Metadata struct {
+	ISBN     string
+	Title    string
+	Year     int
+	Authors  []string
+	Subjects []string
+}
+
+// MetadataProvider ищет метаданные книги по нормализованному ISBN
+// во внешнем источнике.
+type MetadataProvider interface {
+	LookupISBN(ctx context.Context, isbn string) (*Metadata, error)
+}
@@ -0,0 +1,141 @@
+package readinglists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	// ErrNotFound возвращается репозиторием, когда списка с таким ID или
+	// ссылкой нет.
+	ErrNotFound = errors.New("reading list not found")
+	// ErrAlreadyListed — книга уже есть в списке.
+	ErrAlreadyListed = errors.New("book is already in the list")
+	// ErrNotListed — книги нет в списке.
+	ErrNotListed = errors.New("book is not in the list")
+)
+
+const (
+	// MaxNameLength — наибольшая длина названия списка в символах.
+	MaxNameLength = 100
+	// MaxBooks — наибольшее число книг в списке.
+	MaxBooks = 200
+)
+
+// ReadingList — именованный список книг читателя «на потом». Приватный
+// список видят только владелец и администраторы, публичный — любой по
+// ссылке Slug.
+type ReadingList struct {
+	ID       int
+	ReaderID int
+	Name     string
+	Public   bool
+	// Slug — случайная часть ссылки на публичный список; у приватного
+	// пустая.
+	Slug string `json:",omitempty"`
+	// BookIDs — книги в порядке списка.
+	BookIDs   []int
+	CreatedAt time.Time
+}
+
+type ReadingListRepo interface {
+	// Create сохраняет список вместе с книгами.
+	Create(ctx context.Context, list *ReadingList) error
+	GetByID(ctx context.Context, id int) (*ReadingList, error)
+	// GetBySlug возвращает публичный список по ссылке.
+	GetBySlug(ctx context.Context, slug string) (*ReadingList, error)
+	// Update сохраняет название, ссылку и книги списка в их порядке.
+	Update(ctx context.Context, list *ReadingList) error
+	// Modify в одной транзакции читает список с блокировкой, передаёт его
+	// fn и сохраняет результат, как Update. Параллельные изменения одного
+	// списка выполняются по очереди; ошибка fn отменяет изменение и
+	// возвращается.
+	Modify(ctx context.Context, id int, fn func(*ReadingList) error) (*ReadingList, error)
+	Delete(ctx context.Context, id int) error
+	// ListByReader возвращает списки читателя по возрастанию ID.
+	ListByReader(ctx context.Context, readerID int) ([]ReadingList, error)
+}
+
+// NewReadingList создаёт пустой приватный список.
+func NewReadingList(id, readerID int, name string, now time.Time) (*ReadingList, error) {
+	list := &ReadingList{ID: id, ReaderID: readerID, BookIDs: []int{}, CreatedAt: now}
+	if err := list.Rename(name); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Rename меняет название списка.
+func (l *ReadingList) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return fmt.Errorf("name is longer than %d characters", MaxNameLength)
+	}
+	l.Name = name
+	return nil
+}
+
+// Share открывает список по ссылке slug; уже открытый сохраняет прежнюю.
+func (l *ReadingList) Share(slug string) {
+	if l.Public {
+		return
+	}
+	l.Public = true
+	l.Slug = slug
+}
+
+// Unshare закрывает список; прежняя ссылка перестаёт работать.
+func (l *ReadingList) Unshare() {
+	l.Public = false
+	l.Slug = ""
+}
+
+// Add добавляет книгу в конец списка.
+func (l *ReadingList) Add(bookID int) error {
+	if slices.Contains(l.BookIDs, bookID) {
+		return fmt.Errorf("%w: book %d", ErrAlreadyListed, bookID)
+	}
+	if len(l.BookIDs) >= MaxBooks {
+		return fmt.Errorf("list cannot hold more than %d books", MaxBooks)
+	}
+	l.BookIDs = append(l.BookIDs, bookID)
+	return nil
+}
+
+// Remove убирает книгу из списка.
+func (l *ReadingList) Remove(bookID int) error {
+	i := slices.Index(l.BookIDs, bookID)
+	if i < 0 {
+		return fmt.Errorf("%w: book %d", ErrNotListed, bookID)
+	}
+	l.BookIDs = slices.Delete(l.BookIDs, i, i+1)
+	return nil
+}
+
+// Reorder задаёт новый порядок книг; bookIDs должен содержать ровно
+// книги списка.
+func (l *ReadingList) Reorder(bookIDs []int) error {
+	if len(bookIDs) != len(l.BookIDs) {
+		return fmt.Errorf("order must list all %d books of the list", len(l.BookIDs))
+	}
+	seen := make(map[int]struct{}, len(bookIDs))
+	for _, id := range bookIDs {
+		if !slices.Contains(l.BookIDs, id) {
+			return fmt.Errorf("%w: book %d", ErrNotListed, id)
+		}
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("book %d is repeated in the order", id)
+		}
+		seen[id] = struct{}{}
+	}
+	l.BookIDs = slices.Clone(bookIDs)
+	return nil
+}
@@ -0,0 +1,109 @@
+// Package recommendations описывает рекомендации книг по совместным
+// бронированиям: «читатели, бравшие эту книгу, брали и эти».
+package recommendations
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+)
+
+// Neighbour — книга, которую брали вместе с данной.
+type Neighbour struct {
+	BookID int `json:"book_id"`
+	// Score — косинусная близость книг по множествам читателей: число общих
+	// читателей, делённое на среднее геометрическое числа читателей каждой
+	// книги. Значение от 0 до 1, округлено до тысячных.
+	Score float64 `json:"score"`
+	// Readers — сколько читателей брали обе книги.
+	Readers int `json:"readers"`
+}
+
+// SimilarityRepo хранит рассчитанных соседей книг.
+type SimilarityRepo interface {
+	// Replace атомарно заменяет всех соседей: книги, которых нет в
+	// neighbours, остаются без соседей. Порядок соседей сохраняется.
+	Replace(ctx context.Context, neighbours map[int][]Neighbour) error
+	// Neighbours возвращает соседей каждой из книг bookIDs в сохранённом
+	// порядке; книги без соседей в результат не попадают.
+	Neighbours(ctx context.Context, bookIDs []int) (map[int][]Neighbour, error)
+}
+
+// History — история бронирований: какие книги брал каждый читатель.
+type History struct {
+	borrowed map[int]map[int]struct{}
+}
+
+// NewHistory создаёт пустую историю.
+func NewHistory() *History {
+	return &History{borrowed: make(map[int]map[int]struct{})}
+}
+
+// Add отмечает, что читатель брал книгу; повторы не учитываются.
+func (h *History) Add(readerID, bookID int) {
+	set := h.borrowed[readerID]
+	if set == nil {
+		set = make(map[int]struct{})
+		h.borrowed[readerID] = set
+	}
+	set[bookID] = struct{}{}
+}
+
+// Neighbours считает для каждой книги до topN самых близких книг по
+// убыванию Score, при равенстве — по числу общих читателей и возрастанию ID.
+func (h *History) Neighbours(topN int) map[int][]Neighbour {
+	readers := make(map[int]int)
+	common := make(map[int]map[int]int)
+	for _, set := range h.borrowed {
+		ids := make([]int, 0, len(set))
+		for id := range set {
+			ids = append(ids, id)
+			readers[id]++
+		}
+		for _, a := range ids {
+			for _, b := range ids {
+				if a == b {
+					continue
+				}
+				if common[a] == nil {
+					common[a] = make(map[int]int)
+				}
+				common[a][b]++
+			}
+		}
+	}
+
+	result := make(map[int][]Neighbour, len(common))
+	for bookID, others := range common {
+		list := make([]Neighbour, 0, len(others))
+		for otherID, n := range others {
+			score := float64(n) / math.Sqrt(float64(readers[bookID])*float64(readers[otherID]))
+			list = append(list, Neighbour{
+				BookID:  otherID,
+				Score:   math.Round(score*1000) / 1000,
+				Readers: n,
+			})
+		}
+		SortNeighbours(list)
+		if len(list) > topN {
+			list = list[:topN]
+		}
+		result[bookID] = list
+	}
+	return result
+}
+
+// SortNeighbours упорядочивает соседей по убыванию Score, затем по
+// убыванию Readers и возрастанию BookID.
+func SortNeighbours(list []Neighbour) {
+	slices.SortFunc(list, func(a, b Neighbour) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Readers, a.Readers); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.BookID, b.BookID)
+	})
+}
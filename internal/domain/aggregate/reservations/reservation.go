@@ -2,6 +2,7 @@ package reservations
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,20 +10,59 @@ import (
 	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
 )
 
+var (
+	// ErrNotFound возвращается репозиторием, когда бронирования с таким ID нет.
+	ErrNotFound = errors.New("reservation not found")
+	// ErrNoFreeBook — ни одна из предложенных книг не свободна на весь срок.
+	ErrNoFreeBook = errors.New("no book is free for the period")
+)
+
 type Reservation struct {
 	ID        int
 	Book      books.Book
 	Reader    readers.Reader
 	StartDate time.Time
 	EndDate   time.Time
+	// ReturnedDate — день возврата книги; nil, пока книга у читателя.
+	ReturnedDate *time.Time `json:",omitempty"`
 }
 
 type ReservationRepo interface {
 	Create(ctx context.Context, id int, book books.Book, reader readers.Reader, startDate, endDate time.Time) (*Reservation, error)
+	// CreateFirstFree в одной транзакции выбирает первую по порядку bookIDs
+	// книгу без невозвращённых бронирований, пересекающихся с интервалом,
+	// и бронирует её.
+	// Параллельные вызовы с общими книгами выполняются по очереди, поэтому
+	// одну книгу на один срок дважды не выдают. ErrNoFreeBook — свободных нет.
+	CreateFirstFree(ctx context.Context, id int, bookIDs []int, reader readers.Reader, startDate, endDate time.Time) (*Reservation, error)
 	GetById(ctx context.Context, id int) (*Reservation, error)
 	Update(ctx context.Context, id int, book books.Book, reader readers.Reader, startDate, endDate time.Time) error
 	Delete(ctx context.Context, id int) error
 	List(ctx context.Context, startDate, endDate time.Time) ([]Reservation, error)
+	// MarkReturned записывает день возврата книги; ErrNotFound, если
+	// бронирования нет.
+	MarkReturned(ctx context.Context, id int, returnedDate time.Time) error
+	// CountOverdue считает невозвращённые бронирования, срок которых
+	// закончился до now.
+	CountOverdue(ctx context.Context, now time.Time) (int, error)
+	// BusyBooks возвращает по возрастанию те книги из bookIDs, у которых
+	// есть невозвращённое бронирование, пересекающееся с интервалом;
+	// границы включаются.
+	BusyBooks(ctx context.Context, bookIDs []int, startDate, endDate time.Time) ([]int, error)
+	// Iterate передаёт fn бронирования по возрастанию ID, не загружая
+	// выборку целиком; ошибка fn прерывает обход и возвращается.
+	Iterate(ctx context.Context, filter Filter, fn func(*Reservation) error) error
+}
+
+// Filter — условия выборки бронирований, как у List: бронирование целиком
+// лежит в интервале, границы включаются. Нулевая граница не ограничивает.
+type Filter struct {
+	StartDate time.Time
+	EndDate   time.Time
+	// BookID и ReaderID, если не нулевые, оставляют бронирования этой
+	// книги и этого читателя.
+	BookID   int
+	ReaderID int
 }
 
 func NewReservation(id int, book books.Book, reader readers.Reader, startDate, endDate time.Time) (*Reservation, error) {
@@ -0,0 +1,156 @@
+package reviews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+)
+
+// ErrNotFound возвращается репозиторием, когда отзыва с таким ID нет.
+var ErrNotFound = errors.New("review not found")
+
+const (
+	MinRating = 1
+	MaxRating = 5
+	// MaxTextLength — наибольшая длина текста отзыва в символах.
+	MaxTextLength = 4000
+)
+
+// Status — состояние модерации отзыва.
+type Status string
+
+const (
+	// StatusPending — отзыв ждёт проверки администратором и виден только
+	// автору и администраторам.
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// ParseStatus разбирает статус без учёта регистра.
+func ParseStatus(s string) (Status, error) {
+	switch status := Status(strings.ToLower(strings.TrimSpace(s))); status {
+	case StatusPending, StatusApproved, StatusRejected:
+		return status, nil
+	default:
+		return "", fmt.Errorf("unknown review status %q", s)
+	}
+}
+
+// Sort — порядок списка отзывов.
+type Sort string
+
+const (
+	// SortRecent — сначала новые.
+	SortRecent Sort = "recent"
+	// SortHelpful — сначала отмеченные полезными большим числом читателей,
+	// при равенстве — новые.
+	SortHelpful Sort = "helpful"
+)
+
+// ParseSort разбирает порядок; пустая строка — SortRecent.
+func ParseSort(s string) (Sort, error) {
+	switch sort := Sort(strings.ToLower(strings.TrimSpace(s))); sort {
+	case "":
+		return SortRecent, nil
+	case SortRecent, SortHelpful:
+		return sort, nil
+	default:
+		return "", fmt.Errorf("unknown sort %q", s)
+	}
+}
+
+// Review — оценка книги читателем и необязательный текст. Читатель
+// оставляет о книге не больше одного отзыва.
+type Review struct {
+	ID       int
+	BookID   int
+	ReaderID int
+	Rating   int
+	Text     string `json:",omitempty"`
+	Status   Status
+	// Helpful — число читателей, отметивших отзыв полезным; заполняется
+	// репозиторием.
+	Helpful   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ReviewRepo interface {
+	Create(ctx context.Context, review *Review) error
+	GetByID(ctx context.Context, id int) (*Review, error)
+	// Update сохраняет оценку, текст, статус и время изменения.
+	Update(ctx context.Context, review *Review) error
+	// Delete удаляет отзыв вместе с отметками полезности.
+	Delete(ctx context.Context, id int) error
+	// List возвращает отзывы по фильтру в порядке filter.Sort.
+	List(ctx context.Context, filter Filter) ([]Review, error)
+
+	// Vote отмечает отзыв полезным от имени читателя; повторная отметка
+	// не ошибка.
+	Vote(ctx context.Context, reviewID, readerID int) error
+	// Unvote снимает отметку; отсутствие отметки не ошибка.
+	Unvote(ctx context.Context, reviewID, readerID int) error
+
+	// BookRating возвращает сводку одобренных отзывов о книге.
+	BookRating(ctx context.Context, bookID int) (books.Rating, error)
+	// Ratings возвращает сводки одобренных отзывов по книгам; книги без
+	// таких отзывов не выдаются.
+	Ratings(ctx context.Context) (map[int]books.Rating, error)
+}
+
+// Filter — условия выборки отзывов. Нулевые поля не ограничивают выборку,
+// пустой Sort — SortRecent.
+type Filter struct {
+	BookID   int
+	ReaderID int
+	Status   Status
+	Sort     Sort
+}
+
+// NewReview создаёт отзыв. Оценка без текста публикуется сразу, отзыв
+// с текстом ждёт модерации.
+func NewReview(id, bookID, readerID, rating int, text string, now time.Time) (*Review, error) {
+	review := &Review{
+		ID:        id,
+		BookID:    bookID,
+		ReaderID:  readerID,
+		CreatedAt: now,
+	}
+	if err := review.Edit(rating, text, now); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// Edit меняет оценку и текст. Изменённый текст снова отправляется на
+// модерацию; смена одной оценки статус не меняет.
+func (r *Review) Edit(rating int, text string, now time.Time) error {
+	if rating < MinRating || rating > MaxRating {
+		return fmt.Errorf("rating must be between %d and %d", MinRating, MaxRating)
+	}
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) > MaxTextLength {
+		return fmt.Errorf("review text is longer than %d characters", MaxTextLength)
+	}
+	if r.Status == "" || text != r.Text {
+		r.Status = StatusPending
+		if text == "" {
+			r.Status = StatusApproved
+		}
+	}
+	r.Rating = rating
+	r.Text = text
+	r.UpdatedAt = now
+	return nil
+}
+
+// Visible сообщает, что отзыв показывается всем читателям.
+func (r *Review) Visible() bool {
+	return r.Status == StatusApproved
+}
@@ -2,13 +2,27 @@ package authors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 )
 
+// ErrNotFound возвращается репозиторием, когда автора с таким ID нет.
+var ErrNotFound = errors.New("author not found")
+
 type Author struct {
 	ID      int
 	Name    string
 	Country string
+	// Aliases — другие написания имени: транслитерации («Lev Tolstoi»),
+	// инициалы («Толстой Л. Н.»), псевдонимы. Поиск находит автора и по ним.
+	Aliases []string `json:",omitempty"`
+	// BirthDate и DeathDate — даты жизни; nil — дата неизвестна.
+	BirthDate *time.Time `json:",omitempty"`
+	DeathDate *time.Time `json:",omitempty"`
+	Bio       string     `json:",omitempty"`
 }
 
 type AuthorRepo interface {
@@ -17,6 +31,13 @@ type AuthorRepo interface {
 	Update(ctx context.Context, author *Author) error
 	Delete(ctx context.Context, id int) error
 	List(ctx context.Context) ([]Author, error)
+	// Iterate передаёт fn авторов по возрастанию ID, не загружая выборку
+	// целиком; ошибка fn прерывает обход и возвращается.
+	Iterate(ctx context.Context, fn func(*Author) error) error
+	// Merge атомарно сохраняет target, переносит участие авторов sourceIDs
+	// в книгах на target и удаляет их. Если target и источник участвуют в
+	// книге в одной роли, остаётся одна запись на более раннем месте.
+	Merge(ctx context.Context, target *Author, sourceIDs []int) error
 }
 
 func NewAuthor(id int, name string, country string) (*Author, error) {
@@ -29,3 +50,80 @@ func NewAuthor(id int, name string, country string) (*Author, error) {
 		Country: country,
 	}, nil
 }
+
+// Clone копирует автора вместе со списком синонимов и датами.
+func (a Author) Clone() Author {
+	a.Aliases = slices.Clone(a.Aliases)
+	if a.BirthDate != nil {
+		d := *a.BirthDate
+		a.BirthDate = &d
+	}
+	if a.DeathDate != nil {
+		d := *a.DeathDate
+		a.DeathDate = &d
+	}
+	return a
+}
+
+// Key приводит имя к виду для сравнения: регистр, точки и запятые,
+// повторные пробелы и «ё» не различаются, поэтому «Толстой Л.Н.» и
+// «толстой л. н.» совпадают.
+func Key(name string) string {
+	name = strings.NewReplacer(".", " ", ",", " ", "ё", "е", "Ё", "е").Replace(name)
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// SetAliases устанавливает синонимы: пустые и совпадающие с именем или
+// друг с другом (см. Key) отбрасываются, остальные сортируются.
+func (a *Author) SetAliases(aliases []string) {
+	seen := map[string]struct{}{Key(a.Name): {}}
+	var list []string
+	for _, alias := range aliases {
+		alias = strings.Join(strings.Fields(alias), " ")
+		key := Key(alias)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		list = append(list, alias)
+	}
+	slices.Sort(list)
+	a.Aliases = list
+}
+
+// Matches сообщает, входит ли запрос (без учёта регистра, см. Key) в имя
+// или один из синонимов автора.
+func (a *Author) Matches(query string) bool {
+	query = Key(query)
+	if strings.Contains(Key(a.Name), query) {
+		return true
+	}
+	for _, alias := range a.Aliases {
+		if strings.Contains(Key(alias), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Absorb переносит в автора данные дубликата: имя и синонимы дубликата
+// становятся синонимами, незаполненные страна, даты и биография берутся
+// у дубликата.
+func (a *Author) Absorb(dup Author) {
+	a.SetAliases(append(append(slices.Clone(a.Aliases), dup.Name), dup.Aliases...))
+	if a.Country == "" {
+		a.Country = dup.Country
+	}
+	if a.BirthDate == nil {
+		a.BirthDate = dup.BirthDate
+	}
+	if a.DeathDate == nil {
+		a.DeathDate = dup.DeathDate
+	}
+	if a.Bio == "" {
+		a.Bio = dup.Bio
+	}
+}
@@ -0,0 +1,110 @@
+package genres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// ErrNotFound возвращается репозиторием, когда жанра с таким ID нет.
+var ErrNotFound = errors.New("genre not found")
+
+// Genre — узел иерархии жанров. ParentID ссылается на родительский жанр,
+// 0 — жанр верхнего уровня.
+type Genre struct {
+	ID       int
+	ParentID int
+	Name     string
+	// Names — локализованные названия: код языка (ru, en, ...) → название.
+	Names map[string]string
+}
+
+type GenreRepo interface {
+	Create(ctx context.Context, genre *Genre) error
+	GetByID(ctx context.Context, id int) (*Genre, error)
+	Update(ctx context.Context, genre *Genre) error
+	Delete(ctx context.Context, id int) error
+	// List возвращает все жанры по возрастанию ID; справочник небольшой,
+	// поэтому иерархию и поиск по названию строят над полным списком.
+	List(ctx context.Context) ([]Genre, error)
+}
+
+func NewGenre(id, parentID int, name string, names map[string]string) (*Genre, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	localized := make(map[string]string, len(names))
+	for lang, n := range names {
+		lang, n = strings.ToLower(strings.TrimSpace(lang)), strings.TrimSpace(n)
+		if lang == "" || n == "" {
+			return nil, fmt.Errorf("localized name and language cannot be empty")
+		}
+		localized[lang] = n
+	}
+	return &Genre{
+		ID:       id,
+		ParentID: parentID,
+		Name:     name,
+		Names:    localized,
+	}, nil
+}
+
+// Clone копирует жанр вместе с картой названий.
+func (g Genre) Clone() Genre {
+	g.Names = maps.Clone(g.Names)
+	return g
+}
+
+// Key приводит название к виду для сравнения: регистр и повторные
+// пробелы не различаются, поэтому "Programming" и "programming" — один жанр.
+func Key(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Matches сообщает, совпадает ли key (см. Key) с основным или одним
+// из локализованных названий жанра.
+func (g *Genre) Matches(key string) bool {
+	if Key(g.Name) == key {
+		return true
+	}
+	for _, name := range g.Names {
+		if Key(name) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// NameIndex возвращает основные названия жанров из list по ID.
+func NameIndex(list []Genre) map[int]string {
+	names := make(map[int]string, len(list))
+	for _, g := range list {
+		names[g.ID] = g.Name
+	}
+	return names
+}
+
+// Subtree возвращает ID жанра root и всех его потомков из list.
+func Subtree(list []Genre, root int) []int {
+	children := make(map[int][]int, len(list))
+	for _, g := range list {
+		if g.ParentID != 0 {
+			children[g.ParentID] = append(children[g.ParentID], g.ID)
+		}
+	}
+	ids := []int{root}
+	seen := map[int]bool{root: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			// seen защищает от цикла, если он всё же попал в данные.
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,41 @@
+package publishers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound возвращается репозиторием, когда издательства с таким ID нет.
+var ErrNotFound = errors.New("publisher not found")
+
+type Publisher struct {
+	ID      int
+	Name    string
+	City    string
+	Country string
+}
+
+type PublisherRepo interface {
+	Create(ctx context.Context, publisher *Publisher) error
+	GetById(ctx context.Context, id int) (*Publisher, error)
+	Update(ctx context.Context, publisher *Publisher) error
+	// Delete удаляет издательство; издательство с книгами удалить нельзя.
+	Delete(ctx context.Context, id int) error
+	// List возвращает издательства по возрастанию ID.
+	List(ctx context.Context) ([]Publisher, error)
+}
+
+func NewPublisher(id int, name, city, country string) (*Publisher, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	return &Publisher{
+		ID:      id,
+		Name:    name,
+		City:    strings.TrimSpace(city),
+		Country: strings.TrimSpace(country),
+	}, nil
+}
@@ -2,9 +2,14 @@ package readers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 )
 
+// ErrNotFound возвращается репозиторием, когда читатель не найден
+// по ID, email или субъекту OIDC.
+var ErrNotFound = errors.New("reader not found")
+
 type Reader struct {
 	ID       int
 	Name     string
@@ -12,6 +17,14 @@ type Reader struct {
 	Email    string
 	Password string
 	Admin    bool
+
+	// TOTPSecret — секрет второго фактора; задаётся при записи, активен после подтверждения.
+	TOTPSecret   string `json:"-"`
+	TOTPEnabled  bool
+	TOTPLastStep int64 `json:"-"`
+
+	// OIDCSubject — идентификатор (sub) читателя у внешнего провайдера OIDC.
+	OIDCSubject string `json:"-"`
 }
 
 type ReaderRepo interface {
@@ -21,7 +34,17 @@ type ReaderRepo interface {
 	Delete(ctx context.Context, id int) error
 	List(ctx context.Context) ([]Reader, error)
 	GetReaderByEmail(ctx context.Context, email string) (*Reader, error)
+	GetReaderByOIDCSubject(ctx context.Context, subject string) (*Reader, error)
 	Authenticate(ctx context.Context, email, password string) (*Reader, error)
+	UpdateTOTP(ctx context.Context, reader *Reader) error
+	// AdvanceTOTPStep запоминает шаг принятого TOTP-кода, только если он
+	// больше сохранённого; false означает повтор уже принятого кода.
+	AdvanceTOTPStep(ctx context.Context, readerID int, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, readerID int, codeHashes []string) error
+	ConsumeRecoveryCode(ctx context.Context, readerID int, codeHash string) (bool, error)
+	// Iterate передаёт fn читателей по возрастанию ID, не загружая выборку
+	// целиком; ошибка fn прерывает обход и возвращается.
+	Iterate(ctx context.Context, fn func(*Reader) error) error
 }
 
 func NewReader(id int, name string, phone string, email string, password string, admin bool) (*Reader, error) {
@@ -48,3 +71,8 @@ func NewReader(id int, name string, phone string, email string, password string,
 func (r *Reader) CheckPassword(plainPassword string) bool {
 	return r.Password == plainPassword
 }
+
+// HasPendingTOTP сообщает, что секрет выдан, но ещё не подтверждён кодом.
+func (r *Reader) HasPendingTOTP() bool {
+	return r.TOTPSecret != "" && !r.TOTPEnabled
+}
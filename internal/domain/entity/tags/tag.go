@@ -0,0 +1,80 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrNotFound возвращается репозиторием, когда тега с таким ID или
+// именем нет.
+var ErrNotFound = errors.New("tag not found")
+
+// MaxNameLength — наибольшая длина имени тега в символах.
+const MaxNameLength = 64
+
+// Tag — свободная тематическая метка книги («concurrency», «советская
+// фантастика»). Name хранится нормализованным (см. Normalize).
+type Tag struct {
+	ID   int
+	Name string
+	// Aliases — синонимы: имена тегов, слитых в этот. Поиск по синониму
+	// находит этот тег.
+	Aliases []string `json:",omitempty"`
+}
+
+// TagCount — тег и число книг с ним; из таких строк строят облако тегов.
+type TagCount struct {
+	ID    int
+	Name  string
+	Books int
+}
+
+type TagRepo interface {
+	Create(ctx context.Context, tag *Tag) error
+	GetByID(ctx context.Context, id int) (*Tag, error)
+	// FindByName ищет тег по нормализованному имени или синониму.
+	FindByName(ctx context.Context, name string) (*Tag, error)
+	// Delete удаляет тег; книги теряют его, синонимы удаляются.
+	Delete(ctx context.Context, id int) error
+	// Counts возвращает теги с числом книг по убыванию числа, при равенстве —
+	// по имени. Теги без книг не выдаются; limit 0 — без ограничения.
+	Counts(ctx context.Context, limit int) ([]TagCount, error)
+
+	// AddToBook ставит тег книге; повторная установка не ошибка.
+	AddToBook(ctx context.Context, bookID, tagID int) error
+	// RemoveFromBook снимает тег с книги; отсутствие связи не ошибка.
+	RemoveFromBook(ctx context.Context, bookID, tagID int) error
+	// BookTags возвращает теги книги по имени.
+	BookTags(ctx context.Context, bookID int) ([]Tag, error)
+
+	// Merge сливает теги sourceIDs в targetID атомарно: книги получают
+	// target, имена и синонимы источников становятся синонимами target,
+	// сами источники удаляются.
+	Merge(ctx context.Context, targetID int, sourceIDs []int) error
+}
+
+func NewTag(id int, name string) (*Tag, error) {
+	normalized, err := Normalize(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Tag{ID: id, Name: normalized}, nil
+}
+
+// Normalize приводит имя тега к каноническому виду: без ведущего «#»,
+// в нижнем регистре, с одиночными пробелами. Так «#Soviet  Sci-Fi» и
+// «soviet sci-fi» — один тег.
+func Normalize(name string) (string, error) {
+	name = strings.TrimLeft(strings.TrimSpace(name), "#")
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" {
+		return "", fmt.Errorf("tag name cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("tag name is longer than %d characters", MaxNameLength)
+	}
+	return name, nil
+}
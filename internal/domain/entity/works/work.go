@@ -0,0 +1,37 @@
+package works
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound возвращается репозиторием, когда произведения с таким ID нет.
+var ErrNotFound = errors.New("work not found")
+
+// Work — произведение: общая сущность для его изданий (переводов,
+// переизданий). Издания — книги с WorkID этого произведения; у каждого
+// свой ISBN, год, издательство, язык и переводчик.
+type Work struct {
+	ID    int
+	Title string
+}
+
+type WorkRepo interface {
+	Create(ctx context.Context, work *Work) error
+	GetByID(ctx context.Context, id int) (*Work, error)
+	Update(ctx context.Context, work *Work) error
+	// Delete удаляет произведение; произведение с изданиями удалить нельзя.
+	Delete(ctx context.Context, id int) error
+	// List возвращает произведения по возрастанию ID.
+	List(ctx context.Context) ([]Work, error)
+}
+
+func NewWork(id int, title string) (*Work, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, fmt.Errorf("title cannot be empty")
+	}
+	return &Work{ID: id, Title: title}, nil
+}
@@ -2,31 +2,71 @@ package authorsrepo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
 	"github.com/0sokrat0/BookAPI/pkg/logger"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 type authorRepo struct {
-	db *pgxpool.Pool
+	db postgres.DBTX
 }
 
-func NewAuthorRepo(db *pgxpool.Pool) authors.AuthorRepo {
+func NewAuthorRepo(db postgres.DBTX) authors.AuthorRepo {
 	return &authorRepo{db: db}
 }
 
+// authorColumns — поля автора в порядке authorFields; синонимы собираются
+// в массив на стороне базы.
+const authorColumns = `a.id, a.name, a.country, a.birth_date, a.death_date, a.bio,
+		       COALESCE((SELECT array_agg(al.alias ORDER BY al.alias)
+		                 FROM author_aliases al WHERE al.author_id = a.id), '{}')`
+
+func authorFields(a *authors.Author) []any {
+	return []any{&a.ID, &a.Name, &a.Country, &a.BirthDate, &a.DeathDate, &a.Bio, &a.Aliases}
+}
+
+// scanAuthor читает автора и заменяет пустой список синонимов на nil.
+func scanAuthor(row pgx.Row) (*authors.Author, error) {
+	var author authors.Author
+	if err := row.Scan(authorFields(&author)...); err != nil {
+		return nil, err
+	}
+	if len(author.Aliases) == 0 {
+		author.Aliases = nil
+	}
+	return &author, nil
+}
+
 func (r *authorRepo) Create(ctx context.Context, author *authors.Author) error {
 	lg := logger.FromContext(ctx)
-	query := `
-	    INSERT INTO authors (id, name, country)
-		VALUES ($1, $2, $3)`
-	_, err := r.db.Exec(ctx, query, author.ID, author.Name, author.Country)
-	if err != nil {
-		lg.Error("failed to create author", zap.Error(err))
-		return err
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `
+		    INSERT INTO authors (id, name, country, birth_date, death_date, bio)
+			VALUES ($1, $2, $3, $4, $5, $6)`
+		_, err := tx.Exec(ctx, query, author.ID, author.Name, author.Country, author.BirthDate, author.DeathDate, author.Bio)
+		if err != nil {
+			lg.Error("failed to create author", zap.Error(err))
+			return err
+		}
+		if err := insertAliases(ctx, tx, author); err != nil {
+			lg.Error("failed to insert author aliases", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func insertAliases(ctx context.Context, tx pgx.Tx, author *authors.Author) error {
+	query := `INSERT INTO author_aliases (author_id, alias) VALUES ($1, $2)`
+	for _, alias := range author.Aliases {
+		if _, err := tx.Exec(ctx, query, author.ID, alias); err != nil {
+			return fmt.Errorf("failed to insert author alias (author_id=%d, alias=%q): %w", author.ID, alias, err)
+		}
 	}
 	return nil
 }
@@ -34,21 +74,23 @@ func (r *authorRepo) Create(ctx context.Context, author *authors.Author) error {
 func (r *authorRepo) GetById(ctx context.Context, id int) (*authors.Author, error) {
 	lg := logger.FromContext(ctx)
 	query := `
-	    SELECT id, name, country
-		FROM authors
-		WHERE id = $1`
-	row := r.db.QueryRow(ctx, query, id)
-	var author authors.Author
-	err := row.Scan(&author.ID, &author.Name, &author.Country)
+	    SELECT ` + authorColumns + `
+		FROM authors a
+		WHERE a.id = $1`
+	author, err := scanAuthor(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, authors.ErrNotFound
+	}
 	if err != nil {
 		lg.Error("failed to get author by id", zap.Error(err))
 		return nil, err
 	}
-	return &author, nil
+	return author, nil
 }
 
 func (r *authorRepo) Delete(ctx context.Context, id int) error {
 	lg := logger.FromContext(ctx)
+	// Синонимы удаляются каскадом; участие в книгах не даёт удалить автора.
 	query := `
 	    DELETE FROM authors
 		WHERE id = $1`
@@ -62,23 +104,37 @@ func (r *authorRepo) Delete(ctx context.Context, id int) error {
 
 func (r *authorRepo) Update(ctx context.Context, author *authors.Author) error {
 	lg := logger.FromContext(ctx)
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := updateAuthor(ctx, tx, author); err != nil {
+			lg.Error("failed to update author by id", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+// updateAuthor сохраняет поля автора и заменяет его синонимы.
+func updateAuthor(ctx context.Context, tx pgx.Tx, author *authors.Author) error {
 	query := `
         UPDATE authors
-        SET name = $2, country = $3
+        SET name = $2, country = $3, birth_date = $4, death_date = $5, bio = $6
         WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, author.ID, author.Name, author.Country)
+	_, err := tx.Exec(ctx, query, author.ID, author.Name, author.Country, author.BirthDate, author.DeathDate, author.Bio)
 	if err != nil {
-		lg.Error("failed to update author by id", zap.Error(err))
-		return err
+		return fmt.Errorf("failed to update author: %w", err)
 	}
-	return nil
+	if _, err := tx.Exec(ctx, `DELETE FROM author_aliases WHERE author_id = $1`, author.ID); err != nil {
+		return fmt.Errorf("failed to delete old author aliases: %w", err)
+	}
+	return insertAliases(ctx, tx, author)
 }
 
 func (r *authorRepo) List(ctx context.Context) ([]authors.Author, error) {
 	lg := logger.FromContext(ctx)
 	query := `
-        SELECT id, name, country
-        FROM authors`
+        SELECT ` + authorColumns + `
+        FROM authors a
+        ORDER BY a.id`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		lg.Error("failed to list authors", zap.Error(err))
@@ -88,13 +144,12 @@ func (r *authorRepo) List(ctx context.Context) ([]authors.Author, error) {
 
 	var authorsList []authors.Author
 	for rows.Next() {
-		var author authors.Author
-		err := rows.Scan(&author.ID, &author.Name, &author.Country)
+		author, err := scanAuthor(rows)
 		if err != nil {
 			lg.Error("failed to scan author", zap.Error(err))
 			return nil, fmt.Errorf("failed to scan author: %w", err)
 		}
-		authorsList = append(authorsList, author)
+		authorsList = append(authorsList, *author)
 	}
 	if err = rows.Err(); err != nil {
 		lg.Error("rows error", zap.Error(err))
@@ -102,3 +157,65 @@ func (r *authorRepo) List(ctx context.Context) ([]authors.Author, error) {
 	}
 	return authorsList, nil
 }
+
+func (r *authorRepo) Iterate(ctx context.Context, fn func(*authors.Author) error) error {
+	lg := logger.FromContext(ctx)
+	query := `
+        SELECT ` + authorColumns + `
+        FROM authors a
+        ORDER BY a.id`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		lg.Error("failed to iterate authors", zap.Error(err))
+		return fmt.Errorf("failed to iterate authors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			lg.Error("failed to scan author", zap.Error(err))
+			return fmt.Errorf("failed to scan author: %w", err)
+		}
+		if err := fn(author); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
+
+func (r *authorRepo) Merge(ctx context.Context, target *authors.Author, sourceIDs []int) error {
+	lg := logger.FromContext(ctx)
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := updateAuthor(ctx, tx, target); err != nil {
+			lg.Error("failed to merge authors", zap.String("step", "update target"), zap.Error(err))
+			return err
+		}
+		steps := []struct {
+			what  string
+			query string
+		}{
+			{"move book contributors", `
+				INSERT INTO book_authors (book_id, author_id, role, position)
+				SELECT book_id, $1, role, MIN(position)
+				FROM book_authors WHERE author_id = ANY($2)
+				GROUP BY book_id, role
+				ON CONFLICT (book_id, author_id, role)
+				DO UPDATE SET position = LEAST(book_authors.position, EXCLUDED.position)`},
+			{"delete merged contributors", `DELETE FROM book_authors WHERE author_id = ANY($2) AND author_id <> $1`},
+			// Синонимы источников удаляются каскадом: они уже перенесены в target.
+			{"delete merged authors", `DELETE FROM authors WHERE id = ANY($2) AND id <> $1`},
+		}
+		for _, step := range steps {
+			if _, err := tx.Exec(ctx, step.query, target.ID, sourceIDs); err != nil {
+				lg.Error("failed to merge authors", zap.String("step", step.what), zap.Error(err))
+				return fmt.Errorf("failed to %s: %w", step.what, err)
+			}
+		}
+		return nil
+	})
+}
@@ -2,78 +2,164 @@ package booksRepo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
 	"github.com/0sokrat0/BookAPI/pkg/logger"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 type bookRepo struct {
-	db *pgxpool.Pool
+	db postgres.DBTX
 }
 
-func NewBookRepo(db *pgxpool.Pool) books.BookRepo {
+func NewBookRepo(db postgres.DBTX) books.BookRepo {
 	return &bookRepo{db: db}
 }
 
+// bookColumns — поля книги в порядке bookFields; отсутствующие произведение
+// и издательство (NULL) читаются как 0.
+const bookColumns = `b.id, b.title, b.year, b.isbn, COALESCE(b.work_id, 0), COALESCE(b.publisher_id, 0), b.language, b.translator`
+
+func bookFields(b *books.Book) []any {
+	return []any{&b.ID, &b.Title, &b.Year, &b.ISBN, &b.WorkID, &b.PublisherID, &b.Language, &b.Translator}
+}
+
+// workID записывает книгу без произведения как NULL.
+func workID(b *books.Book) *int {
+	if b.WorkID == 0 {
+		return nil
+	}
+	return &b.WorkID
+}
+
+// publisherID записывает книгу без издательства как NULL.
+func publisherID(b *books.Book) *int {
+	if b.PublisherID == 0 {
+		return nil
+	}
+	return &b.PublisherID
+}
+
 func (r *bookRepo) Create(ctx context.Context, book *books.Book) error {
 	lg := logger.FromContext(ctx)
 	query := `
-        INSERT INTO books (id, title, year, isbn, genre)
-        VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.db.Exec(ctx, query, book.ID, book.Title, book.Year, book.ISBN, book.Genre)
+        INSERT INTO books (id, title, year, isbn, work_id, publisher_id, language, translator)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.Exec(ctx, query, book.ID, book.Title, book.Year, book.ISBN,
+		workID(book), publisherID(book), book.Language, book.Translator)
 	if err != nil {
 		lg.Error("failed to create book", zap.Error(err))
 		return err
 	}
 	// Вставляем связи в таблицу book_authors.
-	if err := r.insertBookAuthors(ctx, book.ID, book.AuthorIDs()); err != nil {
+	if err := r.insertBookAuthors(ctx, book.ID, book.Contributors()); err != nil {
 		lg.Error("failed to insert book authors", zap.Error(err))
 		return err
 	}
+	if err := r.insertBookGenres(ctx, book.ID, book.GenreIDs()); err != nil {
+		lg.Error("failed to insert book genres", zap.Error(err))
+		return err
+	}
 	return nil
 }
 
-func (r *bookRepo) insertBookAuthors(ctx context.Context, bookID int, authorIDs []int) error {
-	query := `INSERT INTO book_authors (book_id, author_id) VALUES ($1, $2)`
-	for _, authorID := range authorIDs {
-		if _, err := r.db.Exec(ctx, query, bookID, authorID); err != nil {
-			return fmt.Errorf("failed to insert book author (book_id=%d, author_id=%d): %w", bookID, authorID, err)
+// insertBookAuthors сохраняет участников; position — индекс в списке.
+func (r *bookRepo) insertBookAuthors(ctx context.Context, bookID int, contributors []books.Contributor) error {
+	query := `INSERT INTO book_authors (book_id, author_id, role, position) VALUES ($1, $2, $3, $4)`
+	for i, c := range contributors {
+		if _, err := r.db.Exec(ctx, query, bookID, c.AuthorID, string(c.Role), i); err != nil {
+			return fmt.Errorf("failed to insert book author (book_id=%d, author_id=%d, role=%s): %w", bookID, c.AuthorID, c.Role, err)
 		}
 	}
 	return nil
 }
 
-func (r *bookRepo) loadBookAuthors(ctx context.Context, bookID int) ([]int, error) {
-	query := `SELECT author_id FROM book_authors WHERE book_id = $1`
+func (r *bookRepo) insertBookGenres(ctx context.Context, bookID int, genreIDs []int) error {
+	query := `INSERT INTO book_genres (book_id, genre_id) VALUES ($1, $2)`
+	for _, genreID := range genreIDs {
+		if _, err := r.db.Exec(ctx, query, bookID, genreID); err != nil {
+			return fmt.Errorf("failed to insert book genre (book_id=%d, genre_id=%d): %w", bookID, genreID, err)
+		}
+	}
+	return nil
+}
+
+func (r *bookRepo) loadBookAuthors(ctx context.Context, bookID int) ([]books.Contributor, error) {
+	query := `SELECT author_id, role FROM book_authors WHERE book_id = $1 ORDER BY position`
+	rows, err := r.db.Query(ctx, query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contributors []books.Contributor
+	for rows.Next() {
+		var c books.Contributor
+		if err := rows.Scan(&c.AuthorID, &c.Role); err != nil {
+			return nil, err
+		}
+		contributors = append(contributors, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return contributors, nil
+}
+
+func (r *bookRepo) loadBookGenres(ctx context.Context, bookID int) ([]int, error) {
+	query := `SELECT genre_id FROM book_genres WHERE book_id = $1 ORDER BY genre_id`
 	rows, err := r.db.Query(ctx, query, bookID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var authorIDs []int
+	var genreIDs []int
 	for rows.Next() {
-		var authorID int
-		if err := rows.Scan(&authorID); err != nil {
+		var genreID int
+		if err := rows.Scan(&genreID); err != nil {
 			return nil, err
 		}
-		authorIDs = append(authorIDs, authorID)
+		genreIDs = append(genreIDs, genreID)
 	}
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
-	return authorIDs, nil
+	return genreIDs, nil
+}
+
+// loadLinks загружает авторов и жанры книги.
+func (r *bookRepo) loadLinks(ctx context.Context, book *books.Book) error {
+	contributors, err := r.loadBookAuthors(ctx, book.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load book authors: %w", err)
+	}
+	genreIDs, err := r.loadBookGenres(ctx, book.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load book genres: %w", err)
+	}
+	book.SetContributors(contributors)
+	book.SetGenreIDs(genreIDs)
+	return nil
 }
 
-func (r *bookRepo) updateBookAuthors(ctx context.Context, bookID int, authorIDs []int) error {
+func (r *bookRepo) updateBookAuthors(ctx context.Context, bookID int, contributors []books.Contributor) error {
 	delQuery := `DELETE FROM book_authors WHERE book_id = $1`
 	if _, err := r.db.Exec(ctx, delQuery, bookID); err != nil {
 		return fmt.Errorf("failed to delete old book authors: %w", err)
 	}
-	return r.insertBookAuthors(ctx, bookID, authorIDs)
+	return r.insertBookAuthors(ctx, bookID, contributors)
+}
+
+func (r *bookRepo) updateBookGenres(ctx context.Context, bookID int, genreIDs []int) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM book_genres WHERE book_id = $1`, bookID); err != nil {
+		return fmt.Errorf("failed to delete old book genres: %w", err)
+	}
+	return r.insertBookGenres(ctx, bookID, genreIDs)
 }
 
 func (r *bookRepo) deleteBookAuthors(ctx context.Context, bookID int) error {
@@ -85,50 +171,86 @@ func (r *bookRepo) deleteBookAuthors(ctx context.Context, bookID int) error {
 func (r *bookRepo) GetByID(ctx context.Context, id int) (*books.Book, error) {
 	lg := logger.FromContext(ctx)
 	query := `
-		SELECT id, title, year, isbn, genre
-		FROM books
-		WHERE id = $1`
+		SELECT ` + bookColumns + `
+		FROM books b
+		WHERE b.id = $1`
 	row := r.db.QueryRow(ctx, query, id)
 	var book books.Book
-	err := row.Scan(&book.ID, &book.Title, &book.Year, &book.ISBN, &book.Genre)
+	err := row.Scan(bookFields(&book)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, books.ErrNotFound
+	}
 	if err != nil {
 		lg.Error("failed to get book by id", zap.Error(err))
 		return nil, err
 	}
-	authorIDs, err := r.loadBookAuthors(ctx, book.ID)
-	if err != nil {
-		lg.Error("failed to load book authors", zap.Error(err))
+	if err := r.loadLinks(ctx, &book); err != nil {
+		lg.Error("failed to load book links", zap.Error(err))
 		return nil, err
 	}
-	book.SetAuthorIDs(authorIDs)
 	return &book, nil
 }
 
+// GetByISBN ищет книгу по ISBN без учёта дефисов, пробелов и регистра.
+// Если книг с таким ISBN несколько, возвращается книга с наименьшим ID.
+func (r *bookRepo) GetByISBN(ctx context.Context, isbn string) (*books.Book, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id
+		FROM books
+		WHERE upper(replace(replace(isbn, '-', ''), ' ', '')) = $1
+		ORDER BY id
+		LIMIT 1`
+	var id int
+	err := r.db.QueryRow(ctx, query, books.NormalizeISBN(isbn)).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, books.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get book by isbn", zap.Error(err))
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
 func (r *bookRepo) Update(ctx context.Context, book *books.Book) error {
 	lg := logger.FromContext(ctx)
 	query := `
 		UPDATE books
-		SET title = $1, year = $2, isbn = $3, genre = $4
-		WHERE id = $5`
-	_, err := r.db.Exec(ctx, query, book.Title, book.Year, book.ISBN, book.Genre, book.ID)
+		SET title = $1, year = $2, isbn = $3, work_id = $4, publisher_id = $5, language = $6, translator = $7
+		WHERE id = $8`
+	_, err := r.db.Exec(ctx, query, book.Title, book.Year, book.ISBN,
+		workID(book), publisherID(book), book.Language, book.Translator, book.ID)
 	if err != nil {
 		lg.Error("failed to update book", zap.Error(err))
 		return fmt.Errorf("failed to update book: %w", err)
 	}
-	if err := r.updateBookAuthors(ctx, book.ID, book.AuthorIDs()); err != nil {
+	if err := r.updateBookAuthors(ctx, book.ID, book.Contributors()); err != nil {
 		lg.Error("failed to update book authors", zap.Error(err))
 		return err
 	}
+	if err := r.updateBookGenres(ctx, book.ID, book.GenreIDs()); err != nil {
+		lg.Error("failed to update book genres", zap.Error(err))
+		return err
+	}
 	return nil
 }
 
 func (r *bookRepo) Delete(ctx context.Context, id int) error {
 	lg := logger.FromContext(ctx)
-	// Удаляем связи из таблицы book_authors.
+	// Удаляем связи из таблиц book_authors, book_genres и book_tags.
 	if err := r.deleteBookAuthors(ctx, id); err != nil {
 		lg.Error("failed to delete book authors", zap.Error(err))
 		return fmt.Errorf("failed to delete book authors: %w", err)
 	}
+	if _, err := r.db.Exec(ctx, `DELETE FROM book_genres WHERE book_id = $1`, id); err != nil {
+		lg.Error("failed to delete book genres", zap.Error(err))
+		return fmt.Errorf("failed to delete book genres: %w", err)
+	}
+	if _, err := r.db.Exec(ctx, `DELETE FROM book_tags WHERE book_id = $1`, id); err != nil {
+		lg.Error("failed to delete book tags", zap.Error(err))
+		return fmt.Errorf("failed to delete book tags: %w", err)
+	}
 	query := `
 		DELETE FROM books
 		WHERE id = $1`
@@ -143,8 +265,8 @@ func (r *bookRepo) Delete(ctx context.Context, id int) error {
 func (r *bookRepo) List(ctx context.Context) ([]books.Book, error) {
 	lg := logger.FromContext(ctx)
 	query := `
-		SELECT id, title, year, isbn, genre
-		FROM books`
+		SELECT ` + bookColumns + `
+		FROM books b`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		lg.Error("failed to list books", zap.Error(err))
@@ -155,32 +277,36 @@ func (r *bookRepo) List(ctx context.Context) ([]books.Book, error) {
 	var booksList []books.Book
 	for rows.Next() {
 		var book books.Book
-		err := rows.Scan(&book.ID, &book.Title, &book.Year, &book.ISBN, &book.Genre)
+		err := rows.Scan(bookFields(&book)...)
 		if err != nil {
 			lg.Error("failed to scan book", zap.Error(err))
 			return nil, fmt.Errorf("failed to scan book: %w", err)
 		}
-		authorIDs, err := r.loadBookAuthors(ctx, book.ID)
-		if err != nil {
-			lg.Error("failed to load book authors", zap.Error(err))
-			return nil, err
-		}
-		book.SetAuthorIDs(authorIDs)
 		booksList = append(booksList, book)
 	}
 	if err = rows.Err(); err != nil {
 		lg.Error("rows error", zap.Error(err))
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
+	// Связи загружаем после закрытия курсора: в транзакции соединение
+	// одно, и второй запрос при открытом курсоре завершился бы ошибкой.
+	rows.Close()
+	for i := range booksList {
+		if err := r.loadLinks(ctx, &booksList[i]); err != nil {
+			lg.Error("failed to load book links", zap.Error(err))
+			return nil, err
+		}
+	}
 	return booksList, nil
 }
 
 func (r *bookRepo) ListBooksByAuthor(ctx context.Context, authorID int) ([]books.Book, error) {
 	query := `
-		SELECT b.id, b.title, b.year, b.isbn, b.genre
+		SELECT ` + bookColumns + `
 		FROM books b
-		JOIN book_authors ba ON b.id = ba.book_id
-		WHERE ba.author_id = $1`
+		WHERE EXISTS (
+			SELECT 1 FROM book_authors ba WHERE ba.book_id = b.id AND ba.author_id = $1)
+		ORDER BY b.id`
 	rows, err := r.db.Query(ctx, query, authorID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list books by author: %w", err)
@@ -190,7 +316,7 @@ func (r *bookRepo) ListBooksByAuthor(ctx context.Context, authorID int) ([]books
 	var booksList []books.Book
 	for rows.Next() {
 		var book books.Book
-		err := rows.Scan(&book.ID, &book.Title, &book.Year, &book.ISBN, &book.Genre)
+		err := rows.Scan(bookFields(&book)...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan book: %w", err)
 		}
@@ -199,5 +325,70 @@ func (r *bookRepo) ListBooksByAuthor(ctx context.Context, authorID int) ([]books
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows error: %w", err)
 	}
+	rows.Close()
+	for i := range booksList {
+		if err := r.loadLinks(ctx, &booksList[i]); err != nil {
+			return nil, err
+		}
+	}
 	return booksList, nil
 }
+
+// Iterate читает книги одним запросом: участники и жанры собираются в массивы
+// на стороне базы, строки обрабатываются по мере получения из курсора.
+func (r *bookRepo) Iterate(ctx context.Context, filter books.Filter, fn func(*books.Book) error) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT ` + bookColumns + `,
+		       COALESCE((SELECT array_agg(ba.author_id ORDER BY ba.position)
+		                 FROM book_authors ba WHERE ba.book_id = b.id), '{}'),
+		       COALESCE((SELECT array_agg(ba.role ORDER BY ba.position)
+		                 FROM book_authors ba WHERE ba.book_id = b.id), '{}'),
+		       COALESCE((SELECT array_agg(bg.genre_id ORDER BY bg.genre_id)
+		                 FROM book_genres bg WHERE bg.book_id = b.id), '{}')
+		FROM books b
+		WHERE ($1 = 0 OR EXISTS (
+			SELECT 1 FROM book_authors ba WHERE ba.book_id = b.id AND ba.author_id = $1
+			  AND ($6 = '' OR ba.role = $6)))
+		  AND (cardinality($2::int[]) = 0 OR EXISTS (
+			SELECT 1 FROM book_genres bg WHERE bg.book_id = b.id AND bg.genre_id = ANY($2)))
+		  AND ($3 = 0 OR EXISTS (
+			SELECT 1 FROM book_tags bt WHERE bt.book_id = b.id AND bt.tag_id = $3))
+		  AND ($4 = 0 OR b.work_id = $4)
+		  AND ($5 = 0 OR b.publisher_id = $5)
+		ORDER BY b.id`
+	genreIDs := filter.GenreIDs
+	if genreIDs == nil {
+		genreIDs = []int{}
+	}
+	rows, err := r.db.Query(ctx, query, filter.AuthorID, genreIDs, filter.TagID, filter.WorkID, filter.PublisherID, string(filter.Role))
+	if err != nil {
+		lg.Error("failed to iterate books", zap.Error(err))
+		return fmt.Errorf("failed to iterate books: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book books.Book
+		var authorIDs, genreIDs []int
+		var roles []string
+		if err := rows.Scan(append(bookFields(&book), &authorIDs, &roles, &genreIDs)...); err != nil {
+			lg.Error("failed to scan book", zap.Error(err))
+			return fmt.Errorf("failed to scan book: %w", err)
+		}
+		contributors := make([]books.Contributor, len(authorIDs))
+		for i := range authorIDs {
+			contributors[i] = books.Contributor{AuthorID: authorIDs[i], Role: books.Role(roles[i])}
+		}
+		book.SetContributors(contributors)
+		book.SetGenreIDs(genreIDs)
+		if err := fn(&book); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package booksRepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type coverRepo struct {
+	db postgres.DBTX
+}
+
+func NewCoverRepo(db postgres.DBTX) books.CoverRepo {
+	return &coverRepo{db: db}
+}
+
+func (r *coverRepo) Get(ctx context.Context, bookID int) (*books.Cover, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT book_id, version, content_type, width, height, thumbnail_widths
+		FROM book_covers
+		WHERE book_id = $1`
+	var cover books.Cover
+	err := r.db.QueryRow(ctx, query, bookID).Scan(&cover.BookID, &cover.Version, &cover.ContentType,
+		&cover.Width, &cover.Height, &cover.ThumbnailWidths)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, books.ErrCoverNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get cover", zap.Error(err))
+		return nil, err
+	}
+	return &cover, nil
+}
+
+func (r *coverRepo) Save(ctx context.Context, cover *books.Cover) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO book_covers (book_id, version, content_type, width, height, thumbnail_widths)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (book_id) DO UPDATE
+		SET version = EXCLUDED.version, content_type = EXCLUDED.content_type,
+		    width = EXCLUDED.width, height = EXCLUDED.height,
+		    thumbnail_widths = EXCLUDED.thumbnail_widths`
+	widths := cover.ThumbnailWidths
+	if widths == nil {
+		widths = []int{}
+	}
+	_, err := r.db.Exec(ctx, query, cover.BookID, cover.Version, cover.ContentType,
+		cover.Width, cover.Height, widths)
+	if err != nil {
+		lg.Error("failed to save cover", zap.Error(err))
+		return fmt.Errorf("failed to save cover: %w", err)
+	}
+	return nil
+}
+
+func (r *coverRepo) Delete(ctx context.Context, bookID int) error {
+	lg := logger.FromContext(ctx)
+	if _, err := r.db.Exec(ctx, `DELETE FROM book_covers WHERE book_id = $1`, bookID); err != nil {
+		lg.Error("failed to delete cover", zap.Error(err))
+		return fmt.Errorf("failed to delete cover: %w", err)
+	}
+	return nil
+}
+
+func (r *coverRepo) List(ctx context.Context) ([]books.Cover, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT book_id, version, content_type, width, height, thumbnail_widths
+		FROM book_covers
+		ORDER BY book_id`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		lg.Error("failed to list covers", zap.Error(err))
+		return nil, fmt.Errorf("failed to list covers: %w", err)
+	}
+	defer rows.Close()
+
+	var covers []books.Cover
+	for rows.Next() {
+		var cover books.Cover
+		if err := rows.Scan(&cover.BookID, &cover.Version, &cover.ContentType,
+			&cover.Width, &cover.Height, &cover.ThumbnailWidths); err != nil {
+			lg.Error("failed to scan cover", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan cover: %w", err)
+		}
+		covers = append(covers, cover)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return covers, nil
+}
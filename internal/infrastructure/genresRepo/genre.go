@@ -0,0 +1,157 @@
+package genresrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type genreRepo struct {
+	db postgres.DBTX
+}
+
+func NewGenreRepo(db postgres.DBTX) genres.GenreRepo {
+	return &genreRepo{db: db}
+}
+
+// parentID записывает жанр верхнего уровня как NULL.
+func parentID(g *genres.Genre) *int {
+	if g.ParentID == 0 {
+		return nil
+	}
+	return &g.ParentID
+}
+
+func (r *genreRepo) Create(ctx context.Context, genre *genres.Genre) error {
+	lg := logger.FromContext(ctx)
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `INSERT INTO genres (id, parent_id, name) VALUES ($1, $2, $3)`
+		if _, err := tx.Exec(ctx, query, genre.ID, parentID(genre), genre.Name); err != nil {
+			lg.Error("failed to create genre", zap.Error(err))
+			return err
+		}
+		if err := insertNames(ctx, tx, genre); err != nil {
+			lg.Error("failed to insert genre names", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func insertNames(ctx context.Context, tx pgx.Tx, genre *genres.Genre) error {
+	query := `INSERT INTO genre_names (genre_id, lang, name) VALUES ($1, $2, $3)`
+	for lang, name := range genre.Names {
+		if _, err := tx.Exec(ctx, query, genre.ID, lang, name); err != nil {
+			return fmt.Errorf("failed to insert genre name (genre_id=%d, lang=%s): %w", genre.ID, lang, err)
+		}
+	}
+	return nil
+}
+
+func (r *genreRepo) loadNames(ctx context.Context, genreID int) (map[string]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT lang, name FROM genre_names WHERE genre_id = $1`, genreID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[string]string)
+	for rows.Next() {
+		var lang, name string
+		if err := rows.Scan(&lang, &name); err != nil {
+			return nil, err
+		}
+		names[lang] = name
+	}
+	return names, rows.Err()
+}
+
+func (r *genreRepo) GetByID(ctx context.Context, id int) (*genres.Genre, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id, COALESCE(parent_id, 0), name
+		FROM genres
+		WHERE id = $1`
+	var genre genres.Genre
+	err := r.db.QueryRow(ctx, query, id).Scan(&genre.ID, &genre.ParentID, &genre.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, genres.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get genre by id", zap.Error(err))
+		return nil, err
+	}
+	if genre.Names, err = r.loadNames(ctx, genre.ID); err != nil {
+		lg.Error("failed to load genre names", zap.Error(err))
+		return nil, err
+	}
+	return &genre, nil
+}
+
+func (r *genreRepo) Update(ctx context.Context, genre *genres.Genre) error {
+	lg := logger.FromContext(ctx)
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `UPDATE genres SET parent_id = $1, name = $2 WHERE id = $3`
+		if _, err := tx.Exec(ctx, query, parentID(genre), genre.Name, genre.ID); err != nil {
+			lg.Error("failed to update genre", zap.Error(err))
+			return fmt.Errorf("failed to update genre: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM genre_names WHERE genre_id = $1`, genre.ID); err != nil {
+			lg.Error("failed to delete old genre names", zap.Error(err))
+			return fmt.Errorf("failed to delete old genre names: %w", err)
+		}
+		if err := insertNames(ctx, tx, genre); err != nil {
+			lg.Error("failed to update genre names", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *genreRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	// Локализованные названия удаляются каскадом; ссылки из книг
+	// и дочерних жанров не дают удалить жанр.
+	if _, err := r.db.Exec(ctx, `DELETE FROM genres WHERE id = $1`, id); err != nil {
+		lg.Error("failed to delete genre", zap.Error(err))
+		return fmt.Errorf("failed to delete genre: %w", err)
+	}
+	return nil
+}
+
+func (r *genreRepo) List(ctx context.Context) ([]genres.Genre, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT g.id, COALESCE(g.parent_id, 0), g.name,
+		       COALESCE((SELECT jsonb_object_agg(n.lang, n.name)
+		                 FROM genre_names n WHERE n.genre_id = g.id), '{}')
+		FROM genres g
+		ORDER BY g.id`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		lg.Error("failed to list genres", zap.Error(err))
+		return nil, fmt.Errorf("failed to list genres: %w", err)
+	}
+	defer rows.Close()
+
+	var genresList []genres.Genre
+	for rows.Next() {
+		var genre genres.Genre
+		if err := rows.Scan(&genre.ID, &genre.ParentID, &genre.Name, &genre.Names); err != nil {
+			lg.Error("failed to scan genre", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan genre: %w", err)
+		}
+		genresList = append(genresList, genre)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return genresList, nil
+}
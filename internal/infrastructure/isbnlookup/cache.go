@@ -0,0 +1,109 @@
+package isbnlookup
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+)
+
+// Cache запоминает ответы провайдера на ttl, включая «не найдено», чтобы
+// повторный поиск того же ISBN не уходил во внешний каталог. Ошибки сети
+// и таймауты не кешируются. При переполнении вытесняется давно не
+// запрашивавшийся ISBN.
+type Cache struct {
+	provider books.MetadataProvider
+	ttl      time.Duration
+	size     int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // от недавно запрошенных к давним
+}
+
+type cacheEntry struct {
+	isbn      string
+	meta      *books.Metadata // nil — ISBN не найден
+	expiresAt time.Time
+}
+
+func NewCache(provider books.MetadataProvider, ttl time.Duration, size int) *Cache {
+	return &Cache{
+		provider: provider,
+		ttl:      ttl,
+		size:     size,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *Cache) LookupISBN(ctx context.Context, isbn string) (*books.Metadata, error) {
+	if entry, ok := c.get(isbn); ok {
+		if entry.meta == nil {
+			return nil, books.ErrMetadataNotFound
+		}
+		return clone(entry.meta), nil
+	}
+
+	meta, err := c.provider.LookupISBN(ctx, isbn)
+	if err != nil && !errors.Is(err, books.ErrMetadataNotFound) {
+		return nil, err
+	}
+	c.put(isbn, meta)
+	if meta == nil {
+		return nil, err
+	}
+	return clone(meta), nil
+}
+
+func (c *Cache) get(isbn string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[isbn]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, isbn)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry, true
+}
+
+func (c *Cache) put(isbn string, meta *books.Metadata) {
+	if c.size <= 0 || c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &cacheEntry{isbn: isbn, meta: clone(meta), expiresAt: time.Now().Add(c.ttl)}
+	if el, ok := c.entries[isbn]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[isbn] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).isbn)
+	}
+}
+
+// clone отдаёт вызывающему копию, чтобы правки не попадали в кеш.
+func clone(meta *books.Metadata) *books.Metadata {
+	if meta == nil {
+		return nil
+	}
+	copied := *meta
+	copied.Authors = slices.Clone(meta.Authors)
+	copied.Subjects = slices.Clone(meta.Subjects)
+	return &copied
+}
@@ -0,0 +1,108 @@
+// Package isbnlookup получает метаданные книг по ISBN из внешних каталогов.
+package isbnlookup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+// maxResponseSize ограничивает размер ответа внешнего каталога.
+const maxResponseSize = 1 << 20
+
+// OpenLibrary ищет книги через Books API Open Library
+// (/api/books?bibkeys=ISBN:...&jscmd=data). Базовый адрес настраивается,
+// чтобы в тестах и офлайн-окружении его мог заменить локальный stub
+// с тем же форматом ответа.
+type OpenLibrary struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewOpenLibrary создаёт провайдер; timeout ограничивает весь запрос,
+// включая чтение ответа.
+func NewOpenLibrary(baseURL string, timeout time.Duration) *OpenLibrary {
+	return &OpenLibrary{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type openLibraryBook struct {
+	Title       string `json:"title"`
+	Subtitle    string `json:"subtitle"`
+	PublishDate string `json:"publish_date"`
+	Authors     []struct {
+		Name string `json:"name"`
+	} `json:"authors"`
+	Subjects []struct {
+		Name string `json:"name"`
+	} `json:"subjects"`
+}
+
+// yearPattern выделяет год из publish_date вида "May 2003" или "2003-05-01".
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+func (o *OpenLibrary) LookupISBN(ctx context.Context, isbn string) (*books.Metadata, error) {
+	ctx, span := tracing.Start(ctx, "OpenLibrary.LookupISBN")
+	defer span.End()
+
+	key := "ISBN:" + isbn
+	query := url.Values{"bibkeys": {key}, "format": {"json"}, "jscmd": {"data"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("open library request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("open library: unexpected status %d", resp.StatusCode)
+	}
+
+	// Неизвестный ISBN — пустой объект, а не 404.
+	var found map[string]openLibraryBook
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&found); err != nil {
+		return nil, fmt.Errorf("open library: decode response: %w", err)
+	}
+	book, ok := found[key]
+	if !ok {
+		return nil, books.ErrMetadataNotFound
+	}
+
+	meta := &books.Metadata{
+		ISBN:  isbn,
+		Title: strings.TrimSpace(book.Title),
+	}
+	if subtitle := strings.TrimSpace(book.Subtitle); subtitle != "" {
+		meta.Title += ": " + subtitle
+	}
+	if year := yearPattern.FindString(book.PublishDate); year != "" {
+		meta.Year, _ = strconv.Atoi(year)
+	}
+	for _, a := range book.Authors {
+		if name := strings.TrimSpace(a.Name); name != "" {
+			meta.Authors = append(meta.Authors, name)
+		}
+	}
+	for _, s := range book.Subjects {
+		if name := strings.TrimSpace(s.Name); name != "" {
+			meta.Subjects = append(meta.Subjects, name)
+		}
+	}
+	return meta, nil
+}
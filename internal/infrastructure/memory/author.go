@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+)
+
+type authorRepo struct {
+	s *Store
+}
+
+func NewAuthorRepo(s *Store) authors.AuthorRepo {
+	return &authorRepo{s: s}
+}
+
+func (r *authorRepo) Create(ctx context.Context, author *authors.Author) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.authors[author.ID]; ok {
+		return fmt.Errorf("%w: author %d", ErrDuplicateKey, author.ID)
+	}
+	r.s.authors[author.ID] = author.Clone()
+	return nil
+}
+
+func (r *authorRepo) GetById(ctx context.Context, id int) (*authors.Author, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	author, ok := r.s.authors[id]
+	if !ok {
+		return nil, authors.ErrNotFound
+	}
+	author = author.Clone()
+	return &author, nil
+}
+
+func (r *authorRepo) Update(ctx context.Context, author *authors.Author) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.authors[author.ID]; ok {
+		r.s.authors[author.ID] = author.Clone()
+	}
+	return nil
+}
+
+func (r *authorRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, book := range r.s.books {
+		if slices.Contains(book.ContributorIDs(), id) {
+			return foreignKeyError("author", id, "book_authors")
+		}
+	}
+	delete(r.s.authors, id)
+	return nil
+}
+
+func (r *authorRepo) List(ctx context.Context) ([]authors.Author, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var authorsList []authors.Author
+	for _, id := range sortedKeys(r.s.authors) {
+		authorsList = append(authorsList, r.s.authors[id].Clone())
+	}
+	return authorsList, nil
+}
+
+func (r *authorRepo) Iterate(ctx context.Context, fn func(*authors.Author) error) error {
+	authorsList, err := r.List(ctx)
+	if err != nil {
+		return err
+	}
+	return each(authorsList, fn)
+}
+
+func (r *authorRepo) Merge(ctx context.Context, target *authors.Author, sourceIDs []int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.authors[target.ID]; !ok {
+		return nil
+	}
+	merged := make(map[int]bool, len(sourceIDs))
+	for _, id := range sourceIDs {
+		merged[id] = id != target.ID
+	}
+	// Участник источника становится target; повтор той же роли
+	// отбрасывается, и остаётся более раннее место.
+	for id, book := range r.s.books {
+		list := book.Contributors()
+		changed := false
+		moved := make([]books.Contributor, 0, len(list))
+		for _, c := range list {
+			if merged[c.AuthorID] {
+				c.AuthorID = target.ID
+				changed = true
+			}
+			if !slices.Contains(moved, c) {
+				moved = append(moved, c)
+			}
+		}
+		if changed {
+			book.SetContributors(moved)
+			r.s.books[id] = book
+		}
+	}
+	r.s.authors[target.ID] = target.Clone()
+	for id, ok := range merged {
+		if ok {
+			delete(r.s.authors, id)
+		}
+	}
+	return nil
+}
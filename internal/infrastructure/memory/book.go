@@ -0,0 +1,229 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+)
+
+type bookRepo struct {
+	s *Store
+}
+
+func NewBookRepo(s *Store) books.BookRepo {
+	return &bookRepo{s: s}
+}
+
+// cloneBook копирует книгу вместе со списками участников и жанров, чтобы
+// вызывающий код не мог изменить содержимое хранилища. Обложка и названия
+// жанров хранятся отдельно.
+func cloneBook(b books.Book) books.Book {
+	b.SetContributors(b.Contributors())
+	b.SetGenreIDs(b.GenreIDs())
+	b.Genres = nil
+	b.Cover = nil
+	return b
+}
+
+// checkAuthors проверяет внешний ключ book_authors.author_id и первичный
+// ключ (книга, автор, роль).
+func (r *bookRepo) checkAuthors(list []books.Contributor) error {
+	seen := make(map[books.Contributor]struct{}, len(list))
+	for _, c := range list {
+		if _, ok := r.s.authors[c.AuthorID]; !ok {
+			return fmt.Errorf("%w: author %d does not exist", ErrForeignKey, c.AuthorID)
+		}
+		if _, ok := seen[c]; ok {
+			return fmt.Errorf("%w: author %d is linked twice as %s", ErrDuplicateKey, c.AuthorID, c.Role)
+		}
+		seen[c] = struct{}{}
+	}
+	return nil
+}
+
+// checkGenres проверяет внешний ключ book_genres.genre_id.
+func (r *bookRepo) checkGenres(ids []int) error {
+	seen := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := r.s.genres[id]; !ok {
+			return fmt.Errorf("%w: genre %d does not exist", ErrForeignKey, id)
+		}
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("%w: genre %d is linked twice", ErrDuplicateKey, id)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// checkRefs проверяет внешние ключи books.work_id и books.publisher_id.
+func (r *bookRepo) checkRefs(b *books.Book) error {
+	if _, ok := r.s.works[b.WorkID]; b.WorkID != 0 && !ok {
+		return fmt.Errorf("%w: work %d does not exist", ErrForeignKey, b.WorkID)
+	}
+	if _, ok := r.s.publishers[b.PublisherID]; b.PublisherID != 0 && !ok {
+		return fmt.Errorf("%w: publisher %d does not exist", ErrForeignKey, b.PublisherID)
+	}
+	return nil
+}
+
+func (r *bookRepo) Create(ctx context.Context, book *books.Book) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.books[book.ID]; ok {
+		return fmt.Errorf("%w: book %d", ErrDuplicateKey, book.ID)
+	}
+	if err := r.checkRefs(book); err != nil {
+		return err
+	}
+	if err := r.checkAuthors(book.Contributors()); err != nil {
+		return err
+	}
+	if err := r.checkGenres(book.GenreIDs()); err != nil {
+		return err
+	}
+	r.s.books[book.ID] = cloneBook(*book)
+	return nil
+}
+
+func (r *bookRepo) GetByID(ctx context.Context, id int) (*books.Book, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	book, ok := r.s.books[id]
+	if !ok {
+		return nil, books.ErrNotFound
+	}
+	book = cloneBook(book)
+	return &book, nil
+}
+
+func (r *bookRepo) GetByISBN(ctx context.Context, isbn string) (*books.Book, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	isbn = books.NormalizeISBN(isbn)
+	for _, id := range sortedKeys(r.s.books) {
+		if book := r.s.books[id]; books.NormalizeISBN(book.ISBN) == isbn {
+			book = cloneBook(book)
+			return &book, nil
+		}
+	}
+	return nil, books.ErrNotFound
+}
+
+func (r *bookRepo) Update(ctx context.Context, book *books.Book) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.books[book.ID]; !ok {
+		return nil
+	}
+	if err := r.checkRefs(book); err != nil {
+		return err
+	}
+	if err := r.checkAuthors(book.Contributors()); err != nil {
+		return err
+	}
+	if err := r.checkGenres(book.GenreIDs()); err != nil {
+		return err
+	}
+	r.s.books[book.ID] = cloneBook(*book)
+	return nil
+}
+
+func (r *bookRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, res := range r.s.reservations {
+		if res.bookID == id {
+			return foreignKeyError("book", id, "reservations")
+		}
+	}
+	delete(r.s.books, id)
+	delete(r.s.bookTags, id)
+	// ON DELETE CASCADE у book_covers, reviews, book_similarity и
+	// reading_list_books.
+	delete(r.s.covers, id)
+	r.s.deleteSimilarity(id)
+	r.s.unlistBook(id)
+	for reviewID, review := range r.s.reviews {
+		if review.BookID == id {
+			r.s.deleteReview(reviewID)
+		}
+	}
+	return nil
+}
+
+func (r *bookRepo) List(ctx context.Context) ([]books.Book, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var booksList []books.Book
+	for _, id := range sortedKeys(r.s.books) {
+		booksList = append(booksList, cloneBook(r.s.books[id]))
+	}
+	return booksList, nil
+}
+
+func (r *bookRepo) ListBooksByAuthor(ctx context.Context, authorID int) ([]books.Book, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var booksList []books.Book
+	for _, id := range sortedKeys(r.s.books) {
+		book := r.s.books[id]
+		if slices.Contains(book.ContributorIDs(), authorID) {
+			booksList = append(booksList, cloneBook(book))
+		}
+	}
+	return booksList, nil
+}
+
+func (r *bookRepo) Iterate(ctx context.Context, filter books.Filter, fn func(*books.Book) error) error {
+	list := r.List
+	if filter.AuthorID != 0 {
+		list = func(ctx context.Context) ([]books.Book, error) {
+			return r.ListBooksByAuthor(ctx, filter.AuthorID)
+		}
+	}
+	booksList, err := list(ctx)
+	if err != nil {
+		return err
+	}
+	if filter.AuthorID != 0 && filter.Role != "" {
+		booksList = slices.DeleteFunc(booksList, func(b books.Book) bool {
+			return !slices.Contains(b.Contributors(), books.Contributor{AuthorID: filter.AuthorID, Role: filter.Role})
+		})
+	}
+	if filter.TagID != 0 {
+		r.s.mu.RLock()
+		booksList = slices.DeleteFunc(booksList, func(b books.Book) bool {
+			_, ok := r.s.bookTags[b.ID][filter.TagID]
+			return !ok
+		})
+		r.s.mu.RUnlock()
+	}
+	if filter.WorkID != 0 {
+		booksList = slices.DeleteFunc(booksList, func(b books.Book) bool {
+			return b.WorkID != filter.WorkID
+		})
+	}
+	if filter.PublisherID != 0 {
+		booksList = slices.DeleteFunc(booksList, func(b books.Book) bool {
+			return b.PublisherID != filter.PublisherID
+		})
+	}
+	if len(filter.GenreIDs) > 0 {
+		booksList = slices.DeleteFunc(booksList, func(b books.Book) bool {
+			return !slices.ContainsFunc(b.GenreIDs(), func(id int) bool {
+				return slices.Contains(filter.GenreIDs, id)
+			})
+		})
+	}
+	return each(booksList, fn)
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+)
+
+type coverRepo struct {
+	s *Store
+}
+
+func NewCoverRepo(s *Store) books.CoverRepo {
+	return &coverRepo{s: s}
+}
+
+// cloneCover копирует обложку без URL: их заполняет сервис при выдаче.
+func cloneCover(c books.Cover) books.Cover {
+	c.ThumbnailWidths = slices.Clone(c.ThumbnailWidths)
+	c.URL = ""
+	c.Thumbnails = nil
+	return c
+}
+
+func (r *coverRepo) Get(ctx context.Context, bookID int) (*books.Cover, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	cover, ok := r.s.covers[bookID]
+	if !ok {
+		return nil, books.ErrCoverNotFound
+	}
+	cover = cloneCover(cover)
+	return &cover, nil
+}
+
+func (r *coverRepo) Save(ctx context.Context, cover *books.Cover) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.books[cover.BookID]; !ok {
+		return fmt.Errorf("%w: book %d does not exist", ErrForeignKey, cover.BookID)
+	}
+	r.s.covers[cover.BookID] = cloneCover(*cover)
+	return nil
+}
+
+func (r *coverRepo) Delete(ctx context.Context, bookID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.covers, bookID)
+	return nil
+}
+
+func (r *coverRepo) List(ctx context.Context) ([]books.Cover, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var covers []books.Cover
+	for _, id := range sortedKeys(r.s.covers) {
+		covers = append(covers, cloneCover(r.s.covers[id]))
+	}
+	return covers, nil
+}
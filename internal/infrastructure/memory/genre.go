@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+)
+
+type genreRepo struct {
+	s *Store
+}
+
+func NewGenreRepo(s *Store) genres.GenreRepo {
+	return &genreRepo{s: s}
+}
+
+// checkParent проверяет внешний ключ genres.parent_id.
+func (r *genreRepo) checkParent(g *genres.Genre) error {
+	if g.ParentID == 0 {
+		return nil
+	}
+	if _, ok := r.s.genres[g.ParentID]; !ok {
+		return fmt.Errorf("%w: parent genre %d does not exist", ErrForeignKey, g.ParentID)
+	}
+	return nil
+}
+
+// storedGenre копирует жанр для хранения. Как и SQL-реализации, при
+// чтении жанр без локализованных названий получает пустую карту.
+func storedGenre(g *genres.Genre) genres.Genre {
+	stored := g.Clone()
+	if stored.Names == nil {
+		stored.Names = map[string]string{}
+	}
+	return stored
+}
+
+func (r *genreRepo) Create(ctx context.Context, genre *genres.Genre) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.genres[genre.ID]; ok {
+		return fmt.Errorf("%w: genre %d", ErrDuplicateKey, genre.ID)
+	}
+	if err := r.checkParent(genre); err != nil {
+		return err
+	}
+	r.s.genres[genre.ID] = storedGenre(genre)
+	return nil
+}
+
+func (r *genreRepo) GetByID(ctx context.Context, id int) (*genres.Genre, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	genre, ok := r.s.genres[id]
+	if !ok {
+		return nil, genres.ErrNotFound
+	}
+	genre = genre.Clone()
+	return &genre, nil
+}
+
+func (r *genreRepo) Update(ctx context.Context, genre *genres.Genre) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.genres[genre.ID]; !ok {
+		return nil
+	}
+	if err := r.checkParent(genre); err != nil {
+		return err
+	}
+	r.s.genres[genre.ID] = storedGenre(genre)
+	return nil
+}
+
+func (r *genreRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, g := range r.s.genres {
+		if g.ParentID == id {
+			return foreignKeyError("genre", id, "genres")
+		}
+	}
+	for _, book := range r.s.books {
+		if slices.Contains(book.GenreIDs(), id) {
+			return foreignKeyError("genre", id, "book_genres")
+		}
+	}
+	delete(r.s.genres, id)
+	return nil
+}
+
+func (r *genreRepo) List(ctx context.Context) ([]genres.Genre, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var genresList []genres.Genre
+	for _, id := range sortedKeys(r.s.genres) {
+		genresList = append(genresList, r.s.genres[id].Clone())
+	}
+	return genresList, nil
+}
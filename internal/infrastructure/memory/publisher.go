@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+)
+
+type publisherRepo struct {
+	s *Store
+}
+
+func NewPublisherRepo(s *Store) publishers.PublisherRepo {
+	return &publisherRepo{s: s}
+}
+
+func (r *publisherRepo) Create(ctx context.Context, publisher *publishers.Publisher) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.publishers[publisher.ID]; ok {
+		return fmt.Errorf("%w: publisher %d", ErrDuplicateKey, publisher.ID)
+	}
+	r.s.publishers[publisher.ID] = *publisher
+	return nil
+}
+
+func (r *publisherRepo) GetById(ctx context.Context, id int) (*publishers.Publisher, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	publisher, ok := r.s.publishers[id]
+	if !ok {
+		return nil, publishers.ErrNotFound
+	}
+	return &publisher, nil
+}
+
+func (r *publisherRepo) Update(ctx context.Context, publisher *publishers.Publisher) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.publishers[publisher.ID]; ok {
+		r.s.publishers[publisher.ID] = *publisher
+	}
+	return nil
+}
+
+func (r *publisherRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, book := range r.s.books {
+		if book.PublisherID == id {
+			return foreignKeyError("publisher", id, "books")
+		}
+	}
+	delete(r.s.publishers, id)
+	return nil
+}
+
+func (r *publisherRepo) List(ctx context.Context) ([]publishers.Publisher, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var list []publishers.Publisher
+	for _, id := range sortedKeys(r.s.publishers) {
+		list = append(list, r.s.publishers[id])
+	}
+	return list, nil
+}
@@ -0,0 +1,224 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	domainReaders "github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+)
+
+type readerRepo struct {
+	s *Store
+}
+
+func NewReaderRepo(s *Store) domainReaders.ReaderRepo {
+	return &readerRepo{s: s}
+}
+
+// checkSubject повторяет ограничение UNIQUE на readers.oidc_subject;
+// пустой субъект в базе хранится как NULL и не конфликтует.
+func (r *readerRepo) checkSubject(reader *domainReaders.Reader) error {
+	if reader.OIDCSubject == "" {
+		return nil
+	}
+	for id, other := range r.s.readers {
+		if id != reader.ID && other.OIDCSubject == reader.OIDCSubject {
+			return fmt.Errorf("%w: oidc subject is linked to reader %d", ErrDuplicateKey, id)
+		}
+	}
+	return nil
+}
+
+func (r *readerRepo) Create(ctx context.Context, reader *domainReaders.Reader) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.readers[reader.ID]; ok {
+		return fmt.Errorf("%w: reader %d", ErrDuplicateKey, reader.ID)
+	}
+	if err := r.checkSubject(reader); err != nil {
+		return err
+	}
+	// Create, как и INSERT, не записывает состояние TOTP.
+	stored := *reader
+	stored.TOTPSecret, stored.TOTPEnabled, stored.TOTPLastStep = "", false, 0
+	r.s.readers[reader.ID] = stored
+	return nil
+}
+
+func (r *readerRepo) GetById(ctx context.Context, id int) (*domainReaders.Reader, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	reader, ok := r.s.readers[id]
+	if !ok {
+		return nil, domainReaders.ErrNotFound
+	}
+	return &reader, nil
+}
+
+func (r *readerRepo) Update(ctx context.Context, reader *domainReaders.Reader) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.readers[reader.ID]
+	if !ok {
+		return nil
+	}
+	if err := r.checkSubject(reader); err != nil {
+		return err
+	}
+	// Состояние TOTP меняется только через UpdateTOTP.
+	stored.Name = reader.Name
+	stored.Phone = reader.Phone
+	stored.Email = reader.Email
+	stored.Password = reader.Password
+	stored.Admin = reader.Admin
+	stored.OIDCSubject = reader.OIDCSubject
+	r.s.readers[reader.ID] = stored
+	return nil
+}
+
+func (r *readerRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, res := range r.s.reservations {
+		if res.readerID == id {
+			return foreignKeyError("reader", id, "reservations")
+		}
+	}
+	delete(r.s.readers, id)
+	// reader_recovery_codes, отзывы, отметки и списки чтения читателя
+	// удаляются каскадно.
+	delete(r.s.recoveryCodes, id)
+	for listID, list := range r.s.readingLists {
+		if list.ReaderID == id {
+			delete(r.s.readingLists, listID)
+		}
+	}
+	for reviewID, review := range r.s.reviews {
+		if review.ReaderID == id {
+			r.s.deleteReview(reviewID)
+		}
+	}
+	for _, votes := range r.s.reviewVotes {
+		delete(votes, id)
+	}
+	return nil
+}
+
+func (r *readerRepo) List(ctx context.Context) ([]domainReaders.Reader, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var readersList []domainReaders.Reader
+	for _, id := range sortedKeys(r.s.readers) {
+		readersList = append(readersList, r.s.readers[id])
+	}
+	return readersList, nil
+}
+
+// find возвращает первого по ID читателя, удовлетворяющего условию.
+func (r *readerRepo) find(match func(domainReaders.Reader) bool) (*domainReaders.Reader, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, id := range sortedKeys(r.s.readers) {
+		if reader := r.s.readers[id]; match(reader) {
+			return &reader, nil
+		}
+	}
+	return nil, domainReaders.ErrNotFound
+}
+
+func (r *readerRepo) GetReaderByEmail(ctx context.Context, email string) (*domainReaders.Reader, error) {
+	return r.find(func(reader domainReaders.Reader) bool { return reader.Email == email })
+}
+
+func (r *readerRepo) GetReaderByOIDCSubject(ctx context.Context, subject string) (*domainReaders.Reader, error) {
+	if subject == "" {
+		return nil, domainReaders.ErrNotFound
+	}
+	return r.find(func(reader domainReaders.Reader) bool { return reader.OIDCSubject == subject })
+}
+
+func (r *readerRepo) Authenticate(ctx context.Context, email, password string) (*domainReaders.Reader, error) {
+	reader, err := r.GetReaderByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if reader.Password != password {
+		return nil, fmt.Errorf("invalid password")
+	}
+	return reader, nil
+}
+
+func (r *readerRepo) UpdateTOTP(ctx context.Context, reader *domainReaders.Reader) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.readers[reader.ID]
+	if !ok {
+		return nil
+	}
+	stored.TOTPSecret = reader.TOTPSecret
+	stored.TOTPEnabled = reader.TOTPEnabled
+	stored.TOTPLastStep = reader.TOTPLastStep
+	r.s.readers[reader.ID] = stored
+	return nil
+}
+
+func (r *readerRepo) AdvanceTOTPStep(ctx context.Context, readerID int, step int64) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.readers[readerID]
+	if !ok || stored.TOTPLastStep >= step {
+		return false, nil
+	}
+	stored.TOTPLastStep = step
+	r.s.readers[readerID] = stored
+	return true, nil
+}
+
+func (r *readerRepo) ReplaceRecoveryCodes(ctx context.Context, readerID int, codeHashes []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.readers[readerID]; !ok && len(codeHashes) > 0 {
+		return fmt.Errorf("%w: reader %d does not exist", ErrForeignKey, readerID)
+	}
+	codes := make(map[string]struct{}, len(codeHashes))
+	for _, hash := range codeHashes {
+		if _, ok := codes[hash]; ok {
+			return fmt.Errorf("%w: recovery code for reader %d", ErrDuplicateKey, readerID)
+		}
+		codes[hash] = struct{}{}
+	}
+	delete(r.s.recoveryCodes, readerID)
+	if len(codes) > 0 {
+		r.s.recoveryCodes[readerID] = codes
+	}
+	return nil
+}
+
+func (r *readerRepo) ConsumeRecoveryCode(ctx context.Context, readerID int, codeHash string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	codes := r.s.recoveryCodes[readerID]
+	if _, ok := codes[codeHash]; !ok {
+		return false, nil
+	}
+	delete(codes, codeHash)
+	return true, nil
+}
+
+func (r *readerRepo) Iterate(ctx context.Context, fn func(*domainReaders.Reader) error) error {
+	readersList, err := r.List(ctx)
+	if err != nil {
+		return err
+	}
+	return each(readersList, fn)
+}
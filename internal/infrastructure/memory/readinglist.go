@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/readinglists"
+)
+
+type readingListRepo struct {
+	s *Store
+}
+
+func NewReadingListRepo(s *Store) readinglists.ReadingListRepo {
+	return &readingListRepo{s: s}
+}
+
+func cloneReadingList(l readinglists.ReadingList) readinglists.ReadingList {
+	l.BookIDs = slices.Clone(l.BookIDs)
+	if l.BookIDs == nil {
+		l.BookIDs = []int{}
+	}
+	return l
+}
+
+// checkReadingList проверяет уникальность ссылки и ссылки на книги;
+// вызывается под блокировкой.
+func (r *readingListRepo) checkReadingList(list *readinglists.ReadingList) error {
+	if list.Slug != "" {
+		for id, other := range r.s.readingLists {
+			if id != list.ID && other.Slug == list.Slug {
+				return fmt.Errorf("%w: reading list slug %q", ErrDuplicateKey, list.Slug)
+			}
+		}
+	}
+	seen := make(map[int]struct{}, len(list.BookIDs))
+	for _, bookID := range list.BookIDs {
+		if _, ok := r.s.books[bookID]; !ok {
+			return fmt.Errorf("%w: book %d does not exist", ErrForeignKey, bookID)
+		}
+		if _, ok := seen[bookID]; ok {
+			return fmt.Errorf("%w: book %d is already in reading list %d", ErrDuplicateKey, bookID, list.ID)
+		}
+		seen[bookID] = struct{}{}
+	}
+	return nil
+}
+
+func (r *readingListRepo) Create(ctx context.Context, list *readinglists.ReadingList) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.readingLists[list.ID]; ok {
+		return fmt.Errorf("%w: reading list %d", ErrDuplicateKey, list.ID)
+	}
+	if _, ok := r.s.readers[list.ReaderID]; !ok {
+		return fmt.Errorf("%w: reader %d does not exist", ErrForeignKey, list.ReaderID)
+	}
+	if err := r.checkReadingList(list); err != nil {
+		return err
+	}
+	stored := cloneReadingList(*list)
+	stored.Public = stored.Slug != ""
+	stored.CreatedAt = storedTime(list.CreatedAt)
+	r.s.readingLists[list.ID] = stored
+	return nil
+}
+
+func (r *readingListRepo) GetByID(ctx context.Context, id int) (*readinglists.ReadingList, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	list, ok := r.s.readingLists[id]
+	if !ok {
+		return nil, readinglists.ErrNotFound
+	}
+	list = cloneReadingList(list)
+	return &list, nil
+}
+
+func (r *readingListRepo) GetBySlug(ctx context.Context, slug string) (*readinglists.ReadingList, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, list := range r.s.readingLists {
+		if slug != "" && list.Slug == slug {
+			list = cloneReadingList(list)
+			return &list, nil
+		}
+	}
+	return nil, readinglists.ErrNotFound
+}
+
+func (r *readingListRepo) Update(ctx context.Context, list *readinglists.ReadingList) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.updateLocked(list)
+}
+
+func (r *readingListRepo) Modify(ctx context.Context, id int, fn func(*readinglists.ReadingList) error) (*readinglists.ReadingList, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.readingLists[id]
+	if !ok {
+		return nil, readinglists.ErrNotFound
+	}
+	list := cloneReadingList(stored)
+	if err := fn(&list); err != nil {
+		return nil, err
+	}
+	if err := r.updateLocked(&list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (r *readingListRepo) updateLocked(list *readinglists.ReadingList) error {
+	stored, ok := r.s.readingLists[list.ID]
+	if !ok {
+		return nil
+	}
+	if err := r.checkReadingList(list); err != nil {
+		return err
+	}
+	stored.Name = list.Name
+	stored.Slug = list.Slug
+	stored.Public = list.Slug != ""
+	stored.BookIDs = slices.Clone(list.BookIDs)
+	r.s.readingLists[list.ID] = cloneReadingList(stored)
+	return nil
+}
+
+func (r *readingListRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.readingLists, id)
+	return nil
+}
+
+func (r *readingListRepo) ListByReader(ctx context.Context, readerID int) ([]readinglists.ReadingList, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var lists []readinglists.ReadingList
+	for _, id := range sortedKeys(r.s.readingLists) {
+		if list := r.s.readingLists[id]; list.ReaderID == readerID {
+			lists = append(lists, cloneReadingList(list))
+		}
+	}
+	return lists, nil
+}
+
+// unlistBook убирает книгу из всех списков, как ON DELETE CASCADE у
+// reading_list_books; вызывается под блокировкой.
+func (s *Store) unlistBook(bookID int) {
+	for id, list := range s.readingLists {
+		if i := slices.Index(list.BookIDs, bookID); i >= 0 {
+			list.BookIDs = slices.Delete(slices.Clone(list.BookIDs), i, i+1)
+			s.readingLists[id] = list
+		}
+	}
+}
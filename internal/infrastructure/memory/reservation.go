@@ -0,0 +1,228 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+)
+
+type reservationRepo struct {
+	s *Store
+}
+
+func NewReservationRepo(s *Store) reservations.ReservationRepo {
+	return &reservationRepo{s: s}
+}
+
+// checkRefs проверяет внешние ключи reservations.book_id и reader_id.
+func (r *reservationRepo) checkRefs(bookID, readerID int) error {
+	if _, ok := r.s.books[bookID]; !ok {
+		return fmt.Errorf("%w: book %d does not exist", ErrForeignKey, bookID)
+	}
+	if _, ok := r.s.readers[readerID]; !ok {
+		return fmt.Errorf("%w: reader %d does not exist", ErrForeignKey, readerID)
+	}
+	return nil
+}
+
+// toReservation собирает агрегат так же, как Postgres-репозиторий:
+// у книги и читателя заполнены только ID.
+func (row reservationRow) toReservation() (*reservations.Reservation, error) {
+	res, err := reservations.NewReservation(row.id, books.Book{ID: row.bookID}, readers.Reader{ID: row.readerID}, row.startDate, row.endDate)
+	if err != nil {
+		return nil, err
+	}
+	if row.returnedDate != nil {
+		returned := *row.returnedDate
+		res.ReturnedDate = &returned
+	}
+	return res, nil
+}
+
+func (r *reservationRepo) Create(ctx context.Context, id int, book books.Book, reader readers.Reader, startDate, endDate time.Time) (*reservations.Reservation, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.createLocked(id, book, reader, startDate, endDate)
+}
+
+// CreateFirstFree выбирает и бронирует книгу под одной блокировкой хранилища.
+func (r *reservationRepo) CreateFirstFree(ctx context.Context, id int, bookIDs []int, reader readers.Reader, startDate, endDate time.Time) (*reservations.Reservation, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	busy := r.busyLocked(bookIDs, startDate, endDate)
+	for _, bookID := range bookIDs {
+		if !slices.Contains(busy, bookID) {
+			return r.createLocked(id, books.Book{ID: bookID}, reader, startDate, endDate)
+		}
+	}
+	return nil, reservations.ErrNoFreeBook
+}
+
+func (r *reservationRepo) createLocked(id int, book books.Book, reader readers.Reader, startDate, endDate time.Time) (*reservations.Reservation, error) {
+	// Создаем объект бронирования через доменную фабрику.
+	res, err := reservations.NewReservation(id, book, reader, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.s.reservations[id]; ok {
+		return nil, fmt.Errorf("failed to create reservation: %w: reservation %d", ErrDuplicateKey, id)
+	}
+	if err := r.checkRefs(book.ID, reader.ID); err != nil {
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+	r.s.reservations[id] = reservationRow{
+		id:        id,
+		bookID:    book.ID,
+		readerID:  reader.ID,
+		startDate: truncateDate(startDate),
+		endDate:   truncateDate(endDate),
+	}
+	return res, nil
+}
+
+func (r *reservationRepo) GetById(ctx context.Context, id int) (*reservations.Reservation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.reservations[id]
+	if !ok {
+		return nil, reservations.ErrNotFound
+	}
+	return row.toReservation()
+}
+
+func (r *reservationRepo) Update(ctx context.Context, id int, book books.Book, reader readers.Reader, startDate, endDate time.Time) error {
+	if endDate.Before(startDate) {
+		return fmt.Errorf("end date cannot be before start date")
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	existing, ok := r.s.reservations[id]
+	if !ok {
+		return nil
+	}
+	if err := r.checkRefs(book.ID, reader.ID); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+	r.s.reservations[id] = reservationRow{
+		id:           id,
+		bookID:       book.ID,
+		readerID:     reader.ID,
+		startDate:    truncateDate(startDate),
+		endDate:      truncateDate(endDate),
+		returnedDate: existing.returnedDate,
+	}
+	return nil
+}
+
+func (r *reservationRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.reservations, id)
+	return nil
+}
+
+func (r *reservationRepo) List(ctx context.Context, startDate, endDate time.Time) ([]reservations.Reservation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	from, to := truncateDate(startDate), truncateDate(endDate)
+	var resList []reservations.Reservation
+	for _, id := range sortedKeys(r.s.reservations) {
+		row := r.s.reservations[id]
+		if row.startDate.Before(from) || row.endDate.After(to) {
+			continue
+		}
+		res, err := row.toReservation()
+		if err != nil {
+			return nil, err
+		}
+		resList = append(resList, *res)
+	}
+	return resList, nil
+}
+
+func (r *reservationRepo) MarkReturned(ctx context.Context, id int, returnedDate time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.reservations[id]
+	if !ok {
+		return reservations.ErrNotFound
+	}
+	returned := truncateDate(returnedDate)
+	row.returnedDate = &returned
+	r.s.reservations[id] = row
+	return nil
+}
+
+func (r *reservationRepo) CountOverdue(ctx context.Context, now time.Time) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	today := truncateDate(now)
+	count := 0
+	for _, row := range r.s.reservations {
+		if row.returnedDate == nil && row.endDate.Before(today) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *reservationRepo) BusyBooks(ctx context.Context, bookIDs []int, startDate, endDate time.Time) ([]int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.busyLocked(bookIDs, startDate, endDate), nil
+}
+
+func (r *reservationRepo) busyLocked(bookIDs []int, startDate, endDate time.Time) []int {
+	start, end := truncateDate(startDate), truncateDate(endDate)
+	var ids []int
+	for _, row := range r.s.reservations {
+		if row.returnedDate != nil || !slices.Contains(bookIDs, row.bookID) || slices.Contains(ids, row.bookID) {
+			continue
+		}
+		if !row.startDate.After(end) && !row.endDate.Before(start) {
+			ids = append(ids, row.bookID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (r *reservationRepo) Iterate(ctx context.Context, filter reservations.Filter, fn func(*reservations.Reservation) error) error {
+	r.s.mu.RLock()
+	var resList []reservations.Reservation
+	for _, id := range sortedKeys(r.s.reservations) {
+		row := r.s.reservations[id]
+		if !filter.StartDate.IsZero() && row.startDate.Before(truncateDate(filter.StartDate)) {
+			continue
+		}
+		if !filter.EndDate.IsZero() && row.endDate.After(truncateDate(filter.EndDate)) {
+			continue
+		}
+		if (filter.BookID != 0 && row.bookID != filter.BookID) || (filter.ReaderID != 0 && row.readerID != filter.ReaderID) {
+			continue
+		}
+		res, err := row.toReservation()
+		if err != nil {
+			r.s.mu.RUnlock()
+			return err
+		}
+		resList = append(resList, *res)
+	}
+	r.s.mu.RUnlock()
+	return each(resList, fn)
+}
@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reviews"
+)
+
+type reviewRepo struct {
+	s *Store
+}
+
+func NewReviewRepo(s *Store) reviews.ReviewRepo {
+	return &reviewRepo{s: s}
+}
+
+// storedTime повторяет колонки TIMESTAMPTZ: точность до микросекунд.
+func storedTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+// withVotes возвращает копию отзыва с числом отметок; вызывается под
+// блокировкой.
+func (r *reviewRepo) withVotes(review reviews.Review) reviews.Review {
+	review.Helpful = len(r.s.reviewVotes[review.ID])
+	return review
+}
+
+func (r *reviewRepo) Create(ctx context.Context, review *reviews.Review) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.reviews[review.ID]; ok {
+		return fmt.Errorf("%w: review %d", ErrDuplicateKey, review.ID)
+	}
+	if _, ok := r.s.books[review.BookID]; !ok {
+		return fmt.Errorf("%w: book %d does not exist", ErrForeignKey, review.BookID)
+	}
+	if _, ok := r.s.readers[review.ReaderID]; !ok {
+		return fmt.Errorf("%w: reader %d does not exist", ErrForeignKey, review.ReaderID)
+	}
+	for _, existing := range r.s.reviews {
+		if existing.BookID == review.BookID && existing.ReaderID == review.ReaderID {
+			return fmt.Errorf("%w: reader %d already reviewed book %d", ErrDuplicateKey, review.ReaderID, review.BookID)
+		}
+	}
+	stored := *review
+	stored.Helpful = 0
+	stored.CreatedAt = storedTime(review.CreatedAt)
+	stored.UpdatedAt = storedTime(review.UpdatedAt)
+	r.s.reviews[review.ID] = stored
+	return nil
+}
+
+func (r *reviewRepo) GetByID(ctx context.Context, id int) (*reviews.Review, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	review, ok := r.s.reviews[id]
+	if !ok {
+		return nil, reviews.ErrNotFound
+	}
+	review = r.withVotes(review)
+	return &review, nil
+}
+
+func (r *reviewRepo) Update(ctx context.Context, review *reviews.Review) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.reviews[review.ID]
+	if !ok {
+		return nil
+	}
+	stored.Rating = review.Rating
+	stored.Text = review.Text
+	stored.Status = review.Status
+	stored.UpdatedAt = storedTime(review.UpdatedAt)
+	r.s.reviews[review.ID] = stored
+	return nil
+}
+
+func (r *reviewRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.deleteReview(id)
+	return nil
+}
+
+// deleteReview удаляет отзыв и, как ON DELETE CASCADE, его отметки;
+// вызывается под блокировкой.
+func (s *Store) deleteReview(id int) {
+	delete(s.reviews, id)
+	delete(s.reviewVotes, id)
+}
+
+func (r *reviewRepo) List(ctx context.Context, filter reviews.Filter) ([]reviews.Review, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var list []reviews.Review
+	for _, review := range r.s.reviews {
+		if filter.BookID != 0 && review.BookID != filter.BookID {
+			continue
+		}
+		if filter.ReaderID != 0 && review.ReaderID != filter.ReaderID {
+			continue
+		}
+		if filter.Status != "" && review.Status != filter.Status {
+			continue
+		}
+		list = append(list, r.withVotes(review))
+	}
+	slices.SortFunc(list, func(a, b reviews.Review) int {
+		if filter.Sort == reviews.SortHelpful {
+			if c := cmp.Compare(b.Helpful, a.Helpful); c != 0 {
+				return c
+			}
+		}
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return list, nil
+}
+
+func (r *reviewRepo) Vote(ctx context.Context, reviewID, readerID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.reviews[reviewID]; !ok {
+		return fmt.Errorf("%w: review %d does not exist", ErrForeignKey, reviewID)
+	}
+	if _, ok := r.s.readers[readerID]; !ok {
+		return fmt.Errorf("%w: reader %d does not exist", ErrForeignKey, readerID)
+	}
+	if r.s.reviewVotes[reviewID] == nil {
+		r.s.reviewVotes[reviewID] = make(map[int]struct{})
+	}
+	r.s.reviewVotes[reviewID][readerID] = struct{}{}
+	return nil
+}
+
+func (r *reviewRepo) Unvote(ctx context.Context, reviewID, readerID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.reviewVotes[reviewID], readerID)
+	return nil
+}
+
+func (r *reviewRepo) BookRating(ctx context.Context, bookID int) (books.Rating, error) {
+	ratings, err := r.Ratings(ctx)
+	if err != nil {
+		return books.Rating{}, err
+	}
+	return ratings[bookID], nil
+}
+
+func (r *reviewRepo) Ratings(ctx context.Context) (map[int]books.Rating, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	sums := make(map[int][2]int)
+	for _, review := range r.s.reviews {
+		if review.Status != reviews.StatusApproved {
+			continue
+		}
+		acc := sums[review.BookID]
+		sums[review.BookID] = [2]int{acc[0] + review.Rating, acc[1] + 1}
+	}
+	ratings := make(map[int]books.Rating, len(sums))
+	for bookID, acc := range sums {
+		ratings[bookID] = books.NewRating(acc[0], acc[1])
+	}
+	return ratings, nil
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/recommendations"
+)
+
+type similarityRepo struct {
+	s *Store
+}
+
+func NewSimilarityRepo(s *Store) recommendations.SimilarityRepo {
+	return &similarityRepo{s: s}
+}
+
+func (r *similarityRepo) Replace(ctx context.Context, neighbours map[int][]recommendations.Neighbour) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	similarity := make(map[int][]recommendations.Neighbour, len(neighbours))
+	for bookID, list := range neighbours {
+		if _, ok := r.s.books[bookID]; !ok {
+			return fmt.Errorf("%w: book %d does not exist", ErrForeignKey, bookID)
+		}
+		for _, n := range list {
+			if _, ok := r.s.books[n.BookID]; !ok {
+				return fmt.Errorf("%w: book %d does not exist", ErrForeignKey, n.BookID)
+			}
+		}
+		if len(list) > 0 {
+			similarity[bookID] = slices.Clone(list)
+		}
+	}
+	r.s.similarity = similarity
+	return nil
+}
+
+func (r *similarityRepo) Neighbours(ctx context.Context, bookIDs []int) (map[int][]recommendations.Neighbour, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	result := make(map[int][]recommendations.Neighbour)
+	for _, id := range bookIDs {
+		if list, ok := r.s.similarity[id]; ok {
+			result[id] = slices.Clone(list)
+		}
+	}
+	return result, nil
+}
+
+// deleteSimilarity убирает книгу из соседей, как ON DELETE CASCADE у
+// book_similarity; вызывается под блокировкой.
+func (s *Store) deleteSimilarity(bookID int) {
+	delete(s.similarity, bookID)
+	for id, list := range s.similarity {
+		list = slices.DeleteFunc(list, func(n recommendations.Neighbour) bool {
+			return n.BookID == bookID
+		})
+		if len(list) == 0 {
+			delete(s.similarity, id)
+			continue
+		}
+		s.similarity[id] = list
+	}
+}
@@ -0,0 +1,206 @@
+// Package memory содержит реализации репозиториев в памяти процесса.
+// Семантика повторяет Postgres-реализации: те же ошибки «не найдено»,
+// уникальность ключей и ограничения внешних ключей схемы.
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/readinglists"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/recommendations"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reviews"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/tags"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+)
+
+var (
+	// ErrDuplicateKey — запись с таким первичным или уникальным ключом уже есть.
+	ErrDuplicateKey = errors.New("duplicate key")
+	// ErrForeignKey — операция нарушила бы ссылочную целостность.
+	ErrForeignKey = errors.New("foreign key violation")
+)
+
+// Store — общее хранилище всех репозиториев. Один мьютекс на всё
+// хранилище позволяет проверять связи между таблицами атомарно.
+type Store struct {
+	mu sync.RWMutex
+	// txMu выполняет транзакции по одной.
+	txMu sync.Mutex
+
+	books         map[int]books.Book
+	works         map[int]works.Work
+	covers        map[int]books.Cover
+	authors       map[int]authors.Author
+	publishers    map[int]publishers.Publisher
+	genres        map[int]genres.Genre
+	tags          map[int]tags.Tag
+	tagAliases    map[string]int
+	bookTags      map[int]map[int]struct{}
+	readers       map[int]readers.Reader
+	recoveryCodes map[int]map[string]struct{}
+	reservations  map[int]reservationRow
+	reviews       map[int]reviews.Review
+	// reviewVotes — ID отзыва → читатели, отметившие его полезным.
+	reviewVotes map[int]map[int]struct{}
+	// similarity — ID книги → её соседи по совместным бронированиям.
+	similarity   map[int][]recommendations.Neighbour
+	readingLists map[int]readinglists.ReadingList
+}
+
+// reservationRow хранит бронирование так же, как таблица reservations:
+// только ссылки на книгу и читателя.
+type reservationRow struct {
+	id        int
+	bookID    int
+	readerID  int
+	startDate time.Time
+	endDate   time.Time
+	// returnedDate — день возврата; nil, пока книга у читателя.
+	returnedDate *time.Time
+}
+
+// NewStore создаёт пустое хранилище.
+func NewStore() *Store {
+	return &Store{
+		books:         make(map[int]books.Book),
+		works:         make(map[int]works.Work),
+		covers:        make(map[int]books.Cover),
+		authors:       make(map[int]authors.Author),
+		publishers:    make(map[int]publishers.Publisher),
+		genres:        make(map[int]genres.Genre),
+		tags:          make(map[int]tags.Tag),
+		tagAliases:    make(map[string]int),
+		bookTags:      make(map[int]map[int]struct{}),
+		readers:       make(map[int]readers.Reader),
+		recoveryCodes: make(map[int]map[string]struct{}),
+		reservations:  make(map[int]reservationRow),
+		reviews:       make(map[int]reviews.Review),
+		reviewVotes:   make(map[int]map[int]struct{}),
+		similarity:    make(map[int][]recommendations.Neighbour),
+		readingLists:  make(map[int]readinglists.ReadingList),
+	}
+}
+
+// InTx выполняет fn как транзакцию: если fn вернула ошибку, хранилище
+// возвращается к снимку, снятому перед её вызовом. Транзакции идут по одной,
+// но записи вне транзакции не ждут её завершения и при откате тоже теряются;
+// для хранилища разработки это приемлемо.
+func (s *Store) InTx(fn func() error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.RLock()
+	snapshot := s.clone()
+	s.mu.RUnlock()
+
+	if err := fn(); err != nil {
+		s.mu.Lock()
+		s.restore(snapshot)
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// clone копирует таблицы; книги, обложки, авторы, жанры, теги книг, коды
+// восстановления, отметки отзывов, соседи книг и списки чтения копируются
+// глубоко, потому что содержат срезы и вложенные карты.
+func (s *Store) clone() *Store {
+	c := NewStore()
+	for id, b := range s.books {
+		c.books[id] = cloneBook(b)
+	}
+	maps.Copy(c.works, s.works)
+	for id, cover := range s.covers {
+		c.covers[id] = cloneCover(cover)
+	}
+	for id, a := range s.authors {
+		c.authors[id] = a.Clone()
+	}
+	maps.Copy(c.publishers, s.publishers)
+	for id, g := range s.genres {
+		c.genres[id] = g.Clone()
+	}
+	maps.Copy(c.tags, s.tags)
+	maps.Copy(c.tagAliases, s.tagAliases)
+	for id, set := range s.bookTags {
+		c.bookTags[id] = maps.Clone(set)
+	}
+	maps.Copy(c.readers, s.readers)
+	for id, codes := range s.recoveryCodes {
+		c.recoveryCodes[id] = maps.Clone(codes)
+	}
+	maps.Copy(c.reservations, s.reservations)
+	maps.Copy(c.reviews, s.reviews)
+	for id, votes := range s.reviewVotes {
+		c.reviewVotes[id] = maps.Clone(votes)
+	}
+	for id, list := range s.similarity {
+		c.similarity[id] = slices.Clone(list)
+	}
+	for id, list := range s.readingLists {
+		c.readingLists[id] = cloneReadingList(list)
+	}
+	return c
+}
+
+func (s *Store) restore(snapshot *Store) {
+	s.books = snapshot.books
+	s.works = snapshot.works
+	s.covers = snapshot.covers
+	s.authors = snapshot.authors
+	s.publishers = snapshot.publishers
+	s.genres = snapshot.genres
+	s.tags = snapshot.tags
+	s.tagAliases = snapshot.tagAliases
+	s.bookTags = snapshot.bookTags
+	s.readers = snapshot.readers
+	s.recoveryCodes = snapshot.recoveryCodes
+	s.reservations = snapshot.reservations
+	s.reviews = snapshot.reviews
+	s.reviewVotes = snapshot.reviewVotes
+	s.similarity = snapshot.similarity
+	s.readingLists = snapshot.readingLists
+}
+
+func foreignKeyError(table string, id int, ref string) error {
+	return fmt.Errorf("%w: %s %d is referenced by %s", ErrForeignKey, table, id, ref)
+}
+
+// sortedKeys возвращает ключи по возрастанию, чтобы списки были стабильны.
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// truncateDate повторяет поведение колонок DATE: время отбрасывается.
+func truncateDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// each вызывает fn для элементов снимка. Блокировка к этому моменту снята,
+// поэтому fn может обращаться к хранилищу.
+func each[T any](items []T, fn func(*T) error) error {
+	for i := range items {
+		if err := fn(&items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/tags"
+)
+
+type tagRepo struct {
+	s *Store
+}
+
+func NewTagRepo(s *Store) tags.TagRepo {
+	return &tagRepo{s: s}
+}
+
+func (r *tagRepo) Create(ctx context.Context, tag *tags.Tag) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.tags[tag.ID]; ok {
+		return fmt.Errorf("%w: tag %d", ErrDuplicateKey, tag.ID)
+	}
+	for _, t := range r.s.tags {
+		if t.Name == tag.Name {
+			return fmt.Errorf("%w: tag name %q", ErrDuplicateKey, tag.Name)
+		}
+	}
+	// Синонимы появляются только при слиянии.
+	r.s.tags[tag.ID] = tags.Tag{ID: tag.ID, Name: tag.Name}
+	return nil
+}
+
+// withAliases возвращает копию тега с синонимами; вызывается под блокировкой.
+func (r *tagRepo) withAliases(tag tags.Tag) *tags.Tag {
+	for alias, id := range r.s.tagAliases {
+		if id == tag.ID {
+			tag.Aliases = append(tag.Aliases, alias)
+		}
+	}
+	sort.Strings(tag.Aliases)
+	return &tag
+}
+
+func (r *tagRepo) GetByID(ctx context.Context, id int) (*tags.Tag, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	tag, ok := r.s.tags[id]
+	if !ok {
+		return nil, tags.ErrNotFound
+	}
+	return r.withAliases(tag), nil
+}
+
+func (r *tagRepo) FindByName(ctx context.Context, name string) (*tags.Tag, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, tag := range r.s.tags {
+		if tag.Name == name {
+			return r.withAliases(tag), nil
+		}
+	}
+	if id, ok := r.s.tagAliases[name]; ok {
+		return r.withAliases(r.s.tags[id]), nil
+	}
+	return nil, tags.ErrNotFound
+}
+
+// deleteTag удаляет тег вместе со связями и синонимами, как ON DELETE
+// CASCADE; вызывается под блокировкой.
+func (r *tagRepo) deleteTag(id int) {
+	delete(r.s.tags, id)
+	for alias, tagID := range r.s.tagAliases {
+		if tagID == id {
+			delete(r.s.tagAliases, alias)
+		}
+	}
+	for _, set := range r.s.bookTags {
+		delete(set, id)
+	}
+}
+
+func (r *tagRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.deleteTag(id)
+	return nil
+}
+
+func (r *tagRepo) Counts(ctx context.Context, limit int) ([]tags.TagCount, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	books := make(map[int]int)
+	for _, set := range r.s.bookTags {
+		for id := range set {
+			books[id]++
+		}
+	}
+	var counts []tags.TagCount
+	for id, n := range books {
+		counts = append(counts, tags.TagCount{ID: id, Name: r.s.tags[id].Name, Books: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Books != counts[j].Books {
+			return counts[i].Books > counts[j].Books
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts, nil
+}
+
+func (r *tagRepo) AddToBook(ctx context.Context, bookID, tagID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.books[bookID]; !ok {
+		return fmt.Errorf("%w: book %d does not exist", ErrForeignKey, bookID)
+	}
+	if _, ok := r.s.tags[tagID]; !ok {
+		return fmt.Errorf("%w: tag %d does not exist", ErrForeignKey, tagID)
+	}
+	if r.s.bookTags[bookID] == nil {
+		r.s.bookTags[bookID] = make(map[int]struct{})
+	}
+	r.s.bookTags[bookID][tagID] = struct{}{}
+	return nil
+}
+
+func (r *tagRepo) RemoveFromBook(ctx context.Context, bookID, tagID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.bookTags[bookID], tagID)
+	return nil
+}
+
+func (r *tagRepo) BookTags(ctx context.Context, bookID int) ([]tags.Tag, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var tagsList []tags.Tag
+	for id := range r.s.bookTags[bookID] {
+		tagsList = append(tagsList, tags.Tag{ID: id, Name: r.s.tags[id].Name})
+	}
+	sort.Slice(tagsList, func(i, j int) bool { return tagsList[i].Name < tagsList[j].Name })
+	return tagsList, nil
+}
+
+func (r *tagRepo) Merge(ctx context.Context, targetID int, sourceIDs []int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.tags[targetID]; !ok {
+		return fmt.Errorf("%w: tag %d does not exist", ErrForeignKey, targetID)
+	}
+	for _, id := range sourceIDs {
+		source, ok := r.s.tags[id]
+		if !ok || id == targetID {
+			continue
+		}
+		for _, set := range r.s.bookTags {
+			if _, ok := set[id]; ok {
+				set[targetID] = struct{}{}
+			}
+		}
+		for alias, tagID := range r.s.tagAliases {
+			if tagID == id {
+				r.s.tagAliases[alias] = targetID
+			}
+		}
+		r.deleteTag(id)
+		r.s.tagAliases[source.Name] = targetID
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+)
+
+type workRepo struct {
+	s *Store
+}
+
+func NewWorkRepo(s *Store) works.WorkRepo {
+	return &workRepo{s: s}
+}
+
+func (r *workRepo) Create(ctx context.Context, work *works.Work) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.works[work.ID]; ok {
+		return fmt.Errorf("%w: work %d", ErrDuplicateKey, work.ID)
+	}
+	r.s.works[work.ID] = *work
+	return nil
+}
+
+func (r *workRepo) GetByID(ctx context.Context, id int) (*works.Work, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	work, ok := r.s.works[id]
+	if !ok {
+		return nil, works.ErrNotFound
+	}
+	return &work, nil
+}
+
+func (r *workRepo) Update(ctx context.Context, work *works.Work) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.works[work.ID]; ok {
+		r.s.works[work.ID] = *work
+	}
+	return nil
+}
+
+func (r *workRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, book := range r.s.books {
+		if book.WorkID == id {
+			return foreignKeyError("work", id, "books")
+		}
+	}
+	delete(r.s.works, id)
+	return nil
+}
+
+func (r *workRepo) List(ctx context.Context) ([]works.Work, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var list []works.Work
+	for _, id := range sortedKeys(r.s.works) {
+		list = append(list, r.s.works[id])
+	}
+	return list, nil
+}
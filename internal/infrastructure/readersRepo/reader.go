@@ -2,29 +2,31 @@ package readersRepo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	domainReaders "github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
 	"github.com/0sokrat0/BookAPI/pkg/logger"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
 type readerRepo struct {
-	db *pgxpool.Pool
+	db postgres.DBTX
 }
 
-func NewReaderRepo(db *pgxpool.Pool) domainReaders.ReaderRepo {
+func NewReaderRepo(db postgres.DBTX) domainReaders.ReaderRepo {
 	return &readerRepo{db: db}
 }
 
 func (r *readerRepo) Create(ctx context.Context, reader *domainReaders.Reader) error {
 	lg := logger.FromContext(ctx)
 	query := `
-	    INSERT INTO readers (id, name, phone, email, password, admin)
-		VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err := r.db.Exec(ctx, query, reader.ID, reader.Name, reader.Phone, reader.Email, reader.Password, reader.Admin)
+	    INSERT INTO readers (id, name, phone, email, password, admin, oidc_subject)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))`
+	_, err := r.db.Exec(ctx, query, reader.ID, reader.Name, reader.Phone, reader.Email, reader.Password, reader.Admin, reader.OIDCSubject)
 	if err != nil {
 		lg.Error("failed to create reader", zap.Error(err))
 		return err
@@ -35,12 +37,17 @@ func (r *readerRepo) Create(ctx context.Context, reader *domainReaders.Reader) e
 func (r *readerRepo) GetById(ctx context.Context, id int) (*domainReaders.Reader, error) {
 	lg := logger.FromContext(ctx)
 	query := `
-        SELECT id, name, phone, email, password, admin
+        SELECT id, name, phone, email, password, admin, totp_secret, totp_enabled, totp_last_step,
+               COALESCE(oidc_subject, '')
         FROM readers
         WHERE id = $1`
 	row := r.db.QueryRow(ctx, query, id)
 	var reader domainReaders.Reader
-	err := row.Scan(&reader.ID, &reader.Name, &reader.Phone, &reader.Email, &reader.Password, &reader.Admin)
+	err := row.Scan(&reader.ID, &reader.Name, &reader.Phone, &reader.Email, &reader.Password, &reader.Admin,
+		&reader.TOTPSecret, &reader.TOTPEnabled, &reader.TOTPLastStep, &reader.OIDCSubject)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domainReaders.ErrNotFound
+	}
 	if err != nil {
 		lg.Error("failed to get reader by id", zap.Error(err))
 		return nil, err
@@ -52,9 +59,9 @@ func (r *readerRepo) Update(ctx context.Context, reader *domainReaders.Reader) e
 	lg := logger.FromContext(ctx)
 	query := `
         UPDATE readers
-        SET name = $2, phone = $3, email = $4, password = $5, admin = $6
+        SET name = $2, phone = $3, email = $4, password = $5, admin = $6, oidc_subject = NULLIF($7, '')
         WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, reader.ID, reader.Name, reader.Phone, reader.Email, reader.Password, reader.Admin)
+	_, err := r.db.Exec(ctx, query, reader.ID, reader.Name, reader.Phone, reader.Email, reader.Password, reader.Admin, reader.OIDCSubject)
 	if err != nil {
 		lg.Error("failed to update reader by id", zap.Error(err))
 		return err
@@ -78,7 +85,8 @@ func (r *readerRepo) Delete(ctx context.Context, id int) error {
 func (r *readerRepo) List(ctx context.Context) ([]domainReaders.Reader, error) {
 	lg := logger.FromContext(ctx)
 	query := `
-        SELECT id, name, phone, email, password, admin
+        SELECT id, name, phone, email, password, admin, totp_secret, totp_enabled, totp_last_step,
+               COALESCE(oidc_subject, '')
         FROM readers`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
@@ -90,7 +98,8 @@ func (r *readerRepo) List(ctx context.Context) ([]domainReaders.Reader, error) {
 	var readersList []domainReaders.Reader
 	for rows.Next() {
 		var reader domainReaders.Reader
-		err := rows.Scan(&reader.ID, &reader.Name, &reader.Phone, &reader.Email, &reader.Password, &reader.Admin)
+		err := rows.Scan(&reader.ID, &reader.Name, &reader.Phone, &reader.Email, &reader.Password, &reader.Admin,
+			&reader.TOTPSecret, &reader.TOTPEnabled, &reader.TOTPLastStep, &reader.OIDCSubject)
 		if err != nil {
 			lg.Error("failed to scan reader", zap.Error(err))
 			return nil, fmt.Errorf("failed to scan reader: %w", err)
@@ -107,12 +116,17 @@ func (r *readerRepo) List(ctx context.Context) ([]domainReaders.Reader, error) {
 func (r *readerRepo) GetReaderByEmail(ctx context.Context, email string) (*domainReaders.Reader, error) {
 	lg := logger.FromContext(ctx)
 	query := `
-	    SELECT id, name, phone, email, password, admin
+	    SELECT id, name, phone, email, password, admin, totp_secret, totp_enabled, totp_last_step,
+	           COALESCE(oidc_subject, '')
 	    FROM readers
 	    WHERE email = $1`
 	row := r.db.QueryRow(ctx, query, email)
 	var reader domainReaders.Reader
-	err := row.Scan(&reader.ID, &reader.Name, &reader.Phone, &reader.Email, &reader.Password, &reader.Admin)
+	err := row.Scan(&reader.ID, &reader.Name, &reader.Phone, &reader.Email, &reader.Password, &reader.Admin,
+		&reader.TOTPSecret, &reader.TOTPEnabled, &reader.TOTPLastStep, &reader.OIDCSubject)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domainReaders.ErrNotFound
+	}
 	if err != nil {
 		lg.Error("failed to get reader by email", zap.Error(err))
 		return nil, err
@@ -120,6 +134,27 @@ func (r *readerRepo) GetReaderByEmail(ctx context.Context, email string) (*domai
 	return &reader, nil
 }
 
+func (r *readerRepo) GetReaderByOIDCSubject(ctx context.Context, subject string) (*domainReaders.Reader, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+	    SELECT id, name, phone, email, password, admin, totp_secret, totp_enabled, totp_last_step,
+	           COALESCE(oidc_subject, '')
+	    FROM readers
+	    WHERE oidc_subject = $1`
+	row := r.db.QueryRow(ctx, query, subject)
+	var reader domainReaders.Reader
+	err := row.Scan(&reader.ID, &reader.Name, &reader.Phone, &reader.Email, &reader.Password, &reader.Admin,
+		&reader.TOTPSecret, &reader.TOTPEnabled, &reader.TOTPLastStep, &reader.OIDCSubject)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domainReaders.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get reader by oidc subject", zap.Error(err))
+		return nil, err
+	}
+	return &reader, nil
+}
+
 func (r *readerRepo) Authenticate(ctx context.Context, email, password string) (*domainReaders.Reader, error) {
 	reader, err := r.GetReaderByEmail(ctx, email)
 	if err != nil {
@@ -130,3 +165,100 @@ func (r *readerRepo) Authenticate(ctx context.Context, email, password string) (
 	}
 	return reader, nil
 }
+
+func (r *readerRepo) UpdateTOTP(ctx context.Context, reader *domainReaders.Reader) error {
+	lg := logger.FromContext(ctx)
+	query := `
+        UPDATE readers
+        SET totp_secret = $2, totp_enabled = $3, totp_last_step = $4
+        WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, reader.ID, reader.TOTPSecret, reader.TOTPEnabled, reader.TOTPLastStep)
+	if err != nil {
+		lg.Error("failed to update reader totp", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *readerRepo) AdvanceTOTPStep(ctx context.Context, readerID int, step int64) (bool, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+        UPDATE readers
+        SET totp_last_step = $2
+        WHERE id = $1 AND totp_last_step < $2`
+	tag, err := r.db.Exec(ctx, query, readerID, step)
+	if err != nil {
+		lg.Error("failed to advance reader totp step", zap.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *readerRepo) ReplaceRecoveryCodes(ctx context.Context, readerID int, codeHashes []string) error {
+	lg := logger.FromContext(ctx)
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		lg.Error("failed to begin transaction", zap.Error(err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM reader_recovery_codes WHERE reader_id = $1`, readerID); err != nil {
+		lg.Error("failed to delete recovery codes", zap.Error(err))
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	query := `INSERT INTO reader_recovery_codes (reader_id, code_hash) VALUES ($1, $2)`
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(ctx, query, readerID, hash); err != nil {
+			lg.Error("failed to insert recovery code", zap.Error(err))
+			return fmt.Errorf("failed to insert recovery code: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *readerRepo) ConsumeRecoveryCode(ctx context.Context, readerID int, codeHash string) (bool, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+        DELETE FROM reader_recovery_codes
+        WHERE reader_id = $1 AND code_hash = $2`
+	tag, err := r.db.Exec(ctx, query, readerID, codeHash)
+	if err != nil {
+		lg.Error("failed to consume recovery code", zap.Error(err))
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *readerRepo) Iterate(ctx context.Context, fn func(*domainReaders.Reader) error) error {
+	lg := logger.FromContext(ctx)
+	query := `
+        SELECT id, name, phone, email, password, admin, totp_secret, totp_enabled, totp_last_step,
+               COALESCE(oidc_subject, '')
+        FROM readers
+        ORDER BY id`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		lg.Error("failed to iterate readers", zap.Error(err))
+		return fmt.Errorf("failed to iterate readers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reader domainReaders.Reader
+		err := rows.Scan(&reader.ID, &reader.Name, &reader.Phone, &reader.Email, &reader.Password, &reader.Admin,
+			&reader.TOTPSecret, &reader.TOTPEnabled, &reader.TOTPLastStep, &reader.OIDCSubject)
+		if err != nil {
+			lg.Error("failed to scan reader", zap.Error(err))
+			return fmt.Errorf("failed to scan reader: %w", err)
+		}
+		if err := fn(&reader); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
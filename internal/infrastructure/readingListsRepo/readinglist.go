@@ -0,0 +1,204 @@
+package readinglistsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/readinglists"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type readingListRepo struct {
+	db postgres.DBTX
+}
+
+func NewReadingListRepo(db postgres.DBTX) readinglists.ReadingListRepo {
+	return &readingListRepo{db: db}
+}
+
+const readingListColumns = `id, reader_id, name, COALESCE(slug, ''), created_at`
+
+func readingListFields(l *readinglists.ReadingList) []any {
+	return []any{&l.ID, &l.ReaderID, &l.Name, &l.Slug, &l.CreatedAt}
+}
+
+func (r *readingListRepo) Create(ctx context.Context, list *readinglists.ReadingList) error {
+	lg := logger.FromContext(ctx)
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `
+			INSERT INTO reading_lists (id, reader_id, name, slug, created_at)
+			VALUES ($1, $2, $3, NULLIF($4, ''), $5)`
+		if _, err := tx.Exec(ctx, query, list.ID, list.ReaderID, list.Name, list.Slug, list.CreatedAt); err != nil {
+			lg.Error("failed to create reading list", zap.Error(err))
+			return err
+		}
+		if err := insertListBooks(ctx, tx, list); err != nil {
+			lg.Error("failed to insert reading list books", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+// insertListBooks сохраняет книги списка с позициями от 1.
+func insertListBooks(ctx context.Context, tx pgx.Tx, list *readinglists.ReadingList) error {
+	query := `
+		INSERT INTO reading_list_books (list_id, book_id, position)
+		SELECT $1, book_id, position
+		FROM unnest($2::int[]) WITH ORDINALITY AS b(book_id, position)`
+	if _, err := tx.Exec(ctx, query, list.ID, list.BookIDs); err != nil {
+		return fmt.Errorf("failed to insert books of reading list %d: %w", list.ID, err)
+	}
+	return nil
+}
+
+func (r *readingListRepo) GetByID(ctx context.Context, id int) (*readinglists.ReadingList, error) {
+	return r.getBy(ctx, "id", `id = $1`, id)
+}
+
+func (r *readingListRepo) GetBySlug(ctx context.Context, slug string) (*readinglists.ReadingList, error) {
+	return r.getBy(ctx, "slug", `slug = $1`, slug)
+}
+
+func (r *readingListRepo) getBy(ctx context.Context, what, cond string, arg any) (*readinglists.ReadingList, error) {
+	lg := logger.FromContext(ctx)
+	var list readinglists.ReadingList
+	query := `SELECT ` + readingListColumns + ` FROM reading_lists WHERE ` + cond
+	err := r.db.QueryRow(ctx, query, arg).Scan(readingListFields(&list)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, readinglists.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get reading list by "+what, zap.Error(err))
+		return nil, err
+	}
+	list.Public = list.Slug != ""
+	books, err := r.listBooks(ctx, `list_id = $1`, list.ID)
+	if err != nil {
+		lg.Error("failed to get reading list books", zap.Error(err))
+		return nil, err
+	}
+	list.BookIDs = books[list.ID]
+	if list.BookIDs == nil {
+		list.BookIDs = []int{}
+	}
+	return &list, nil
+}
+
+// listBooks возвращает книги списков, отобранных условием cond, в порядке
+// позиций.
+func (r *readingListRepo) listBooks(ctx context.Context, cond string, arg any) (map[int][]int, error) {
+	query := `
+		SELECT list_id, book_id
+		FROM reading_list_books
+		WHERE ` + cond + `
+		ORDER BY list_id, position`
+	rows, err := r.db.Query(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	books := make(map[int][]int)
+	for rows.Next() {
+		var listID, bookID int
+		if err := rows.Scan(&listID, &bookID); err != nil {
+			return nil, fmt.Errorf("failed to scan reading list book: %w", err)
+		}
+		books[listID] = append(books[listID], bookID)
+	}
+	return books, rows.Err()
+}
+
+func (r *readingListRepo) Update(ctx context.Context, list *readinglists.ReadingList) error {
+	lg := logger.FromContext(ctx)
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `UPDATE reading_lists SET name = $2, slug = NULLIF($3, '') WHERE id = $1`
+		if _, err := tx.Exec(ctx, query, list.ID, list.Name, list.Slug); err != nil {
+			lg.Error("failed to update reading list", zap.Error(err))
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM reading_list_books WHERE list_id = $1`, list.ID); err != nil {
+			lg.Error("failed to clear reading list books", zap.Error(err))
+			return err
+		}
+		if err := insertListBooks(ctx, tx, list); err != nil {
+			lg.Error("failed to insert reading list books", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *readingListRepo) Modify(ctx context.Context, id int, fn func(*readinglists.ReadingList) error) (*readinglists.ReadingList, error) {
+	var list *readinglists.ReadingList
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		repo := &readingListRepo{db: tx}
+		var err error
+		list, err = repo.getBy(ctx, "id", `id = $1 FOR UPDATE`, id)
+		if err != nil {
+			return err
+		}
+		if err := fn(list); err != nil {
+			return err
+		}
+		return repo.Update(ctx, list)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *readingListRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	// Книги списка удаляются каскадом.
+	if _, err := r.db.Exec(ctx, `DELETE FROM reading_lists WHERE id = $1`, id); err != nil {
+		lg.Error("failed to delete reading list", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *readingListRepo) ListByReader(ctx context.Context, readerID int) ([]readinglists.ReadingList, error) {
+	lg := logger.FromContext(ctx)
+	query := `SELECT ` + readingListColumns + ` FROM reading_lists WHERE reader_id = $1 ORDER BY id`
+	rows, err := r.db.Query(ctx, query, readerID)
+	if err != nil {
+		lg.Error("failed to list reading lists", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lists []readinglists.ReadingList
+	for rows.Next() {
+		var list readinglists.ReadingList
+		if err := rows.Scan(readingListFields(&list)...); err != nil {
+			lg.Error("failed to scan reading list", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan reading list: %w", err)
+		}
+		list.Public = list.Slug != ""
+		lists = append(lists, list)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	books, err := r.listBooks(ctx, `list_id IN (SELECT id FROM reading_lists WHERE reader_id = $1)`, readerID)
+	if err != nil {
+		lg.Error("failed to list reading list books", zap.Error(err))
+		return nil, err
+	}
+	for i := range lists {
+		lists[i].BookIDs = books[lists[i].ID]
+		if lists[i].BookIDs == nil {
+			lists[i].BookIDs = []int{}
+		}
+	}
+	return lists, nil
+}
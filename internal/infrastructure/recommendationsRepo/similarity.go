@@ -0,0 +1,83 @@
+package recommendationsrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/recommendations"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type similarityRepo struct {
+	db postgres.DBTX
+}
+
+func NewSimilarityRepo(db postgres.DBTX) recommendations.SimilarityRepo {
+	return &similarityRepo{db: db}
+}
+
+func (r *similarityRepo) Replace(ctx context.Context, neighbours map[int][]recommendations.Neighbour) error {
+	lg := logger.FromContext(ctx)
+
+	// Строки передаются столбцами и разворачиваются unnest — одна вставка
+	// вместо запроса на каждого соседа.
+	var bookIDs, similarIDs, positions, readers []int
+	var scores []float64
+	for bookID, list := range neighbours {
+		for i, n := range list {
+			bookIDs = append(bookIDs, bookID)
+			similarIDs = append(similarIDs, n.BookID)
+			positions = append(positions, i+1)
+			scores = append(scores, n.Score)
+			readers = append(readers, n.Readers)
+		}
+	}
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM book_similarity`); err != nil {
+			lg.Error("failed to clear book similarity", zap.Error(err))
+			return err
+		}
+		query := `
+			INSERT INTO book_similarity (book_id, similar_book_id, position, score, readers)
+			SELECT * FROM unnest($1::int[], $2::int[], $3::int[], $4::float8[], $5::int[])`
+		if _, err := tx.Exec(ctx, query, bookIDs, similarIDs, positions, scores, readers); err != nil {
+			lg.Error("failed to insert book similarity", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *similarityRepo) Neighbours(ctx context.Context, bookIDs []int) (map[int][]recommendations.Neighbour, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT book_id, similar_book_id, score, readers
+		FROM book_similarity
+		WHERE book_id = ANY($1)
+		ORDER BY book_id, position`
+	rows, err := r.db.Query(ctx, query, bookIDs)
+	if err != nil {
+		lg.Error("failed to list book neighbours", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[int][]recommendations.Neighbour)
+	for rows.Next() {
+		var bookID int
+		var n recommendations.Neighbour
+		if err := rows.Scan(&bookID, &n.BookID, &n.Score, &n.Readers); err != nil {
+			lg.Error("failed to scan book neighbour", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan book neighbour: %w", err)
+		}
+		result[bookID] = append(result[bookID], n)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,188 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// TestAuthorRepo проверяет контракт authors.AuthorRepo.
+func TestAuthorRepo(t *testing.T, newRepos Factory) {
+	subtest(t, "CreateGet", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Authors.Create(ctx, &authors.Author{ID: 1, Name: "Лев Толстой", Country: "Россия"}), "create")
+
+		got, err := repos.Authors.GetById(ctx, 1)
+		must(t, err, "get")
+		if got.Name != "Лев Толстой" || got.Country != "Россия" {
+			t.Fatalf("got %+v", got)
+		}
+	})
+
+	subtest(t, "DuplicateID", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Authors.Create(ctx, &authors.Author{ID: 1, Name: "A"}), "create")
+		if err := repos.Authors.Create(ctx, &authors.Author{ID: 1, Name: "B"}); err == nil {
+			t.Fatal("expected error for duplicate id")
+		}
+	})
+
+	subtest(t, "NotFound", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		if _, err := repos.Authors.GetById(ctx, 404); !errors.Is(err, authors.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "Update", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Authors.Create(ctx, &authors.Author{ID: 1, Name: "A", Country: "X"}), "create")
+		must(t, repos.Authors.Update(ctx, &authors.Author{ID: 1, Name: "B", Country: "Y"}), "update")
+
+		got, err := repos.Authors.GetById(ctx, 1)
+		must(t, err, "get")
+		if got.Name != "B" || got.Country != "Y" {
+			t.Fatalf("update not applied: %+v", got)
+		}
+	})
+
+	subtest(t, "Details", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		birth := time.Date(1828, 9, 9, 0, 0, 0, 0, time.UTC)
+		author := &authors.Author{ID: 1, Name: "Лев Толстой", BirthDate: &birth, Bio: "Писатель"}
+		author.SetAliases([]string{"Lev Tolstoi", "Толстой Л. Н."})
+		must(t, repos.Authors.Create(ctx, author), "create")
+
+		check := func(what string, got *authors.Author) {
+			t.Helper()
+			if !slices.Equal(got.Aliases, author.Aliases) || got.Bio != "Писатель" || got.DeathDate != nil ||
+				got.BirthDate == nil || !got.BirthDate.Equal(birth) {
+				t.Fatalf("%s: got %+v", what, got)
+			}
+		}
+		got, err := repos.Authors.GetById(ctx, 1)
+		must(t, err, "get")
+		check("get", got)
+		list, err := repos.Authors.List(ctx)
+		must(t, err, "list")
+		check("list", &list[0])
+		must(t, repos.Authors.Iterate(ctx, func(a *authors.Author) error {
+			check("iterate", a)
+			return nil
+		}), "iterate")
+
+		// Update заменяет синонимы целиком.
+		got.SetAliases([]string{"Leo Tolstoy"})
+		must(t, repos.Authors.Update(ctx, got), "update")
+		got, err = repos.Authors.GetById(ctx, 1)
+		must(t, err, "get updated")
+		if !slices.Equal(got.Aliases, []string{"Leo Tolstoy"}) {
+			t.Fatalf("aliases not replaced: %v", got.Aliases)
+		}
+	})
+
+	subtest(t, "Merge", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		for _, a := range []authors.Author{{ID: 1, Name: "Лев Толстой"}, {ID: 2, Name: "Толстой Л. Н.", Aliases: []string{"Lev Tolstoi"}}, {ID: 3, Name: "Другой"}} {
+			must(t, repos.Authors.Create(ctx, &a), "create author")
+		}
+		// В книге 10 автор записан дважды: дубликат стоит раньше основной записи.
+		book, err := books.NewBook(10, "Война и мир", 1869, "", nil, nil)
+		must(t, err, "new book")
+		book.SetContributors([]books.Contributor{{AuthorID: 3, Role: books.RoleAuthor}, {AuthorID: 2, Role: books.RoleAuthor}, {AuthorID: 1, Role: books.RoleAuthor}})
+		must(t, repos.Books.Create(ctx, book), "create book 10")
+		book, err = books.NewBook(11, "Перевод", 1900, "", nil, nil)
+		must(t, err, "new book")
+		book.SetContributors([]books.Contributor{{AuthorID: 3, Role: books.RoleAuthor}, {AuthorID: 2, Role: books.RoleTranslator}})
+		must(t, repos.Books.Create(ctx, book), "create book 11")
+
+		target, err := repos.Authors.GetById(ctx, 1)
+		must(t, err, "get target")
+		dup, err := repos.Authors.GetById(ctx, 2)
+		must(t, err, "get source")
+		target.Absorb(*dup)
+		must(t, repos.Authors.Merge(ctx, target, []int{2}), "merge")
+
+		if _, err := repos.Authors.GetById(ctx, 2); !errors.Is(err, authors.ErrNotFound) {
+			t.Fatalf("merged author must be deleted, got %v", err)
+		}
+		got, err := repos.Authors.GetById(ctx, 1)
+		must(t, err, "get merged")
+		if !slices.Equal(got.Aliases, []string{"Lev Tolstoi", "Толстой Л. Н."}) {
+			t.Fatalf("target aliases: %v", got.Aliases)
+		}
+		want := map[int][]books.Contributor{
+			10: {{AuthorID: 3, Role: books.RoleAuthor}, {AuthorID: 1, Role: books.RoleAuthor}},
+			11: {{AuthorID: 3, Role: books.RoleAuthor}, {AuthorID: 1, Role: books.RoleTranslator}},
+		}
+		for id, contributors := range want {
+			b, err := repos.Books.GetByID(ctx, id)
+			must(t, err, "get book")
+			if !slices.Equal(b.Contributors(), contributors) {
+				t.Fatalf("book %d contributors: got %v, want %v", id, b.Contributors(), contributors)
+			}
+		}
+		// Синонимы удалённого дубликата не мешают завести автора с тем же ID.
+		must(t, repos.Authors.Create(ctx, &authors.Author{ID: 2, Name: "Новый"}), "reuse merged id")
+		got, err = repos.Authors.GetById(ctx, 2)
+		must(t, err, "get reused")
+		if got.Aliases != nil {
+			t.Fatalf("stale aliases of merged author: %v", got.Aliases)
+		}
+	})
+
+	subtest(t, "List", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		list, err := repos.Authors.List(ctx)
+		must(t, err, "list empty")
+		if len(list) != 0 {
+			t.Fatalf("expected empty list, got %d", len(list))
+		}
+
+		for _, id := range []int{3, 1, 2} {
+			must(t, repos.Authors.Create(ctx, &authors.Author{ID: id, Name: "A"}), "create")
+		}
+		list, err = repos.Authors.List(ctx)
+		must(t, err, "list")
+		var ids []int
+		for _, a := range list {
+			ids = append(ids, a.ID)
+		}
+		if !equalInts(ids, []int{1, 2, 3}) {
+			t.Fatalf("got ids %v", ids)
+		}
+
+		ids = nil
+		err = repos.Authors.Iterate(ctx, func(a *authors.Author) error {
+			ids = append(ids, a.ID)
+			return nil
+		})
+		must(t, err, "iterate")
+		if !slices.Equal(ids, []int{1, 2, 3}) {
+			t.Fatalf("iterate must go in id order, got %v", ids)
+		}
+	})
+
+	subtest(t, "Delete", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Authors.Create(ctx, &authors.Author{ID: 1, Name: "A"}), "create")
+		must(t, repos.Authors.Delete(ctx, 1), "delete")
+		if _, err := repos.Authors.GetById(ctx, 1); !errors.Is(err, authors.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after delete, got %v", err)
+		}
+		// Удаление отсутствующей записи не ошибка.
+		must(t, repos.Authors.Delete(ctx, 1), "delete missing")
+	})
+
+	subtest(t, "DeleteLinkedToBook", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Authors.Create(ctx, &authors.Author{ID: 1, Name: "A"}), "create author")
+		book, err := books.NewBook(10, "Книга", 2000, "", []int{1}, nil)
+		must(t, err, "new book")
+		must(t, repos.Books.Create(ctx, book), "create book")
+
+		if err := repos.Authors.Delete(ctx, 1); err == nil {
+			t.Fatal("expected error when deleting an author linked to a book")
+		}
+		if _, err := repos.Authors.GetById(ctx, 1); err != nil {
+			t.Fatalf("author must survive failed delete: %v", err)
+		}
+	})
+}
@@ -0,0 +1,337 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// seedAuthors создаёт авторов с указанными ID.
+func seedAuthors(t *testing.T, ctx context.Context, repos storage.Repositories, ids ...int) {
+	t.Helper()
+	for _, id := range ids {
+		must(t, repos.Authors.Create(ctx, &authors.Author{ID: id, Name: "Автор"}), "create author")
+	}
+}
+
+func newBook(t *testing.T, id int, title string, authorIDs ...int) *books.Book {
+	t.Helper()
+	book, err := books.NewBook(id, title, 1869, "978-5-17-000000-0", authorIDs, nil)
+	must(t, err, "new book")
+	return book
+}
+
+// TestBookRepo проверяет контракт books.BookRepo.
+func TestBookRepo(t *testing.T, newRepos Factory) {
+	subtest(t, "CreateGetWithAuthors", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedAuthors(t, ctx, repos, 1, 2)
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "Война и мир", 2, 1)), "create")
+
+		got, err := repos.Books.GetByID(ctx, 10)
+		must(t, err, "get")
+		if got.Title != "Война и мир" || got.Year != 1869 || got.ISBN != "978-5-17-000000-0" {
+			t.Fatalf("got %+v", got)
+		}
+		if !equalInts(got.AuthorIDs(), []int{1, 2}) {
+			t.Fatalf("got authors %v", got.AuthorIDs())
+		}
+	})
+
+	subtest(t, "CreateWithoutAuthors", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "Аноним")), "create")
+		got, err := repos.Books.GetByID(ctx, 10)
+		must(t, err, "get")
+		if len(got.AuthorIDs()) != 0 {
+			t.Fatalf("expected no authors, got %v", got.AuthorIDs())
+		}
+	})
+
+	subtest(t, "UnknownAuthor", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		if err := repos.Books.Create(ctx, newBook(t, 10, "Книга", 99)); err == nil {
+			t.Fatal("expected error for a link to a missing author")
+		}
+	})
+
+	subtest(t, "NotFound", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		if _, err := repos.Books.GetByID(ctx, 404); !errors.Is(err, books.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "GetByISBN", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "A")), "create 10")
+		must(t, repos.Books.Create(ctx, newBook(t, 11, "B")), "create 11")
+
+		// Дефисы, пробелы и регистр не влияют на поиск; из дубликатов
+		// возвращается книга с меньшим ID.
+		got, err := repos.Books.GetByISBN(ctx, "978 5170 000000")
+		must(t, err, "get by isbn")
+		if got.ID != 10 {
+			t.Fatalf("get by isbn: got id %d", got.ID)
+		}
+
+		must(t, repos.Books.Create(ctx, &books.Book{ID: 12, Title: "C", ISBN: "0-306-40615-x"}), "create 12")
+		got, err = repos.Books.GetByISBN(ctx, "030640615X")
+		must(t, err, "get by isbn-10")
+		if got.ID != 12 {
+			t.Fatalf("get by isbn-10: got id %d", got.ID)
+		}
+
+		if _, err := repos.Books.GetByISBN(ctx, "9780000000002"); !errors.Is(err, books.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "UpdateReplacesAuthors", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedAuthors(t, ctx, repos, 1, 2, 3)
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "Черновик", 1, 2)), "create")
+
+		book := newBook(t, 10, "Чистовик", 3)
+		book.Year = 1870
+		must(t, repos.Books.Update(ctx, book), "update")
+
+		got, err := repos.Books.GetByID(ctx, 10)
+		must(t, err, "get")
+		if got.Title != "Чистовик" || got.Year != 1870 {
+			t.Fatalf("update not applied: %+v", got)
+		}
+		if !equalInts(got.AuthorIDs(), []int{3}) {
+			t.Fatalf("authors not replaced: %v", got.AuthorIDs())
+		}
+	})
+
+	subtest(t, "Genres", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedGenres(t, ctx, repos, 1, 2, 3)
+		book := newBook(t, 10, "Книга")
+		book.SetGenreIDs([]int{2, 1})
+		must(t, repos.Books.Create(ctx, book), "create")
+
+		got, err := repos.Books.GetByID(ctx, 10)
+		must(t, err, "get")
+		if !equalInts(got.GenreIDs(), []int{1, 2}) {
+			t.Fatalf("got genres %v", got.GenreIDs())
+		}
+
+		got.SetGenreIDs([]int{3})
+		must(t, repos.Books.Update(ctx, got), "update")
+		got, err = repos.Books.GetByID(ctx, 10)
+		must(t, err, "get after update")
+		if !equalInts(got.GenreIDs(), []int{3}) {
+			t.Fatalf("genres not replaced: %v", got.GenreIDs())
+		}
+
+		unknown := newBook(t, 11, "Другая")
+		unknown.SetGenreIDs([]int{99})
+		if err := repos.Books.Create(ctx, unknown); err == nil {
+			t.Fatal("expected error for a link to a missing genre")
+		}
+
+		must(t, repos.Books.Delete(ctx, 10), "delete")
+		// После удаления книги жанр можно удалить.
+		must(t, repos.Genres.Delete(ctx, 3), "delete genre")
+	})
+
+	subtest(t, "ListAndListByAuthor", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedAuthors(t, ctx, repos, 1, 2)
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "A", 1)), "create 10")
+		must(t, repos.Books.Create(ctx, newBook(t, 11, "B", 1, 2)), "create 11")
+		must(t, repos.Books.Create(ctx, newBook(t, 12, "C")), "create 12")
+
+		all, err := repos.Books.List(ctx)
+		must(t, err, "list")
+		if got := bookIDs(all); !equalInts(got, []int{10, 11, 12}) {
+			t.Fatalf("list: got %v", got)
+		}
+		for _, b := range all {
+			if b.ID == 11 && !equalInts(b.AuthorIDs(), []int{1, 2}) {
+				t.Fatalf("list must load authors, got %v", b.AuthorIDs())
+			}
+		}
+
+		byAuthor, err := repos.Books.ListBooksByAuthor(ctx, 2)
+		must(t, err, "list by author")
+		if got := bookIDs(byAuthor); !equalInts(got, []int{11}) {
+			t.Fatalf("list by author: got %v", got)
+		}
+		if !equalInts(byAuthor[0].AuthorIDs(), []int{1, 2}) {
+			t.Fatalf("list by author must load all authors, got %v", byAuthor[0].AuthorIDs())
+		}
+
+		none, err := repos.Books.ListBooksByAuthor(ctx, 404)
+		must(t, err, "list by missing author")
+		if len(none) != 0 {
+			t.Fatalf("expected no books, got %v", bookIDs(none))
+		}
+	})
+
+	subtest(t, "Iterate", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedAuthors(t, ctx, repos, 1, 2)
+		must(t, repos.Books.Create(ctx, newBook(t, 12, "C")), "create 12")
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "A", 2, 1)), "create 10")
+		must(t, repos.Books.Create(ctx, newBook(t, 11, "B", 2)), "create 11")
+
+		var ids []int
+		authorsOf := map[int][]int{}
+		err := repos.Books.Iterate(ctx, books.Filter{}, func(b *books.Book) error {
+			ids = append(ids, b.ID)
+			authorsOf[b.ID] = b.AuthorIDs()
+			return nil
+		})
+		must(t, err, "iterate")
+		if !slices.Equal(ids, []int{10, 11, 12}) {
+			t.Fatalf("iterate must go in id order, got %v", ids)
+		}
+		if !equalInts(authorsOf[10], []int{1, 2}) || len(authorsOf[12]) != 0 {
+			t.Fatalf("iterate must load authors, got %v", authorsOf)
+		}
+
+		ids = nil
+		err = repos.Books.Iterate(ctx, books.Filter{AuthorID: 2}, func(b *books.Book) error {
+			ids = append(ids, b.ID)
+			return nil
+		})
+		must(t, err, "iterate by author")
+		if !slices.Equal(ids, []int{10, 11}) {
+			t.Fatalf("iterate by author: got %v", ids)
+		}
+
+		// Фильтр по жанрам — «любой из»; книга с двумя подходящими
+		// жанрами выдаётся один раз.
+		seedGenres(t, ctx, repos, 1, 2, 3)
+		for id, genreIDs := range map[int][]int{10: {1, 2}, 11: {3}, 12: {2}} {
+			b, err := repos.Books.GetByID(ctx, id)
+			must(t, err, "get")
+			b.SetGenreIDs(genreIDs)
+			must(t, repos.Books.Update(ctx, b), "set genres")
+		}
+		ids = nil
+		genresOf := map[int][]int{}
+		err = repos.Books.Iterate(ctx, books.Filter{GenreIDs: []int{1, 2}}, func(b *books.Book) error {
+			ids = append(ids, b.ID)
+			genresOf[b.ID] = b.GenreIDs()
+			return nil
+		})
+		must(t, err, "iterate by genres")
+		if !slices.Equal(ids, []int{10, 12}) {
+			t.Fatalf("iterate by genres: got %v", ids)
+		}
+		if !equalInts(genresOf[10], []int{1, 2}) {
+			t.Fatalf("iterate must load all genres, got %v", genresOf[10])
+		}
+		ids = nil
+		err = repos.Books.Iterate(ctx, books.Filter{AuthorID: 2, GenreIDs: []int{3}}, func(b *books.Book) error {
+			ids = append(ids, b.ID)
+			return nil
+		})
+		must(t, err, "iterate by author and genre")
+		if !slices.Equal(ids, []int{11}) {
+			t.Fatalf("iterate by author and genre: got %v", ids)
+		}
+
+		errStop := errors.New("stop")
+		calls := 0
+		err = repos.Books.Iterate(ctx, books.Filter{}, func(b *books.Book) error {
+			calls++
+			return errStop
+		})
+		if !errors.Is(err, errStop) || calls != 1 {
+			t.Fatalf("fn error must stop iteration: err %v, calls %d", err, calls)
+		}
+	})
+
+	subtest(t, "Contributors", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedAuthors(t, ctx, repos, 1, 2, 3)
+		want := []books.Contributor{
+			{AuthorID: 3, Role: books.RoleAuthor},
+			{AuthorID: 1, Role: books.RoleAuthor},
+			{AuthorID: 2, Role: books.RoleTranslator},
+			{AuthorID: 3, Role: books.RoleIllustrator},
+		}
+		book := newBook(t, 10, "Маленький принц")
+		book.SetContributors(want)
+		must(t, repos.Books.Create(ctx, book), "create")
+		must(t, repos.Books.Create(ctx, newBook(t, 11, "Другая", 2)), "create other")
+
+		got, err := repos.Books.GetByID(ctx, 10)
+		must(t, err, "get")
+		if !slices.Equal(got.Contributors(), want) {
+			t.Fatalf("contributors must keep order and roles: got %v", got.Contributors())
+		}
+		if !slices.Equal(got.AuthorIDs(), []int{3, 1}) {
+			t.Fatalf("authors in order: got %v", got.AuthorIDs())
+		}
+
+		byAuthor, err := repos.Books.ListBooksByAuthor(ctx, 3)
+		must(t, err, "list by author")
+		if !slices.Equal(bookIDs(byAuthor), []int{10}) {
+			t.Fatalf("a book with several roles is listed once: got %v", bookIDs(byAuthor))
+		}
+
+		var iterated []books.Contributor
+		err = repos.Books.Iterate(ctx, books.Filter{}, func(b *books.Book) error {
+			if b.ID == 10 {
+				iterated = b.Contributors()
+			}
+			return nil
+		})
+		must(t, err, "iterate")
+		if !slices.Equal(iterated, want) {
+			t.Fatalf("iterate contributors: got %v", iterated)
+		}
+
+		for _, tc := range []struct {
+			role books.Role
+			want []int
+		}{
+			{books.RoleTranslator, []int{10}},
+			{books.RoleAuthor, []int{11}},
+			{books.RoleEditor, nil},
+		} {
+			var ids []int
+			err := repos.Books.Iterate(ctx, books.Filter{AuthorID: 2, Role: tc.role}, func(b *books.Book) error {
+				ids = append(ids, b.ID)
+				return nil
+			})
+			must(t, err, "iterate by role")
+			if !slices.Equal(ids, tc.want) {
+				t.Fatalf("author 2 as %s: got %v, want %v", tc.role, ids, tc.want)
+			}
+		}
+
+		dup := newBook(t, 12, "Дубль")
+		dup.SetContributors([]books.Contributor{{AuthorID: 1, Role: books.RoleEditor}, {AuthorID: 1, Role: books.RoleEditor}})
+		if err := repos.Books.Create(ctx, dup); err == nil {
+			t.Fatal("expected error for a repeated author and role")
+		}
+	})
+
+	subtest(t, "Delete", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedAuthors(t, ctx, repos, 1)
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "A", 1)), "create")
+		must(t, repos.Books.Delete(ctx, 10), "delete")
+
+		if _, err := repos.Books.GetByID(ctx, 10); !errors.Is(err, books.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after delete, got %v", err)
+		}
+		left, err := repos.Books.ListBooksByAuthor(ctx, 1)
+		must(t, err, "list by author")
+		if len(left) != 0 {
+			t.Fatalf("author links must be removed, got %v", bookIDs(left))
+		}
+		// После удаления книги автора можно удалить.
+		must(t, repos.Authors.Delete(ctx, 1), "delete author")
+	})
+}
+
+func bookIDs(list []books.Book) []int {
+	ids := make([]int, 0, len(list))
+	for _, b := range list {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
@@ -0,0 +1,72 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// TestCoverRepo проверяет контракт books.CoverRepo.
+func TestCoverRepo(t *testing.T, newRepos Factory) {
+	subtest(t, "SaveGetReplace", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "Книга")), "create book")
+		if _, err := repos.Covers.Get(ctx, 10); !errors.Is(err, books.ErrCoverNotFound) {
+			t.Fatalf("expected ErrCoverNotFound, got %v", err)
+		}
+
+		cover := &books.Cover{BookID: 10, Version: "v1", ContentType: "image/png", Width: 600, Height: 900,
+			ThumbnailWidths: []int{160, 320}}
+		must(t, repos.Covers.Save(ctx, cover), "save")
+		got, err := repos.Covers.Get(ctx, 10)
+		must(t, err, "get")
+		if got.Version != "v1" || got.ContentType != "image/png" || got.Width != 600 || got.Height != 900 ||
+			!equalInts(got.ThumbnailWidths, []int{160, 320}) {
+			t.Fatalf("got %+v", got)
+		}
+
+		// Повторное сохранение заменяет обложку.
+		must(t, repos.Covers.Save(ctx, &books.Cover{BookID: 10, Version: "v2", ContentType: "image/jpeg",
+			Width: 100, Height: 150}), "replace")
+		got, err = repos.Covers.Get(ctx, 10)
+		must(t, err, "get replaced")
+		if got.Version != "v2" || len(got.ThumbnailWidths) != 0 {
+			t.Fatalf("got %+v", got)
+		}
+	})
+
+	subtest(t, "UnknownBook", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		if err := repos.Covers.Save(ctx, &books.Cover{BookID: 99, Version: "v1", ContentType: "image/png"}); err == nil {
+			t.Fatal("expected error for a cover of a missing book")
+		}
+	})
+
+	subtest(t, "ListDelete", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		for _, id := range []int{11, 10} {
+			must(t, repos.Books.Create(ctx, newBook(t, id, "Книга")), "create book")
+			must(t, repos.Covers.Save(ctx, &books.Cover{BookID: id, Version: "v", ContentType: "image/png"}), "save")
+		}
+		covers, err := repos.Covers.List(ctx)
+		must(t, err, "list")
+		if len(covers) != 2 || covers[0].BookID != 10 || covers[1].BookID != 11 {
+			t.Fatalf("got %+v", covers)
+		}
+
+		must(t, repos.Covers.Delete(ctx, 10), "delete")
+		must(t, repos.Covers.Delete(ctx, 10), "delete missing")
+		if _, err := repos.Covers.Get(ctx, 10); !errors.Is(err, books.ErrCoverNotFound) {
+			t.Fatalf("expected ErrCoverNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "DeletedWithBook", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "Книга")), "create book")
+		must(t, repos.Covers.Save(ctx, &books.Cover{BookID: 10, Version: "v", ContentType: "image/png"}), "save")
+		must(t, repos.Books.Delete(ctx, 10), "delete book")
+		if _, err := repos.Covers.Get(ctx, 10); !errors.Is(err, books.ErrCoverNotFound) {
+			t.Fatalf("expected ErrCoverNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// seedGenres создаёт жанры верхнего уровня с указанными ID.
+func seedGenres(t *testing.T, ctx context.Context, repos storage.Repositories, ids ...int) {
+	t.Helper()
+	for _, id := range ids {
+		must(t, repos.Genres.Create(ctx, &genres.Genre{ID: id, Name: "Жанр"}), "create genre")
+	}
+}
+
+// TestGenreRepo проверяет контракт genres.GenreRepo.
+func TestGenreRepo(t *testing.T, newRepos Factory) {
+	subtest(t, "CRUD", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Genres.Create(ctx, &genres.Genre{ID: 1, Name: "Fiction", Names: map[string]string{"ru": "Художественная"}}), "create 1")
+		must(t, repos.Genres.Create(ctx, &genres.Genre{ID: 2, ParentID: 1, Name: "Novel"}), "create 2")
+
+		got, err := repos.Genres.GetByID(ctx, 1)
+		must(t, err, "get")
+		if got.Name != "Fiction" || got.ParentID != 0 || got.Names["ru"] != "Художественная" || len(got.Names) != 1 {
+			t.Fatalf("got %+v", got)
+		}
+		got, err = repos.Genres.GetByID(ctx, 2)
+		must(t, err, "get child")
+		if got.ParentID != 1 || got.Names == nil || len(got.Names) != 0 {
+			t.Fatalf("got child %+v", got)
+		}
+
+		// Update заменяет названия целиком.
+		must(t, repos.Genres.Update(ctx, &genres.Genre{ID: 1, Name: "Prose", Names: map[string]string{"en": "Prose"}}), "update")
+		got, err = repos.Genres.GetByID(ctx, 1)
+		must(t, err, "get after update")
+		if got.Name != "Prose" || len(got.Names) != 1 || got.Names["en"] != "Prose" {
+			t.Fatalf("update not applied: %+v", got)
+		}
+
+		list, err := repos.Genres.List(ctx)
+		must(t, err, "list")
+		if len(list) != 2 || list[0].ID != 1 || list[1].ID != 2 || list[0].Names["en"] != "Prose" {
+			t.Fatalf("list: got %+v", list)
+		}
+
+		must(t, repos.Genres.Delete(ctx, 2), "delete")
+		if _, err := repos.Genres.GetByID(ctx, 2); !errors.Is(err, genres.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after delete, got %v", err)
+		}
+		// Удаление отсутствующей записи не ошибка.
+		must(t, repos.Genres.Delete(ctx, 2), "delete missing")
+	})
+
+	subtest(t, "NotFound", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		if _, err := repos.Genres.GetByID(ctx, 404); !errors.Is(err, genres.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "UnknownParent", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		if err := repos.Genres.Create(ctx, &genres.Genre{ID: 1, ParentID: 99, Name: "X"}); err == nil {
+			t.Fatal("expected error for a missing parent")
+		}
+	})
+
+	subtest(t, "DeleteParent", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Genres.Create(ctx, &genres.Genre{ID: 1, Name: "A"}), "create parent")
+		must(t, repos.Genres.Create(ctx, &genres.Genre{ID: 2, ParentID: 1, Name: "B"}), "create child")
+		if err := repos.Genres.Delete(ctx, 1); err == nil {
+			t.Fatal("expected error when deleting a genre with children")
+		}
+		if _, err := repos.Genres.GetByID(ctx, 1); err != nil {
+			t.Fatalf("parent must survive the failed delete: %v", err)
+		}
+	})
+
+	subtest(t, "DeleteLinkedToBook", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedGenres(t, ctx, repos, 1)
+		book := newBook(t, 10, "Книга")
+		book.SetGenreIDs([]int{1})
+		must(t, repos.Books.Create(ctx, book), "create book")
+
+		if err := repos.Genres.Delete(ctx, 1); err == nil {
+			t.Fatal("expected error when deleting a genre linked to a book")
+		}
+		if _, err := repos.Genres.GetByID(ctx, 1); err != nil {
+			t.Fatalf("genre must survive the failed delete: %v", err)
+		}
+	})
+}
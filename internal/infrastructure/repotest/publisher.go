@@ -0,0 +1,92 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// TestPublisherRepo проверяет контракт publishers.PublisherRepo и привязку книг.
+func TestPublisherRepo(t *testing.T, newRepos Factory) {
+	subtest(t, "CRUD", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Publishers.Create(ctx, &publishers.Publisher{ID: 1, Name: "АСТ", City: "Москва", Country: "Россия"}), "create 1")
+		must(t, repos.Publishers.Create(ctx, &publishers.Publisher{ID: 2, Name: "Penguin Books"}), "create 2")
+
+		got, err := repos.Publishers.GetById(ctx, 1)
+		must(t, err, "get")
+		if got.Name != "АСТ" || got.City != "Москва" || got.Country != "Россия" {
+			t.Fatalf("got %+v", got)
+		}
+
+		must(t, repos.Publishers.Update(ctx, &publishers.Publisher{ID: 2, Name: "Penguin Books", City: "London", Country: "United Kingdom"}), "update")
+		got, err = repos.Publishers.GetById(ctx, 2)
+		must(t, err, "get after update")
+		if got.City != "London" || got.Country != "United Kingdom" {
+			t.Fatalf("update not applied: %+v", got)
+		}
+
+		list, err := repos.Publishers.List(ctx)
+		must(t, err, "list")
+		if len(list) != 2 || list[0].ID != 1 || list[1].ID != 2 {
+			t.Fatalf("list: got %+v", list)
+		}
+
+		must(t, repos.Publishers.Delete(ctx, 2), "delete")
+		if _, err := repos.Publishers.GetById(ctx, 2); !errors.Is(err, publishers.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after delete, got %v", err)
+		}
+	})
+
+	subtest(t, "Books", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Publishers.Create(ctx, &publishers.Publisher{ID: 1, Name: "АСТ"}), "create publisher")
+		first := newBook(t, 10, "Первая")
+		first.PublisherID = 1
+		must(t, repos.Books.Create(ctx, first), "create first")
+		must(t, repos.Books.Create(ctx, newBook(t, 11, "Без издательства")), "create standalone")
+		second := newBook(t, 12, "Вторая")
+		must(t, repos.Books.Create(ctx, second), "create second")
+		second.PublisherID = 1
+		must(t, repos.Books.Update(ctx, second), "link second")
+
+		got, err := repos.Books.GetByID(ctx, 10)
+		must(t, err, "get first")
+		if got.PublisherID != 1 {
+			t.Fatalf("publisher not stored: %+v", got)
+		}
+		got, err = repos.Books.GetByID(ctx, 11)
+		must(t, err, "get standalone")
+		if got.PublisherID != 0 {
+			t.Fatalf("book without publisher: got PublisherID %d", got.PublisherID)
+		}
+
+		var ids []int
+		err = repos.Books.Iterate(ctx, books.Filter{PublisherID: 1}, func(b *books.Book) error {
+			ids = append(ids, b.ID)
+			return nil
+		})
+		must(t, err, "iterate by publisher")
+		if !slices.Equal(ids, []int{10, 12}) {
+			t.Fatalf("iterate by publisher: got %v", ids)
+		}
+
+		if err := repos.Publishers.Delete(ctx, 1); err == nil {
+			t.Fatal("expected error when deleting a publisher with books")
+		}
+		if _, err := repos.Publishers.GetById(ctx, 1); err != nil {
+			t.Fatalf("publisher must survive the failed delete: %v", err)
+		}
+	})
+
+	subtest(t, "UnknownPublisher", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book := newBook(t, 10, "Книга")
+		book.PublisherID = 404
+		if err := repos.Books.Create(ctx, book); err == nil {
+			t.Fatal("expected error for a missing publisher")
+		}
+	})
+}
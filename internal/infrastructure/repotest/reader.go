@@ -0,0 +1,224 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+func newReader(t *testing.T, id int, email string) *readers.Reader {
+	t.Helper()
+	reader, err := readers.NewReader(id, "Читатель", "+70000000000", email, "secret", false)
+	must(t, err, "new reader")
+	return reader
+}
+
+// TestReaderRepo проверяет контракт readers.ReaderRepo.
+func TestReaderRepo(t *testing.T, newRepos Factory) {
+	subtest(t, "CreateGet", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		reader := newReader(t, 1, "a@example.com")
+		reader.Admin = true
+		must(t, repos.Readers.Create(ctx, reader), "create")
+
+		got, err := repos.Readers.GetById(ctx, 1)
+		must(t, err, "get")
+		if got.Name != reader.Name || got.Phone != reader.Phone || got.Email != reader.Email ||
+			got.Password != reader.Password || !got.Admin {
+			t.Fatalf("got %+v", got)
+		}
+
+		byEmail, err := repos.Readers.GetReaderByEmail(ctx, "a@example.com")
+		must(t, err, "get by email")
+		if byEmail.ID != 1 {
+			t.Fatalf("get by email: got id %d", byEmail.ID)
+		}
+	})
+
+	subtest(t, "NotFound", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		if _, err := repos.Readers.GetById(ctx, 404); !errors.Is(err, readers.ErrNotFound) {
+			t.Fatalf("GetById: expected ErrNotFound, got %v", err)
+		}
+		if _, err := repos.Readers.GetReaderByEmail(ctx, "none@example.com"); !errors.Is(err, readers.ErrNotFound) {
+			t.Fatalf("GetReaderByEmail: expected ErrNotFound, got %v", err)
+		}
+		if _, err := repos.Readers.GetReaderByOIDCSubject(ctx, "none"); !errors.Is(err, readers.ErrNotFound) {
+			t.Fatalf("GetReaderByOIDCSubject: expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "UpdateListDelete", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Readers.Create(ctx, newReader(t, 1, "a@example.com")), "create 1")
+		must(t, repos.Readers.Create(ctx, newReader(t, 2, "b@example.com")), "create 2")
+
+		updated := newReader(t, 1, "c@example.com")
+		updated.Name = "Новое имя"
+		must(t, repos.Readers.Update(ctx, updated), "update")
+		got, err := repos.Readers.GetById(ctx, 1)
+		must(t, err, "get")
+		if got.Name != "Новое имя" || got.Email != "c@example.com" {
+			t.Fatalf("update not applied: %+v", got)
+		}
+
+		list, err := repos.Readers.List(ctx)
+		must(t, err, "list")
+		var ids []int
+		for _, r := range list {
+			ids = append(ids, r.ID)
+		}
+		if !equalInts(ids, []int{1, 2}) {
+			t.Fatalf("list: got %v", ids)
+		}
+
+		ids = nil
+		err = repos.Readers.Iterate(ctx, func(r *readers.Reader) error {
+			ids = append(ids, r.ID)
+			return nil
+		})
+		must(t, err, "iterate")
+		if !slices.Equal(ids, []int{1, 2}) {
+			t.Fatalf("iterate must go in id order, got %v", ids)
+		}
+
+		must(t, repos.Readers.Delete(ctx, 1), "delete")
+		if _, err := repos.Readers.GetById(ctx, 1); !errors.Is(err, readers.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after delete, got %v", err)
+		}
+	})
+
+	subtest(t, "Authenticate", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Readers.Create(ctx, newReader(t, 1, "a@example.com")), "create")
+
+		got, err := repos.Readers.Authenticate(ctx, "a@example.com", "secret")
+		must(t, err, "authenticate")
+		if got.ID != 1 {
+			t.Fatalf("authenticate: got id %d", got.ID)
+		}
+		if _, err := repos.Readers.Authenticate(ctx, "a@example.com", "wrong"); err == nil {
+			t.Fatal("expected error for wrong password")
+		}
+		if _, err := repos.Readers.Authenticate(ctx, "none@example.com", "secret"); err == nil {
+			t.Fatal("expected error for unknown email")
+		}
+	})
+
+	subtest(t, "TOTP", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		reader := newReader(t, 1, "a@example.com")
+		reader.TOTPSecret = "ignored"
+		reader.TOTPEnabled = true
+		must(t, repos.Readers.Create(ctx, reader), "create")
+
+		got, err := repos.Readers.GetById(ctx, 1)
+		must(t, err, "get")
+		if got.TOTPSecret != "" || got.TOTPEnabled {
+			t.Fatalf("Create must not write TOTP state: %+v", got)
+		}
+
+		reader.TOTPSecret = "JBSWY3DPEHPK3PXP"
+		reader.TOTPEnabled = true
+		reader.TOTPLastStep = 42
+		must(t, repos.Readers.UpdateTOTP(ctx, reader), "update totp")
+
+		// Обычное обновление профиля не сбрасывает второй фактор.
+		profile := newReader(t, 1, "a@example.com")
+		profile.Name = "Другое имя"
+		must(t, repos.Readers.Update(ctx, profile), "update")
+
+		got, err = repos.Readers.GetById(ctx, 1)
+		must(t, err, "get")
+		if got.TOTPSecret != "JBSWY3DPEHPK3PXP" || !got.TOTPEnabled || got.TOTPLastStep != 42 {
+			t.Fatalf("TOTP state not persisted: %+v", got)
+		}
+	})
+
+	subtest(t, "AdvanceTOTPStep", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Readers.Create(ctx, newReader(t, 1, "a@example.com")), "create")
+
+		// Один и тот же шаг, предъявленный одновременно, принимается один раз.
+		const attempts = 8
+		var wg sync.WaitGroup
+		advanced := make([]bool, attempts)
+		errs := make([]error, attempts)
+		for i := range attempts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				advanced[i], errs[i] = repos.Readers.AdvanceTOTPStep(ctx, 1, 42)
+			}()
+		}
+		wg.Wait()
+		accepted := 0
+		for i := range attempts {
+			must(t, errs[i], "advance")
+			if advanced[i] {
+				accepted++
+			}
+		}
+		if accepted != 1 {
+			t.Fatalf("step 42 accepted %d times, want once", accepted)
+		}
+
+		ok, err := repos.Readers.AdvanceTOTPStep(ctx, 1, 41)
+		must(t, err, "advance back")
+		if ok {
+			t.Fatal("earlier step accepted")
+		}
+		ok, err = repos.Readers.AdvanceTOTPStep(ctx, 1, 43)
+		must(t, err, "advance forward")
+		if !ok {
+			t.Fatal("next step rejected")
+		}
+		got, err := repos.Readers.GetById(ctx, 1)
+		must(t, err, "get")
+		if got.TOTPLastStep != 43 {
+			t.Fatalf("last step = %d, want 43", got.TOTPLastStep)
+		}
+	})
+
+	subtest(t, "RecoveryCodes", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Readers.Create(ctx, newReader(t, 1, "a@example.com")), "create")
+		must(t, repos.Readers.ReplaceRecoveryCodes(ctx, 1, []string{"h1", "h2"}), "replace")
+		must(t, repos.Readers.ReplaceRecoveryCodes(ctx, 1, []string{"h3"}), "replace again")
+
+		ok, err := repos.Readers.ConsumeRecoveryCode(ctx, 1, "h1")
+		must(t, err, "consume replaced")
+		if ok {
+			t.Fatal("replaced code must not be accepted")
+		}
+		ok, err = repos.Readers.ConsumeRecoveryCode(ctx, 1, "h3")
+		must(t, err, "consume")
+		if !ok {
+			t.Fatal("valid code rejected")
+		}
+		ok, err = repos.Readers.ConsumeRecoveryCode(ctx, 1, "h3")
+		must(t, err, "consume twice")
+		if ok {
+			t.Fatal("code must be consumed once")
+		}
+	})
+
+	subtest(t, "OIDCSubject", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		first := newReader(t, 1, "a@example.com")
+		first.OIDCSubject = "sub-1"
+		must(t, repos.Readers.Create(ctx, first), "create")
+		// Пустой субъект не участвует в уникальности.
+		must(t, repos.Readers.Create(ctx, newReader(t, 2, "b@example.com")), "create without subject")
+		must(t, repos.Readers.Create(ctx, newReader(t, 3, "c@example.com")), "create another without subject")
+
+		got, err := repos.Readers.GetReaderByOIDCSubject(ctx, "sub-1")
+		must(t, err, "get by subject")
+		if got.ID != 1 {
+			t.Fatalf("get by subject: got id %d", got.ID)
+		}
+
+		dup := newReader(t, 4, "d@example.com")
+		dup.OIDCSubject = "sub-1"
+		if err := repos.Readers.Create(ctx, dup); err == nil {
+			t.Fatal("expected error for duplicate oidc subject")
+		}
+	})
+}
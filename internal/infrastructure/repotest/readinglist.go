@@ -0,0 +1,205 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/readinglists"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// seedReadingListDeps создаёт книги 10–12 и читателей 1 и 2.
+func seedReadingListDeps(t *testing.T, ctx context.Context, repos storage.Repositories) {
+	t.Helper()
+	for _, id := range []int{10, 11, 12} {
+		must(t, repos.Books.Create(ctx, newBook(t, id, "Книга")), "create book")
+	}
+	must(t, repos.Readers.Create(ctx, newReader(t, 1, "a@example.com")), "create reader 1")
+	must(t, repos.Readers.Create(ctx, newReader(t, 2, "b@example.com")), "create reader 2")
+}
+
+func newReadingList(t *testing.T, id, readerID int, name string, at time.Time, bookIDs ...int) *readinglists.ReadingList {
+	t.Helper()
+	list, err := readinglists.NewReadingList(id, readerID, name, at)
+	must(t, err, "new reading list")
+	for _, bookID := range bookIDs {
+		must(t, list.Add(bookID), "add book")
+	}
+	return list
+}
+
+// TestReadingListRepo проверяет контракт readinglists.ReadingListRepo.
+func TestReadingListRepo(t *testing.T, newRepos Factory) {
+	at := time.Date(2025, 3, 1, 12, 30, 15, 123456000, time.UTC)
+
+	subtest(t, "CreateGet", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReadingListDeps(t, ctx, repos)
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 100, 1, "На лето", at, 12, 10)), "create")
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 101, 1, "Пустой", at)), "create empty")
+
+		got, err := repos.ReadingLists.GetByID(ctx, 100)
+		must(t, err, "get")
+		if got.ReaderID != 1 || got.Name != "На лето" || got.Public || got.Slug != "" ||
+			!slices.Equal(got.BookIDs, []int{12, 10}) || !got.CreatedAt.Equal(at) {
+			t.Fatalf("got %+v", got)
+		}
+		got, err = repos.ReadingLists.GetByID(ctx, 101)
+		must(t, err, "get empty")
+		if got.BookIDs == nil || len(got.BookIDs) != 0 {
+			t.Fatalf("expected empty book list, got %#v", got.BookIDs)
+		}
+		if _, err := repos.ReadingLists.GetByID(ctx, 404); !errors.Is(err, readinglists.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "UnknownRefs", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReadingListDeps(t, ctx, repos)
+		if err := repos.ReadingLists.Create(ctx, newReadingList(t, 100, 404, "Список", at)); err == nil {
+			t.Fatal("expected error for unknown reader")
+		}
+		if err := repos.ReadingLists.Create(ctx, newReadingList(t, 100, 1, "Список", at, 404)); err == nil {
+			t.Fatal("expected error for unknown book")
+		}
+		if _, err := repos.ReadingLists.GetByID(ctx, 100); !errors.Is(err, readinglists.ErrNotFound) {
+			t.Fatalf("failed create must not leave the list, got %v", err)
+		}
+	})
+
+	subtest(t, "UpdateShare", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReadingListDeps(t, ctx, repos)
+		list := newReadingList(t, 100, 1, "Список", at, 10, 11)
+		must(t, repos.ReadingLists.Create(ctx, list), "create")
+
+		must(t, list.Rename("Прочитать"), "rename")
+		list.Share("abc123")
+		must(t, list.Remove(10), "remove")
+		must(t, list.Add(12), "add")
+		must(t, list.Reorder([]int{12, 11}), "reorder")
+		must(t, repos.ReadingLists.Update(ctx, list), "update")
+
+		got, err := repos.ReadingLists.GetBySlug(ctx, "abc123")
+		must(t, err, "get by slug")
+		if got.ID != 100 || got.Name != "Прочитать" || !got.Public || !slices.Equal(got.BookIDs, []int{12, 11}) {
+			t.Fatalf("update not applied: %+v", got)
+		}
+
+		// Второй список не может занять ту же ссылку.
+		other := newReadingList(t, 101, 2, "Чужой", at)
+		must(t, repos.ReadingLists.Create(ctx, other), "create other")
+		other.Share("abc123")
+		if err := repos.ReadingLists.Update(ctx, other); err == nil {
+			t.Fatal("expected error for a duplicate slug")
+		}
+
+		list.Unshare()
+		must(t, repos.ReadingLists.Update(ctx, list), "unshare")
+		if _, err := repos.ReadingLists.GetBySlug(ctx, "abc123"); !errors.Is(err, readinglists.ErrNotFound) {
+			t.Fatalf("closed list must not be found by slug, got %v", err)
+		}
+		got, err = repos.ReadingLists.GetByID(ctx, 100)
+		must(t, err, "get")
+		if got.Public || got.Slug != "" {
+			t.Fatalf("list must be private: %+v", got)
+		}
+	})
+
+	subtest(t, "Modify", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReadingListDeps(t, ctx, repos)
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 100, 1, "Список", at, 10)), "create")
+
+		// Параллельные правки не затирают друг друга.
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, bookID := range []int{11, 12} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = repos.ReadingLists.Modify(ctx, 100, func(list *readinglists.ReadingList) error {
+					return list.Add(bookID)
+				})
+			}()
+		}
+		wg.Wait()
+		for _, err := range errs {
+			must(t, err, "modify")
+		}
+		got, err := repos.ReadingLists.GetByID(ctx, 100)
+		must(t, err, "get")
+		if !slices.Equal(sortedInts(got.BookIDs), []int{10, 11, 12}) {
+			t.Fatalf("books after concurrent edits: %v", got.BookIDs)
+		}
+
+		// Ошибка fn отменяет изменение.
+		_, err = repos.ReadingLists.Modify(ctx, 100, func(list *readinglists.ReadingList) error {
+			must(t, list.Remove(10), "remove")
+			return readinglists.ErrNotListed
+		})
+		if !errors.Is(err, readinglists.ErrNotListed) {
+			t.Fatalf("expected fn error, got %v", err)
+		}
+		got, err = repos.ReadingLists.GetByID(ctx, 100)
+		must(t, err, "get")
+		if len(got.BookIDs) != 3 {
+			t.Fatalf("failed modify changed the list: %v", got.BookIDs)
+		}
+		if _, err := repos.ReadingLists.Modify(ctx, 404, func(*readinglists.ReadingList) error { return nil }); !errors.Is(err, readinglists.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "ListByReader", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReadingListDeps(t, ctx, repos)
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 101, 1, "Второй", at, 11)), "create 101")
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 100, 1, "Первый", at, 12, 10)), "create 100")
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 102, 2, "Чужой", at, 10)), "create 102")
+
+		lists, err := repos.ReadingLists.ListByReader(ctx, 1)
+		must(t, err, "list")
+		if len(lists) != 2 || lists[0].ID != 100 || lists[1].ID != 101 ||
+			!slices.Equal(lists[0].BookIDs, []int{12, 10}) || !slices.Equal(lists[1].BookIDs, []int{11}) {
+			t.Fatalf("got %+v", lists)
+		}
+	})
+
+	subtest(t, "Cascade", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReadingListDeps(t, ctx, repos)
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 100, 1, "Список", at, 10, 11, 12)), "create 100")
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 101, 2, "Список", at, 11)), "create 101")
+
+		// Удалённая книга исчезает из списков, порядок остальных сохраняется.
+		must(t, repos.Books.Delete(ctx, 11), "delete book")
+		got, err := repos.ReadingLists.GetByID(ctx, 100)
+		must(t, err, "get")
+		if !slices.Equal(got.BookIDs, []int{10, 12}) {
+			t.Fatalf("got %v", got.BookIDs)
+		}
+
+		// Списки удалённого читателя удаляются.
+		must(t, repos.Readers.Delete(ctx, 2), "delete reader")
+		if _, err := repos.ReadingLists.GetByID(ctx, 101); !errors.Is(err, readinglists.ErrNotFound) {
+			t.Fatalf("list of deleted reader must be gone, got %v", err)
+		}
+	})
+
+	subtest(t, "Delete", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReadingListDeps(t, ctx, repos)
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 100, 1, "Список", at, 10)), "create")
+		must(t, repos.ReadingLists.Delete(ctx, 100), "delete")
+		if _, err := repos.ReadingLists.GetByID(ctx, 100); !errors.Is(err, readinglists.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after delete, got %v", err)
+		}
+		// Список с тем же ID создаётся заново без старых книг.
+		must(t, repos.ReadingLists.Create(ctx, newReadingList(t, 100, 1, "Список", at, 11)), "recreate")
+		got, err := repos.ReadingLists.GetByID(ctx, 100)
+		must(t, err, "get")
+		if !slices.Equal(got.BookIDs, []int{11}) {
+			t.Fatalf("stale books: %v", got.BookIDs)
+		}
+		must(t, repos.ReadingLists.Delete(ctx, 404), "delete missing")
+	})
+}
@@ -0,0 +1,81 @@
+// Package repotest — общий набор контрактных тестов для реализаций
+// репозиториев. Пакет хранилища подключает его из своего _test.go:
+//
+//	func TestMemory(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) storage.Repositories { return storage.NewMemory() })
+//	}
+//
+// Фабрика вызывается для каждого подтеста и должна возвращать пустое хранилище.
+package repotest
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap/zaptest"
+)
+
+// Factory возвращает репозитории поверх чистого хранилища.
+type Factory func(t *testing.T) storage.Repositories
+
+// Run прогоняет все контракты.
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("AuthorRepo", func(t *testing.T) { TestAuthorRepo(t, newRepos) })
+	t.Run("BookRepo", func(t *testing.T) { TestBookRepo(t, newRepos) })
+	t.Run("CoverRepo", func(t *testing.T) { TestCoverRepo(t, newRepos) })
+	t.Run("GenreRepo", func(t *testing.T) { TestGenreRepo(t, newRepos) })
+	t.Run("PublisherRepo", func(t *testing.T) { TestPublisherRepo(t, newRepos) })
+	t.Run("ReaderRepo", func(t *testing.T) { TestReaderRepo(t, newRepos) })
+	t.Run("ReadingListRepo", func(t *testing.T) { TestReadingListRepo(t, newRepos) })
+	t.Run("ReservationRepo", func(t *testing.T) { TestReservationRepo(t, newRepos) })
+	t.Run("ReviewRepo", func(t *testing.T) { TestReviewRepo(t, newRepos) })
+	t.Run("SimilarityRepo", func(t *testing.T) { TestSimilarityRepo(t, newRepos) })
+	t.Run("TagRepo", func(t *testing.T) { TestTagRepo(t, newRepos) })
+	t.Run("Transactions", func(t *testing.T) { TestTransactions(t, newRepos) })
+	t.Run("WorkRepo", func(t *testing.T) { TestWorkRepo(t, newRepos) })
+}
+
+// Context возвращает контекст с логгером теста: репозитории берут логгер
+// из контекста.
+func Context(t *testing.T) context.Context {
+	lg := &logger.Logger{SugaredLogger: zaptest.NewLogger(t).Sugar()}
+	return logger.WithLogger(context.Background(), lg)
+}
+
+// subtest запускает случай на свежем хранилище.
+func subtest(t *testing.T, name string, newRepos Factory, fn func(t *testing.T, ctx context.Context, repos storage.Repositories)) {
+	t.Run(name, func(t *testing.T) {
+		fn(t, Context(t), newRepos(t))
+	})
+}
+
+func must(t *testing.T, err error, what string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %v", what, err)
+	}
+}
+
+// sortedInts возвращает отсортированную копию: порядок связей и строк
+// без ORDER BY контрактом не гарантируется.
+func sortedInts(ids []int) []int {
+	out := append([]int(nil), ids...)
+	sort.Ints(out)
+	return out
+}
+
+func equalInts(a, b []int) bool {
+	a, b = sortedInts(a), sortedInts(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
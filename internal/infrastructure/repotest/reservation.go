@@ -0,0 +1,320 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// seedReservationDeps создаёт книгу 10 и читателя 1 для бронирований.
+func seedReservationDeps(t *testing.T, ctx context.Context, repos storage.Repositories) (books.Book, readers.Reader) {
+	t.Helper()
+	book := newBook(t, 10, "Книга")
+	must(t, repos.Books.Create(ctx, book), "create book")
+	reader := newReader(t, 1, "a@example.com")
+	must(t, repos.Readers.Create(ctx, reader), "create reader")
+	return *book, *reader
+}
+
+// TestReservationRepo проверяет контракт reservations.ReservationRepo.
+func TestReservationRepo(t *testing.T, newRepos Factory) {
+	subtest(t, "CreateGet", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		// Время суток отбрасывается: колонки хранят только дату.
+		start := time.Date(2025, 3, 1, 15, 30, 0, 0, time.UTC)
+		_, err := repos.Reservations.Create(ctx, 100, book, reader, start, date(2025, 3, 10))
+		must(t, err, "create")
+
+		got, err := repos.Reservations.GetById(ctx, 100)
+		must(t, err, "get")
+		if got.Book.ID != 10 || got.Reader.ID != 1 {
+			t.Fatalf("got book %d reader %d", got.Book.ID, got.Reader.ID)
+		}
+		if !got.StartDate.Equal(date(2025, 3, 1)) || !got.EndDate.Equal(date(2025, 3, 10)) {
+			t.Fatalf("got dates %s..%s", got.StartDate, got.EndDate)
+		}
+	})
+
+	subtest(t, "InvalidRange", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		if _, err := repos.Reservations.Create(ctx, 100, book, reader, date(2025, 3, 10), date(2025, 3, 1)); err == nil {
+			t.Fatal("expected error for end date before start date")
+		}
+	})
+
+	subtest(t, "MissingBookOrReader", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		_, reader := seedReservationDeps(t, ctx, repos)
+		if _, err := repos.Reservations.Create(ctx, 100, books.Book{ID: 404}, reader, date(2025, 3, 1), date(2025, 3, 2)); err == nil {
+			t.Fatal("expected error for a missing book")
+		}
+		book := books.Book{ID: 10}
+		if _, err := repos.Reservations.Create(ctx, 101, book, readers.Reader{ID: 404}, date(2025, 3, 1), date(2025, 3, 2)); err == nil {
+			t.Fatal("expected error for a missing reader")
+		}
+	})
+
+	subtest(t, "NotFound", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		if _, err := repos.Reservations.GetById(ctx, 404); !errors.Is(err, reservations.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "UpdateDelete", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		_, err := repos.Reservations.Create(ctx, 100, book, reader, date(2025, 3, 1), date(2025, 3, 10))
+		must(t, err, "create")
+
+		must(t, repos.Reservations.Update(ctx, 100, book, reader, date(2025, 4, 1), date(2025, 4, 5)), "update")
+		got, err := repos.Reservations.GetById(ctx, 100)
+		must(t, err, "get")
+		if !got.StartDate.Equal(date(2025, 4, 1)) || !got.EndDate.Equal(date(2025, 4, 5)) {
+			t.Fatalf("update not applied: %s..%s", got.StartDate, got.EndDate)
+		}
+		if err := repos.Reservations.Update(ctx, 100, book, reader, date(2025, 4, 5), date(2025, 4, 1)); err == nil {
+			t.Fatal("expected error for end date before start date")
+		}
+
+		must(t, repos.Reservations.Delete(ctx, 100), "delete")
+		if _, err := repos.Reservations.GetById(ctx, 100); !errors.Is(err, reservations.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after delete, got %v", err)
+		}
+	})
+
+	subtest(t, "ListByDateRange", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		ranges := map[int][2]time.Time{
+			100: {date(2025, 3, 1), date(2025, 3, 10)},
+			101: {date(2025, 3, 5), date(2025, 3, 31)},
+			102: {date(2025, 2, 20), date(2025, 3, 3)},
+			103: {date(2025, 4, 1), date(2025, 4, 2)},
+		}
+		for id, r := range ranges {
+			_, err := repos.Reservations.Create(ctx, id, book, reader, r[0], r[1])
+			must(t, err, "create")
+		}
+
+		// Границы включаются; бронирование должно целиком лежать в интервале.
+		list, err := repos.Reservations.List(ctx, date(2025, 3, 1), date(2025, 3, 31))
+		must(t, err, "list")
+		var ids []int
+		for _, res := range list {
+			ids = append(ids, res.ID)
+		}
+		if !equalInts(ids, []int{100, 101}) {
+			t.Fatalf("list: got %v", ids)
+		}
+
+		empty, err := repos.Reservations.List(ctx, date(2030, 1, 1), date(2030, 12, 31))
+		must(t, err, "list empty")
+		if len(empty) != 0 {
+			t.Fatalf("expected empty list, got %d", len(empty))
+		}
+	})
+
+	subtest(t, "Iterate", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		ranges := map[int][2]time.Time{
+			100: {date(2025, 3, 1), date(2025, 3, 10)},
+			101: {date(2025, 3, 5), date(2025, 3, 31)},
+			102: {date(2025, 2, 20), date(2025, 3, 3)},
+		}
+		for id, r := range ranges {
+			_, err := repos.Reservations.Create(ctx, id, book, reader, r[0], r[1])
+			must(t, err, "create")
+		}
+
+		collect := func(filter reservations.Filter) []int {
+			var ids []int
+			err := repos.Reservations.Iterate(ctx, filter, func(res *reservations.Reservation) error {
+				ids = append(ids, res.ID)
+				return nil
+			})
+			must(t, err, "iterate")
+			return ids
+		}
+		// Нулевые границы не ограничивают выборку; заданные работают как в List.
+		if got := collect(reservations.Filter{}); !slices.Equal(got, []int{100, 101, 102}) {
+			t.Fatalf("iterate all: got %v", got)
+		}
+		if got := collect(reservations.Filter{StartDate: date(2025, 3, 1)}); !slices.Equal(got, []int{100, 101}) {
+			t.Fatalf("iterate from: got %v", got)
+		}
+		if got := collect(reservations.Filter{EndDate: date(2025, 3, 10)}); !slices.Equal(got, []int{100, 102}) {
+			t.Fatalf("iterate to: got %v", got)
+		}
+		if got := collect(reservations.Filter{BookID: book.ID, ReaderID: reader.ID, EndDate: date(2025, 3, 3)}); !slices.Equal(got, []int{102}) {
+			t.Fatalf("iterate by book and reader: got %v", got)
+		}
+		if got := collect(reservations.Filter{ReaderID: reader.ID + 1}); len(got) != 0 {
+			t.Fatalf("iterate by other reader: got %v", got)
+		}
+	})
+
+	subtest(t, "CountOverdue", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		_, err := repos.Reservations.Create(ctx, 100, book, reader, date(2025, 3, 1), date(2025, 3, 9))
+		must(t, err, "create 100")
+		_, err = repos.Reservations.Create(ctx, 101, book, reader, date(2025, 3, 1), date(2025, 3, 10))
+		must(t, err, "create 101")
+
+		_, err = repos.Reservations.Create(ctx, 102, book, reader, date(2025, 3, 1), date(2025, 3, 5))
+		must(t, err, "create 102")
+		must(t, repos.Reservations.MarkReturned(ctx, 102, date(2025, 3, 7)), "return 102")
+
+		// Бронирование, заканчивающееся сегодня, ещё не просрочено;
+		// возвращённое не просрочено, даже если вернули поздно.
+		count, err := repos.Reservations.CountOverdue(ctx, time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC))
+		must(t, err, "count overdue")
+		if count != 1 {
+			t.Fatalf("expected 1 overdue, got %d", count)
+		}
+	})
+
+	subtest(t, "MarkReturned", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		_, err := repos.Reservations.Create(ctx, 100, book, reader, date(2025, 3, 1), date(2025, 3, 10))
+		must(t, err, "create")
+		got, err := repos.Reservations.GetById(ctx, 100)
+		must(t, err, "get")
+		if got.ReturnedDate != nil {
+			t.Fatalf("new reservation returned on %s", got.ReturnedDate)
+		}
+
+		must(t, repos.Reservations.MarkReturned(ctx, 100, date(2025, 3, 8)), "return")
+		// Изменение срока не сбрасывает возврат.
+		must(t, repos.Reservations.Update(ctx, 100, book, reader, date(2025, 3, 1), date(2025, 3, 12)), "update")
+		got, err = repos.Reservations.GetById(ctx, 100)
+		must(t, err, "get returned")
+		if got.ReturnedDate == nil || !got.ReturnedDate.Equal(date(2025, 3, 8)) {
+			t.Fatalf("returned date = %v, want 2025-03-08", got.ReturnedDate)
+		}
+
+		if err := repos.Reservations.MarkReturned(ctx, 404, date(2025, 3, 8)); !errors.Is(err, reservations.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "BusyBooks", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		must(t, repos.Books.Create(ctx, newBook(t, 11, "Другое издание")), "create book 11")
+		must(t, repos.Books.Create(ctx, newBook(t, 12, "Третье издание")), "create book 12")
+		_, err := repos.Reservations.Create(ctx, 100, book, reader, date(2025, 3, 1), date(2025, 3, 10))
+		must(t, err, "create 100")
+		_, err = repos.Reservations.Create(ctx, 101, books.Book{ID: 11}, reader, date(2025, 3, 20), date(2025, 3, 25))
+		must(t, err, "create 101")
+
+		// Пересечение по границе считается занятостью.
+		busy, err := repos.Reservations.BusyBooks(ctx, []int{12, 11, 10}, date(2025, 3, 10), date(2025, 3, 20))
+		must(t, err, "busy")
+		if !slices.Equal(busy, []int{10, 11}) {
+			t.Fatalf("busy: got %v", busy)
+		}
+		busy, err = repos.Reservations.BusyBooks(ctx, []int{10, 11}, date(2025, 3, 11), date(2025, 3, 19))
+		must(t, err, "busy between")
+		if len(busy) != 0 {
+			t.Fatalf("busy between reservations: got %v", busy)
+		}
+		// Книги вне списка не выдаются.
+		busy, err = repos.Reservations.BusyBooks(ctx, []int{12}, date(2025, 3, 1), date(2025, 3, 31))
+		must(t, err, "busy other")
+		if len(busy) != 0 {
+			t.Fatalf("busy for unreserved book: got %v", busy)
+		}
+		// Возвращённая книга свободна до конца срока бронирования.
+		must(t, repos.Reservations.MarkReturned(ctx, 100, date(2025, 3, 5)), "return 100")
+		busy, err = repos.Reservations.BusyBooks(ctx, []int{10, 11}, date(2025, 3, 6), date(2025, 3, 20))
+		must(t, err, "busy after return")
+		if !slices.Equal(busy, []int{11}) {
+			t.Fatalf("busy after return: got %v", busy)
+		}
+		got, err := repos.Reservations.CreateFirstFree(ctx, 102, []int{10, 11}, reader, date(2025, 3, 6), date(2025, 3, 20))
+		must(t, err, "create first free after return")
+		if got.Book.ID != 10 {
+			t.Fatalf("got book %d, want returned book 10", got.Book.ID)
+		}
+	})
+
+	subtest(t, "CreateFirstFree", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		must(t, repos.Books.Create(ctx, newBook(t, 11, "Другое издание")), "create book 11")
+		_, err := repos.Reservations.Create(ctx, 100, book, reader, date(2025, 3, 1), date(2025, 3, 10))
+		must(t, err, "create 100")
+
+		// Книги перебираются в порядке кандидатов; занятая пропускается.
+		got, err := repos.Reservations.CreateFirstFree(ctx, 101, []int{10, 11}, reader, date(2025, 3, 5), date(2025, 3, 6))
+		must(t, err, "create first free")
+		if got.ID != 101 || got.Book.ID != 11 {
+			t.Fatalf("got reservation %d of book %d, want 101 of book 11", got.ID, got.Book.ID)
+		}
+		if _, err := repos.Reservations.CreateFirstFree(ctx, 102, []int{10, 11}, reader, date(2025, 3, 6), date(2025, 3, 8)); !errors.Is(err, reservations.ErrNoFreeBook) {
+			t.Fatalf("expected ErrNoFreeBook, got %v", err)
+		}
+		if _, err := repos.Reservations.GetById(ctx, 102); !errors.Is(err, reservations.ErrNotFound) {
+			t.Fatalf("rejected reservation was stored: %v", err)
+		}
+	})
+
+	subtest(t, "CreateFirstFreeConcurrent", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		_, reader := seedReservationDeps(t, ctx, repos)
+		must(t, repos.Books.Create(ctx, newBook(t, 11, "Другое издание")), "create book 11")
+
+		// Две книги на восемь одновременных запросов: выдать можно ровно две.
+		const attempts = 8
+		var wg sync.WaitGroup
+		errs := make([]error, attempts)
+		for i := range attempts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[i] = repos.Reservations.CreateFirstFree(ctx, 200+i, []int{10, 11}, reader, date(2025, 3, 1), date(2025, 3, 10))
+			}()
+		}
+		wg.Wait()
+
+		created := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				created++
+			case !errors.Is(err, reservations.ErrNoFreeBook):
+				t.Fatalf("create first free: %v", err)
+			}
+		}
+		busy, err := repos.Reservations.BusyBooks(ctx, []int{10, 11}, date(2025, 3, 1), date(2025, 3, 10))
+		must(t, err, "busy")
+		var reserved []int
+		err = repos.Reservations.Iterate(ctx, reservations.Filter{}, func(res *reservations.Reservation) error {
+			reserved = append(reserved, res.Book.ID)
+			return nil
+		})
+		must(t, err, "iterate")
+		if created != 2 || !slices.Equal(busy, []int{10, 11}) || !slices.Equal(sortedInts(reserved), []int{10, 11}) {
+			t.Fatalf("created %d reservations of books %v, want one per book", created, reserved)
+		}
+	})
+
+	subtest(t, "DeleteReferencedBook", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book, reader := seedReservationDeps(t, ctx, repos)
+		_, err := repos.Reservations.Create(ctx, 100, book, reader, date(2025, 3, 1), date(2025, 3, 10))
+		must(t, err, "create")
+
+		if err := repos.Books.Delete(ctx, book.ID); err == nil {
+			t.Fatal("expected error when deleting a reserved book")
+		}
+		if err := repos.Readers.Delete(ctx, reader.ID); err == nil {
+			t.Fatal("expected error when deleting a reader with reservations")
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reviews"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// seedReviewDeps создаёт книги 10 и 11 и читателей 1–3.
+func seedReviewDeps(t *testing.T, ctx context.Context, repos storage.Repositories) {
+	t.Helper()
+	must(t, repos.Books.Create(ctx, newBook(t, 10, "Книга")), "create book 10")
+	must(t, repos.Books.Create(ctx, newBook(t, 11, "Другая книга")), "create book 11")
+	for i, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		must(t, repos.Readers.Create(ctx, newReader(t, i+1, email)), "create reader")
+	}
+}
+
+func newReview(t *testing.T, id, bookID, readerID, rating int, text string, at time.Time) *reviews.Review {
+	t.Helper()
+	review, err := reviews.NewReview(id, bookID, readerID, rating, text, at)
+	must(t, err, "new review")
+	return review
+}
+
+func reviewIDs(list []reviews.Review) []int {
+	ids := make([]int, len(list))
+	for i, r := range list {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+// TestReviewRepo проверяет контракт reviews.ReviewRepo.
+func TestReviewRepo(t *testing.T, newRepos Factory) {
+	at := time.Date(2025, 3, 1, 12, 30, 15, 123456000, time.UTC)
+
+	subtest(t, "CreateGet", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReviewDeps(t, ctx, repos)
+		must(t, repos.Reviews.Create(ctx, newReview(t, 100, 10, 1, 4, "Хорошая книга", at)), "create")
+
+		got, err := repos.Reviews.GetByID(ctx, 100)
+		must(t, err, "get")
+		if got.BookID != 10 || got.ReaderID != 1 || got.Rating != 4 || got.Text != "Хорошая книга" ||
+			got.Status != reviews.StatusPending || got.Helpful != 0 || !got.CreatedAt.Equal(at) || !got.UpdatedAt.Equal(at) {
+			t.Fatalf("got %+v", got)
+		}
+		if _, err := repos.Reviews.GetByID(ctx, 404); !errors.Is(err, reviews.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	subtest(t, "OnePerReader", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReviewDeps(t, ctx, repos)
+		must(t, repos.Reviews.Create(ctx, newReview(t, 100, 10, 1, 4, "", at)), "create")
+		if err := repos.Reviews.Create(ctx, newReview(t, 101, 10, 1, 5, "", at)); err == nil {
+			t.Fatal("expected error for a second review of the same book")
+		}
+		must(t, repos.Reviews.Create(ctx, newReview(t, 102, 11, 1, 5, "", at)), "review another book")
+	})
+
+	subtest(t, "UnknownRefs", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReviewDeps(t, ctx, repos)
+		if err := repos.Reviews.Create(ctx, newReview(t, 100, 404, 1, 4, "", at)); err == nil {
+			t.Fatal("expected error for unknown book")
+		}
+		if err := repos.Reviews.Create(ctx, newReview(t, 100, 10, 404, 4, "", at)); err == nil {
+			t.Fatal("expected error for unknown reader")
+		}
+	})
+
+	subtest(t, "Update", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReviewDeps(t, ctx, repos)
+		review := newReview(t, 100, 10, 1, 4, "Текст", at)
+		must(t, repos.Reviews.Create(ctx, review), "create")
+
+		later := at.Add(time.Hour)
+		must(t, review.Edit(2, "Перечитал — хуже", later), "edit")
+		review.Status = reviews.StatusApproved
+		must(t, repos.Reviews.Update(ctx, review), "update")
+
+		got, err := repos.Reviews.GetByID(ctx, 100)
+		must(t, err, "get")
+		if got.Rating != 2 || got.Text != "Перечитал — хуже" || got.Status != reviews.StatusApproved ||
+			!got.CreatedAt.Equal(at) || !got.UpdatedAt.Equal(later) {
+			t.Fatalf("update not applied: %+v", got)
+		}
+	})
+
+	subtest(t, "ListSort", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReviewDeps(t, ctx, repos)
+		must(t, repos.Reviews.Create(ctx, newReview(t, 100, 10, 1, 5, "", at)), "create 100")
+		must(t, repos.Reviews.Create(ctx, newReview(t, 101, 10, 2, 3, "", at.Add(time.Hour))), "create 101")
+		must(t, repos.Reviews.Create(ctx, newReview(t, 102, 10, 3, 1, "Текст", at.Add(2*time.Hour))), "create 102")
+		must(t, repos.Reviews.Create(ctx, newReview(t, 103, 11, 1, 4, "", at)), "create 103")
+		// Отзыв 100 полезен двум читателям; повторная отметка не считается.
+		must(t, repos.Reviews.Vote(ctx, 100, 2), "vote")
+		must(t, repos.Reviews.Vote(ctx, 100, 3), "vote")
+		must(t, repos.Reviews.Vote(ctx, 100, 3), "vote again")
+		must(t, repos.Reviews.Vote(ctx, 101, 1), "vote")
+
+		list := func(filter reviews.Filter) []int {
+			got, err := repos.Reviews.List(ctx, filter)
+			must(t, err, "list")
+			return reviewIDs(got)
+		}
+		if got := list(reviews.Filter{BookID: 10}); !slices.Equal(got, []int{102, 101, 100}) {
+			t.Fatalf("recent: got %v", got)
+		}
+		if got := list(reviews.Filter{BookID: 10, Sort: reviews.SortHelpful}); !slices.Equal(got, []int{100, 101, 102}) {
+			t.Fatalf("helpful: got %v", got)
+		}
+		if got := list(reviews.Filter{BookID: 10, Status: reviews.StatusPending}); !slices.Equal(got, []int{102}) {
+			t.Fatalf("pending: got %v", got)
+		}
+		if got := list(reviews.Filter{ReaderID: 1}); !slices.Equal(got, []int{103, 100}) {
+			t.Fatalf("by reader: got %v", got)
+		}
+
+		got, err := repos.Reviews.GetByID(ctx, 100)
+		must(t, err, "get")
+		if got.Helpful != 2 {
+			t.Fatalf("helpful count: got %d", got.Helpful)
+		}
+		must(t, repos.Reviews.Unvote(ctx, 100, 2), "unvote")
+		must(t, repos.Reviews.Unvote(ctx, 100, 2), "unvote missing")
+		got, err = repos.Reviews.GetByID(ctx, 100)
+		must(t, err, "get")
+		if got.Helpful != 1 {
+			t.Fatalf("helpful count after unvote: got %d", got.Helpful)
+		}
+	})
+
+	subtest(t, "Ratings", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReviewDeps(t, ctx, repos)
+		rating, err := repos.Reviews.BookRating(ctx, 10)
+		must(t, err, "rating without reviews")
+		if rating != (books.Rating{}) {
+			t.Fatalf("expected empty rating, got %+v", rating)
+		}
+
+		must(t, repos.Reviews.Create(ctx, newReview(t, 100, 10, 1, 5, "", at)), "create")
+		must(t, repos.Reviews.Create(ctx, newReview(t, 101, 10, 2, 4, "", at)), "create")
+		must(t, repos.Reviews.Create(ctx, newReview(t, 102, 10, 3, 4, "", at)), "create")
+		// Отзыв на модерации в рейтинг не входит.
+		must(t, repos.Reviews.Create(ctx, newReview(t, 103, 11, 1, 1, "Текст", at)), "create pending")
+
+		want := books.Rating{Average: 4.33, Count: 3}
+		rating, err = repos.Reviews.BookRating(ctx, 10)
+		must(t, err, "rating")
+		if rating != want {
+			t.Fatalf("book rating: got %+v, want %+v", rating, want)
+		}
+		ratings, err := repos.Reviews.Ratings(ctx)
+		must(t, err, "ratings")
+		if len(ratings) != 1 || ratings[10] != want {
+			t.Fatalf("ratings: got %+v", ratings)
+		}
+	})
+
+	subtest(t, "Cascade", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReviewDeps(t, ctx, repos)
+		must(t, repos.Reviews.Create(ctx, newReview(t, 100, 10, 1, 5, "", at)), "create")
+		must(t, repos.Reviews.Create(ctx, newReview(t, 101, 11, 2, 5, "", at)), "create")
+		must(t, repos.Reviews.Vote(ctx, 101, 3), "vote")
+
+		// Удаление читателя удаляет его отзывы и отметки.
+		must(t, repos.Readers.Delete(ctx, 1), "delete reader")
+		if _, err := repos.Reviews.GetByID(ctx, 100); !errors.Is(err, reviews.ErrNotFound) {
+			t.Fatalf("review of deleted reader must be gone, got %v", err)
+		}
+		must(t, repos.Readers.Delete(ctx, 3), "delete voter")
+		got, err := repos.Reviews.GetByID(ctx, 101)
+		must(t, err, "get")
+		if got.Helpful != 0 {
+			t.Fatalf("vote of deleted reader must be gone, got %d", got.Helpful)
+		}
+
+		// Удаление книги удаляет отзывы о ней.
+		must(t, repos.Books.Delete(ctx, 11), "delete book")
+		if _, err := repos.Reviews.GetByID(ctx, 101); !errors.Is(err, reviews.ErrNotFound) {
+			t.Fatalf("review of deleted book must be gone, got %v", err)
+		}
+	})
+
+	subtest(t, "Delete", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedReviewDeps(t, ctx, repos)
+		must(t, repos.Reviews.Create(ctx, newReview(t, 100, 10, 1, 5, "", at)), "create")
+		must(t, repos.Reviews.Vote(ctx, 100, 2), "vote")
+		must(t, repos.Reviews.Delete(ctx, 100), "delete")
+		if _, err := repos.Reviews.GetByID(ctx, 100); !errors.Is(err, reviews.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after delete, got %v", err)
+		}
+		// Отзыв с тем же ID создаётся заново без старых отметок.
+		must(t, repos.Reviews.Create(ctx, newReview(t, 100, 10, 1, 5, "", at)), "recreate")
+		got, err := repos.Reviews.GetByID(ctx, 100)
+		must(t, err, "get")
+		if got.Helpful != 0 {
+			t.Fatalf("stale votes: %d", got.Helpful)
+		}
+		must(t, repos.Reviews.Delete(ctx, 404), "delete missing")
+	})
+}
@@ -0,0 +1,88 @@
+package repotest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/recommendations"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// TestSimilarityRepo проверяет контракт recommendations.SimilarityRepo.
+func TestSimilarityRepo(t *testing.T, newRepos Factory) {
+	seed := func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		t.Helper()
+		for _, id := range []int{10, 11, 12, 13} {
+			must(t, repos.Books.Create(ctx, newBook(t, id, "Книга")), "create book")
+		}
+	}
+
+	subtest(t, "ReplaceNeighbours", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seed(t, ctx, repos)
+		got, err := repos.Similarity.Neighbours(ctx, []int{10})
+		must(t, err, "neighbours before replace")
+		if len(got) != 0 {
+			t.Fatalf("expected no neighbours, got %+v", got)
+		}
+
+		first := map[int][]recommendations.Neighbour{
+			10: {{BookID: 12, Score: 0.5, Readers: 1}, {BookID: 11, Score: 0.5, Readers: 1}},
+			11: {{BookID: 10, Score: 0.5, Readers: 1}},
+		}
+		must(t, repos.Similarity.Replace(ctx, first), "replace")
+		got, err = repos.Similarity.Neighbours(ctx, []int{10, 11, 13})
+		must(t, err, "neighbours")
+		// Порядок соседей сохраняется как передан, книги без соседей не попадают.
+		if !reflect.DeepEqual(got, first) {
+			t.Fatalf("got %+v, want %+v", got, first)
+		}
+
+		second := map[int][]recommendations.Neighbour{
+			13: {{BookID: 12, Score: 0.816, Readers: 2}},
+		}
+		must(t, repos.Similarity.Replace(ctx, second), "replace again")
+		got, err = repos.Similarity.Neighbours(ctx, []int{10, 11, 12, 13})
+		must(t, err, "neighbours after replace")
+		if !reflect.DeepEqual(got, second) {
+			t.Fatalf("old neighbours must be gone: got %+v", got)
+		}
+		got, err = repos.Similarity.Neighbours(ctx, nil)
+		must(t, err, "neighbours of no books")
+		if len(got) != 0 {
+			t.Fatalf("expected no neighbours, got %+v", got)
+		}
+	})
+
+	subtest(t, "UnknownBook", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seed(t, ctx, repos)
+		kept := map[int][]recommendations.Neighbour{10: {{BookID: 11, Score: 1, Readers: 1}}}
+		must(t, repos.Similarity.Replace(ctx, kept), "replace")
+		bad := map[int][]recommendations.Neighbour{10: {{BookID: 404, Score: 1, Readers: 1}}}
+		if err := repos.Similarity.Replace(ctx, bad); err == nil {
+			t.Fatal("expected error for unknown book")
+		}
+		// Неудачная замена не трогает прежних соседей.
+		got, err := repos.Similarity.Neighbours(ctx, []int{10})
+		must(t, err, "neighbours")
+		if !reflect.DeepEqual(got, kept) {
+			t.Fatalf("got %+v, want %+v", got, kept)
+		}
+	})
+
+	subtest(t, "CascadeBook", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seed(t, ctx, repos)
+		must(t, repos.Similarity.Replace(ctx, map[int][]recommendations.Neighbour{
+			10: {{BookID: 11, Score: 1, Readers: 2}, {BookID: 12, Score: 0.5, Readers: 1}},
+			11: {{BookID: 10, Score: 1, Readers: 2}},
+		}), "replace")
+
+		must(t, repos.Books.Delete(ctx, 11), "delete book")
+		got, err := repos.Similarity.Neighbours(ctx, []int{10, 11})
+		must(t, err, "neighbours")
+		want := map[int][]recommendations.Neighbour{10: {{BookID: 12, Score: 0.5, Readers: 1}}}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	})
+}
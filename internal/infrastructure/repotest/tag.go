@@ -0,0 +1,175 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/tags"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// seedTags создаёт теги: ID → имя.
+func seedTags(t *testing.T, ctx context.Context, repos storage.Repositories, names map[int]string) {
+	t.Helper()
+	for id, name := range names {
+		must(t, repos.Tags.Create(ctx, &tags.Tag{ID: id, Name: name}), "create tag")
+	}
+}
+
+func tagIDs(list []tags.Tag) []int {
+	ids := make([]int, 0, len(list))
+	for _, tag := range list {
+		ids = append(ids, tag.ID)
+	}
+	return ids
+}
+
+// TestTagRepo проверяет контракт tags.TagRepo.
+func TestTagRepo(t *testing.T, newRepos Factory) {
+	subtest(t, "CreateGetFind", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedTags(t, ctx, repos, map[int]string{1: "go"})
+
+		got, err := repos.Tags.GetByID(ctx, 1)
+		must(t, err, "get")
+		if got.Name != "go" || len(got.Aliases) != 0 {
+			t.Fatalf("got %+v", got)
+		}
+		got, err = repos.Tags.FindByName(ctx, "go")
+		must(t, err, "find")
+		if got.ID != 1 {
+			t.Fatalf("find: got %+v", got)
+		}
+		if _, err := repos.Tags.FindByName(ctx, "rust"); !errors.Is(err, tags.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+		if _, err := repos.Tags.GetByID(ctx, 404); !errors.Is(err, tags.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+		if err := repos.Tags.Create(ctx, &tags.Tag{ID: 2, Name: "go"}); err == nil {
+			t.Fatal("expected error for a duplicate name")
+		}
+	})
+
+	subtest(t, "BookTags", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedTags(t, ctx, repos, map[int]string{1: "go", 2: "concurrency"})
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "A")), "create book")
+
+		must(t, repos.Tags.AddToBook(ctx, 10, 1), "tag")
+		must(t, repos.Tags.AddToBook(ctx, 10, 2), "tag")
+		// Повторная пометка не ошибка.
+		must(t, repos.Tags.AddToBook(ctx, 10, 1), "tag again")
+
+		list, err := repos.Tags.BookTags(ctx, 10)
+		must(t, err, "book tags")
+		if got := tagIDs(list); !slices.Equal(got, []int{2, 1}) {
+			t.Fatalf("book tags must be ordered by name, got %v", got)
+		}
+
+		if err := repos.Tags.AddToBook(ctx, 404, 1); err == nil {
+			t.Fatal("expected error for a missing book")
+		}
+		if err := repos.Tags.AddToBook(ctx, 10, 404); err == nil {
+			t.Fatal("expected error for a missing tag")
+		}
+
+		must(t, repos.Tags.RemoveFromBook(ctx, 10, 1), "untag")
+		must(t, repos.Tags.RemoveFromBook(ctx, 10, 1), "untag again")
+		list, err = repos.Tags.BookTags(ctx, 10)
+		must(t, err, "book tags after untag")
+		if got := tagIDs(list); !slices.Equal(got, []int{2}) {
+			t.Fatalf("got %v", got)
+		}
+
+		// Книга с тегами удаляется вместе со связями.
+		must(t, repos.Books.Delete(ctx, 10), "delete book")
+		counts, err := repos.Tags.Counts(ctx, 0)
+		must(t, err, "counts")
+		if len(counts) != 0 {
+			t.Fatalf("links must be removed with the book, got %+v", counts)
+		}
+	})
+
+	subtest(t, "CountsAndIterate", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedTags(t, ctx, repos, map[int]string{1: "b", 2: "a", 3: "c", 4: "unused"})
+		for id := 10; id <= 12; id++ {
+			must(t, repos.Books.Create(ctx, newBook(t, id, "Книга")), "create book")
+		}
+		for bookID, tagIDs := range map[int][]int{10: {1, 2, 3}, 11: {1, 2}, 12: {1}} {
+			for _, tagID := range tagIDs {
+				must(t, repos.Tags.AddToBook(ctx, bookID, tagID), "tag")
+			}
+		}
+
+		counts, err := repos.Tags.Counts(ctx, 0)
+		must(t, err, "counts")
+		want := []tags.TagCount{{ID: 1, Name: "b", Books: 3}, {ID: 2, Name: "a", Books: 2}, {ID: 3, Name: "c", Books: 1}}
+		if !slices.Equal(counts, want) {
+			t.Fatalf("counts: got %+v", counts)
+		}
+		counts, err = repos.Tags.Counts(ctx, 2)
+		must(t, err, "counts with limit")
+		if !slices.Equal(counts, want[:2]) {
+			t.Fatalf("counts with limit: got %+v", counts)
+		}
+
+		var ids []int
+		err = repos.Books.Iterate(ctx, books.Filter{TagID: 2}, func(b *books.Book) error {
+			ids = append(ids, b.ID)
+			return nil
+		})
+		must(t, err, "iterate by tag")
+		if !slices.Equal(ids, []int{10, 11}) {
+			t.Fatalf("iterate by tag: got %v", ids)
+		}
+	})
+
+	subtest(t, "Merge", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedTags(t, ctx, repos, map[int]string{1: "go", 2: "golang", 3: "go lang"})
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "A")), "create 10")
+		must(t, repos.Books.Create(ctx, newBook(t, 11, "B")), "create 11")
+		must(t, repos.Tags.AddToBook(ctx, 10, 1), "tag 10")
+		must(t, repos.Tags.AddToBook(ctx, 10, 2), "tag 10")
+		must(t, repos.Tags.AddToBook(ctx, 11, 3), "tag 11")
+
+		// Сначала golang поглощает «go lang», затем go — golang: синонимы
+		// переходят по цепочке.
+		must(t, repos.Tags.Merge(ctx, 2, []int{3}), "merge into golang")
+		must(t, repos.Tags.Merge(ctx, 1, []int{2}), "merge into go")
+
+		got, err := repos.Tags.GetByID(ctx, 1)
+		must(t, err, "get")
+		if !slices.Equal(got.Aliases, []string{"go lang", "golang"}) {
+			t.Fatalf("aliases: got %v", got.Aliases)
+		}
+		for _, id := range []int{2, 3} {
+			if _, err := repos.Tags.GetByID(ctx, id); !errors.Is(err, tags.ErrNotFound) {
+				t.Fatalf("merged tag %d must be deleted, got %v", id, err)
+			}
+		}
+		found, err := repos.Tags.FindByName(ctx, "go lang")
+		must(t, err, "find by alias")
+		if found.ID != 1 {
+			t.Fatalf("find by alias: got %+v", found)
+		}
+
+		counts, err := repos.Tags.Counts(ctx, 0)
+		must(t, err, "counts")
+		if !slices.Equal(counts, []tags.TagCount{{ID: 1, Name: "go", Books: 2}}) {
+			t.Fatalf("counts after merge: got %+v", counts)
+		}
+
+		// Удаление тега удаляет и синонимы.
+		must(t, repos.Tags.Delete(ctx, 1), "delete")
+		if _, err := repos.Tags.FindByName(ctx, "golang"); !errors.Is(err, tags.ErrNotFound) {
+			t.Fatalf("aliases must be deleted with the tag, got %v", err)
+		}
+		list, err := repos.Tags.BookTags(ctx, 10)
+		must(t, err, "book tags")
+		if len(list) != 0 {
+			t.Fatalf("links must be deleted with the tag, got %v", tagIDs(list))
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// TestTransactions проверяет Repositories.InTx.
+func TestTransactions(t *testing.T, newRepos Factory) {
+	subtest(t, "Commit", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		err := repos.InTx(ctx, func(tx storage.Repositories) error {
+			if err := tx.Authors.Create(ctx, &authors.Author{ID: 1, Name: "A"}); err != nil {
+				return err
+			}
+			// Внутри транзакции видны её собственные изменения.
+			if _, err := tx.Authors.GetById(ctx, 1); err != nil {
+				return err
+			}
+			return tx.Books.Create(ctx, newBook(t, 10, "Книга", 1))
+		})
+		must(t, err, "transaction")
+
+		got, err := repos.Books.GetByID(ctx, 10)
+		must(t, err, "get after commit")
+		if !equalInts(got.AuthorIDs(), []int{1}) {
+			t.Fatalf("got authors %v", got.AuthorIDs())
+		}
+	})
+
+	subtest(t, "Rollback", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		seedAuthors(t, ctx, repos, 1)
+		must(t, repos.Books.Create(ctx, newBook(t, 10, "Было", 1)), "create")
+
+		errAbort := errors.New("abort")
+		err := repos.InTx(ctx, func(tx storage.Repositories) error {
+			if err := tx.Authors.Create(ctx, &authors.Author{ID: 2, Name: "B"}); err != nil {
+				return err
+			}
+			if err := tx.Books.Update(ctx, newBook(t, 10, "Стало", 2)); err != nil {
+				return err
+			}
+			if err := tx.Books.Delete(ctx, 10); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			t.Fatalf("expected fn error, got %v", err)
+		}
+
+		if _, err := repos.Authors.GetById(ctx, 2); !errors.Is(err, authors.ErrNotFound) {
+			t.Fatalf("author must be rolled back, got %v", err)
+		}
+		got, err := repos.Books.GetByID(ctx, 10)
+		must(t, err, "book must survive rollback")
+		if got.Title != "Было" || !equalInts(got.AuthorIDs(), []int{1}) {
+			t.Fatalf("book changes must be rolled back: %+v %v", got, got.AuthorIDs())
+		}
+	})
+
+	subtest(t, "Nested", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		errAbort := errors.New("abort")
+		err := repos.InTx(ctx, func(tx storage.Repositories) error {
+			if err := tx.Authors.Create(ctx, &authors.Author{ID: 1, Name: "A"}); err != nil {
+				return err
+			}
+			return tx.InTx(ctx, func(inner storage.Repositories) error {
+				if err := inner.Books.Create(ctx, newBook(t, 10, "Книга", 1)); err != nil {
+					return err
+				}
+				return errAbort
+			})
+		})
+		if !errors.Is(err, errAbort) {
+			t.Fatalf("expected fn error, got %v", err)
+		}
+		if _, err := repos.Authors.GetById(ctx, 1); !errors.Is(err, authors.ErrNotFound) {
+			t.Fatalf("outer changes must be rolled back, got %v", err)
+		}
+		if _, err := repos.Books.GetByID(ctx, 10); !errors.Is(err, books.ErrNotFound) {
+			t.Fatalf("inner changes must be rolled back, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+)
+
+// TestWorkRepo проверяет контракт works.WorkRepo и привязку изданий.
+func TestWorkRepo(t *testing.T, newRepos Factory) {
+	subtest(t, "CRUD", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Works.Create(ctx, &works.Work{ID: 1, Title: "Война и мир"}), "create 1")
+		must(t, repos.Works.Create(ctx, &works.Work{ID: 2, Title: "Анна Каренина"}), "create 2")
+
+		got, err := repos.Works.GetByID(ctx, 1)
+		must(t, err, "get")
+		if got.Title != "Война и мир" {
+			t.Fatalf("got %+v", got)
+		}
+
+		must(t, repos.Works.Update(ctx, &works.Work{ID: 1, Title: "War and Peace"}), "update")
+		got, err = repos.Works.GetByID(ctx, 1)
+		must(t, err, "get after update")
+		if got.Title != "War and Peace" {
+			t.Fatalf("update not applied: %+v", got)
+		}
+
+		list, err := repos.Works.List(ctx)
+		must(t, err, "list")
+		if len(list) != 2 || list[0].ID != 1 || list[1].ID != 2 {
+			t.Fatalf("list: got %+v", list)
+		}
+
+		must(t, repos.Works.Delete(ctx, 2), "delete")
+		if _, err := repos.Works.GetByID(ctx, 2); !errors.Is(err, works.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound after delete, got %v", err)
+		}
+	})
+
+	subtest(t, "Editions", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		must(t, repos.Works.Create(ctx, &works.Work{ID: 1, Title: "Война и мир"}), "create work")
+		original := newBook(t, 10, "Война и мир")
+		original.WorkID, original.Language = 1, "ru"
+		translation := newBook(t, 11, "War and Peace")
+		translation.WorkID, translation.Language = 1, "en"
+		translation.Translator = "Anthony Briggs"
+		must(t, repos.Books.Create(ctx, original), "create original")
+		must(t, repos.Books.Create(ctx, translation), "create translation")
+		must(t, repos.Books.Create(ctx, newBook(t, 12, "Без произведения")), "create standalone")
+
+		got, err := repos.Books.GetByID(ctx, 11)
+		must(t, err, "get translation")
+		if got.WorkID != 1 || got.Language != "en" || got.Translator != "Anthony Briggs" {
+			t.Fatalf("edition fields not stored: %+v", got)
+		}
+		got, err = repos.Books.GetByID(ctx, 12)
+		must(t, err, "get standalone")
+		if got.WorkID != 0 {
+			t.Fatalf("book without work: got WorkID %d", got.WorkID)
+		}
+
+		var ids []int
+		err = repos.Books.Iterate(ctx, books.Filter{WorkID: 1}, func(b *books.Book) error {
+			ids = append(ids, b.ID)
+			return nil
+		})
+		must(t, err, "iterate by work")
+		if !slices.Equal(ids, []int{10, 11}) {
+			t.Fatalf("iterate by work: got %v", ids)
+		}
+
+		if err := repos.Works.Delete(ctx, 1); err == nil {
+			t.Fatal("expected error when deleting a work with editions")
+		}
+		if _, err := repos.Works.GetByID(ctx, 1); err != nil {
+			t.Fatalf("work must survive the failed delete: %v", err)
+		}
+	})
+
+	subtest(t, "UnknownWork", newRepos, func(t *testing.T, ctx context.Context, repos storage.Repositories) {
+		book := newBook(t, 10, "Книга")
+		book.WorkID = 404
+		if err := repos.Books.Create(ctx, book); err == nil {
+			t.Fatal("expected error for a missing work")
+		}
+	})
+}
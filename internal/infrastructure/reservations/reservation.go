@@ -2,20 +2,23 @@ package reservations
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
 	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
 	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/jackc/pgx/v5"
 )
 
 type reservationRepo struct {
-	db *pgxpool.Pool
+	db postgres.DBTX
 }
 
-func NewReservationRepo(db *pgxpool.Pool) reservations.ReservationRepo {
+func NewReservationRepo(db postgres.DBTX) reservations.ReservationRepo {
 	return &reservationRepo{db: db}
 }
 
@@ -35,15 +38,49 @@ func (r *reservationRepo) Create(ctx context.Context, id int, book books.Book, r
 	return res, nil
 }
 
+func (r *reservationRepo) CreateFirstFree(ctx context.Context, id int, bookIDs []int, reader readers.Reader, startDate, endDate time.Time) (*reservations.Reservation, error) {
+	var created *reservations.Reservation
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Блокировка книг-кандидатов ставит параллельные выборы в очередь:
+		// следующий увидит бронирование, созданное предыдущим. Строки
+		// блокируются по возрастанию ID, чтобы не было взаимоблокировок.
+		if _, err := tx.Exec(ctx, `SELECT id FROM books WHERE id = ANY($1) ORDER BY id FOR UPDATE`, bookIDs); err != nil {
+			return fmt.Errorf("failed to lock books: %w", err)
+		}
+		repo := &reservationRepo{db: tx}
+		busy, err := repo.BusyBooks(ctx, bookIDs, startDate, endDate)
+		if err != nil {
+			return err
+		}
+		for _, bookID := range bookIDs {
+			if slices.Contains(busy, bookID) {
+				continue
+			}
+			created, err = repo.Create(ctx, id, books.Book{ID: bookID}, reader, startDate, endDate)
+			return err
+		}
+		return reservations.ErrNoFreeBook
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
 func (r *reservationRepo) GetById(ctx context.Context, id int) (*reservations.Reservation, error) {
 	query := `
-		SELECT id, book_id, reader_id, start_date, end_date
+		SELECT id, book_id, reader_id, start_date, end_date, returned_date
 		FROM reservations
 		WHERE id = $1`
 	row := r.db.QueryRow(ctx, query, id)
 	var resID, bookID, readerID int
 	var startDate, endDate time.Time
-	if err := row.Scan(&resID, &bookID, &readerID, &startDate, &endDate); err != nil {
+	var returnedDate *time.Time
+	err := row.Scan(&resID, &bookID, &readerID, &startDate, &endDate, &returnedDate)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, reservations.ErrNotFound
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to get reservation by id: %w", err)
 	}
 	book := books.Book{ID: bookID}
@@ -52,6 +89,7 @@ func (r *reservationRepo) GetById(ctx context.Context, id int) (*reservations.Re
 	if err != nil {
 		return nil, err
 	}
+	res.ReturnedDate = returnedDate
 	return res, nil
 }
 
@@ -81,7 +119,7 @@ func (r *reservationRepo) Delete(ctx context.Context, id int) error {
 
 func (r *reservationRepo) List(ctx context.Context, startDate, endDate time.Time) ([]reservations.Reservation, error) {
 	query := `
-		SELECT id, book_id, reader_id, start_date, end_date
+		SELECT id, book_id, reader_id, start_date, end_date, returned_date
 		FROM reservations
 		WHERE start_date >= $1 AND end_date <= $2`
 	rows, err := r.db.Query(ctx, query, startDate, endDate)
@@ -94,7 +132,8 @@ func (r *reservationRepo) List(ctx context.Context, startDate, endDate time.Time
 	for rows.Next() {
 		var id, bookID, readerID int
 		var sDate, eDate time.Time
-		err := rows.Scan(&id, &bookID, &readerID, &sDate, &eDate)
+		var returnedDate *time.Time
+		err := rows.Scan(&id, &bookID, &readerID, &sDate, &eDate, &returnedDate)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan reservation: %w", err)
 		}
@@ -104,6 +143,7 @@ func (r *reservationRepo) List(ctx context.Context, startDate, endDate time.Time
 		if err != nil {
 			return nil, err
 		}
+		res.ReturnedDate = returnedDate
 		resList = append(resList, *res)
 	}
 	if err = rows.Err(); err != nil {
@@ -111,3 +151,86 @@ func (r *reservationRepo) List(ctx context.Context, startDate, endDate time.Time
 	}
 	return resList, nil
 }
+
+func (r *reservationRepo) MarkReturned(ctx context.Context, id int, returnedDate time.Time) error {
+	tag, err := r.db.Exec(ctx, `UPDATE reservations SET returned_date = $1 WHERE id = $2`, returnedDate, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark reservation returned: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return reservations.ErrNotFound
+	}
+	return nil
+}
+
+func (r *reservationRepo) CountOverdue(ctx context.Context, now time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM reservations WHERE end_date < $1 AND returned_date IS NULL`
+	var count int
+	if err := r.db.QueryRow(ctx, query, now).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count overdue reservations: %w", err)
+	}
+	return count, nil
+}
+
+func (r *reservationRepo) BusyBooks(ctx context.Context, bookIDs []int, startDate, endDate time.Time) ([]int, error) {
+	query := `
+		SELECT DISTINCT book_id
+		FROM reservations
+		WHERE book_id = ANY($1) AND start_date <= $3 AND end_date >= $2
+		  AND returned_date IS NULL
+		ORDER BY book_id`
+	rows, err := r.db.Query(ctx, query, bookIDs, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find busy books: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan busy books: %w", err)
+	}
+	return ids, nil
+}
+
+// nullDate передаёт нулевую дату как NULL, чтобы граница не ограничивала выборку.
+func nullDate(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func (r *reservationRepo) Iterate(ctx context.Context, filter reservations.Filter, fn func(*reservations.Reservation) error) error {
+	query := `
+		SELECT id, book_id, reader_id, start_date, end_date, returned_date
+		FROM reservations
+		WHERE ($1::date IS NULL OR start_date >= $1)
+		  AND ($2::date IS NULL OR end_date <= $2)
+		  AND ($3 = 0 OR book_id = $3)
+		  AND ($4 = 0 OR reader_id = $4)
+		ORDER BY id`
+	rows, err := r.db.Query(ctx, query, nullDate(filter.StartDate), nullDate(filter.EndDate), filter.BookID, filter.ReaderID)
+	if err != nil {
+		return fmt.Errorf("failed to iterate reservations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, bookID, readerID int
+		var sDate, eDate time.Time
+		var returnedDate *time.Time
+		if err := rows.Scan(&id, &bookID, &readerID, &sDate, &eDate, &returnedDate); err != nil {
+			return fmt.Errorf("failed to scan reservation: %w", err)
+		}
+		res, err := reservations.NewReservation(id, books.Book{ID: bookID}, readers.Reader{ID: readerID}, sDate, eDate)
+		if err != nil {
+			return err
+		}
+		res.ReturnedDate = returnedDate
+		if err := fn(res); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package reviewsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reviews"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type reviewRepo struct {
+	db postgres.DBTX
+}
+
+func NewReviewRepo(db postgres.DBTX) reviews.ReviewRepo {
+	return &reviewRepo{db: db}
+}
+
+// reviewColumns — поля отзыва в порядке reviewFields; число отметок
+// полезности считается подзапросом.
+const reviewColumns = `r.id, r.book_id, r.reader_id, r.rating, r.body, r.status, r.created_at, r.updated_at,
+	       (SELECT COUNT(*) FROM review_votes v WHERE v.review_id = r.id)`
+
+func reviewFields(r *reviews.Review) []any {
+	return []any{&r.ID, &r.BookID, &r.ReaderID, &r.Rating, &r.Text, &r.Status, &r.CreatedAt, &r.UpdatedAt, &r.Helpful}
+}
+
+func (r *reviewRepo) Create(ctx context.Context, review *reviews.Review) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO reviews (id, book_id, reader_id, rating, body, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.Exec(ctx, query, review.ID, review.BookID, review.ReaderID, review.Rating,
+		review.Text, string(review.Status), review.CreatedAt, review.UpdatedAt)
+	if err != nil {
+		lg.Error("failed to create review", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) GetByID(ctx context.Context, id int) (*reviews.Review, error) {
+	lg := logger.FromContext(ctx)
+	var review reviews.Review
+	query := `SELECT ` + reviewColumns + ` FROM reviews r WHERE r.id = $1`
+	err := r.db.QueryRow(ctx, query, id).Scan(reviewFields(&review)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, reviews.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get review by id", zap.Error(err))
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepo) Update(ctx context.Context, review *reviews.Review) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		UPDATE reviews
+		SET rating = $2, body = $3, status = $4, updated_at = $5
+		WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, review.ID, review.Rating, review.Text, string(review.Status), review.UpdatedAt)
+	if err != nil {
+		lg.Error("failed to update review", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	// Отметки полезности удаляются каскадом.
+	if _, err := r.db.Exec(ctx, `DELETE FROM reviews WHERE id = $1`, id); err != nil {
+		lg.Error("failed to delete review", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) List(ctx context.Context, filter reviews.Filter) ([]reviews.Review, error) {
+	lg := logger.FromContext(ctx)
+	order := `r.created_at DESC, r.id DESC`
+	if filter.Sort == reviews.SortHelpful {
+		order = `9 DESC, ` + order
+	}
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM reviews r
+		WHERE ($1 = 0 OR r.book_id = $1)
+		  AND ($2 = 0 OR r.reader_id = $2)
+		  AND ($3 = '' OR r.status = $3)
+		ORDER BY ` + order
+	rows, err := r.db.Query(ctx, query, filter.BookID, filter.ReaderID, string(filter.Status))
+	if err != nil {
+		lg.Error("failed to list reviews", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []reviews.Review
+	for rows.Next() {
+		var review reviews.Review
+		if err := rows.Scan(reviewFields(&review)...); err != nil {
+			lg.Error("failed to scan review", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		list = append(list, review)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return list, nil
+}
+
+func (r *reviewRepo) Vote(ctx context.Context, reviewID, readerID int) error {
+	lg := logger.FromContext(ctx)
+	query := `INSERT INTO review_votes (review_id, reader_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if _, err := r.db.Exec(ctx, query, reviewID, readerID); err != nil {
+		lg.Error("failed to vote for review", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) Unvote(ctx context.Context, reviewID, readerID int) error {
+	lg := logger.FromContext(ctx)
+	query := `DELETE FROM review_votes WHERE review_id = $1 AND reader_id = $2`
+	if _, err := r.db.Exec(ctx, query, reviewID, readerID); err != nil {
+		lg.Error("failed to remove review vote", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) BookRating(ctx context.Context, bookID int) (books.Rating, error) {
+	lg := logger.FromContext(ctx)
+	var sum, count int
+	query := `
+		SELECT COALESCE(SUM(rating), 0), COUNT(*)
+		FROM reviews
+		WHERE book_id = $1 AND status = 'approved'`
+	if err := r.db.QueryRow(ctx, query, bookID).Scan(&sum, &count); err != nil {
+		lg.Error("failed to get book rating", zap.Error(err))
+		return books.Rating{}, err
+	}
+	return books.NewRating(sum, count), nil
+}
+
+func (r *reviewRepo) Ratings(ctx context.Context) (map[int]books.Rating, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT book_id, SUM(rating), COUNT(*)
+		FROM reviews
+		WHERE status = 'approved'
+		GROUP BY book_id`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		lg.Error("failed to list book ratings", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratings := make(map[int]books.Rating)
+	for rows.Next() {
+		var bookID, sum, count int
+		if err := rows.Scan(&bookID, &sum, &count); err != nil {
+			lg.Error("failed to scan book rating", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan book rating: %w", err)
+		}
+		ratings[bookID] = books.NewRating(sum, count)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return ratings, nil
+}
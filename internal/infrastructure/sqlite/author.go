@@ -0,0 +1,252 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type authorRepo struct {
+	db DBTX
+}
+
+func NewAuthorRepo(db DBTX) authors.AuthorRepo {
+	return &authorRepo{db: db}
+}
+
+// authorColumns — поля автора для scanAuthor; синонимы собираются в
+// JSON-массив, потому что могут содержать запятые.
+const authorColumns = `a.id, a.name, a.country, a.birth_date, a.death_date, a.bio,
+		       (SELECT json_group_array(alias) FROM (
+		           SELECT alias FROM author_aliases WHERE author_id = a.id ORDER BY alias))`
+
+func scanAuthor(row rowScanner) (*authors.Author, error) {
+	var author authors.Author
+	var birth, death sql.NullString
+	var aliases string
+	if err := row.Scan(&author.ID, &author.Name, &author.Country, &birth, &death, &author.Bio, &aliases); err != nil {
+		return nil, err
+	}
+	var err error
+	if author.BirthDate, err = parseNullDate(birth); err != nil {
+		return nil, fmt.Errorf("invalid birth_date: %w", err)
+	}
+	if author.DeathDate, err = parseNullDate(death); err != nil {
+		return nil, fmt.Errorf("invalid death_date: %w", err)
+	}
+	if err := json.Unmarshal([]byte(aliases), &author.Aliases); err != nil {
+		return nil, fmt.Errorf("invalid aliases: %w", err)
+	}
+	if len(author.Aliases) == 0 {
+		author.Aliases = nil
+	}
+	return &author, nil
+}
+
+func parseNullDate(s sql.NullString) (*time.Time, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, s.String)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// nullDate записывает неизвестную дату как NULL.
+func nullDate(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return formatDate(*t)
+}
+
+func (r *authorRepo) Create(ctx context.Context, author *authors.Author) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO authors (id, name, country, birth_date, death_date, bio)
+		VALUES (?, ?, ?, ?, ?, ?)`
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		_, err := tx.ExecContext(ctx, query, author.ID, author.Name, author.Country,
+			nullDate(author.BirthDate), nullDate(author.DeathDate), author.Bio)
+		if err != nil {
+			lg.Error("failed to create author", zap.Error(err))
+			return err
+		}
+		if err := insertAuthorAliases(ctx, tx, author); err != nil {
+			lg.Error("failed to insert author aliases", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func insertAuthorAliases(ctx context.Context, tx DBTX, author *authors.Author) error {
+	query := `INSERT INTO author_aliases (author_id, alias) VALUES (?, ?)`
+	for _, alias := range author.Aliases {
+		if _, err := tx.ExecContext(ctx, query, author.ID, alias); err != nil {
+			return fmt.Errorf("failed to insert author alias (author_id=%d, alias=%q): %w", author.ID, alias, err)
+		}
+	}
+	return nil
+}
+
+func (r *authorRepo) GetById(ctx context.Context, id int) (*authors.Author, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT ` + authorColumns + `
+		FROM authors a
+		WHERE a.id = ?`
+	author, err := scanAuthor(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, authors.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get author by id", zap.Error(err))
+		return nil, err
+	}
+	return author, nil
+}
+
+func (r *authorRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	_, err := r.db.ExecContext(ctx, `DELETE FROM authors WHERE id = ?`, id)
+	if err != nil {
+		lg.Error("failed to delete author by id", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *authorRepo) Update(ctx context.Context, author *authors.Author) error {
+	lg := logger.FromContext(ctx)
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if err := updateAuthor(ctx, tx, author); err != nil {
+			lg.Error("failed to update author by id", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+// updateAuthor сохраняет поля автора и заменяет его синонимы.
+func updateAuthor(ctx context.Context, tx DBTX, author *authors.Author) error {
+	query := `
+		UPDATE authors
+		SET name = ?, country = ?, birth_date = ?, death_date = ?, bio = ?
+		WHERE id = ?`
+	_, err := tx.ExecContext(ctx, query, author.Name, author.Country,
+		nullDate(author.BirthDate), nullDate(author.DeathDate), author.Bio, author.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update author: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM author_aliases WHERE author_id = ?`, author.ID); err != nil {
+		return fmt.Errorf("failed to delete old author aliases: %w", err)
+	}
+	return insertAuthorAliases(ctx, tx, author)
+}
+
+func (r *authorRepo) List(ctx context.Context) ([]authors.Author, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT ` + authorColumns + `
+		FROM authors a
+		ORDER BY a.id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		lg.Error("failed to list authors", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var authorsList []authors.Author
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			lg.Error("failed to scan author", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan author: %w", err)
+		}
+		authorsList = append(authorsList, *author)
+	}
+	if err = rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return authorsList, nil
+}
+
+func (r *authorRepo) Iterate(ctx context.Context, fn func(*authors.Author) error) error {
+	lg := logger.FromContext(ctx)
+	rows, err := r.db.QueryContext(ctx, `SELECT `+authorColumns+` FROM authors a ORDER BY a.id`)
+	if err != nil {
+		lg.Error("failed to iterate authors", zap.Error(err))
+		return fmt.Errorf("failed to iterate authors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		author, err := scanAuthor(rows)
+		if err != nil {
+			lg.Error("failed to scan author", zap.Error(err))
+			return fmt.Errorf("failed to scan author: %w", err)
+		}
+		if err := fn(author); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
+
+func (r *authorRepo) Merge(ctx context.Context, target *authors.Author, sourceIDs []int) error {
+	lg := logger.FromContext(ctx)
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+	// Массивы SQLite не принимает, поэтому IN собирается по числу источников.
+	in := `(?` + strings.Repeat(", ?", len(sourceIDs)-1) + `)`
+	args := []any{target.ID}
+	for _, id := range sourceIDs {
+		args = append(args, id)
+	}
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if err := updateAuthor(ctx, tx, target); err != nil {
+			lg.Error("failed to merge authors", zap.String("step", "update target"), zap.Error(err))
+			return err
+		}
+		steps := []struct {
+			what  string
+			query string
+		}{
+			{"move book contributors", `
+				INSERT INTO book_authors (book_id, author_id, role, position)
+				SELECT book_id, ?, role, MIN(position)
+				FROM book_authors WHERE author_id IN ` + in + `
+				GROUP BY book_id, role
+				ON CONFLICT (book_id, author_id, role)
+				DO UPDATE SET position = min(position, excluded.position)`},
+			{"delete merged contributors", `DELETE FROM book_authors WHERE author_id <> ? AND author_id IN ` + in},
+			// Синонимы источников удаляются каскадом: они уже перенесены в target.
+			{"delete merged authors", `DELETE FROM authors WHERE id <> ? AND id IN ` + in},
+		}
+		for _, step := range steps {
+			if _, err := tx.ExecContext(ctx, step.query, args...); err != nil {
+				lg.Error("failed to merge authors", zap.String("step", step.what), zap.Error(err))
+				return fmt.Errorf("failed to %s: %w", step.what, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,411 @@
+// Package sqlite содержит реализации репозиториев поверх SQLite.
+// Запросы повторяют Postgres-репозитории с поправкой на диалект.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type bookRepo struct {
+	db DBTX
+}
+
+func NewBookRepo(db DBTX) books.BookRepo {
+	return &bookRepo{db: db}
+}
+
+// bookColumns — поля книги в порядке bookFields; отсутствующие произведение
+// и издательство (NULL) читаются как 0.
+const bookColumns = `b.id, b.title, b.year, b.isbn, COALESCE(b.work_id, 0), COALESCE(b.publisher_id, 0), b.language, b.translator`
+
+func bookFields(b *books.Book) []any {
+	return []any{&b.ID, &b.Title, &b.Year, &b.ISBN, &b.WorkID, &b.PublisherID, &b.Language, &b.Translator}
+}
+
+// bookWorkID записывает книгу без произведения как NULL.
+func bookWorkID(b *books.Book) any {
+	if b.WorkID == 0 {
+		return nil
+	}
+	return b.WorkID
+}
+
+// bookPublisherID записывает книгу без издательства как NULL.
+func bookPublisherID(b *books.Book) any {
+	if b.PublisherID == 0 {
+		return nil
+	}
+	return b.PublisherID
+}
+
+func (r *bookRepo) Create(ctx context.Context, book *books.Book) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO books (id, title, year, isbn, work_id, publisher_id, language, translator)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if _, err := tx.ExecContext(ctx, query, book.ID, book.Title, book.Year, book.ISBN,
+			bookWorkID(book), bookPublisherID(book), book.Language, book.Translator); err != nil {
+			lg.Error("failed to create book", zap.Error(err))
+			return err
+		}
+		if err := insertBookAuthors(ctx, tx, book.ID, book.Contributors()); err != nil {
+			lg.Error("failed to insert book authors", zap.Error(err))
+			return err
+		}
+		if err := insertBookGenres(ctx, tx, book.ID, book.GenreIDs()); err != nil {
+			lg.Error("failed to insert book genres", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+// insertBookAuthors сохраняет участников; position — индекс в списке.
+func insertBookAuthors(ctx context.Context, tx DBTX, bookID int, contributors []books.Contributor) error {
+	query := `INSERT INTO book_authors (book_id, author_id, role, position) VALUES (?, ?, ?, ?)`
+	for i, c := range contributors {
+		if _, err := tx.ExecContext(ctx, query, bookID, c.AuthorID, string(c.Role), i); err != nil {
+			return fmt.Errorf("failed to insert book author (book_id=%d, author_id=%d, role=%s): %w", bookID, c.AuthorID, c.Role, err)
+		}
+	}
+	return nil
+}
+
+func insertBookGenres(ctx context.Context, tx DBTX, bookID int, genreIDs []int) error {
+	query := `INSERT INTO book_genres (book_id, genre_id) VALUES (?, ?)`
+	for _, genreID := range genreIDs {
+		if _, err := tx.ExecContext(ctx, query, bookID, genreID); err != nil {
+			return fmt.Errorf("failed to insert book genre (book_id=%d, genre_id=%d): %w", bookID, genreID, err)
+		}
+	}
+	return nil
+}
+
+// loadLinks загружает участников и жанры книги.
+func (r *bookRepo) loadLinks(ctx context.Context, book *books.Book) error {
+	contributors, err := r.loadBookAuthors(ctx, book.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load book authors: %w", err)
+	}
+	genreIDs, err := r.loadIDs(ctx, `SELECT genre_id FROM book_genres WHERE book_id = ? ORDER BY genre_id`, book.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load book genres: %w", err)
+	}
+	book.SetContributors(contributors)
+	book.SetGenreIDs(genreIDs)
+	return nil
+}
+
+func (r *bookRepo) loadBookAuthors(ctx context.Context, bookID int) ([]books.Contributor, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT author_id, role FROM book_authors WHERE book_id = ? ORDER BY position`, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contributors []books.Contributor
+	for rows.Next() {
+		var c books.Contributor
+		if err := rows.Scan(&c.AuthorID, &c.Role); err != nil {
+			return nil, err
+		}
+		contributors = append(contributors, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return contributors, nil
+}
+
+func (r *bookRepo) loadIDs(ctx context.Context, query string, bookID int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, query, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var authorIDs []int
+	for rows.Next() {
+		var authorID int
+		if err := rows.Scan(&authorID); err != nil {
+			return nil, err
+		}
+		authorIDs = append(authorIDs, authorID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return authorIDs, nil
+}
+
+func (r *bookRepo) GetByID(ctx context.Context, id int) (*books.Book, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT ` + bookColumns + `
+		FROM books b
+		WHERE b.id = ?`
+	row := r.db.QueryRowContext(ctx, query, id)
+	var book books.Book
+	err := row.Scan(bookFields(&book)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, books.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get book by id", zap.Error(err))
+		return nil, err
+	}
+	if err := r.loadLinks(ctx, &book); err != nil {
+		lg.Error("failed to load book links", zap.Error(err))
+		return nil, err
+	}
+	return &book, nil
+}
+
+// GetByISBN ищет книгу по ISBN без учёта дефисов, пробелов и регистра.
+// Если книг с таким ISBN несколько, возвращается книга с наименьшим ID.
+func (r *bookRepo) GetByISBN(ctx context.Context, isbn string) (*books.Book, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id
+		FROM books
+		WHERE upper(replace(replace(isbn, '-', ''), ' ', '')) = ?
+		ORDER BY id
+		LIMIT 1`
+	var id int
+	err := r.db.QueryRowContext(ctx, query, books.NormalizeISBN(isbn)).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, books.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get book by isbn", zap.Error(err))
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *bookRepo) Update(ctx context.Context, book *books.Book) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		UPDATE books
+		SET title = ?, year = ?, isbn = ?, work_id = ?, publisher_id = ?, language = ?, translator = ?
+		WHERE id = ?`
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if _, err := tx.ExecContext(ctx, query, book.Title, book.Year, book.ISBN,
+			bookWorkID(book), bookPublisherID(book), book.Language, book.Translator, book.ID); err != nil {
+			lg.Error("failed to update book", zap.Error(err))
+			return fmt.Errorf("failed to update book: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM book_authors WHERE book_id = ?`, book.ID); err != nil {
+			lg.Error("failed to delete old book authors", zap.Error(err))
+			return fmt.Errorf("failed to delete old book authors: %w", err)
+		}
+		if err := insertBookAuthors(ctx, tx, book.ID, book.Contributors()); err != nil {
+			lg.Error("failed to update book authors", zap.Error(err))
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM book_genres WHERE book_id = ?`, book.ID); err != nil {
+			lg.Error("failed to delete old book genres", zap.Error(err))
+			return fmt.Errorf("failed to delete old book genres: %w", err)
+		}
+		if err := insertBookGenres(ctx, tx, book.ID, book.GenreIDs()); err != nil {
+			lg.Error("failed to update book genres", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *bookRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		// Удаляем связи из таблиц book_authors, book_genres и book_tags.
+		if _, err := tx.ExecContext(ctx, `DELETE FROM book_authors WHERE book_id = ?`, id); err != nil {
+			lg.Error("failed to delete book authors", zap.Error(err))
+			return fmt.Errorf("failed to delete book authors: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM book_genres WHERE book_id = ?`, id); err != nil {
+			lg.Error("failed to delete book genres", zap.Error(err))
+			return fmt.Errorf("failed to delete book genres: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM book_tags WHERE book_id = ?`, id); err != nil {
+			lg.Error("failed to delete book tags", zap.Error(err))
+			return fmt.Errorf("failed to delete book tags: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM books WHERE id = ?`, id); err != nil {
+			lg.Error("failed to delete book", zap.Error(err))
+			return fmt.Errorf("failed to delete book: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *bookRepo) List(ctx context.Context) ([]books.Book, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT ` + bookColumns + `
+		FROM books b
+		ORDER BY b.id`
+	booksList, err := r.queryBooks(ctx, query)
+	if err != nil {
+		lg.Error("failed to list books", zap.Error(err))
+		return nil, fmt.Errorf("failed to list books: %w", err)
+	}
+	return booksList, nil
+}
+
+func (r *bookRepo) ListBooksByAuthor(ctx context.Context, authorID int) ([]books.Book, error) {
+	query := `
+		SELECT ` + bookColumns + `
+		FROM books b
+		WHERE EXISTS (
+			SELECT 1 FROM book_authors ba WHERE ba.book_id = b.id AND ba.author_id = ?)
+		ORDER BY b.id`
+	booksList, err := r.queryBooks(ctx, query, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list books by author: %w", err)
+	}
+	return booksList, nil
+}
+
+// queryBooks читает книги, а связи загружает после закрытия курсора,
+// чтобы не держать второе соединение на время чтения.
+func (r *bookRepo) queryBooks(ctx context.Context, query string, args ...interface{}) ([]books.Book, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var booksList []books.Book
+	for rows.Next() {
+		var book books.Book
+		if err := rows.Scan(bookFields(&book)...); err != nil {
+			return nil, fmt.Errorf("failed to scan book: %w", err)
+		}
+		booksList = append(booksList, book)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	rows.Close()
+
+	for i := range booksList {
+		if err := r.loadLinks(ctx, &booksList[i]); err != nil {
+			return nil, err
+		}
+	}
+	return booksList, nil
+}
+
+// Iterate читает книги одним запросом: участники («автор:роль») и жанры
+// собираются строками через group_concat, строки обрабатываются по мере
+// чтения курсора.
+func (r *bookRepo) Iterate(ctx context.Context, filter books.Filter, fn func(*books.Book) error) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT ` + bookColumns + `,
+		       COALESCE((SELECT group_concat(contributor) FROM (
+		           SELECT author_id || ':' || role AS contributor FROM book_authors
+		           WHERE book_id = b.id ORDER BY position)), ''),
+		       COALESCE((SELECT group_concat(genre_id) FROM (
+		           SELECT genre_id FROM book_genres WHERE book_id = b.id ORDER BY genre_id)), '')
+		FROM books b
+		WHERE (? = 0 OR EXISTS (
+			SELECT 1 FROM book_authors ba WHERE ba.book_id = b.id AND ba.author_id = ?
+			  AND (? = '' OR ba.role = ?)))
+		  AND (? = 0 OR EXISTS (
+			SELECT 1 FROM book_tags bt WHERE bt.book_id = b.id AND bt.tag_id = ?))
+		  AND (? = 0 OR b.work_id = ?)
+		  AND (? = 0 OR b.publisher_id = ?)`
+	args := []any{filter.AuthorID, filter.AuthorID, string(filter.Role), string(filter.Role), filter.TagID, filter.TagID, filter.WorkID, filter.WorkID, filter.PublisherID, filter.PublisherID}
+	if len(filter.GenreIDs) > 0 {
+		// Массивы SQLite не принимает, поэтому IN собирается по числу жанров.
+		query += `
+		  AND EXISTS (SELECT 1 FROM book_genres bg WHERE bg.book_id = b.id
+		              AND bg.genre_id IN (?` + strings.Repeat(", ?", len(filter.GenreIDs)-1) + `))`
+		for _, id := range filter.GenreIDs {
+			args = append(args, id)
+		}
+	}
+	query += `
+		ORDER BY b.id`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		lg.Error("failed to iterate books", zap.Error(err))
+		return fmt.Errorf("failed to iterate books: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book books.Book
+		var authorList, genreList string
+		if err := rows.Scan(append(bookFields(&book), &authorList, &genreList)...); err != nil {
+			lg.Error("failed to scan book", zap.Error(err))
+			return fmt.Errorf("failed to scan book: %w", err)
+		}
+		contributors, err := parseContributors(authorList)
+		if err != nil {
+			return fmt.Errorf("invalid contributors: %w", err)
+		}
+		genreIDs, err := parseIntList(genreList)
+		if err != nil {
+			return fmt.Errorf("invalid genre ids: %w", err)
+		}
+		book.SetContributors(contributors)
+		book.SetGenreIDs(genreIDs)
+		if err := fn(&book); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
+
+// parseIntList разбирает список чисел через запятую (например, собранный group_concat).
+func parseIntList(list string) ([]int, error) {
+	var ids []int
+	for _, s := range strings.Split(list, ",") {
+		if s == "" {
+			continue
+		}
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", s, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseContributors разбирает участников «автор:роль» через запятую.
+func parseContributors(list string) ([]books.Contributor, error) {
+	var contributors []books.Contributor
+	for _, s := range strings.Split(list, ",") {
+		if s == "" {
+			continue
+		}
+		idPart, role, ok := strings.Cut(s, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid contributor %q", s)
+		}
+		id, err := strconv.Atoi(idPart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", idPart, err)
+		}
+		contributors = append(contributors, books.Contributor{AuthorID: id, Role: books.Role(role)})
+	}
+	return contributors, nil
+}
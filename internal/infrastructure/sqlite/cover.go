@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type coverRepo struct {
+	db DBTX
+}
+
+func NewCoverRepo(db DBTX) books.CoverRepo {
+	return &coverRepo{db: db}
+}
+
+type coverScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCover(row coverScanner) (*books.Cover, error) {
+	var cover books.Cover
+	var widths string
+	if err := row.Scan(&cover.BookID, &cover.Version, &cover.ContentType,
+		&cover.Width, &cover.Height, &widths); err != nil {
+		return nil, err
+	}
+	var err error
+	if cover.ThumbnailWidths, err = parseIntList(widths); err != nil {
+		return nil, fmt.Errorf("invalid thumbnail widths: %w", err)
+	}
+	return &cover, nil
+}
+
+func (r *coverRepo) Get(ctx context.Context, bookID int) (*books.Cover, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT book_id, version, content_type, width, height, thumbnail_widths
+		FROM book_covers
+		WHERE book_id = ?`
+	cover, err := scanCover(r.db.QueryRowContext(ctx, query, bookID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, books.ErrCoverNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get cover", zap.Error(err))
+		return nil, err
+	}
+	return cover, nil
+}
+
+func (r *coverRepo) Save(ctx context.Context, cover *books.Cover) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO book_covers (book_id, version, content_type, width, height, thumbnail_widths)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (book_id) DO UPDATE
+		SET version = excluded.version, content_type = excluded.content_type,
+		    width = excluded.width, height = excluded.height,
+		    thumbnail_widths = excluded.thumbnail_widths`
+	widths := make([]string, len(cover.ThumbnailWidths))
+	for i, w := range cover.ThumbnailWidths {
+		widths[i] = strconv.Itoa(w)
+	}
+	_, err := r.db.ExecContext(ctx, query, cover.BookID, cover.Version, cover.ContentType,
+		cover.Width, cover.Height, strings.Join(widths, ","))
+	if err != nil {
+		lg.Error("failed to save cover", zap.Error(err))
+		return fmt.Errorf("failed to save cover: %w", err)
+	}
+	return nil
+}
+
+func (r *coverRepo) Delete(ctx context.Context, bookID int) error {
+	lg := logger.FromContext(ctx)
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM book_covers WHERE book_id = ?`, bookID); err != nil {
+		lg.Error("failed to delete cover", zap.Error(err))
+		return fmt.Errorf("failed to delete cover: %w", err)
+	}
+	return nil
+}
+
+func (r *coverRepo) List(ctx context.Context) ([]books.Cover, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT book_id, version, content_type, width, height, thumbnail_widths
+		FROM book_covers
+		ORDER BY book_id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		lg.Error("failed to list covers", zap.Error(err))
+		return nil, fmt.Errorf("failed to list covers: %w", err)
+	}
+	defer rows.Close()
+
+	var covers []books.Cover
+	for rows.Next() {
+		cover, err := scanCover(rows)
+		if err != nil {
+			lg.Error("failed to scan cover", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan cover: %w", err)
+		}
+		covers = append(covers, *cover)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return covers, nil
+}
@@ -0,0 +1,180 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type genreRepo struct {
+	db DBTX
+}
+
+func NewGenreRepo(db DBTX) genres.GenreRepo {
+	return &genreRepo{db: db}
+}
+
+// parentID записывает жанр верхнего уровня как NULL.
+func parentID(g *genres.Genre) any {
+	if g.ParentID == 0 {
+		return nil
+	}
+	return g.ParentID
+}
+
+func (r *genreRepo) Create(ctx context.Context, genre *genres.Genre) error {
+	lg := logger.FromContext(ctx)
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		query := `INSERT INTO genres (id, parent_id, name) VALUES (?, ?, ?)`
+		if _, err := tx.ExecContext(ctx, query, genre.ID, parentID(genre), genre.Name); err != nil {
+			lg.Error("failed to create genre", zap.Error(err))
+			return err
+		}
+		if err := insertGenreNames(ctx, tx, genre); err != nil {
+			lg.Error("failed to insert genre names", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func insertGenreNames(ctx context.Context, tx DBTX, genre *genres.Genre) error {
+	query := `INSERT INTO genre_names (genre_id, lang, name) VALUES (?, ?, ?)`
+	for lang, name := range genre.Names {
+		if _, err := tx.ExecContext(ctx, query, genre.ID, lang, name); err != nil {
+			return fmt.Errorf("failed to insert genre name (genre_id=%d, lang=%s): %w", genre.ID, lang, err)
+		}
+	}
+	return nil
+}
+
+// loadNames загружает локализованные названия жанров с указанным ID
+// или всех жанров, если id равен 0.
+func (r *genreRepo) loadNames(ctx context.Context, id int) (map[int]map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT genre_id, lang, name FROM genre_names WHERE ? = 0 OR genre_id = ?`, id, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[int]map[string]string)
+	for rows.Next() {
+		var genreID int
+		var lang, name string
+		if err := rows.Scan(&genreID, &lang, &name); err != nil {
+			return nil, err
+		}
+		if names[genreID] == nil {
+			names[genreID] = make(map[string]string)
+		}
+		names[genreID][lang] = name
+	}
+	return names, rows.Err()
+}
+
+func (r *genreRepo) GetByID(ctx context.Context, id int) (*genres.Genre, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id, COALESCE(parent_id, 0), name
+		FROM genres
+		WHERE id = ?`
+	var genre genres.Genre
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&genre.ID, &genre.ParentID, &genre.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, genres.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get genre by id", zap.Error(err))
+		return nil, err
+	}
+	names, err := r.loadNames(ctx, genre.ID)
+	if err != nil {
+		lg.Error("failed to load genre names", zap.Error(err))
+		return nil, err
+	}
+	genre.Names = withNames(names[genre.ID])
+	return &genre, nil
+}
+
+func (r *genreRepo) Update(ctx context.Context, genre *genres.Genre) error {
+	lg := logger.FromContext(ctx)
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		query := `UPDATE genres SET parent_id = ?, name = ? WHERE id = ?`
+		if _, err := tx.ExecContext(ctx, query, parentID(genre), genre.Name, genre.ID); err != nil {
+			lg.Error("failed to update genre", zap.Error(err))
+			return fmt.Errorf("failed to update genre: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM genre_names WHERE genre_id = ?`, genre.ID); err != nil {
+			lg.Error("failed to delete old genre names", zap.Error(err))
+			return fmt.Errorf("failed to delete old genre names: %w", err)
+		}
+		if err := insertGenreNames(ctx, tx, genre); err != nil {
+			lg.Error("failed to update genre names", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *genreRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	// Локализованные названия удаляются каскадом; ссылки из книг
+	// и дочерних жанров не дают удалить жанр.
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM genres WHERE id = ?`, id); err != nil {
+		lg.Error("failed to delete genre", zap.Error(err))
+		return fmt.Errorf("failed to delete genre: %w", err)
+	}
+	return nil
+}
+
+func (r *genreRepo) List(ctx context.Context) ([]genres.Genre, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id, COALESCE(parent_id, 0), name
+		FROM genres
+		ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		lg.Error("failed to list genres", zap.Error(err))
+		return nil, fmt.Errorf("failed to list genres: %w", err)
+	}
+	defer rows.Close()
+
+	var genresList []genres.Genre
+	for rows.Next() {
+		var genre genres.Genre
+		if err := rows.Scan(&genre.ID, &genre.ParentID, &genre.Name); err != nil {
+			lg.Error("failed to scan genre", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan genre: %w", err)
+		}
+		genresList = append(genresList, genre)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	// Названия читаем после закрытия курсора: внутри транзакции соединение одно.
+	rows.Close()
+	names, err := r.loadNames(ctx, 0)
+	if err != nil {
+		lg.Error("failed to load genre names", zap.Error(err))
+		return nil, fmt.Errorf("failed to load genre names: %w", err)
+	}
+	for i := range genresList {
+		genresList[i].Names = withNames(names[genresList[i].ID])
+	}
+	return genresList, nil
+}
+
+// withNames возвращает пустую карту вместо nil, как Postgres-реализация.
+func withNames(names map[string]string) map[string]string {
+	if names == nil {
+		return map[string]string{}
+	}
+	return names
+}
@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type publisherRepo struct {
+	db DBTX
+}
+
+func NewPublisherRepo(db DBTX) publishers.PublisherRepo {
+	return &publisherRepo{db: db}
+}
+
+func (r *publisherRepo) Create(ctx context.Context, publisher *publishers.Publisher) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO publishers (id, name, city, country)
+		VALUES (?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, publisher.ID, publisher.Name, publisher.City, publisher.Country)
+	if err != nil {
+		lg.Error("failed to create publisher", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *publisherRepo) GetById(ctx context.Context, id int) (*publishers.Publisher, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id, name, city, country
+		FROM publishers
+		WHERE id = ?`
+	var publisher publishers.Publisher
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&publisher.ID, &publisher.Name, &publisher.City, &publisher.Country)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, publishers.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get publisher by id", zap.Error(err))
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+func (r *publisherRepo) Update(ctx context.Context, publisher *publishers.Publisher) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		UPDATE publishers
+		SET name = ?, city = ?, country = ?
+		WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, publisher.Name, publisher.City, publisher.Country, publisher.ID)
+	if err != nil {
+		lg.Error("failed to update publisher by id", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *publisherRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM publishers WHERE id = ?`, id); err != nil {
+		lg.Error("failed to delete publisher by id", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *publisherRepo) List(ctx context.Context) ([]publishers.Publisher, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id, name, city, country
+		FROM publishers
+		ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		lg.Error("failed to list publishers", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []publishers.Publisher
+	for rows.Next() {
+		var publisher publishers.Publisher
+		if err := rows.Scan(&publisher.ID, &publisher.Name, &publisher.City, &publisher.Country); err != nil {
+			lg.Error("failed to scan publisher", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan publisher: %w", err)
+		}
+		list = append(list, publisher)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return list, nil
+}
@@ -0,0 +1,230 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	domainReaders "github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type readerRepo struct {
+	db DBTX
+}
+
+func NewReaderRepo(db DBTX) domainReaders.ReaderRepo {
+	return &readerRepo{db: db}
+}
+
+const readerColumns = `id, name, phone, email, password, admin, totp_secret, totp_enabled, totp_last_step,
+		COALESCE(oidc_subject, '')`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReader(row rowScanner) (*domainReaders.Reader, error) {
+	var reader domainReaders.Reader
+	err := row.Scan(&reader.ID, &reader.Name, &reader.Phone, &reader.Email, &reader.Password, &reader.Admin,
+		&reader.TOTPSecret, &reader.TOTPEnabled, &reader.TOTPLastStep, &reader.OIDCSubject)
+	if err != nil {
+		return nil, err
+	}
+	return &reader, nil
+}
+
+func (r *readerRepo) Create(ctx context.Context, reader *domainReaders.Reader) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO readers (id, name, phone, email, password, admin, oidc_subject)
+		VALUES (?, ?, ?, ?, ?, ?, NULLIF(?, ''))`
+	_, err := r.db.ExecContext(ctx, query, reader.ID, reader.Name, reader.Phone, reader.Email, reader.Password, reader.Admin, reader.OIDCSubject)
+	if err != nil {
+		lg.Error("failed to create reader", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// getBy возвращает читателя по условию where; отсутствие строки — ErrNotFound.
+func (r *readerRepo) getBy(ctx context.Context, what, where string, arg interface{}) (*domainReaders.Reader, error) {
+	lg := logger.FromContext(ctx)
+	query := `SELECT ` + readerColumns + ` FROM readers WHERE ` + where + ` LIMIT 1`
+	reader, err := scanReader(r.db.QueryRowContext(ctx, query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domainReaders.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get reader by "+what, zap.Error(err))
+		return nil, err
+	}
+	return reader, nil
+}
+
+func (r *readerRepo) GetById(ctx context.Context, id int) (*domainReaders.Reader, error) {
+	return r.getBy(ctx, "id", "id = ?", id)
+}
+
+func (r *readerRepo) Update(ctx context.Context, reader *domainReaders.Reader) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		UPDATE readers
+		SET name = ?, phone = ?, email = ?, password = ?, admin = ?, oidc_subject = NULLIF(?, '')
+		WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, reader.Name, reader.Phone, reader.Email, reader.Password, reader.Admin, reader.OIDCSubject, reader.ID)
+	if err != nil {
+		lg.Error("failed to update reader by id", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *readerRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	_, err := r.db.ExecContext(ctx, `DELETE FROM readers WHERE id = ?`, id)
+	if err != nil {
+		lg.Error("failed to delete reader by id", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *readerRepo) List(ctx context.Context) ([]domainReaders.Reader, error) {
+	lg := logger.FromContext(ctx)
+	rows, err := r.db.QueryContext(ctx, `SELECT `+readerColumns+` FROM readers ORDER BY id`)
+	if err != nil {
+		lg.Error("failed to list readers", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var readersList []domainReaders.Reader
+	for rows.Next() {
+		reader, err := scanReader(rows)
+		if err != nil {
+			lg.Error("failed to scan reader", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan reader: %w", err)
+		}
+		readersList = append(readersList, *reader)
+	}
+	if err = rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return readersList, nil
+}
+
+func (r *readerRepo) GetReaderByEmail(ctx context.Context, email string) (*domainReaders.Reader, error) {
+	return r.getBy(ctx, "email", "email = ? ORDER BY id", email)
+}
+
+func (r *readerRepo) GetReaderByOIDCSubject(ctx context.Context, subject string) (*domainReaders.Reader, error) {
+	return r.getBy(ctx, "oidc subject", "oidc_subject = ?", subject)
+}
+
+func (r *readerRepo) Authenticate(ctx context.Context, email, password string) (*domainReaders.Reader, error) {
+	reader, err := r.GetReaderByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if reader.Password != password {
+		return nil, fmt.Errorf("invalid password")
+	}
+	return reader, nil
+}
+
+func (r *readerRepo) UpdateTOTP(ctx context.Context, reader *domainReaders.Reader) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		UPDATE readers
+		SET totp_secret = ?, totp_enabled = ?, totp_last_step = ?
+		WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, reader.TOTPSecret, reader.TOTPEnabled, reader.TOTPLastStep, reader.ID)
+	if err != nil {
+		lg.Error("failed to update reader totp", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *readerRepo) AdvanceTOTPStep(ctx context.Context, readerID int, step int64) (bool, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		UPDATE readers
+		SET totp_last_step = ?2
+		WHERE id = ?1 AND totp_last_step < ?2`
+	res, err := r.db.ExecContext(ctx, query, readerID, step)
+	if err != nil {
+		lg.Error("failed to advance reader totp step", zap.Error(err))
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *readerRepo) ReplaceRecoveryCodes(ctx context.Context, readerID int, codeHashes []string) error {
+	lg := logger.FromContext(ctx)
+	query := `INSERT INTO reader_recovery_codes (reader_id, code_hash) VALUES (?, ?)`
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM reader_recovery_codes WHERE reader_id = ?`, readerID); err != nil {
+			lg.Error("failed to delete recovery codes", zap.Error(err))
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+		for _, hash := range codeHashes {
+			if _, err := tx.ExecContext(ctx, query, readerID, hash); err != nil {
+				lg.Error("failed to insert recovery code", zap.Error(err))
+				return fmt.Errorf("failed to insert recovery code: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *readerRepo) ConsumeRecoveryCode(ctx context.Context, readerID int, codeHash string) (bool, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		DELETE FROM reader_recovery_codes
+		WHERE reader_id = ? AND code_hash = ?`
+	res, err := r.db.ExecContext(ctx, query, readerID, codeHash)
+	if err != nil {
+		lg.Error("failed to consume recovery code", zap.Error(err))
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *readerRepo) Iterate(ctx context.Context, fn func(*domainReaders.Reader) error) error {
+	lg := logger.FromContext(ctx)
+	rows, err := r.db.QueryContext(ctx, `SELECT `+readerColumns+` FROM readers ORDER BY id`)
+	if err != nil {
+		lg.Error("failed to iterate readers", zap.Error(err))
+		return fmt.Errorf("failed to iterate readers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		reader, err := scanReader(rows)
+		if err != nil {
+			lg.Error("failed to scan reader", zap.Error(err))
+			return fmt.Errorf("failed to scan reader: %w", err)
+		}
+		if err := fn(reader); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
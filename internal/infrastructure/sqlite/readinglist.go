@@ -0,0 +1,213 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/readinglists"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type readingListRepo struct {
+	db DBTX
+}
+
+func NewReadingListRepo(db DBTX) readinglists.ReadingListRepo {
+	return &readingListRepo{db: db}
+}
+
+const readingListColumns = `id, reader_id, name, COALESCE(slug, ''), created_at`
+
+func scanReadingList(row rowScanner) (*readinglists.ReadingList, error) {
+	var list readinglists.ReadingList
+	var created string
+	if err := row.Scan(&list.ID, &list.ReaderID, &list.Name, &list.Slug, &created); err != nil {
+		return nil, err
+	}
+	list.Public = list.Slug != ""
+	var err error
+	if list.CreatedAt, err = time.Parse(timeLayout, created); err != nil {
+		return nil, fmt.Errorf("invalid created_at %q: %w", created, err)
+	}
+	return &list, nil
+}
+
+func (r *readingListRepo) Create(ctx context.Context, list *readinglists.ReadingList) error {
+	lg := logger.FromContext(ctx)
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		query := `
+			INSERT INTO reading_lists (id, reader_id, name, slug, created_at)
+			VALUES (?, ?, ?, NULLIF(?, ''), ?)`
+		if _, err := tx.ExecContext(ctx, query, list.ID, list.ReaderID, list.Name, list.Slug, formatTime(list.CreatedAt)); err != nil {
+			lg.Error("failed to create reading list", zap.Error(err))
+			return err
+		}
+		if err := insertListBooks(ctx, tx, list); err != nil {
+			lg.Error("failed to insert reading list books", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+// insertListBooks сохраняет книги списка с позициями от 1.
+func insertListBooks(ctx context.Context, tx DBTX, list *readinglists.ReadingList) error {
+	query := `INSERT INTO reading_list_books (list_id, book_id, position) VALUES (?, ?, ?)`
+	for i, bookID := range list.BookIDs {
+		if _, err := tx.ExecContext(ctx, query, list.ID, bookID, i+1); err != nil {
+			return fmt.Errorf("failed to insert reading list book (list_id=%d, book_id=%d): %w", list.ID, bookID, err)
+		}
+	}
+	return nil
+}
+
+func (r *readingListRepo) GetByID(ctx context.Context, id int) (*readinglists.ReadingList, error) {
+	return r.getBy(ctx, "id", `id = ?`, id)
+}
+
+func (r *readingListRepo) GetBySlug(ctx context.Context, slug string) (*readinglists.ReadingList, error) {
+	return r.getBy(ctx, "slug", `slug = ?`, slug)
+}
+
+func (r *readingListRepo) getBy(ctx context.Context, what, cond string, arg any) (*readinglists.ReadingList, error) {
+	lg := logger.FromContext(ctx)
+	query := `SELECT ` + readingListColumns + ` FROM reading_lists WHERE ` + cond
+	list, err := scanReadingList(r.db.QueryRowContext(ctx, query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, readinglists.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get reading list by "+what, zap.Error(err))
+		return nil, err
+	}
+	books, err := r.listBooks(ctx, `list_id = ?`, list.ID)
+	if err != nil {
+		lg.Error("failed to get reading list books", zap.Error(err))
+		return nil, err
+	}
+	list.BookIDs = books[list.ID]
+	if list.BookIDs == nil {
+		list.BookIDs = []int{}
+	}
+	return list, nil
+}
+
+// listBooks возвращает книги списков, отобранных условием cond, в порядке
+// позиций.
+func (r *readingListRepo) listBooks(ctx context.Context, cond string, arg any) (map[int][]int, error) {
+	query := `
+		SELECT list_id, book_id
+		FROM reading_list_books
+		WHERE ` + cond + `
+		ORDER BY list_id, position`
+	rows, err := r.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	books := make(map[int][]int)
+	for rows.Next() {
+		var listID, bookID int
+		if err := rows.Scan(&listID, &bookID); err != nil {
+			return nil, fmt.Errorf("failed to scan reading list book: %w", err)
+		}
+		books[listID] = append(books[listID], bookID)
+	}
+	return books, rows.Err()
+}
+
+func (r *readingListRepo) Update(ctx context.Context, list *readinglists.ReadingList) error {
+	lg := logger.FromContext(ctx)
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		query := `UPDATE reading_lists SET name = ?, slug = NULLIF(?, '') WHERE id = ?`
+		if _, err := tx.ExecContext(ctx, query, list.Name, list.Slug, list.ID); err != nil {
+			lg.Error("failed to update reading list", zap.Error(err))
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM reading_list_books WHERE list_id = ?`, list.ID); err != nil {
+			lg.Error("failed to clear reading list books", zap.Error(err))
+			return err
+		}
+		if err := insertListBooks(ctx, tx, list); err != nil {
+			lg.Error("failed to insert reading list books", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
+
+// Modify полагается на то, что транзакции SQLite сразу берут блокировку на
+// запись (_txlock=immediate): чтение и запись списка не перемежаются с чужими.
+func (r *readingListRepo) Modify(ctx context.Context, id int, fn func(*readinglists.ReadingList) error) (*readinglists.ReadingList, error) {
+	var list *readinglists.ReadingList
+	err := withTx(ctx, r.db, func(tx DBTX) error {
+		repo := &readingListRepo{db: tx}
+		var err error
+		list, err = repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := fn(list); err != nil {
+			return err
+		}
+		return repo.Update(ctx, list)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *readingListRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	// Книги списка удаляются каскадом.
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM reading_lists WHERE id = ?`, id); err != nil {
+		lg.Error("failed to delete reading list", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *readingListRepo) ListByReader(ctx context.Context, readerID int) ([]readinglists.ReadingList, error) {
+	lg := logger.FromContext(ctx)
+	query := `SELECT ` + readingListColumns + ` FROM reading_lists WHERE reader_id = ? ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query, readerID)
+	if err != nil {
+		lg.Error("failed to list reading lists", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lists []readinglists.ReadingList
+	for rows.Next() {
+		list, err := scanReadingList(rows)
+		if err != nil {
+			lg.Error("failed to scan reading list", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan reading list: %w", err)
+		}
+		lists = append(lists, *list)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	rows.Close()
+
+	books, err := r.listBooks(ctx, `list_id IN (SELECT id FROM reading_lists WHERE reader_id = ?)`, readerID)
+	if err != nil {
+		lg.Error("failed to list reading list books", zap.Error(err))
+		return nil, err
+	}
+	for i := range lists {
+		lists[i].BookIDs = books[lists[i].ID]
+		if lists[i].BookIDs == nil {
+			lists[i].BookIDs = []int{}
+		}
+	}
+	return lists, nil
+}
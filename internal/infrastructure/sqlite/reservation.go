@@ -0,0 +1,252 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+)
+
+// dateLayout — формат колонок start_date и end_date. Строки в этом формате
+// сравниваются так же, как даты, поэтому фильтры работают в SQL.
+const dateLayout = "2006-01-02"
+
+type reservationRepo struct {
+	db DBTX
+}
+
+func NewReservationRepo(db DBTX) reservations.ReservationRepo {
+	return &reservationRepo{db: db}
+}
+
+func formatDate(t time.Time) string {
+	return t.Format(dateLayout)
+}
+
+func scanReservation(row rowScanner) (*reservations.Reservation, error) {
+	var id, bookID, readerID int
+	var startDate, endDate string
+	var returnedDate sql.NullString
+	if err := row.Scan(&id, &bookID, &readerID, &startDate, &endDate, &returnedDate); err != nil {
+		return nil, err
+	}
+	start, err := time.Parse(dateLayout, startDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_date %q: %w", startDate, err)
+	}
+	end, err := time.Parse(dateLayout, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_date %q: %w", endDate, err)
+	}
+	res, err := reservations.NewReservation(id, books.Book{ID: bookID}, readers.Reader{ID: readerID}, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if res.ReturnedDate, err = parseNullDate(returnedDate); err != nil {
+		return nil, fmt.Errorf("invalid returned_date: %w", err)
+	}
+	return res, nil
+}
+
+func (r *reservationRepo) Create(ctx context.Context, id int, book books.Book, reader readers.Reader, startDate, endDate time.Time) (*reservations.Reservation, error) {
+	// Создаем объект бронирования через доменную фабрику.
+	res, err := reservations.NewReservation(id, book, reader, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		INSERT INTO reservations (id, book_id, reader_id, start_date, end_date)
+		VALUES (?, ?, ?, ?, ?)`
+	_, err = r.db.ExecContext(ctx, query, res.ID, res.Book.ID, res.Reader.ID, formatDate(res.StartDate), formatDate(res.EndDate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+	return res, nil
+}
+
+// CreateFirstFree полагается на то, что транзакции SQLite сразу берут
+// блокировку на запись (_txlock=immediate): выбор и вставка не
+// перемежаются с чужими.
+func (r *reservationRepo) CreateFirstFree(ctx context.Context, id int, bookIDs []int, reader readers.Reader, startDate, endDate time.Time) (*reservations.Reservation, error) {
+	var created *reservations.Reservation
+	err := withTx(ctx, r.db, func(tx DBTX) error {
+		repo := &reservationRepo{db: tx}
+		busy, err := repo.BusyBooks(ctx, bookIDs, startDate, endDate)
+		if err != nil {
+			return err
+		}
+		for _, bookID := range bookIDs {
+			if slices.Contains(busy, bookID) {
+				continue
+			}
+			created, err = repo.Create(ctx, id, books.Book{ID: bookID}, reader, startDate, endDate)
+			return err
+		}
+		return reservations.ErrNoFreeBook
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *reservationRepo) GetById(ctx context.Context, id int) (*reservations.Reservation, error) {
+	query := `
+		SELECT id, book_id, reader_id, start_date, end_date, returned_date
+		FROM reservations
+		WHERE id = ?`
+	res, err := scanReservation(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservations.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation by id: %w", err)
+	}
+	return res, nil
+}
+
+func (r *reservationRepo) Update(ctx context.Context, id int, book books.Book, reader readers.Reader, startDate, endDate time.Time) error {
+	if endDate.Before(startDate) {
+		return fmt.Errorf("end date cannot be before start date")
+	}
+	query := `
+		UPDATE reservations
+		SET book_id = ?, reader_id = ?, start_date = ?, end_date = ?
+		WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, book.ID, reader.ID, formatDate(startDate), formatDate(endDate), id)
+	if err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+	return nil
+}
+
+func (r *reservationRepo) Delete(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM reservations WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete reservation: %w", err)
+	}
+	return nil
+}
+
+func (r *reservationRepo) List(ctx context.Context, startDate, endDate time.Time) ([]reservations.Reservation, error) {
+	query := `
+		SELECT id, book_id, reader_id, start_date, end_date, returned_date
+		FROM reservations
+		WHERE start_date >= ? AND end_date <= ?
+		ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query, formatDate(startDate), formatDate(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var resList []reservations.Reservation
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reservation: %w", err)
+		}
+		resList = append(resList, *res)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return resList, nil
+}
+
+func (r *reservationRepo) MarkReturned(ctx context.Context, id int, returnedDate time.Time) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE reservations SET returned_date = ? WHERE id = ?`, formatDate(returnedDate), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark reservation returned: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return reservations.ErrNotFound
+	}
+	return nil
+}
+
+func (r *reservationRepo) CountOverdue(ctx context.Context, now time.Time) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM reservations WHERE end_date < ? AND returned_date IS NULL`
+	err := r.db.QueryRowContext(ctx, query, formatDate(now)).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count overdue reservations: %w", err)
+	}
+	return count, nil
+}
+
+func (r *reservationRepo) BusyBooks(ctx context.Context, bookIDs []int, startDate, endDate time.Time) ([]int, error) {
+	if len(bookIDs) == 0 {
+		return nil, nil
+	}
+	// Даты пронумерованы явно и стоят до IN: голый ? получает номер на
+	// единицу больше наибольшего из уже встреченных.
+	query := `
+		SELECT DISTINCT book_id
+		FROM reservations
+		WHERE start_date <= ?2 AND end_date >= ?1 AND returned_date IS NULL
+		  AND book_id IN (?` + strings.Repeat(", ?", len(bookIDs)-1) + `)
+		ORDER BY book_id`
+	args := []any{formatDate(startDate), formatDate(endDate)}
+	for _, id := range bookIDs {
+		args = append(args, id)
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find busy books: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan busy books: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *reservationRepo) Iterate(ctx context.Context, filter reservations.Filter, fn func(*reservations.Reservation) error) error {
+	query := `
+		SELECT id, book_id, reader_id, start_date, end_date, returned_date
+		FROM reservations
+		WHERE (? = '' OR start_date >= ?) AND (? = '' OR end_date <= ?)
+		  AND (? = 0 OR book_id = ?) AND (? = 0 OR reader_id = ?)
+		ORDER BY id`
+	var start, end string
+	if !filter.StartDate.IsZero() {
+		start = formatDate(filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		end = formatDate(filter.EndDate)
+	}
+	rows, err := r.db.QueryContext(ctx, query, start, start, end, end,
+		filter.BookID, filter.BookID, filter.ReaderID, filter.ReaderID)
+	if err != nil {
+		return fmt.Errorf("failed to iterate reservations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan reservation: %w", err)
+		}
+		if err := fn(res); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+	return nil
+}
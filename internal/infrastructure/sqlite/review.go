@@ -0,0 +1,203 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reviews"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// timeLayout — формат колонок created_at и updated_at: UTC с
+// микросекундами фиксированной ширины, чтобы строки сортировались как время.
+const timeLayout = "2006-01-02T15:04:05.000000Z"
+
+type reviewRepo struct {
+	db DBTX
+}
+
+func NewReviewRepo(db DBTX) reviews.ReviewRepo {
+	return &reviewRepo{db: db}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+const reviewColumns = `r.id, r.book_id, r.reader_id, r.rating, r.body, r.status, r.created_at, r.updated_at,
+	       (SELECT COUNT(*) FROM review_votes v WHERE v.review_id = r.id) AS helpful`
+
+func scanReview(row rowScanner) (*reviews.Review, error) {
+	var review reviews.Review
+	var status, created, updated string
+	err := row.Scan(&review.ID, &review.BookID, &review.ReaderID, &review.Rating, &review.Text,
+		&status, &created, &updated, &review.Helpful)
+	if err != nil {
+		return nil, err
+	}
+	review.Status = reviews.Status(status)
+	if review.CreatedAt, err = time.Parse(timeLayout, created); err != nil {
+		return nil, fmt.Errorf("invalid created_at %q: %w", created, err)
+	}
+	if review.UpdatedAt, err = time.Parse(timeLayout, updated); err != nil {
+		return nil, fmt.Errorf("invalid updated_at %q: %w", updated, err)
+	}
+	return &review, nil
+}
+
+func (r *reviewRepo) Create(ctx context.Context, review *reviews.Review) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO reviews (id, book_id, reader_id, rating, body, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, review.ID, review.BookID, review.ReaderID, review.Rating,
+		review.Text, string(review.Status), formatTime(review.CreatedAt), formatTime(review.UpdatedAt))
+	if err != nil {
+		lg.Error("failed to create review", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) GetByID(ctx context.Context, id int) (*reviews.Review, error) {
+	lg := logger.FromContext(ctx)
+	query := `SELECT ` + reviewColumns + ` FROM reviews r WHERE r.id = ?`
+	review, err := scanReview(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reviews.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get review by id", zap.Error(err))
+		return nil, err
+	}
+	return review, nil
+}
+
+func (r *reviewRepo) Update(ctx context.Context, review *reviews.Review) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		UPDATE reviews
+		SET rating = ?, body = ?, status = ?, updated_at = ?
+		WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, review.Rating, review.Text, string(review.Status),
+		formatTime(review.UpdatedAt), review.ID)
+	if err != nil {
+		lg.Error("failed to update review", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	// Отметки полезности удаляются каскадом.
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM reviews WHERE id = ?`, id); err != nil {
+		lg.Error("failed to delete review", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) List(ctx context.Context, filter reviews.Filter) ([]reviews.Review, error) {
+	lg := logger.FromContext(ctx)
+	order := `r.created_at DESC, r.id DESC`
+	if filter.Sort == reviews.SortHelpful {
+		order = `helpful DESC, ` + order
+	}
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM reviews r
+		WHERE (? = 0 OR r.book_id = ?) AND (? = 0 OR r.reader_id = ?) AND (? = '' OR r.status = ?)
+		ORDER BY ` + order
+	rows, err := r.db.QueryContext(ctx, query, filter.BookID, filter.BookID,
+		filter.ReaderID, filter.ReaderID, string(filter.Status), string(filter.Status))
+	if err != nil {
+		lg.Error("failed to list reviews", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []reviews.Review
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			lg.Error("failed to scan review", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		list = append(list, *review)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return list, nil
+}
+
+func (r *reviewRepo) Vote(ctx context.Context, reviewID, readerID int) error {
+	lg := logger.FromContext(ctx)
+	query := `INSERT INTO review_votes (review_id, reader_id) VALUES (?, ?) ON CONFLICT DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, reviewID, readerID); err != nil {
+		lg.Error("failed to vote for review", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) Unvote(ctx context.Context, reviewID, readerID int) error {
+	lg := logger.FromContext(ctx)
+	query := `DELETE FROM review_votes WHERE review_id = ? AND reader_id = ?`
+	if _, err := r.db.ExecContext(ctx, query, reviewID, readerID); err != nil {
+		lg.Error("failed to remove review vote", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *reviewRepo) BookRating(ctx context.Context, bookID int) (books.Rating, error) {
+	lg := logger.FromContext(ctx)
+	var sum, count int
+	query := `
+		SELECT COALESCE(SUM(rating), 0), COUNT(*)
+		FROM reviews
+		WHERE book_id = ? AND status = 'approved'`
+	if err := r.db.QueryRowContext(ctx, query, bookID).Scan(&sum, &count); err != nil {
+		lg.Error("failed to get book rating", zap.Error(err))
+		return books.Rating{}, err
+	}
+	return books.NewRating(sum, count), nil
+}
+
+func (r *reviewRepo) Ratings(ctx context.Context) (map[int]books.Rating, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT book_id, SUM(rating), COUNT(*)
+		FROM reviews
+		WHERE status = 'approved'
+		GROUP BY book_id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		lg.Error("failed to list book ratings", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratings := make(map[int]books.Rating)
+	for rows.Next() {
+		var bookID, sum, count int
+		if err := rows.Scan(&bookID, &sum, &count); err != nil {
+			lg.Error("failed to scan book rating", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan book rating: %w", err)
+		}
+		ratings[bookID] = books.NewRating(sum, count)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return ratings, nil
+}
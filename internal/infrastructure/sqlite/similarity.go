@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/recommendations"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type similarityRepo struct {
+	db DBTX
+}
+
+func NewSimilarityRepo(db DBTX) recommendations.SimilarityRepo {
+	return &similarityRepo{db: db}
+}
+
+func (r *similarityRepo) Replace(ctx context.Context, neighbours map[int][]recommendations.Neighbour) error {
+	lg := logger.FromContext(ctx)
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM book_similarity`); err != nil {
+			lg.Error("failed to clear book similarity", zap.Error(err))
+			return err
+		}
+		query := `
+			INSERT INTO book_similarity (book_id, similar_book_id, position, score, readers)
+			VALUES (?, ?, ?, ?, ?)`
+		for bookID, list := range neighbours {
+			for i, n := range list {
+				if _, err := tx.ExecContext(ctx, query, bookID, n.BookID, i+1, n.Score, n.Readers); err != nil {
+					lg.Error("failed to insert book similarity", zap.Error(err))
+					return fmt.Errorf("failed to insert neighbour (book_id=%d, similar_book_id=%d): %w", bookID, n.BookID, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (r *similarityRepo) Neighbours(ctx context.Context, bookIDs []int) (map[int][]recommendations.Neighbour, error) {
+	lg := logger.FromContext(ctx)
+	result := make(map[int][]recommendations.Neighbour)
+	if len(bookIDs) == 0 {
+		return result, nil
+	}
+	query := `
+		SELECT book_id, similar_book_id, score, readers
+		FROM book_similarity
+		WHERE book_id IN (?` + strings.Repeat(", ?", len(bookIDs)-1) + `)
+		ORDER BY book_id, position`
+	args := make([]any, len(bookIDs))
+	for i, id := range bookIDs {
+		args[i] = id
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		lg.Error("failed to list book neighbours", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bookID int
+		var n recommendations.Neighbour
+		if err := rows.Scan(&bookID, &n.BookID, &n.Score, &n.Readers); err != nil {
+			lg.Error("failed to scan book neighbour", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan book neighbour: %w", err)
+		}
+		result[bookID] = append(result[bookID], n)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,220 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/tags"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type tagRepo struct {
+	db DBTX
+}
+
+func NewTagRepo(db DBTX) tags.TagRepo {
+	return &tagRepo{db: db}
+}
+
+func (r *tagRepo) Create(ctx context.Context, tag *tags.Tag) error {
+	lg := logger.FromContext(ctx)
+	query := `INSERT INTO tags (id, name) VALUES (?, ?)`
+	if _, err := r.db.ExecContext(ctx, query, tag.ID, tag.Name); err != nil {
+		lg.Error("failed to create tag", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *tagRepo) GetByID(ctx context.Context, id int) (*tags.Tag, error) {
+	return r.getTag(ctx, `SELECT id, name FROM tags WHERE id = ?`, id)
+}
+
+func (r *tagRepo) FindByName(ctx context.Context, name string) (*tags.Tag, error) {
+	query := `
+		SELECT id, name FROM tags WHERE name = ?
+		UNION ALL
+		SELECT t.id, t.name
+		FROM tag_aliases a
+		JOIN tags t ON t.id = a.tag_id
+		WHERE a.alias = ?
+		LIMIT 1`
+	return r.getTag(ctx, query, name, name)
+}
+
+// getTag читает тег запросом, возвращающим id и name, и его синонимы.
+func (r *tagRepo) getTag(ctx context.Context, query string, args ...any) (*tags.Tag, error) {
+	lg := logger.FromContext(ctx)
+	var tag tags.Tag
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&tag.ID, &tag.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, tags.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get tag", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT alias FROM tag_aliases WHERE tag_id = ? ORDER BY alias`, tag.ID)
+	if err != nil {
+		lg.Error("failed to load tag aliases", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			lg.Error("failed to scan tag alias", zap.Error(err))
+			return nil, err
+		}
+		tag.Aliases = append(tag.Aliases, alias)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	// Связи с книгами и синонимы удаляются каскадом.
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM tags WHERE id = ?`, id); err != nil {
+		lg.Error("failed to delete tag", zap.Error(err))
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepo) Counts(ctx context.Context, limit int) ([]tags.TagCount, error) {
+	lg := logger.FromContext(ctx)
+	if limit <= 0 {
+		// Отрицательный LIMIT в SQLite снимает ограничение.
+		limit = -1
+	}
+	query := `
+		SELECT t.id, t.name, COUNT(*)
+		FROM tags t
+		JOIN book_tags bt ON bt.tag_id = t.id
+		GROUP BY t.id, t.name
+		ORDER BY COUNT(*) DESC, t.name
+		LIMIT ?`
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		lg.Error("failed to count tags", zap.Error(err))
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []tags.TagCount
+	for rows.Next() {
+		var c tags.TagCount
+		if err := rows.Scan(&c.ID, &c.Name, &c.Books); err != nil {
+			lg.Error("failed to scan tag count", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan tag count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *tagRepo) AddToBook(ctx context.Context, bookID, tagID int) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO book_tags (book_id, tag_id) VALUES (?, ?)
+		ON CONFLICT DO NOTHING`
+	if _, err := r.db.ExecContext(ctx, query, bookID, tagID); err != nil {
+		lg.Error("failed to tag book", zap.Error(err))
+		return fmt.Errorf("failed to tag book: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepo) RemoveFromBook(ctx context.Context, bookID, tagID int) error {
+	lg := logger.FromContext(ctx)
+	query := `DELETE FROM book_tags WHERE book_id = ? AND tag_id = ?`
+	if _, err := r.db.ExecContext(ctx, query, bookID, tagID); err != nil {
+		lg.Error("failed to untag book", zap.Error(err))
+		return fmt.Errorf("failed to untag book: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepo) BookTags(ctx context.Context, bookID int) ([]tags.Tag, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT t.id, t.name
+		FROM book_tags bt
+		JOIN tags t ON t.id = bt.tag_id
+		WHERE bt.book_id = ?
+		ORDER BY t.name`
+	rows, err := r.db.QueryContext(ctx, query, bookID)
+	if err != nil {
+		lg.Error("failed to list book tags", zap.Error(err))
+		return nil, fmt.Errorf("failed to list book tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tagsList []tags.Tag
+	for rows.Next() {
+		var tag tags.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name); err != nil {
+			lg.Error("failed to scan tag", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tagsList = append(tagsList, tag)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return tagsList, nil
+}
+
+func (r *tagRepo) Merge(ctx context.Context, targetID int, sourceIDs []int) error {
+	lg := logger.FromContext(ctx)
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+	// Массивы SQLite не принимает, поэтому IN собирается по числу тегов.
+	// Безымянный ? получает номер на единицу больше предыдущего, поэтому
+	// во всех запросах ?1 (цель) стоит раньше списка источников.
+	in := "(?" + strings.Repeat(", ?", len(sourceIDs)-1) + ")"
+	args := []any{targetID}
+	for _, id := range sourceIDs {
+		args = append(args, id)
+	}
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		steps := []struct {
+			what  string
+			query string
+		}{
+			{"move book tags", `
+				INSERT INTO book_tags (book_id, tag_id)
+				SELECT book_id, ?1 FROM book_tags WHERE tag_id IN ` + in + `
+				ON CONFLICT DO NOTHING`},
+			{"move tag aliases", `UPDATE tag_aliases SET tag_id = ?1 WHERE tag_id IN ` + in},
+			{"add merged names as aliases", `
+				INSERT INTO tag_aliases (alias, tag_id)
+				SELECT name, ?1 FROM tags WHERE id IN ` + in + ` AND id <> ?1
+				ON CONFLICT (alias) DO UPDATE SET tag_id = excluded.tag_id`},
+			// Оставшиеся связи источников удаляются каскадом.
+			{"delete merged tags", `DELETE FROM tags WHERE id <> ?1 AND id IN ` + in},
+		}
+		for _, step := range steps {
+			if _, err := tx.ExecContext(ctx, step.query, args...); err != nil {
+				lg.Error("failed to merge tags", zap.String("step", step.what), zap.Error(err))
+				return fmt.Errorf("failed to %s: %w", step.what, err)
+			}
+		}
+		return nil
+	})
+}
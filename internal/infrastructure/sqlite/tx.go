@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DBTX — общие методы *sql.DB и *sql.Tx. Репозитории принимают DBTX,
+// поэтому одинаково работают с базой и внутри транзакции.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// withTx выполняет fn атомарно. Поверх *sql.DB открывается транзакция,
+// а внутри уже открытой транзакции — точка сохранения, чтобы ошибка fn
+// откатывала только её изменения.
+func withTx(ctx context.Context, db DBTX, fn func(tx DBTX) error) error {
+	if conn, ok := db.(*sql.DB); ok {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	if _, err := db.ExecContext(ctx, `SAVEPOINT repo`); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+	if err := fn(db); err != nil {
+		if _, rbErr := db.ExecContext(ctx, `ROLLBACK TO repo`); rbErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint: %v)", err, rbErr)
+		}
+		db.ExecContext(ctx, `RELEASE repo`)
+		return err
+	}
+	_, err := db.ExecContext(ctx, `RELEASE repo`)
+	return err
+}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type workRepo struct {
+	db DBTX
+}
+
+func NewWorkRepo(db DBTX) works.WorkRepo {
+	return &workRepo{db: db}
+}
+
+func (r *workRepo) Create(ctx context.Context, work *works.Work) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO works (id, title)
+		VALUES (?, ?)`
+	if _, err := r.db.ExecContext(ctx, query, work.ID, work.Title); err != nil {
+		lg.Error("failed to create work", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *workRepo) GetByID(ctx context.Context, id int) (*works.Work, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id, title
+		FROM works
+		WHERE id = ?`
+	var work works.Work
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&work.ID, &work.Title)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, works.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get work by id", zap.Error(err))
+		return nil, err
+	}
+	return &work, nil
+}
+
+func (r *workRepo) Update(ctx context.Context, work *works.Work) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		UPDATE works
+		SET title = ?
+		WHERE id = ?`
+	if _, err := r.db.ExecContext(ctx, query, work.Title, work.ID); err != nil {
+		lg.Error("failed to update work", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *workRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM works WHERE id = ?`, id); err != nil {
+		lg.Error("failed to delete work", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *workRepo) List(ctx context.Context) ([]works.Work, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id, title
+		FROM works
+		ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		lg.Error("failed to list works", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []works.Work
+	for rows.Next() {
+		var work works.Work
+		if err := rows.Scan(&work.ID, &work.Title); err != nil {
+			lg.Error("failed to scan work", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan work: %w", err)
+		}
+		list = append(list, work)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return list, nil
+}
@@ -0,0 +1,168 @@
+// Package storage собирает набор репозиториев для выбранного хранилища.
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/readinglists"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/recommendations"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reviews"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/tags"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+	authorsrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/authorsRepo"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/booksRepo"
+	genresrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/genresRepo"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/memory"
+	publishersrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/publishersRepo"
+	readersrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/readersRepo"
+	readinglistsrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/readingListsRepo"
+	recommendationsrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/recommendationsRepo"
+	reservrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/reservations"
+	reviewsrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/reviewsRepo"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/sqlite"
+	tagsrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/tagsRepo"
+	worksrepo "github.com/0sokrat0/BookAPI/internal/infrastructure/worksRepo"
+	"github.com/0sokrat0/BookAPI/internal/service/catalog"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repositories — репозитории, с которыми работают сервисы.
+type Repositories struct {
+	Books        books.BookRepo
+	Covers       books.CoverRepo
+	Works        works.WorkRepo
+	Authors      authors.AuthorRepo
+	Publishers   publishers.PublisherRepo
+	Genres       genres.GenreRepo
+	Tags         tags.TagRepo
+	Readers      readers.ReaderRepo
+	Reservations reservations.ReservationRepo
+	Reviews      reviews.ReviewRepo
+	Similarity   recommendations.SimilarityRepo
+	ReadingLists readinglists.ReadingListRepo
+
+	inTx func(ctx context.Context, fn func(Repositories) error) error
+}
+
+// InTx выполняет fn над репозиториями, работающими в одной транзакции:
+// если fn вернула ошибку, все её изменения откатываются. Репозитории,
+// переданные в fn, нельзя использовать после возврата; вложенный InTx
+// выполняется в той же транзакции.
+func (r Repositories) InTx(ctx context.Context, fn func(Repositories) error) error {
+	return r.inTx(ctx, fn)
+}
+
+// CatalogTx адаптирует InTx для сервиса каталога.
+func (r Repositories) CatalogTx() catalog.TxFunc {
+	return func(ctx context.Context, fn func(catalog.Repos) error) error {
+		return r.InTx(ctx, func(tx Repositories) error {
+			return fn(catalog.Repos{Books: tx.Books, Authors: tx.Authors, Genres: tx.Genres, Works: tx.Works, Publishers: tx.Publishers})
+		})
+	}
+}
+
+// joinTx используется внутри транзакции: вложенный вызов продолжает её.
+func (r Repositories) joinTx() Repositories {
+	r.inTx = func(ctx context.Context, fn func(Repositories) error) error {
+		return fn(r)
+	}
+	return r
+}
+
+// NewPostgres возвращает репозитории поверх пула Postgres.
+func NewPostgres(db *pgxpool.Pool) Repositories {
+	repos := postgresRepos(db)
+	repos.inTx = func(ctx context.Context, fn func(Repositories) error) error {
+		return pgx.BeginFunc(ctx, db, func(tx pgx.Tx) error {
+			return fn(postgresRepos(tx).joinTx())
+		})
+	}
+	return repos
+}
+
+func postgresRepos(db postgres.DBTX) Repositories {
+	return Repositories{
+		Books:        booksRepo.NewBookRepo(db),
+		Covers:       booksRepo.NewCoverRepo(db),
+		Works:        worksrepo.NewWorkRepo(db),
+		Authors:      authorsrepo.NewAuthorRepo(db),
+		Publishers:   publishersrepo.NewPublisherRepo(db),
+		Genres:       genresrepo.NewGenreRepo(db),
+		Tags:         tagsrepo.NewTagRepo(db),
+		Readers:      readersrepo.NewReaderRepo(db),
+		Reservations: reservrepo.NewReservationRepo(db),
+		Reviews:      reviewsrepo.NewReviewRepo(db),
+		Similarity:   recommendationsrepo.NewSimilarityRepo(db),
+		ReadingLists: readinglistsrepo.NewReadingListRepo(db),
+	}
+}
+
+// NewSQLite возвращает репозитории поверх файла SQLite.
+func NewSQLite(db *sql.DB) Repositories {
+	repos := sqliteRepos(db)
+	repos.inTx = func(ctx context.Context, fn func(Repositories) error) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback()
+		if err := fn(sqliteRepos(tx).joinTx()); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+	return repos
+}
+
+func sqliteRepos(db sqlite.DBTX) Repositories {
+	return Repositories{
+		Books:        sqlite.NewBookRepo(db),
+		Covers:       sqlite.NewCoverRepo(db),
+		Works:        sqlite.NewWorkRepo(db),
+		Authors:      sqlite.NewAuthorRepo(db),
+		Publishers:   sqlite.NewPublisherRepo(db),
+		Genres:       sqlite.NewGenreRepo(db),
+		Tags:         sqlite.NewTagRepo(db),
+		Readers:      sqlite.NewReaderRepo(db),
+		Reservations: sqlite.NewReservationRepo(db),
+		Reviews:      sqlite.NewReviewRepo(db),
+		Similarity:   sqlite.NewSimilarityRepo(db),
+		ReadingLists: sqlite.NewReadingListRepo(db),
+	}
+}
+
+// NewMemory возвращает репозитории в памяти с общим хранилищем.
+// Данные теряются при перезапуске.
+func NewMemory() Repositories {
+	store := memory.NewStore()
+	repos := Repositories{
+		Books:        memory.NewBookRepo(store),
+		Covers:       memory.NewCoverRepo(store),
+		Works:        memory.NewWorkRepo(store),
+		Authors:      memory.NewAuthorRepo(store),
+		Publishers:   memory.NewPublisherRepo(store),
+		Genres:       memory.NewGenreRepo(store),
+		Tags:         memory.NewTagRepo(store),
+		Readers:      memory.NewReaderRepo(store),
+		Reservations: memory.NewReservationRepo(store),
+		Reviews:      memory.NewReviewRepo(store),
+		Similarity:   memory.NewSimilarityRepo(store),
+		ReadingLists: memory.NewReadingListRepo(store),
+	}
+	repos.inTx = func(ctx context.Context, fn func(Repositories) error) error {
+		return store.InTx(func() error {
+			return fn(repos.joinTx())
+		})
+	}
+	return repos
+}
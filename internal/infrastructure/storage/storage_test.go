@@ -0,0 +1,83 @@
+package storage_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/internal/config"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/repotest"
+	"github.com/0sokrat0/BookAPI/internal/infrastructure/storage"
+	"github.com/0sokrat0/BookAPI/pkg/db/dbmigrate"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/0sokrat0/BookAPI/pkg/db/sqlite"
+)
+
+// postgresDSNEnv — переменная со строкой подключения к отдельной тестовой
+// базе Postgres. Таблицы этой базы очищаются перед каждым случаем.
+const postgresDSNEnv = "BOOKAPI_TEST_POSTGRES_DSN"
+
+func TestMemory(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) storage.Repositories {
+		return storage.NewMemory()
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) storage.Repositories {
+		ctx := repotest.Context(t)
+		cfg := &config.Config{}
+		cfg.Database.Driver = config.DriverSQLite
+		cfg.Database.Path = filepath.Join(t.TempDir(), "test.db")
+
+		db, err := sqlite.New(ctx, cfg)
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		migrateUp(t, db)
+		return storage.NewSQLite(db.DB)
+	})
+}
+
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+
+	ctx := repotest.Context(t)
+	pg, err := postgres.Connect(ctx, dsn, 4, 1)
+	if err != nil {
+		t.Fatalf("connect postgres: %v", err)
+	}
+	t.Cleanup(pg.Close)
+	migrateUp(t, pg)
+
+	repotest.Run(t, func(t *testing.T) storage.Repositories {
+		_, err := pg.DB.Exec(repotest.Context(t),
+			`TRUNCATE reading_list_books, reading_lists, book_similarity, review_votes, reviews, reservations, book_covers, book_tags, tag_aliases, tags, book_genres, genre_names, genres, book_authors, reader_recovery_codes, readers, author_aliases, authors, books, works, publishers`)
+		if err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		return storage.NewPostgres(pg.DB)
+	})
+}
+
+type migratable interface {
+	NewMigrator(ctx context.Context) (*dbmigrate.Migrator, error)
+}
+
+func migrateUp(t *testing.T, db migratable) {
+	t.Helper()
+	ctx := repotest.Context(t)
+	m, err := db.NewMigrator(ctx)
+	if err != nil {
+		t.Fatalf("prepare migrations: %v", err)
+	}
+	defer m.Close()
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("apply migrations: %v", err)
+	}
+}
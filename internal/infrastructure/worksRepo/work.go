@@ -0,0 +1,102 @@
+package worksrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+	"github.com/0sokrat0/BookAPI/pkg/db/postgres"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type workRepo struct {
+	db postgres.DBTX
+}
+
+func NewWorkRepo(db postgres.DBTX) works.WorkRepo {
+	return &workRepo{db: db}
+}
+
+func (r *workRepo) Create(ctx context.Context, work *works.Work) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		INSERT INTO works (id, title)
+		VALUES ($1, $2)`
+	if _, err := r.db.Exec(ctx, query, work.ID, work.Title); err != nil {
+		lg.Error("failed to create work", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *workRepo) GetByID(ctx context.Context, id int) (*works.Work, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id, title
+		FROM works
+		WHERE id = $1`
+	var work works.Work
+	err := r.db.QueryRow(ctx, query, id).Scan(&work.ID, &work.Title)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, works.ErrNotFound
+	}
+	if err != nil {
+		lg.Error("failed to get work by id", zap.Error(err))
+		return nil, err
+	}
+	return &work, nil
+}
+
+func (r *workRepo) Update(ctx context.Context, work *works.Work) error {
+	lg := logger.FromContext(ctx)
+	query := `
+		UPDATE works
+		SET title = $2
+		WHERE id = $1`
+	if _, err := r.db.Exec(ctx, query, work.ID, work.Title); err != nil {
+		lg.Error("failed to update work", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *workRepo) Delete(ctx context.Context, id int) error {
+	lg := logger.FromContext(ctx)
+	if _, err := r.db.Exec(ctx, `DELETE FROM works WHERE id = $1`, id); err != nil {
+		lg.Error("failed to delete work", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *workRepo) List(ctx context.Context) ([]works.Work, error) {
+	lg := logger.FromContext(ctx)
+	query := `
+		SELECT id, title
+		FROM works
+		ORDER BY id`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		lg.Error("failed to list works", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []works.Work
+	for rows.Next() {
+		var work works.Work
+		if err := rows.Scan(&work.ID, &work.Title); err != nil {
+			lg.Error("failed to scan work", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan work: %w", err)
+		}
+		list = append(list, work)
+	}
+	if err := rows.Err(); err != nil {
+		lg.Error("rows error", zap.Error(err))
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return list, nil
+}
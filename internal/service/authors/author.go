@@ -2,12 +2,23 @@ package authors
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/0sokrat0/BookAPI/internal/application/commands"
 	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
 	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
 )
 
+// ErrInvalidInput — неверная дата жизни или список сливаемых авторов.
+var ErrInvalidInput = errors.New("invalid input")
+
+// dateLayout — формат дат жизни в запросах.
+const dateLayout = "2006-01-02"
+
 // AuthorService описывает бизнес-логику для авторов.
 type AuthorService interface {
 	CreateAuthor(ctx context.Context, req commands.CreateAuthorRequest) (*authors.Author, error)
@@ -15,6 +26,10 @@ type AuthorService interface {
 	UpdateAuthor(ctx context.Context, id int, req commands.UpdateAuthorRequest) (*authors.Author, error)
 	DeleteAuthor(ctx context.Context, id int) error
 	ListAuthors(ctx context.Context) ([]authors.Author, error)
+	// SearchAuthors ищет авторов, в имени или синониме которых есть query.
+	SearchAuthors(ctx context.Context, query string) ([]authors.Author, error)
+	// MergeAuthors сливает дубликаты sourceIDs в автора targetID.
+	MergeAuthors(ctx context.Context, targetID int, sourceIDs []int) (*authors.Author, error)
 }
 
 type authorService struct {
@@ -31,11 +46,19 @@ func NewAuthorService(repo authors.AuthorRepo, counter *genid.IDcounter) AuthorS
 }
 
 func (s *authorService) CreateAuthor(ctx context.Context, req commands.CreateAuthorRequest) (*authors.Author, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.CreateAuthor")
+	defer span.End()
+
 	newID := s.idCounter.GenerateID()
 	newAuthor, err := authors.NewAuthor(newID, req.Name, req.Country)
 	if err != nil {
 		return nil, err
 	}
+	newAuthor.SetAliases(req.Aliases)
+	newAuthor.Bio = req.Bio
+	if err := setLifespan(newAuthor, req.BirthDate, req.DeathDate); err != nil {
+		return nil, err
+	}
 	if err := s.authorRepo.Create(ctx, newAuthor); err != nil {
 		return nil, err
 	}
@@ -43,10 +66,16 @@ func (s *authorService) CreateAuthor(ctx context.Context, req commands.CreateAut
 }
 
 func (s *authorService) GetAuthor(ctx context.Context, id int) (*authors.Author, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.GetAuthor")
+	defer span.End()
+
 	return s.authorRepo.GetById(ctx, id)
 }
 
 func (s *authorService) UpdateAuthor(ctx context.Context, id int, req commands.UpdateAuthorRequest) (*authors.Author, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.UpdateAuthor")
+	defer span.End()
+
 	existingAuthor, err := s.authorRepo.GetById(ctx, id)
 	if err != nil {
 		return nil, err
@@ -56,6 +85,11 @@ func (s *authorService) UpdateAuthor(ctx context.Context, id int, req commands.U
 		existingAuthor.Name = req.Name
 	}
 	existingAuthor.Country = req.Country
+	existingAuthor.SetAliases(req.Aliases)
+	existingAuthor.Bio = req.Bio
+	if err := setLifespan(existingAuthor, req.BirthDate, req.DeathDate); err != nil {
+		return nil, err
+	}
 	if err := s.authorRepo.Update(ctx, existingAuthor); err != nil {
 		return nil, err
 	}
@@ -63,9 +97,89 @@ func (s *authorService) UpdateAuthor(ctx context.Context, id int, req commands.U
 }
 
 func (s *authorService) DeleteAuthor(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "AuthorService.DeleteAuthor")
+	defer span.End()
+
 	return s.authorRepo.Delete(ctx, id)
 }
 
 func (s *authorService) ListAuthors(ctx context.Context) ([]authors.Author, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.ListAuthors")
+	defer span.End()
+
 	return s.authorRepo.List(ctx)
 }
+
+func (s *authorService) SearchAuthors(ctx context.Context, query string) ([]authors.Author, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.SearchAuthors")
+	defer span.End()
+
+	list, err := s.authorRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	found := []authors.Author{}
+	for _, a := range list {
+		if a.Matches(query) {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+func (s *authorService) MergeAuthors(ctx context.Context, targetID int, sourceIDs []int) (*authors.Author, error) {
+	ctx, span := tracing.Start(ctx, "AuthorService.MergeAuthors")
+	defer span.End()
+
+	if len(sourceIDs) == 0 {
+		return nil, fmt.Errorf("%w: no authors to merge", ErrInvalidInput)
+	}
+	if slices.Contains(sourceIDs, targetID) {
+		return nil, fmt.Errorf("%w: author %d cannot be merged into itself", ErrInvalidInput, targetID)
+	}
+	target, err := s.authorRepo.GetById(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range sourceIDs {
+		dup, err := s.authorRepo.GetById(ctx, id)
+		if err != nil {
+			if errors.Is(err, authors.ErrNotFound) {
+				return nil, fmt.Errorf("%w: author %d not found", ErrInvalidInput, id)
+			}
+			return nil, err
+		}
+		target.Absorb(*dup)
+	}
+	if err := s.authorRepo.Merge(ctx, target, sourceIDs); err != nil {
+		return nil, err
+	}
+	return s.authorRepo.GetById(ctx, targetID)
+}
+
+// setLifespan разбирает даты жизни; дата смерти не может предшествовать
+// дате рождения.
+func setLifespan(a *authors.Author, birth, death string) error {
+	var err error
+	if a.BirthDate, err = parseDate("birth_date", birth); err != nil {
+		return err
+	}
+	if a.DeathDate, err = parseDate("death_date", death); err != nil {
+		return err
+	}
+	if a.BirthDate != nil && a.DeathDate != nil && a.DeathDate.Before(*a.BirthDate) {
+		return fmt.Errorf("%w: death_date is before birth_date", ErrInvalidInput)
+	}
+	return nil
+}
+
+func parseDate(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be YYYY-MM-DD", ErrInvalidInput, field)
+	}
+	return &t, nil
+}
@@ -2,46 +2,117 @@ package books
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/0sokrat0/BookAPI/internal/application/commands"
 	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
 	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
 )
 
 type bookService struct {
-	bookRepo  books.BookRepo
-	idCounter *genid.IDcounter
+	bookRepo      books.BookRepo
+	genreRepo     genres.GenreRepo
+	workRepo      works.WorkRepo
+	publisherRepo publishers.PublisherRepo
+	idCounter     *genid.IDcounter
+	covers        CoverLoader
+	ratings       RatingLoader
 }
 
-func NewBookService(repo books.BookRepo, counter *genid.IDcounter) BookService {
+// NewBookService создаёт сервис книг; covers и ratings могут быть nil, тогда
+// книги выдаются без обложек и оценок.
+func NewBookService(repo books.BookRepo, genreRepo genres.GenreRepo, workRepo works.WorkRepo, publisherRepo publishers.PublisherRepo, counter *genid.IDcounter, covers CoverLoader, ratings RatingLoader) BookService {
 	return &bookService{
-		bookRepo:  repo,
-		idCounter: counter,
+		bookRepo:      repo,
+		genreRepo:     genreRepo,
+		workRepo:      workRepo,
+		publisherRepo: publisherRepo,
+		idCounter:     counter,
+		covers:        covers,
+		ratings:       ratings,
 	}
 }
 
 func (s *bookService) CreateBook(ctx context.Context, req commands.CreateBookRequest) (*books.Book, error) {
+	ctx, span := tracing.Start(ctx, "BookService.CreateBook")
+	defer span.End()
+
 	if req.Title == "" {
 		return nil, fmt.Errorf("title is required")
 	}
+	contributors, err := contributorList(req.AuthorIDs, req.Contributors)
+	if err != nil {
+		return nil, err
+	}
 	newID := s.idCounter.GenerateID()
-	newBook, err := books.NewBook(newID, req.Title, req.Year, req.ISBN, req.Genre, req.AuthorIDs)
+	newBook, err := books.NewBook(newID, req.Title, req.Year, req.ISBN, nil, req.GenreIDs)
 	if err != nil {
 		return nil, err
 	}
+	newBook.SetContributors(contributors)
+	newBook.PublisherID = req.PublisherID
+	newBook.Language = req.Language
+	newBook.Translator = req.Translator
+	if err := s.checkPublisher(ctx, req.PublisherID); err != nil {
+		return nil, err
+	}
+
+	// Книга без произведения становится единственным изданием нового.
+	var created *works.Work
+	if req.WorkID == 0 {
+		created, err = works.NewWork(s.idCounter.GenerateID(), req.Title)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.workRepo.Create(ctx, created); err != nil {
+			return nil, err
+		}
+		newBook.WorkID = created.ID
+	} else {
+		if err := s.checkWork(ctx, req.WorkID); err != nil {
+			return nil, err
+		}
+		newBook.WorkID = req.WorkID
+	}
 	err = s.bookRepo.Create(ctx, newBook)
 	if err != nil {
+		if created != nil {
+			_ = s.workRepo.Delete(ctx, created.ID)
+		}
+		return nil, err
+	}
+	if err := s.fillGenres(ctx, newBook); err != nil {
 		return nil, err
 	}
 	return newBook, nil
 }
 
 func (s *bookService) GetBook(ctx context.Context, id int) (*books.Book, error) {
-	return s.bookRepo.GetByID(ctx, id)
+	ctx, span := tracing.Start(ctx, "BookService.GetBook")
+	defer span.End()
+
+	book, err := s.bookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillGenres(ctx, book); err != nil {
+		return nil, err
+	}
+	if err := s.loadExtras(ctx, book); err != nil {
+		return nil, err
+	}
+	return book, nil
 }
 
 func (s *bookService) UpdateBook(ctx context.Context, id int, req commands.UpdateBookRequest) (*books.Book, error) {
+	ctx, span := tracing.Start(ctx, "BookService.UpdateBook")
+	defer span.End()
+
 	// Получаем существующую книгу, чтобы обновить её
 	existingBook, err := s.bookRepo.GetByID(ctx, id)
 	if err != nil {
@@ -53,23 +124,180 @@ func (s *bookService) UpdateBook(ctx context.Context, id int, req commands.Updat
 	}
 	existingBook.Year = req.Year
 	existingBook.ISBN = req.ISBN
-	existingBook.Genre = req.Genre
-	existingBook.SetAuthorIDs(req.AuthorIDs)
+	contributors, err := contributorList(req.AuthorIDs, req.Contributors)
+	if err != nil {
+		return nil, err
+	}
+	existingBook.SetContributors(contributors)
+	existingBook.SetGenreIDs(req.GenreIDs)
+	if err := s.checkPublisher(ctx, req.PublisherID); err != nil {
+		return nil, err
+	}
+	existingBook.PublisherID = req.PublisherID
+	existingBook.Language = req.Language
+	existingBook.Translator = req.Translator
+	if req.WorkID != 0 {
+		if err := s.checkWork(ctx, req.WorkID); err != nil {
+			return nil, err
+		}
+		existingBook.WorkID = req.WorkID
+	}
 	// Вызываем репозиторий для сохранения изменений
 	if err := s.bookRepo.Update(ctx, existingBook); err != nil {
 		return nil, err
 	}
+	if err := s.fillGenres(ctx, existingBook); err != nil {
+		return nil, err
+	}
+	if err := s.loadExtras(ctx, existingBook); err != nil {
+		return nil, err
+	}
 	return existingBook, nil
 }
 
 func (s *bookService) DeleteBook(ctx context.Context, id int) error {
-	return s.bookRepo.Delete(ctx, id)
+	ctx, span := tracing.Start(ctx, "BookService.DeleteBook")
+	defer span.End()
+
+	if s.covers == nil {
+		return s.bookRepo.Delete(ctx, id)
+	}
+	// Запись обложки удаляется вместе с книгой, файлы — после неё.
+	book := &books.Book{ID: id}
+	if err := s.covers.LoadCover(ctx, book); err != nil {
+		return err
+	}
+	if err := s.bookRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if book.Cover != nil {
+		s.covers.PurgeCover(ctx, book.Cover)
+	}
+	return nil
 }
 
 func (s *bookService) ListBooks(ctx context.Context) ([]books.Book, error) {
-	return s.bookRepo.List(ctx)
+	ctx, span := tracing.Start(ctx, "BookService.ListBooks")
+	defer span.End()
+
+	list, err := s.bookRepo.List(ctx)
+	return s.withDetails(ctx, list, err)
 }
 
 func (s *bookService) ListBooksByAuthor(ctx context.Context, authorID int) ([]books.Book, error) {
-	return s.bookRepo.ListBooksByAuthor(ctx, authorID)
+	ctx, span := tracing.Start(ctx, "BookService.ListBooksByAuthor")
+	defer span.End()
+
+	list, err := s.bookRepo.ListBooksByAuthor(ctx, authorID)
+	return s.withDetails(ctx, list, err)
+}
+
+func (s *bookService) FindBooks(ctx context.Context, filter books.Filter) ([]books.Book, error) {
+	ctx, span := tracing.Start(ctx, "BookService.FindBooks")
+	defer span.End()
+
+	list := []books.Book{}
+	err := s.bookRepo.Iterate(ctx, filter, func(b *books.Book) error {
+		list = append(list, *b)
+		return nil
+	})
+	return s.withDetails(ctx, list, err)
+}
+
+// contributorList собирает участников книги: сначала авторы из authorIDs,
+// затем contributors в заданном порядке.
+func contributorList(authorIDs []int, list []commands.ContributorRequest) ([]books.Contributor, error) {
+	contributors := make([]books.Contributor, 0, len(authorIDs)+len(list))
+	for _, id := range authorIDs {
+		contributors = append(contributors, books.Contributor{AuthorID: id, Role: books.RoleAuthor})
+	}
+	for _, c := range list {
+		role, err := books.ParseRole(c.Role)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		contributors = append(contributors, books.Contributor{AuthorID: c.AuthorID, Role: role})
+	}
+	if err := books.ValidateContributors(contributors); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	return contributors, nil
+}
+
+// checkWork проверяет, что произведение существует.
+func (s *bookService) checkWork(ctx context.Context, id int) error {
+	_, err := s.workRepo.GetByID(ctx, id)
+	if errors.Is(err, works.ErrNotFound) {
+		return fmt.Errorf("%w: work %d not found", ErrInvalidInput, id)
+	}
+	return err
+}
+
+// checkPublisher проверяет, что издательство существует; 0 — книга без
+// издательства.
+func (s *bookService) checkPublisher(ctx context.Context, id int) error {
+	if id == 0 {
+		return nil
+	}
+	_, err := s.publisherRepo.GetById(ctx, id)
+	if errors.Is(err, publishers.ErrNotFound) {
+		return fmt.Errorf("%w: publisher %d not found", ErrInvalidInput, id)
+	}
+	return err
+}
+
+// fillGenres заполняет Book.Genres одним чтением справочника жанров.
+func (s *bookService) fillGenres(ctx context.Context, list ...*books.Book) error {
+	if len(list) == 0 {
+		return nil
+	}
+	all, err := s.genreRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	names := genres.NameIndex(all)
+	for _, b := range list {
+		b.FillGenres(names)
+	}
+	return nil
+}
+
+// loadExtras дополняет книгу обложкой и оценкой.
+func (s *bookService) loadExtras(ctx context.Context, book *books.Book) error {
+	if s.covers != nil {
+		if err := s.covers.LoadCover(ctx, book); err != nil {
+			return err
+		}
+	}
+	if s.ratings != nil {
+		if err := s.ratings.LoadRating(ctx, book); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withDetails дополняет список книг жанрами, обложками и оценками.
+func (s *bookService) withDetails(ctx context.Context, list []books.Book, err error) ([]books.Book, error) {
+	if err != nil {
+		return nil, err
+	}
+	ptrs := make([]*books.Book, len(list))
+	for i := range list {
+		ptrs[i] = &list[i]
+	}
+	if err := s.fillGenres(ctx, ptrs...); err != nil {
+		return nil, err
+	}
+	if s.covers != nil {
+		if err := s.covers.LoadCovers(ctx, list); err != nil {
+			return nil, err
+		}
+	}
+	if s.ratings != nil {
+		if err := s.ratings.LoadRatings(ctx, list); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
 }
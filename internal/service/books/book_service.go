@@ -2,11 +2,16 @@ package books
 
 import (
 	"context"
+	"errors"
 
 	"github.com/0sokrat0/BookAPI/internal/application/commands"
 	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
 )
 
+// ErrInvalidInput — запрос ссылается на несуществующее произведение или
+// издательство либо содержит неверный список участников.
+var ErrInvalidInput = errors.New("invalid input")
+
 type BookService interface {
 	CreateBook(ctx context.Context, req commands.CreateBookRequest) (*books.Book, error)
 	GetBook(ctx context.Context, id int) (*books.Book, error)
@@ -14,4 +19,20 @@ type BookService interface {
 	DeleteBook(ctx context.Context, id int) error
 	ListBooks(ctx context.Context) ([]books.Book, error)
 	ListBooksByAuthor(ctx context.Context, authorID int) ([]books.Book, error)
+	// FindBooks возвращает книги, подходящие под фильтр, с жанрами,
+	// обложками и оценками.
+	FindBooks(ctx context.Context, filter books.Filter) ([]books.Book, error)
+}
+
+// CoverLoader дополняет книги обложками (см. сервис covers).
+type CoverLoader interface {
+	LoadCover(ctx context.Context, book *books.Book) error
+	LoadCovers(ctx context.Context, list []books.Book) error
+	PurgeCover(ctx context.Context, cover *books.Cover)
+}
+
+// RatingLoader дополняет книги сводкой оценок читателей (см. сервис reviews).
+type RatingLoader interface {
+	LoadRating(ctx context.Context, book *books.Book) error
+	LoadRatings(ctx context.Context, list []books.Book) error
 }
@@ -0,0 +1,56 @@
+// Package catalog выполняет массовые операции над каталогом книг.
+package catalog
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+)
+
+var (
+	// ErrInvalidInput — входные данные нельзя разобрать целиком:
+	// неизвестный формат, некорректный заголовок CSV, неверный ISBN.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrLookupDisabled — поиск по ISBN не настроен.
+	ErrLookupDisabled = errors.New("isbn lookup is not configured")
+)
+
+type CatalogService interface {
+	ImportBooks(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportReport, error)
+	BookFromISBN(ctx context.Context, isbn string) (*BookDraft, error)
+}
+
+// Repos — репозитории каталога, работающие внутри одной транзакции.
+type Repos struct {
+	Books      books.BookRepo
+	Authors    authors.AuthorRepo
+	Genres     genres.GenreRepo
+	Works      works.WorkRepo
+	Publishers publishers.PublisherRepo
+}
+
+// TxFunc выполняет fn в одной транзакции хранилища; ошибка fn её откатывает.
+type TxFunc func(ctx context.Context, fn func(Repos) error) error
+
+type catalogService struct {
+	inTx      TxFunc
+	idCounter *genid.IDcounter
+	metadata  books.MetadataProvider
+}
+
+// NewCatalogService создаёт сервис каталога. metadata может быть nil —
+// тогда BookFromISBN возвращает ErrLookupDisabled.
+func NewCatalogService(inTx TxFunc, counter *genid.IDcounter, metadata books.MetadataProvider) CatalogService {
+	return &catalogService{
+		inTx:      inTx,
+		idCounter: counter,
+		metadata:  metadata,
+	}
+}
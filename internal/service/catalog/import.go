@@ -0,0 +1,463 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+// ImportOptions — параметры импорта.
+type ImportOptions struct {
+	// Format — FormatCSV или FormatJSONL.
+	Format string
+	// BatchSize — число строк в одной транзакции. 0 — весь импорт в одной
+	// транзакции: если хоть одна строка не прошла, ничего не сохраняется.
+	BatchSize int
+	// Offset — сколько первых строк пропустить; равен ResumeOffset
+	// из отчёта прерванного импорта.
+	Offset int
+}
+
+// RowStatus — итог обработки строки.
+type RowStatus string
+
+const (
+	RowCreated RowStatus = "created"
+	RowUpdated RowStatus = "updated"
+	RowSkipped RowStatus = "skipped"
+	RowFailed  RowStatus = "failed"
+)
+
+// RowResult — результат одной строки входного файла.
+type RowResult struct {
+	// Row — номер строки данных, начиная с 1 (заголовок CSV и пустые
+	// строки JSON Lines не считаются).
+	Row    int       `json:"row" example:"1"`
+	Status RowStatus `json:"status" example:"created"`
+	BookID int       `json:"book_id,omitempty" example:"42"`
+	Title  string    `json:"title,omitempty" example:"Война и мир"`
+	ISBN   string    `json:"isbn,omitempty" example:"9785170000000"`
+	Reason string    `json:"reason,omitempty" example:"invalid isbn"`
+	// Unmapped — поля записи MARC, которые не перенесены в книгу:
+	// "tag" для поля целиком, "tag$code" для подполя.
+	Unmapped []string `json:"unmapped,omitempty" example:"245$c,500"`
+}
+
+// ImportReport — отчёт об импорте.
+type ImportReport struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+	// Committed — все обработанные строки сохранены.
+	Committed bool `json:"committed"`
+	// ResumeOffset — сколько строк от начала файла уже сохранено; передаётся
+	// в Offset, чтобы продолжить прерванный импорт.
+	ResumeOffset int `json:"resume_offset"`
+	// Error — причина, по которой импорт остановлен.
+	Error string      `json:"error,omitempty"`
+	Rows  []RowResult `json:"rows"`
+}
+
+func (r *ImportReport) add(rows ...RowResult) {
+	for _, row := range rows {
+		switch row.Status {
+		case RowCreated:
+			r.Created++
+		case RowUpdated:
+			r.Updated++
+		case RowSkipped:
+			r.Skipped++
+		case RowFailed:
+			r.Failed++
+		}
+	}
+	r.Rows = append(r.Rows, rows...)
+}
+
+// errRowsFailed откатывает атомарный импорт, в котором есть непрошедшие строки.
+var errRowsFailed = errors.New("import rolled back because some rows failed")
+
+// ImportBooks создаёт и обновляет книги из CSV или JSON Lines. Книги
+// сопоставляются по ISBN, авторы и жанры — по имени без учёта регистра
+// (жанры — также по локализованным названиям); недостающие создаются,
+// жанры — верхнего уровня. Строка с ошибкой данных попадает в отчёт как failed.
+// Ошибка хранилища останавливает импорт: текущая транзакция откатывается,
+// а отчёт содержит ResumeOffset для продолжения.
+func (s *catalogService) ImportBooks(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportReport, error) {
+	ctx, span := tracing.Start(ctx, "CatalogService.ImportBooks")
+	defer span.End()
+
+	if opts.BatchSize < 0 || opts.Offset < 0 {
+		return nil, fmt.Errorf("%w: batch size and offset must not be negative", ErrInvalidInput)
+	}
+	records, err := newRecordReader(r, opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	src := &rowSource{records: records}
+	for src.row < opts.Offset {
+		if _, err := src.next(); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+	}
+
+	report := &ImportReport{ResumeOffset: src.row, Rows: []RowResult{}}
+	im := &importer{idCounter: s.idCounter}
+
+	if opts.BatchSize == 0 {
+		var rows []RowResult
+		err := s.inTx(ctx, func(repos Repos) error {
+			im.repos = repos
+			var err error
+			rows, err = im.importRows(ctx, src, -1)
+			if err != nil {
+				return err
+			}
+			if slices.ContainsFunc(rows, func(row RowResult) bool { return row.Status == RowFailed }) {
+				return errRowsFailed
+			}
+			return nil
+		})
+		report.add(rows...)
+		if errors.Is(err, errRowsFailed) {
+			report.Error = err.Error()
+			return report, nil
+		}
+		if err != nil {
+			report.Error = err.Error()
+			return report, err
+		}
+		report.Committed = true
+		report.ResumeOffset = src.row
+		return report, nil
+	}
+
+	for !src.done {
+		var rows []RowResult
+		err := s.inTx(ctx, func(repos Repos) error {
+			im.repos = repos
+			var err error
+			rows, err = im.importRows(ctx, src, opts.BatchSize)
+			return err
+		})
+		if err != nil {
+			// Строки откатившегося пакета в отчёт не попадают, кроме той,
+			// на которой произошла ошибка.
+			report.add(RowResult{Row: src.row, Status: RowFailed, Reason: err.Error()})
+			report.Error = fmt.Sprintf("batch after row %d rolled back: %v", report.ResumeOffset, err)
+			return report, err
+		}
+		report.add(rows...)
+		report.ResumeOffset = src.row
+		logger.FromContext(ctx).Infof("import: %d rows committed", src.row)
+	}
+	report.Committed = true
+	return report, nil
+}
+
+// rowSource нумерует записи и запоминает конец входа.
+type rowSource struct {
+	records recordReader
+	row     int
+	done    bool
+}
+
+func (s *rowSource) next() (record, error) {
+	rec, err := s.records.Next()
+	if errors.Is(err, io.EOF) {
+		s.done = true
+		return record{}, io.EOF
+	}
+	if err != nil {
+		return record{}, err
+	}
+	s.row++
+	return rec, nil
+}
+
+// importer применяет записи к хранилищу. Кеши авторов и жанров переживают
+// пакеты: после ошибки хранилища импорт останавливается, поэтому в кеш
+// не попадают записи из откатившихся транзакций.
+type importer struct {
+	repos     Repos
+	idCounter *genid.IDcounter
+	authorIDs map[string]int
+	genreIDs  map[string]int
+	// publisherIDs — ID издательств по названию в нижнем регистре.
+	publisherIDs map[string]int
+	// created — ID авторов, созданных за время работы.
+	created []int
+}
+
+// importRows обрабатывает до limit записей (limit < 0 — до конца входа).
+func (im *importer) importRows(ctx context.Context, src *rowSource, limit int) ([]RowResult, error) {
+	var rows []RowResult
+	for limit < 0 || len(rows) < limit {
+		rec, err := src.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return rows, fmt.Errorf("read row %d: %w", src.row+1, err)
+		}
+		row, err := im.importRecord(ctx, rec)
+		if err != nil {
+			return rows, fmt.Errorf("row %d: %w", src.row, err)
+		}
+		row.Row = src.row
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// importRecord проверяет запись до первой записи в хранилище, поэтому
+// отклонённая строка ничего не меняет. Ошибка означает сбой хранилища.
+func (im *importer) importRecord(ctx context.Context, rec record) (RowResult, error) {
+	row := RowResult{Title: rec.Title, ISBN: rec.ISBN, Unmapped: rec.unmapped}
+	fail := func(reason string) (RowResult, error) {
+		row.Status, row.Reason = RowFailed, reason
+		return row, nil
+	}
+	if rec.err != nil {
+		return fail(rec.err.Error())
+	}
+	if rec.Title == "" {
+		return fail("title is required")
+	}
+	isbn := books.NormalizeISBN(rec.ISBN)
+	if isbn != "" && !books.ValidISBN(isbn) {
+		return fail("invalid isbn")
+	}
+	authorIDs, err := im.resolveAuthors(ctx, cleanNames(rec.Authors))
+	if err != nil {
+		return row, err
+	}
+	genreIDs, err := im.resolveGenres(ctx, rec.genreNames(), true)
+	if err != nil {
+		return row, err
+	}
+	publisherID, err := im.resolvePublisher(ctx, strings.Join(strings.Fields(rec.Publisher), " "))
+	if err != nil {
+		return row, err
+	}
+
+	if isbn != "" {
+		existing, err := im.repos.Books.GetByISBN(ctx, isbn)
+		if err != nil && !errors.Is(err, books.ErrNotFound) {
+			return row, err
+		}
+		if existing != nil {
+			row.BookID = existing.ID
+			if existing.Title == rec.Title && existing.Year == rec.Year && sameEdition(existing, rec, publisherID) &&
+				sameIDs(existing.AuthorIDs(), authorIDs) && sameIDs(existing.GenreIDs(), genreIDs) {
+				row.Status, row.Reason = RowSkipped, "unchanged"
+				return row, nil
+			}
+			existing.Title = rec.Title
+			existing.Year = rec.Year
+			setEdition(existing, rec, publisherID)
+			existing.SetAuthorIDs(authorIDs)
+			existing.SetGenreIDs(genreIDs)
+			if err := im.repos.Books.Update(ctx, existing); err != nil {
+				return row, err
+			}
+			row.Status = RowUpdated
+			return row, nil
+		}
+	}
+
+	book, err := books.NewBook(im.idCounter.GenerateID(), rec.Title, rec.Year, rec.ISBN, authorIDs, genreIDs)
+	if err != nil {
+		return fail(err.Error())
+	}
+	setEdition(book, rec, publisherID)
+	// Новая книга становится единственным изданием нового произведения.
+	work, err := works.NewWork(im.idCounter.GenerateID(), rec.Title)
+	if err != nil {
+		return fail(err.Error())
+	}
+	if err := im.repos.Works.Create(ctx, work); err != nil {
+		return row, err
+	}
+	book.WorkID = work.ID
+	if err := im.repos.Books.Create(ctx, book); err != nil {
+		return row, err
+	}
+	row.BookID, row.Status = book.ID, RowCreated
+	return row, nil
+}
+
+// sameEdition сообщает, совпадают ли сведения об издании в книге и записи.
+func sameEdition(b *books.Book, rec record, publisherID int) bool {
+	return b.PublisherID == publisherID && b.Language == rec.Language && b.Translator == rec.Translator
+}
+
+func setEdition(b *books.Book, rec record, publisherID int) {
+	b.PublisherID, b.Language, b.Translator = publisherID, rec.Language, rec.Translator
+}
+
+// resolvePublisher находит издательство по названию без учёта регистра и
+// создаёт недостающее; пустое название — книга без издательства.
+func (im *importer) resolvePublisher(ctx context.Context, name string) (int, error) {
+	if name == "" {
+		return 0, nil
+	}
+	if im.publisherIDs == nil {
+		list, err := im.repos.Publishers.List(ctx)
+		if err != nil {
+			return 0, err
+		}
+		im.publisherIDs = make(map[string]int, len(list))
+		for _, p := range list {
+			// List идёт по возрастанию ID: при совпадении названий
+			// выбираем издательство с меньшим ID.
+			key := strings.ToLower(p.Name)
+			if _, ok := im.publisherIDs[key]; !ok {
+				im.publisherIDs[key] = p.ID
+			}
+		}
+	}
+	key := strings.ToLower(name)
+	if id, ok := im.publisherIDs[key]; ok {
+		return id, nil
+	}
+	publisher, err := publishers.NewPublisher(im.idCounter.GenerateID(), name, "", "")
+	if err != nil {
+		return 0, err
+	}
+	if err := im.repos.Publishers.Create(ctx, publisher); err != nil {
+		return 0, err
+	}
+	im.publisherIDs[key] = publisher.ID
+	return publisher.ID, nil
+}
+
+// resolveAuthors находит авторов по имени или синониму (см. authors.Key) и создаёт
+// недостающих. Повторы в списке схлопываются.
+func (im *importer) resolveAuthors(ctx context.Context, names []string) ([]int, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if im.authorIDs == nil {
+		list, err := im.repos.Authors.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		im.authorIDs = make(map[string]int, len(list))
+		// Список отсортирован по ID, поэтому при однофамильцах остаётся
+		// автор с меньшим ID. Синонимы не перекрывают основные имена.
+		for _, a := range list {
+			if _, ok := im.authorIDs[authors.Key(a.Name)]; !ok {
+				im.authorIDs[authors.Key(a.Name)] = a.ID
+			}
+		}
+		for _, a := range list {
+			for _, alias := range a.Aliases {
+				if _, ok := im.authorIDs[authors.Key(alias)]; !ok {
+					im.authorIDs[authors.Key(alias)] = a.ID
+				}
+			}
+		}
+	}
+
+	ids := make([]int, 0, len(names))
+	for _, name := range names {
+		key := authors.Key(name)
+		id, ok := im.authorIDs[key]
+		if !ok {
+			author, err := authors.NewAuthor(im.idCounter.GenerateID(), name, "")
+			if err != nil {
+				return nil, err
+			}
+			if err := im.repos.Authors.Create(ctx, author); err != nil {
+				return nil, err
+			}
+			id = author.ID
+			im.authorIDs[key] = id
+			im.created = append(im.created, id)
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// resolveGenres находит жанры по основному или локализованному названию
+// без учёта регистра. Недостающие жанры создаются верхнего уровня, если
+// create, и иначе пропускаются. Повторы в списке схлопываются.
+func (im *importer) resolveGenres(ctx context.Context, names []string, create bool) ([]int, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if im.genreIDs == nil {
+		list, err := im.repos.Genres.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		im.genreIDs = make(map[string]int, len(list))
+		for _, g := range list {
+			keys := []string{genres.Key(g.Name)}
+			for _, name := range g.Names {
+				keys = append(keys, genres.Key(name))
+			}
+			for _, key := range keys {
+				// Основное название проверяется первым; при совпадениях
+				// выбираем жанр с меньшим ID — список отсортирован.
+				if _, ok := im.genreIDs[key]; !ok {
+					im.genreIDs[key] = g.ID
+				}
+			}
+		}
+	}
+
+	ids := make([]int, 0, len(names))
+	for _, name := range names {
+		key := genres.Key(name)
+		id, ok := im.genreIDs[key]
+		if !ok {
+			if !create {
+				continue
+			}
+			genre, err := genres.NewGenre(im.idCounter.GenerateID(), 0, name, nil)
+			if err != nil {
+				return nil, err
+			}
+			if err := im.repos.Genres.Create(ctx, genre); err != nil {
+				return nil, err
+			}
+			id = genre.ID
+			im.genreIDs[key] = id
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// sameIDs сравнивает списки ID без учёта порядка.
+func sameIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+// BookDraft — книга, заполненная по метаданным ISBN. Она не сохраняется:
+// сотрудник проверяет поля и создаёт книгу обычным запросом.
+type BookDraft struct {
+	Title     string `json:"title" example:"Война и мир"`
+	Year      int    `json:"year" example:"1869"`
+	ISBN      string `json:"isbn" example:"9785170000000"`
+	AuthorIDs []int  `json:"author_ids"`
+	// GenreIDs — жанры каталога, совпавшие с рубриками внешнего каталога.
+	// Новые жанры не создаются: рубрик обычно много и они мельче жанров.
+	GenreIDs []int `json:"genre_ids"`
+	// CreatedAuthorIDs — авторы, которых не было в каталоге и которые
+	// созданы при поиске.
+	CreatedAuthorIDs []int `json:"created_author_ids"`
+	// ExistingBookID — книга с этим ISBN уже есть в каталоге.
+	ExistingBookID int `json:"existing_book_id,omitempty" example:"0"`
+	// Subjects — все рубрики внешнего каталога.
+	Subjects []string `json:"subjects"`
+}
+
+// BookFromISBN ищет метаданные книги во внешнем каталоге и возвращает
+// черновик книги. Недостающие авторы создаются, как при импорте.
+func (s *catalogService) BookFromISBN(ctx context.Context, isbn string) (*BookDraft, error) {
+	ctx, span := tracing.Start(ctx, "CatalogService.BookFromISBN")
+	defer span.End()
+
+	isbn = books.NormalizeISBN(isbn)
+	if !books.ValidISBN(isbn) {
+		return nil, fmt.Errorf("%w: invalid isbn", ErrInvalidInput)
+	}
+	if s.metadata == nil {
+		return nil, ErrLookupDisabled
+	}
+	meta, err := s.metadata.LookupISBN(ctx, isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &BookDraft{
+		Title:            meta.Title,
+		Year:             meta.Year,
+		ISBN:             isbn,
+		AuthorIDs:        []int{},
+		GenreIDs:         []int{},
+		CreatedAuthorIDs: []int{},
+		Subjects:         meta.Subjects,
+	}
+	if draft.Subjects == nil {
+		draft.Subjects = []string{}
+	}
+
+	im := &importer{idCounter: s.idCounter}
+	err = s.inTx(ctx, func(repos Repos) error {
+		im.repos = repos
+		existing, err := repos.Books.GetByISBN(ctx, isbn)
+		if err != nil && !errors.Is(err, books.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			draft.ExistingBookID = existing.ID
+		}
+		ids, err := im.resolveAuthors(ctx, cleanNames(meta.Authors))
+		if err != nil {
+			return err
+		}
+		draft.AuthorIDs = append(draft.AuthorIDs, ids...)
+		ids, err = im.resolveGenres(ctx, cleanNames(meta.Subjects), false)
+		if err != nil {
+			return err
+		}
+		draft.GenreIDs = append(draft.GenreIDs, ids...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	draft.CreatedAuthorIDs = append(draft.CreatedAuthorIDs, im.created...)
+	return draft, nil
+}
@@ -0,0 +1,237 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/pkg/marc"
+)
+
+// Соответствие полей MARC 21 и книги:
+//
+//	245 $a $b  — title (подзаголовок через ": ")
+//	020 $a     — isbn (первое поле)
+//	100/700 $a — authors ("Фамилия, Имя" разворачивается в "Имя Фамилия")
+//	264 $c     — year (публикация, ind2=1; иначе 260 $c, иначе любое 264)
+//	650 $a     — genres (все тематические рубрики)
+//
+// Управляющие поля 001–009 описывают саму запись и не сообщаются;
+// остальные поля и подполя, не вошедшие в книгу, попадают в отчёт
+// как "tag" или "tag$code".
+
+// marcSource — общий интерфейс читателей ISO 2709 и MARCXML.
+type marcSource interface {
+	Read() (*marc.Record, error)
+}
+
+type marcReader struct {
+	src marcSource
+}
+
+func (m *marcReader) Next() (record, error) {
+	rec, err := m.src.Read()
+	if errors.Is(err, marc.ErrInvalidRecord) {
+		return record{err: err}, nil
+	}
+	if err != nil {
+		return record{}, err
+	}
+	return recordFromMARC(rec), nil
+}
+
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+func recordFromMARC(m *marc.Record) record {
+	var rec record
+	if !validUTF8(m) {
+		rec.err = errors.New("record is not valid UTF-8 (MARC-8 is not supported)")
+		return rec
+	}
+
+	// used[i] — коды подполей i-го поля данных, перенесённые в книгу.
+	used := make([]map[string]bool, len(m.DataFields))
+	use := func(i int, codes ...string) {
+		if used[i] == nil {
+			used[i] = map[string]bool{}
+		}
+		for _, code := range codes {
+			used[i][code] = true
+		}
+	}
+	first := func(tag string, match func(*marc.DataField) bool) int {
+		for i := range m.DataFields {
+			f := &m.DataFields[i]
+			if f.Tag == tag && (match == nil || match(f)) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	if i := first("245", nil); i >= 0 {
+		f := &m.DataFields[i]
+		rec.Title = trimISBD(f.Subfield("a"))
+		if sub := trimISBD(f.Subfield("b")); sub != "" {
+			rec.Title += ": " + sub
+		}
+		use(i, "a", "b")
+	}
+
+	if i := first("020", func(f *marc.DataField) bool { return len(strings.Fields(f.Subfield("a"))) > 0 }); i >= 0 {
+		// "9785170000000 (пер.)" — уточнение после ISBN отбрасывается.
+		rec.ISBN = strings.Fields(m.DataFields[i].Subfield("a"))[0]
+		use(i, "a")
+	}
+
+	hasYear := func(f *marc.DataField) bool { return yearPattern.MatchString(f.Subfield("c")) }
+	i := first("264", func(f *marc.DataField) bool { return f.Ind2 == "1" && hasYear(f) })
+	if i < 0 {
+		i = first("260", hasYear)
+	}
+	if i < 0 {
+		i = first("264", hasYear)
+	}
+	if i >= 0 {
+		rec.Year, _ = strconv.Atoi(yearPattern.FindString(m.DataFields[i].Subfield("c")))
+		use(i, "c")
+	}
+
+	for i := range m.DataFields {
+		f := &m.DataFields[i]
+		switch f.Tag {
+		case "100", "700":
+			if name := personalName(f); name != "" {
+				rec.Authors = append(rec.Authors, name)
+				use(i, "a")
+			}
+		case "650":
+			if genre := trimISBD(f.Subfield("a")); genre != "" {
+				rec.Genres = append(rec.Genres, genre)
+				use(i, "a")
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	report := func(s string) {
+		if !seen[s] {
+			seen[s] = true
+			rec.unmapped = append(rec.unmapped, s)
+		}
+	}
+	for i, f := range m.DataFields {
+		if used[i] == nil {
+			report(f.Tag)
+			continue
+		}
+		for _, sf := range f.Subfields {
+			if !used[i][sf.Code] {
+				report(f.Tag + "$" + sf.Code)
+			}
+		}
+	}
+	return rec
+}
+
+func validUTF8(m *marc.Record) bool {
+	for _, f := range m.ControlFields {
+		if !utf8.ValidString(f.Value) {
+			return false
+		}
+	}
+	for _, f := range m.DataFields {
+		for _, sf := range f.Subfields {
+			if !utf8.ValidString(sf.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// trimISBD убирает завершающую пунктуацию ISBD (" /", " :", ";", ",", ".").
+// Точка после инициала ("Толстой, Л.") сохраняется.
+func trimISBD(s string) string {
+	s = strings.TrimRight(strings.TrimSpace(s), " /:;,=")
+	if strings.HasSuffix(s, ".") {
+		words := strings.Fields(s)
+		if last := words[len(words)-1]; utf8.RuneCountInString(last) != 2 {
+			s = strings.TrimSuffix(s, ".")
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// personalName возвращает имя из $a. При ind1=1 имя записано как
+// "Фамилия, Имя" и разворачивается в порядок, принятый в каталоге.
+func personalName(f *marc.DataField) string {
+	name := trimISBD(f.Subfield("a"))
+	if f.Ind1 == "1" {
+		if surname, forename, ok := strings.Cut(name, ","); ok && strings.TrimSpace(forename) != "" {
+			name = strings.TrimSpace(forename) + " " + strings.TrimSpace(surname)
+		}
+	}
+	return name
+}
+
+// invertedName записывает имя каталога для 100/700: "Имя Фамилия" становится
+// "Фамилия, Имя" с ind1=1, одно слово остаётся как есть с ind1=0.
+func invertedName(name string) (string, byte) {
+	words := strings.Fields(name)
+	if len(words) < 2 {
+		return name, '0'
+	}
+	last := len(words) - 1
+	return words[last] + ", " + strings.Join(words[:last], " "), '1'
+}
+
+// MARCRecord преобразует книгу в запись MARC 21. authorNames — имена
+// авторов книги в порядке AuthorIDs; первый становится основным (100).
+// genreNames — названия жанров, каждый записывается в 650.
+func MARCRecord(book *books.Book, authorNames, genreNames []string) *marc.Record {
+	rec := marc.NewRecord()
+	rec.AddControlField("001", strconv.Itoa(book.ID))
+	if book.ISBN != "" {
+		rec.AddDataField("020", ' ', ' ', "a", book.ISBN)
+	}
+	if len(authorNames) > 0 {
+		name, ind1 := invertedName(authorNames[0])
+		rec.AddDataField("100", ind1, ' ', "a", name)
+	}
+	// ind1 245: есть ли основная точка доступа (100).
+	titleInd1 := byte('0')
+	if len(authorNames) > 0 {
+		titleInd1 = '1'
+	}
+	rec.AddDataField("245", titleInd1, '0', "a", book.Title)
+	if book.Year != 0 {
+		rec.AddDataField("264", ' ', '1', "c", strconv.Itoa(book.Year))
+	}
+	for _, genre := range genreNames {
+		rec.AddDataField("650", ' ', '4', "a", genre)
+	}
+	if len(authorNames) > 1 {
+		for _, author := range authorNames[1:] {
+			name, ind1 := invertedName(author)
+			rec.AddDataField("700", ind1, ' ', "a", name)
+		}
+	}
+	return rec
+}
+
+func newMARCReader(r io.Reader, format string) (recordReader, error) {
+	switch format {
+	case FormatMARC:
+		return &marcReader{src: marc.NewReader(r)}, nil
+	case FormatMARCXML:
+		return &marcReader{src: marc.NewXMLReader(r)}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported marc format %q", ErrInvalidInput, format)
+	}
+}
@@ -0,0 +1,241 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Форматы импорта.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+	// FormatMARC — MARC 21 в ISO 2709, FormatMARCXML — MARC 21 в XML.
+	FormatMARC    = "marc"
+	FormatMARCXML = "marcxml"
+)
+
+// listSeparator разделяет имена авторов и жанров в колонках CSV.
+const listSeparator = ";"
+
+// maxJSONLine ограничивает длину одной строки JSON Lines.
+const maxJSONLine = 1 << 20
+
+// record — строка входного файла. err заполняется, если строку не удалось
+// разобрать; такая строка попадает в отчёт как failed, импорт продолжается.
+type record struct {
+	Title string `json:"title"`
+	Year  int    `json:"year"`
+	ISBN  string `json:"isbn"`
+	// Publisher — название издательства; Language и Translator описывают
+	// издание.
+	Publisher  string `json:"publisher"`
+	Language   string `json:"language"`
+	Translator string `json:"translator"`
+	// Genre — один жанр, как в файлах до появления справочника жанров;
+	// Genres — список. Оба варианта объединяются при импорте.
+	Genre   string   `json:"genre"`
+	Genres  []string `json:"genres"`
+	Authors []string `json:"authors"`
+
+	err error
+	// unmapped — поля исходной записи, которые не перенесены в книгу.
+	unmapped []string
+}
+
+// recordReader читает записи по одной, не загружая файл целиком.
+// Next возвращает io.EOF после последней записи; прочие ошибки фатальны.
+type recordReader interface {
+	Next() (record, error)
+}
+
+// FormatFromName определяет формат по расширению файла.
+func FormatFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return FormatCSV
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	case ".mrc", ".marc":
+		return FormatMARC
+	case ".xml":
+		return FormatMARCXML
+	default:
+		return ""
+	}
+}
+
+func newRecordReader(r io.Reader, format string) (recordReader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatJSONL:
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 64*1024), maxJSONLine)
+		return &jsonlReader{sc: sc}, nil
+	case FormatMARC, FormatMARCXML:
+		return newMARCReader(r, format)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q, want %s, %s, %s or %s",
+			ErrInvalidInput, format, FormatCSV, FormatJSONL, FormatMARC, FormatMARCXML)
+	}
+}
+
+// csvColumns — допустимые колонки CSV; обязательна только title.
+var csvColumns = map[string]bool{
+	"title":      true,
+	"year":       true,
+	"isbn":       true,
+	"publisher":  true,
+	"language":   true,
+	"translator": true,
+	"genre":      true,
+	"genres":     true,
+	"authors":    true,
+}
+
+// csvReader читает CSV с заголовком. Имена авторов (authors) и жанров
+// (genre или genres) разделяются точкой с запятой.
+type csvReader struct {
+	r       *csv.Reader
+	columns []string
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: empty csv", ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: csv header: %v", ErrInvalidInput, err)
+	}
+	hasTitle := false
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !csvColumns[name] {
+			return nil, fmt.Errorf("%w: unknown csv column %q", ErrInvalidInput, name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: duplicate csv column %q", ErrInvalidInput, name)
+		}
+		seen[name] = true
+		hasTitle = hasTitle || name == "title"
+		header[i] = name
+	}
+	if !hasTitle {
+		return nil, fmt.Errorf("%w: csv header must contain a title column", ErrInvalidInput)
+	}
+	return &csvReader{r: cr, columns: header}, nil
+}
+
+func (c *csvReader) Next() (record, error) {
+	fields, err := c.r.Read()
+	if errors.Is(err, io.EOF) {
+		return record{}, io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return record{err: parseErr.Err}, nil
+	}
+	if err != nil {
+		return record{}, err
+	}
+	if len(fields) != len(c.columns) {
+		return record{err: fmt.Errorf("expected %d fields, got %d", len(c.columns), len(fields))}, nil
+	}
+
+	var rec record
+	for i, value := range fields {
+		value = strings.TrimSpace(value)
+		switch c.columns[i] {
+		case "title":
+			rec.Title = value
+		case "year":
+			if value == "" {
+				continue
+			}
+			year, err := strconv.Atoi(value)
+			if err != nil {
+				rec.err = fmt.Errorf("invalid year %q", value)
+			}
+			rec.Year = year
+		case "isbn":
+			rec.ISBN = value
+		case "publisher":
+			rec.Publisher = value
+		case "language":
+			rec.Language = value
+		case "translator":
+			rec.Translator = value
+		case "genre", "genres":
+			if value != "" {
+				rec.Genres = append(rec.Genres, strings.Split(value, listSeparator)...)
+			}
+		case "authors":
+			if value != "" {
+				rec.Authors = strings.Split(value, listSeparator)
+			}
+		}
+	}
+	return rec, nil
+}
+
+// jsonlReader читает JSON Lines: один объект книги на строку, пустые
+// строки пропускаются.
+type jsonlReader struct {
+	sc *bufio.Scanner
+}
+
+func (j *jsonlReader) Next() (record, error) {
+	for j.sc.Scan() {
+		line := bytes.TrimSpace(j.sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var rec record
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&rec); err != nil {
+			return record{err: fmt.Errorf("invalid json: %v", err)}, nil
+		}
+		rec.Title = strings.TrimSpace(rec.Title)
+		rec.ISBN = strings.TrimSpace(rec.ISBN)
+		rec.Publisher = strings.TrimSpace(rec.Publisher)
+		rec.Language = strings.TrimSpace(rec.Language)
+		rec.Translator = strings.TrimSpace(rec.Translator)
+		return rec, nil
+	}
+	if err := j.sc.Err(); err != nil {
+		return record{}, err
+	}
+	return record{}, io.EOF
+}
+
+// genreNames объединяет Genre и Genres, убирая пустые названия и лишние
+// пробелы.
+func (r *record) genreNames() []string {
+	return cleanNames(append([]string{r.Genre}, r.Genres...))
+}
+
+// cleanNames схлопывает пробелы в именах и отбрасывает пустые.
+func cleanNames(list []string) []string {
+	var names []string
+	for _, name := range list {
+		if name = strings.Join(strings.Fields(name), " "); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,280 @@
+// Package covers хранит обложки книг: оригинал и миниатюры лежат
+// в хранилище файлов (pkg/blob), в базе — только их версия.
+package covers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/pkg/blob"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+var (
+	// ErrTooLarge — файл или изображение превышает допустимый размер.
+	ErrTooLarge = errors.New("cover is too large")
+	// ErrUnsupportedType — содержимое не JPEG, PNG, GIF или WebP.
+	ErrUnsupportedType = errors.New("unsupported cover type")
+	// ErrInvalidImage — файл не удалось разобрать как изображение.
+	ErrInvalidImage = errors.New("invalid cover image")
+)
+
+// Options — параметры сервиса обложек.
+type Options struct {
+	// MaxSize — наибольший размер файла в байтах.
+	MaxSize int64
+	// Widths — ширины миниатюр. Миниатюры шире оригинала не создаются.
+	Widths []int
+	// PublicURL — адрес, по которому хранилище отдаёт объекты напрямую;
+	// пусто — ссылки ведут на GET /book/{id}/cover.
+	PublicURL string
+}
+
+// Image — открытое изображение обложки; Body закрывает вызывающий.
+type Image struct {
+	Body        io.ReadCloser
+	ContentType string
+	// ETag не меняется, пока не заменена обложка.
+	ETag string
+}
+
+type CoverService interface {
+	// Upload заменяет обложку книги изображением из r.
+	Upload(ctx context.Context, bookID int, r io.Reader) (*books.Cover, error)
+	// Open открывает оригинал (width == 0) или наименьшую миниатюру не уже
+	// width; если такой нет, открывается оригинал.
+	Open(ctx context.Context, bookID, width int) (*Image, error)
+	Delete(ctx context.Context, bookID int) error
+
+	// LoadCover и LoadCovers заполняют Book.Cover для выдачи книг.
+	LoadCover(ctx context.Context, book *books.Book) error
+	LoadCovers(ctx context.Context, list []books.Book) error
+	// PurgeCover удаляет файлы обложки; вызывается после удаления книги.
+	PurgeCover(ctx context.Context, cover *books.Cover)
+}
+
+type coverService struct {
+	bookRepo  books.BookRepo
+	coverRepo books.CoverRepo
+	store     blob.BlobStore
+	opts      Options
+}
+
+func NewCoverService(bookRepo books.BookRepo, coverRepo books.CoverRepo, store blob.BlobStore, opts Options) CoverService {
+	opts.Widths = slices.Clone(opts.Widths)
+	slices.Sort(opts.Widths)
+	opts.Widths = slices.Compact(opts.Widths)
+	return &coverService{
+		bookRepo:  bookRepo,
+		coverRepo: coverRepo,
+		store:     store,
+		opts:      opts,
+	}
+}
+
+func (s *coverService) Upload(ctx context.Context, bookID int, r io.Reader) (*books.Cover, error) {
+	ctx, span := tracing.Start(ctx, "CoverService.Upload")
+	defer span.End()
+
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, s.opts.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cover: %w", err)
+	}
+	if int64(len(data)) > s.opts.MaxSize {
+		return nil, fmt.Errorf("%w: file exceeds %d bytes", ErrTooLarge, s.opts.MaxSize)
+	}
+	img, contentType, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	bounds := img.Bounds()
+	cover := &books.Cover{
+		BookID:      bookID,
+		Version:     hex.EncodeToString(sum[:8]),
+		ContentType: contentType,
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+	}
+
+	old, err := s.coverRepo.Get(ctx, bookID)
+	if err != nil && !errors.Is(err, books.ErrCoverNotFound) {
+		return nil, err
+	}
+	if old != nil && old.Version == cover.Version {
+		s.attachURLs(old)
+		return old, nil
+	}
+
+	// Всё, что успели записать, удаляется, если обложку сохранить не удалось.
+	var written []string
+	put := func(key string, data []byte, contentType string) error {
+		if err := s.store.Put(ctx, key, data, contentType); err != nil {
+			return fmt.Errorf("failed to store %s: %w", key, err)
+		}
+		written = append(written, key)
+		return nil
+	}
+	err = put(originalKey(cover), data, contentType)
+	for _, width := range s.opts.Widths {
+		if err != nil || width >= cover.Width {
+			break
+		}
+		var thumb []byte
+		if thumb, err = thumbnail(img, width); err == nil {
+			if err = put(thumbnailKey(cover, width), thumb, "image/jpeg"); err == nil {
+				cover.ThumbnailWidths = append(cover.ThumbnailWidths, width)
+			}
+		}
+	}
+	if err == nil {
+		err = s.coverRepo.Save(ctx, cover)
+	}
+	if err != nil {
+		s.deleteKeys(ctx, written)
+		return nil, err
+	}
+	if old != nil {
+		s.PurgeCover(ctx, old)
+	}
+	s.attachURLs(cover)
+	return cover, nil
+}
+
+func (s *coverService) Open(ctx context.Context, bookID, width int) (*Image, error) {
+	ctx, span := tracing.Start(ctx, "CoverService.Open")
+	defer span.End()
+
+	cover, err := s.coverRepo.Get(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	key, contentType, etag := originalKey(cover), cover.ContentType, cover.Version
+	if width > 0 {
+		for _, w := range cover.ThumbnailWidths {
+			if w >= width {
+				key, contentType, etag = thumbnailKey(cover, w), "image/jpeg", cover.Version+"-w"+strconv.Itoa(w)
+				break
+			}
+		}
+	}
+	body, err := s.store.Get(ctx, key)
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s is missing from the store", books.ErrCoverNotFound, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Image{Body: body, ContentType: contentType, ETag: `"` + etag + `"`}, nil
+}
+
+func (s *coverService) Delete(ctx context.Context, bookID int) error {
+	ctx, span := tracing.Start(ctx, "CoverService.Delete")
+	defer span.End()
+
+	cover, err := s.coverRepo.Get(ctx, bookID)
+	if err != nil {
+		return err
+	}
+	if err := s.coverRepo.Delete(ctx, bookID); err != nil {
+		return err
+	}
+	s.PurgeCover(ctx, cover)
+	return nil
+}
+
+func (s *coverService) LoadCover(ctx context.Context, book *books.Book) error {
+	cover, err := s.coverRepo.Get(ctx, book.ID)
+	if errors.Is(err, books.ErrCoverNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.attachURLs(cover)
+	book.Cover = cover
+	return nil
+}
+
+func (s *coverService) LoadCovers(ctx context.Context, list []books.Book) error {
+	if len(list) == 0 {
+		return nil
+	}
+	covers, err := s.coverRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	byBook := make(map[int]*books.Cover, len(covers))
+	for i := range covers {
+		byBook[covers[i].BookID] = &covers[i]
+	}
+	for i := range list {
+		if cover, ok := byBook[list[i].ID]; ok {
+			s.attachURLs(cover)
+			list[i].Cover = cover
+		}
+	}
+	return nil
+}
+
+// PurgeCover удаляет файлы без повторных попыток: ошибка оставляет в
+// хранилище лишние объекты, но не ломает выдачу, поэтому только логируется.
+func (s *coverService) PurgeCover(ctx context.Context, cover *books.Cover) {
+	keys := []string{originalKey(cover)}
+	for _, w := range cover.ThumbnailWidths {
+		keys = append(keys, thumbnailKey(cover, w))
+	}
+	s.deleteKeys(ctx, keys)
+}
+
+func (s *coverService) deleteKeys(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			logger.FromContext(ctx).Warnw("failed to delete cover blob", "key", key, "error", err)
+		}
+	}
+}
+
+// attachURLs заполняет ссылки на оригинал и миниатюры. Версия входит
+// в ссылку, поэтому изображения можно кешировать надолго.
+func (s *coverService) attachURLs(cover *books.Cover) {
+	cover.URL = s.url(cover, originalKey(cover), 0)
+	cover.Thumbnails = nil
+	if len(cover.ThumbnailWidths) > 0 {
+		cover.Thumbnails = make(map[int]string, len(cover.ThumbnailWidths))
+		for _, w := range cover.ThumbnailWidths {
+			cover.Thumbnails[w] = s.url(cover, thumbnailKey(cover, w), w)
+		}
+	}
+}
+
+func (s *coverService) url(cover *books.Cover, key string, width int) string {
+	if s.opts.PublicURL != "" {
+		return s.opts.PublicURL + "/" + key
+	}
+	q := url.Values{"v": {cover.Version}}
+	if width > 0 {
+		q.Set("width", strconv.Itoa(width))
+	}
+	return fmt.Sprintf("/book/%d/cover?%s", cover.BookID, q.Encode())
+}
+
+func originalKey(cover *books.Cover) string {
+	return fmt.Sprintf("covers/%d/%s/original%s", cover.BookID, cover.Version, extensions[cover.ContentType])
+}
+
+func thumbnailKey(cover *books.Cover, width int) string {
+	return fmt.Sprintf("covers/%d/%s/w%d.jpg", cover.BookID, cover.Version, width)
+}
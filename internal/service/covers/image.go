@@ -0,0 +1,69 @@
+package covers
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// maxPixels ограничивает размер картинки после распаковки: небольшой
+// файл может описывать изображение на гигабайты памяти.
+const maxPixels = 40_000_000
+
+// thumbnailQuality — качество JPEG для миниатюр.
+const thumbnailQuality = 85
+
+// extensions — допустимые типы обложек и расширения ключей для них.
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// decode определяет тип по содержимому, а не по заголовку клиента,
+// проверяет размер в пикселях и только затем распаковывает изображение.
+func decode(data []byte) (image.Image, string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := extensions[contentType]; !ok {
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, "", fmt.Errorf("%w: empty image", ErrInvalidImage)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxPixels {
+		return nil, "", fmt.Errorf("%w: %dx%d pixels", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	return img, contentType, nil
+}
+
+// thumbnail уменьшает изображение до ширины width с сохранением пропорций
+// и кодирует в JPEG. Прозрачные области заливаются белым.
+func thumbnail(src image.Image, width int) ([]byte, error) {
+	b := src.Bounds()
+	height := max(1, b.Dy()*width/b.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,80 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+)
+
+// column — выгружаемое поле сущности T.
+type column[T any] struct {
+	name  string
+	value func(*T) any
+}
+
+var bookColumns = []column[books.Book]{
+	{"id", func(b *books.Book) any { return b.ID }},
+	{"title", func(b *books.Book) any { return b.Title }},
+	{"year", func(b *books.Book) any { return b.Year }},
+	{"isbn", func(b *books.Book) any { return b.ISBN }},
+	{"work_id", func(b *books.Book) any { return b.WorkID }},
+	{"publisher_id", func(b *books.Book) any { return b.PublisherID }},
+	{"language", func(b *books.Book) any { return b.Language }},
+	{"translator", func(b *books.Book) any { return b.Translator }},
+	{"author_ids", func(b *books.Book) any { return b.AuthorIDs() }},
+	{"contributors", contributorsValue},
+	{"genre_ids", func(b *books.Book) any { return b.GenreIDs() }},
+}
+
+// contributorsValue записывает участников как «автор:роль» в порядке книги.
+func contributorsValue(b *books.Book) any {
+	list := b.Contributors()
+	values := make([]string, len(list))
+	for i, c := range list {
+		values[i] = strconv.Itoa(c.AuthorID) + ":" + string(c.Role)
+	}
+	return values
+}
+
+var authorColumns = []column[authors.Author]{
+	{"id", func(a *authors.Author) any { return a.ID }},
+	{"name", func(a *authors.Author) any { return a.Name }},
+	{"country", func(a *authors.Author) any { return a.Country }},
+	{"aliases", func(a *authors.Author) any { return a.Aliases }},
+	{"birth_date", func(a *authors.Author) any { return optionalDate(a.BirthDate) }},
+	{"death_date", func(a *authors.Author) any { return optionalDate(a.DeathDate) }},
+	{"bio", func(a *authors.Author) any { return a.Bio }},
+}
+
+// optionalDate записывает неизвестную дату пустой строкой.
+func optionalDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+// Пароль и секреты второго фактора не выгружаются.
+var readerColumns = []column[readers.Reader]{
+	{"id", func(r *readers.Reader) any { return r.ID }},
+	{"name", func(r *readers.Reader) any { return r.Name }},
+	{"phone", func(r *readers.Reader) any { return r.Phone }},
+	{"email", func(r *readers.Reader) any { return r.Email }},
+	{"admin", func(r *readers.Reader) any { return r.Admin }},
+	{"totp_enabled", func(r *readers.Reader) any { return r.TOTPEnabled }},
+}
+
+const dateLayout = "2006-01-02"
+
+var reservationColumns = []column[reservations.Reservation]{
+	{"id", func(r *reservations.Reservation) any { return r.ID }},
+	{"book_id", func(r *reservations.Reservation) any { return r.Book.ID }},
+	{"reader_id", func(r *reservations.Reservation) any { return r.Reader.ID }},
+	{"start_date", func(r *reservations.Reservation) any { return r.StartDate.Format(dateLayout) }},
+	{"end_date", func(r *reservations.Reservation) any { return r.EndDate.Format(dateLayout) }},
+	{"returned_date", func(r *reservations.Reservation) any { return optionalDate(r.ReturnedDate) }},
+}
@@ -0,0 +1,159 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// flushEvery — через сколько строк буфер отправляется клиенту.
+const flushEvery = 256
+
+// encoder пишет строки сущности T в выбранном формате.
+type encoder[T any] struct {
+	format  string
+	columns []column[T]
+}
+
+func newEncoder[T any](opts Options, all []column[T]) (*encoder[T], error) {
+	format := normalizeFormat(opts.Format)
+	if isMARC(format) {
+		return nil, fmt.Errorf("%w: format %q is only supported for books", ErrInvalidOptions, opts.Format)
+	}
+	if format != FormatCSV && format != FormatNDJSON {
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidOptions, opts.Format)
+	}
+	columns, err := selectColumns(all, opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+	return &encoder[T]{format: format, columns: columns}, nil
+}
+
+// stream пишет заголовок и строки, которые iterate передаёт в write.
+func stream[T any](w io.Writer, enc *encoder[T], iterate func(write func(*T) error) error) error {
+	bw := bufio.NewWriter(w)
+	write, err := enc.rowWriter(bw)
+	if err != nil {
+		return err
+	}
+	rows := 0
+	err = iterate(func(item *T) error {
+		if err := write(item); err != nil {
+			return err
+		}
+		if rows++; rows%flushEvery == 0 {
+			return flush(bw, w)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush(bw, w)
+}
+
+// flush сбрасывает буфер и, если w сам буферизован (поток ответа fasthttp),
+// его тоже, чтобы строки уходили клиенту по мере чтения.
+func flush(bw *bufio.Writer, w io.Writer) error {
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if f, ok := w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (e *encoder[T]) rowWriter(w *bufio.Writer) (func(*T) error, error) {
+	if e.format == FormatNDJSON {
+		return e.ndjsonRow(w), nil
+	}
+	cw := csv.NewWriter(w)
+	header := make([]string, len(e.columns))
+	for i, c := range e.columns {
+		header[i] = c.name
+	}
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+	record := make([]string, len(e.columns))
+	return func(item *T) error {
+		for i, c := range e.columns {
+			record[i] = csvValue(c.value(item))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+		// csv.Writer буферизует сам; сбрасываем его в bufio.Writer после
+		// каждой строки, чтобы периодический flush видел все данные.
+		cw.Flush()
+		return cw.Error()
+	}, nil
+}
+
+// ndjsonRow пишет объект с ключами в порядке колонок, чего не даёт map.
+func (e *encoder[T]) ndjsonRow(w *bufio.Writer) func(*T) error {
+	var buf bytes.Buffer
+	return func(item *T) error {
+		buf.Reset()
+		buf.WriteByte('{')
+		for i, c := range e.columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(c.name)
+			buf.Write(key)
+			buf.WriteByte(':')
+			value, err := json.Marshal(jsonValue(c.value(item)))
+			if err != nil {
+				return err
+			}
+			buf.Write(value)
+		}
+		buf.WriteString("}\n")
+		_, err := w.Write(buf.Bytes())
+		return err
+	}
+}
+
+func csvValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case []int:
+		ids := make([]string, len(v))
+		for i, id := range v {
+			ids[i] = strconv.Itoa(id)
+		}
+		return strings.Join(ids, ";")
+	case []string:
+		return strings.Join(v, ";")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// jsonValue заменяет nil-срез пустым массивом.
+func jsonValue(v any) any {
+	switch list := v.(type) {
+	case []int:
+		if list == nil {
+			return []int{}
+		}
+	case []string:
+		if list == nil {
+			return []string{}
+		}
+	}
+	return v
+}
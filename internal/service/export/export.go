@@ -0,0 +1,198 @@
+// Package export выгружает каталог потоком в CSV и NDJSON.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	// FormatMARC и FormatMARCXML — записи MARC 21; только для книг.
+	FormatMARC    = "marc"
+	FormatMARCXML = "marcxml"
+)
+
+// ErrInvalidOptions — неизвестный формат или колонка.
+var ErrInvalidOptions = errors.New("invalid export options")
+
+// Options — параметры выгрузки.
+type Options struct {
+	// Format — FormatCSV (по умолчанию) или FormatNDJSON; "jsonl" — синоним
+	// NDJSON. Книги также выгружаются в FormatMARC и FormatMARCXML.
+	Format string
+	// Columns — выгружаемые колонки в нужном порядке; пусто — все колонки.
+	// Для MARC не задаются: состав записи фиксирован.
+	Columns []string
+}
+
+// WriteFunc пишет выгрузку в w. Строки читаются из хранилища по одной,
+// поэтому выборка целиком в памяти не держится.
+type WriteFunc func(ctx context.Context, w io.Writer) error
+
+type ExportService interface {
+	ExportBooks(ctx context.Context, opts Options, filter books.Filter) (WriteFunc, error)
+	ExportAuthors(ctx context.Context, opts Options) (WriteFunc, error)
+	ExportReaders(ctx context.Context, opts Options) (WriteFunc, error)
+	ExportReservations(ctx context.Context, opts Options, filter reservations.Filter) (WriteFunc, error)
+}
+
+type exportService struct {
+	bookRepo        books.BookRepo
+	authorRepo      authors.AuthorRepo
+	genreRepo       genres.GenreRepo
+	readerRepo      readers.ReaderRepo
+	reservationRepo reservations.ReservationRepo
+}
+
+func NewExportService(bookRepo books.BookRepo, authorRepo authors.AuthorRepo, genreRepo genres.GenreRepo, readerRepo readers.ReaderRepo, reservationRepo reservations.ReservationRepo) ExportService {
+	return &exportService{
+		bookRepo:        bookRepo,
+		authorRepo:      authorRepo,
+		genreRepo:       genreRepo,
+		readerRepo:      readerRepo,
+		reservationRepo: reservationRepo,
+	}
+}
+
+// Параметры проверяются сразу, чтобы ошибка ушла клиенту до начала потока;
+// сама выгрузка начинается при вызове WriteFunc.
+
+func (s *exportService) ExportBooks(ctx context.Context, opts Options, filter books.Filter) (WriteFunc, error) {
+	if isMARC(opts.Format) {
+		if len(opts.Columns) > 0 {
+			return nil, fmt.Errorf("%w: columns are not supported for %s", ErrInvalidOptions, opts.Format)
+		}
+		return func(ctx context.Context, w io.Writer) error {
+			ctx, span := tracing.Start(ctx, "ExportService.ExportBooks")
+			defer span.End()
+			return s.exportBooksMARC(ctx, w, opts.Format, filter)
+		}, nil
+	}
+	enc, err := newEncoder(opts, bookColumns)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, w io.Writer) error {
+		ctx, span := tracing.Start(ctx, "ExportService.ExportBooks")
+		defer span.End()
+		return stream(w, enc, func(write func(*books.Book) error) error {
+			return s.bookRepo.Iterate(ctx, filter, write)
+		})
+	}, nil
+}
+
+func (s *exportService) ExportAuthors(ctx context.Context, opts Options) (WriteFunc, error) {
+	enc, err := newEncoder(opts, authorColumns)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, w io.Writer) error {
+		ctx, span := tracing.Start(ctx, "ExportService.ExportAuthors")
+		defer span.End()
+		return stream(w, enc, func(write func(*authors.Author) error) error {
+			return s.authorRepo.Iterate(ctx, write)
+		})
+	}, nil
+}
+
+func (s *exportService) ExportReaders(ctx context.Context, opts Options) (WriteFunc, error) {
+	enc, err := newEncoder(opts, readerColumns)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, w io.Writer) error {
+		ctx, span := tracing.Start(ctx, "ExportService.ExportReaders")
+		defer span.End()
+		return stream(w, enc, func(write func(*readers.Reader) error) error {
+			return s.readerRepo.Iterate(ctx, write)
+		})
+	}, nil
+}
+
+func (s *exportService) ExportReservations(ctx context.Context, opts Options, filter reservations.Filter) (WriteFunc, error) {
+	enc, err := newEncoder(opts, reservationColumns)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, w io.Writer) error {
+		ctx, span := tracing.Start(ctx, "ExportService.ExportReservations")
+		defer span.End()
+		return stream(w, enc, func(write func(*reservations.Reservation) error) error {
+			return s.reservationRepo.Iterate(ctx, filter, write)
+		})
+	}, nil
+}
+
+// ContentType возвращает MIME-тип выгрузки в формате format.
+func ContentType(format string) string {
+	switch normalizeFormat(format) {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatMARC:
+		return "application/marc"
+	case FormatMARCXML:
+		return "application/marcxml+xml"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// FileExtension возвращает расширение файла выгрузки в формате format.
+func FileExtension(format string) string {
+	switch normalizeFormat(format) {
+	case FormatNDJSON:
+		return "ndjson"
+	case FormatMARC:
+		return "mrc"
+	case FormatMARCXML:
+		return "xml"
+	default:
+		return "csv"
+	}
+}
+
+func normalizeFormat(format string) string {
+	switch strings.ToLower(format) {
+	case "", FormatCSV:
+		return FormatCSV
+	case FormatNDJSON, "jsonl":
+		return FormatNDJSON
+	case FormatMARC, FormatMARCXML:
+		return strings.ToLower(format)
+	default:
+		return format
+	}
+}
+
+// selectColumns возвращает колонки из all в порядке names; пустой names — все.
+func selectColumns[T any](all []column[T], names []string) ([]column[T], error) {
+	if len(names) == 0 {
+		return all, nil
+	}
+	selected := make([]column[T], 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		i := slices.IndexFunc(all, func(c column[T]) bool { return c.name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidOptions, name)
+		}
+		if slices.ContainsFunc(selected, func(c column[T]) bool { return c.name == name }) {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidOptions, name)
+		}
+		selected = append(selected, all[i])
+	}
+	return selected, nil
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/authors"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	"github.com/0sokrat0/BookAPI/internal/service/catalog"
+	"github.com/0sokrat0/BookAPI/pkg/marc"
+)
+
+func isMARC(format string) bool {
+	format = normalizeFormat(format)
+	return format == FormatMARC || format == FormatMARCXML
+}
+
+// marcWriter — общий интерфейс писателей ISO 2709 и MARCXML.
+type marcWriter interface {
+	Write(rec *marc.Record) error
+}
+
+// exportBooksMARC пишет книги записями MARC 21. Имена авторов и жанров
+// загружаются заранее: их на порядки меньше, чем книг, а Iterate книг
+// держит соединение и не допускает вложенных запросов.
+func (s *exportService) exportBooksMARC(ctx context.Context, w io.Writer, format string, filter books.Filter) error {
+	names := map[int]string{}
+	err := s.authorRepo.Iterate(ctx, func(a *authors.Author) error {
+		names[a.ID] = a.Name
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	genreList, err := s.genreRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	genreNames := genres.NameIndex(genreList)
+
+	bw := bufio.NewWriter(w)
+	var out marcWriter
+	var xw *marc.XMLWriter
+	if normalizeFormat(format) == FormatMARCXML {
+		xw = marc.NewXMLWriter(bw)
+		out = xw
+	} else {
+		out = marc.NewWriter(bw)
+	}
+
+	rows := 0
+	err = s.bookRepo.Iterate(ctx, filter, func(b *books.Book) error {
+		if err := out.Write(catalog.MARCRecord(b, lookupNames(names, b.AuthorIDs()), lookupNames(genreNames, b.GenreIDs()))); err != nil {
+			return fmt.Errorf("book %d: %w", b.ID, err)
+		}
+		if rows++; rows%flushEvery == 0 {
+			return flush(bw, w)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if xw != nil {
+		if err := xw.Close(); err != nil {
+			return err
+		}
+	}
+	return flush(bw, w)
+}
+
+// lookupNames возвращает имена по ids в том же порядке, пропуская
+// неизвестные.
+func lookupNames(names map[int]string, ids []int) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if name, ok := names[id]; ok {
+			out = append(out, name)
+		}
+	}
+	return out
+}
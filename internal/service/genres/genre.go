@@ -0,0 +1,192 @@
+// Package genres управляет иерархическим справочником жанров.
+package genres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/genres"
+	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+var (
+	// ErrInvalidInput — пустое или занятое название, неизвестный родитель
+	// или цикл в иерархии.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrInUse — у жанра есть дочерние жанры или книги.
+	ErrInUse = errors.New("genre is in use")
+)
+
+// GenreService описывает бизнес-логику для жанров.
+type GenreService interface {
+	CreateGenre(ctx context.Context, req commands.CreateGenreRequest) (*genres.Genre, error)
+	GetGenre(ctx context.Context, id int) (*genres.Genre, error)
+	UpdateGenre(ctx context.Context, id int, req commands.UpdateGenreRequest) (*genres.Genre, error)
+	DeleteGenre(ctx context.Context, id int) error
+	ListGenres(ctx context.Context) ([]genres.Genre, error)
+	// ListBooks возвращает книги жанра и всех его потомков.
+	ListBooks(ctx context.Context, id int) ([]books.Book, error)
+}
+
+// BookFinder выдаёт книги по фильтру вместе с жанрами и обложками
+// (см. сервис books).
+type BookFinder interface {
+	FindBooks(ctx context.Context, filter books.Filter) ([]books.Book, error)
+}
+
+type genreService struct {
+	genreRepo genres.GenreRepo
+	bookRepo  books.BookRepo
+	idCounter *genid.IDcounter
+	finder    BookFinder
+}
+
+// NewGenreService возвращает реализацию GenreService.
+func NewGenreService(repo genres.GenreRepo, bookRepo books.BookRepo, counter *genid.IDcounter, finder BookFinder) GenreService {
+	return &genreService{
+		genreRepo: repo,
+		bookRepo:  bookRepo,
+		idCounter: counter,
+		finder:    finder,
+	}
+}
+
+func (s *genreService) CreateGenre(ctx context.Context, req commands.CreateGenreRequest) (*genres.Genre, error) {
+	ctx, span := tracing.Start(ctx, "GenreService.CreateGenre")
+	defer span.End()
+
+	genre, err := genres.NewGenre(s.idCounter.GenerateID(), req.ParentID, req.Name, req.Names)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := s.validate(ctx, genre); err != nil {
+		return nil, err
+	}
+	if err := s.genreRepo.Create(ctx, genre); err != nil {
+		return nil, err
+	}
+	return genre, nil
+}
+
+func (s *genreService) GetGenre(ctx context.Context, id int) (*genres.Genre, error) {
+	ctx, span := tracing.Start(ctx, "GenreService.GetGenre")
+	defer span.End()
+
+	return s.genreRepo.GetByID(ctx, id)
+}
+
+func (s *genreService) UpdateGenre(ctx context.Context, id int, req commands.UpdateGenreRequest) (*genres.Genre, error) {
+	ctx, span := tracing.Start(ctx, "GenreService.UpdateGenre")
+	defer span.End()
+
+	existing, err := s.genreRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	name := req.Name
+	if name == "" {
+		name = existing.Name
+	}
+	genre, err := genres.NewGenre(id, req.ParentID, name, req.Names)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := s.validate(ctx, genre); err != nil {
+		return nil, err
+	}
+	if err := s.genreRepo.Update(ctx, genre); err != nil {
+		return nil, err
+	}
+	return genre, nil
+}
+
+// validate проверяет жанр против всего справочника: родитель существует
+// и не лежит в поддереве самого жанра, а названия — основное и
+// локализованные — не совпадают с названиями других жанров.
+func (s *genreService) validate(ctx context.Context, genre *genres.Genre) error {
+	list, err := s.genreRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	if genre.ParentID != 0 {
+		if !slices.ContainsFunc(list, func(g genres.Genre) bool { return g.ID == genre.ParentID }) {
+			return fmt.Errorf("%w: parent genre %d not found", ErrInvalidInput, genre.ParentID)
+		}
+		if slices.Contains(genres.Subtree(list, genre.ID), genre.ParentID) {
+			return fmt.Errorf("%w: genre %d cannot be moved under its own subtree", ErrInvalidInput, genre.ID)
+		}
+	}
+	names := []string{genre.Name}
+	for _, name := range genre.Names {
+		names = append(names, name)
+	}
+	for _, g := range list {
+		if g.ID == genre.ID {
+			continue
+		}
+		for _, name := range names {
+			if g.Matches(genres.Key(name)) {
+				return fmt.Errorf("%w: name %q is already used by genre %d", ErrInvalidInput, name, g.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *genreService) DeleteGenre(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "GenreService.DeleteGenre")
+	defer span.End()
+
+	list, err := s.genreRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(list, func(g genres.Genre) bool { return g.ID == id }) {
+		return genres.ErrNotFound
+	}
+	if slices.ContainsFunc(list, func(g genres.Genre) bool { return g.ParentID == id }) {
+		return fmt.Errorf("%w: genre %d has child genres", ErrInUse, id)
+	}
+	used := false
+	err = s.bookRepo.Iterate(ctx, books.Filter{GenreIDs: []int{id}}, func(*books.Book) error {
+		used = true
+		return errStop
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return err
+	}
+	if used {
+		return fmt.Errorf("%w: genre %d is assigned to books", ErrInUse, id)
+	}
+	return s.genreRepo.Delete(ctx, id)
+}
+
+// errStop прерывает Iterate после первой книги.
+var errStop = errors.New("stop")
+
+func (s *genreService) ListGenres(ctx context.Context) ([]genres.Genre, error) {
+	ctx, span := tracing.Start(ctx, "GenreService.ListGenres")
+	defer span.End()
+
+	return s.genreRepo.List(ctx)
+}
+
+func (s *genreService) ListBooks(ctx context.Context, id int) ([]books.Book, error) {
+	ctx, span := tracing.Start(ctx, "GenreService.ListBooks")
+	defer span.End()
+
+	list, err := s.genreRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(list, func(g genres.Genre) bool { return g.ID == id }) {
+		return nil, genres.ErrNotFound
+	}
+
+	return s.finder.FindBooks(ctx, books.Filter{GenreIDs: genres.Subtree(list, id)})
+}
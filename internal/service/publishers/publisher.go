@@ -0,0 +1,127 @@
+package publishers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/publishers"
+	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+var (
+	// ErrInvalidInput — пустое название издательства.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrInUse — у издательства есть книги.
+	ErrInUse = errors.New("publisher is in use")
+)
+
+// PublisherService описывает бизнес-логику для издательств.
+type PublisherService interface {
+	CreatePublisher(ctx context.Context, req commands.CreatePublisherRequest) (*publishers.Publisher, error)
+	GetPublisher(ctx context.Context, id int) (*publishers.Publisher, error)
+	UpdatePublisher(ctx context.Context, id int, req commands.UpdatePublisherRequest) (*publishers.Publisher, error)
+	DeletePublisher(ctx context.Context, id int) error
+	ListPublishers(ctx context.Context) ([]publishers.Publisher, error)
+	// ListBooks возвращает книги издательства по возрастанию ID.
+	ListBooks(ctx context.Context, id int) ([]books.Book, error)
+}
+
+// BookFinder выдаёт книги по фильтру вместе с жанрами и обложками
+// (см. сервис books).
+type BookFinder interface {
+	FindBooks(ctx context.Context, filter books.Filter) ([]books.Book, error)
+}
+
+type publisherService struct {
+	publisherRepo publishers.PublisherRepo
+	idCounter     *genid.IDcounter
+	finder        BookFinder
+}
+
+// NewPublisherService возвращает реализацию PublisherService.
+func NewPublisherService(repo publishers.PublisherRepo, counter *genid.IDcounter, finder BookFinder) PublisherService {
+	return &publisherService{
+		publisherRepo: repo,
+		idCounter:     counter,
+		finder:        finder,
+	}
+}
+
+func (s *publisherService) CreatePublisher(ctx context.Context, req commands.CreatePublisherRequest) (*publishers.Publisher, error) {
+	ctx, span := tracing.Start(ctx, "PublisherService.CreatePublisher")
+	defer span.End()
+
+	newPublisher, err := publishers.NewPublisher(s.idCounter.GenerateID(), req.Name, req.City, req.Country)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := s.publisherRepo.Create(ctx, newPublisher); err != nil {
+		return nil, err
+	}
+	return newPublisher, nil
+}
+
+func (s *publisherService) GetPublisher(ctx context.Context, id int) (*publishers.Publisher, error) {
+	ctx, span := tracing.Start(ctx, "PublisherService.GetPublisher")
+	defer span.End()
+
+	return s.publisherRepo.GetById(ctx, id)
+}
+
+func (s *publisherService) UpdatePublisher(ctx context.Context, id int, req commands.UpdatePublisherRequest) (*publishers.Publisher, error) {
+	ctx, span := tracing.Start(ctx, "PublisherService.UpdatePublisher")
+	defer span.End()
+
+	existing, err := s.publisherRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Пустое название оставляет прежнее, как у авторов.
+	name := req.Name
+	if name == "" {
+		name = existing.Name
+	}
+	updated, err := publishers.NewPublisher(id, name, req.City, req.Country)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := s.publisherRepo.Update(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *publisherService) DeletePublisher(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "PublisherService.DeletePublisher")
+	defer span.End()
+
+	list, err := s.ListBooks(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(list) > 0 {
+		return fmt.Errorf("%w: publisher %d has %d books", ErrInUse, id, len(list))
+	}
+	return s.publisherRepo.Delete(ctx, id)
+}
+
+func (s *publisherService) ListPublishers(ctx context.Context) ([]publishers.Publisher, error) {
+	ctx, span := tracing.Start(ctx, "PublisherService.ListPublishers")
+	defer span.End()
+
+	return s.publisherRepo.List(ctx)
+}
+
+func (s *publisherService) ListBooks(ctx context.Context, id int) ([]books.Book, error) {
+	ctx, span := tracing.Start(ctx, "PublisherService.ListBooks")
+	defer span.End()
+
+	if _, err := s.publisherRepo.GetById(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.finder.FindBooks(ctx, books.Filter{PublisherID: id})
+}
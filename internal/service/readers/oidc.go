@@ -0,0 +1,160 @@
+package readers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+
+	domainReaders "github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/pkg/metrics"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+var (
+	ErrOIDCReaderNotFound   = errors.New("no reader is linked to this identity")
+	ErrOIDCEmailNotVerified = errors.New("identity provider has not verified this email")
+	ErrOIDCEmailMissing     = errors.New("identity provider did not return an email")
+	ErrOIDCSubjectConflict  = errors.New("reader is already linked to another identity")
+)
+
+// OIDCIdentity — личность, подтверждённая внешним провайдером.
+type OIDCIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+	// AMR и ACR — методы и уровень аутентификации из ID token.
+	AMR []string
+	ACR string
+}
+
+// OIDCOptions задаёт правила сопоставления личности IdP с читателем.
+type OIDCOptions struct {
+	// AutoProvision создаёт читателя при первом входе.
+	AutoProvision bool
+	// AdminGroup — группа IdP, членство в которой даёт флаг Admin.
+	// Пустое значение оставляет флаг под управлением API.
+	AdminGroup string
+	// TrustIdPMFA засчитывает второй фактор, пройденный на IdP, вместо
+	// локального TOTP. Вход считается многофакторным, только если ID token
+	// содержит один из MFAMethods в amr или один из MFAACR в acr.
+	TrustIdPMFA bool
+	MFAMethods  []string
+	MFAACR      []string
+}
+
+// idpMFA сообщает, подтвердил ли IdP второй фактор по правилам OIDCOptions.
+func (o OIDCOptions) idpMFA(identity OIDCIdentity) bool {
+	if !o.TrustIdPMFA {
+		return false
+	}
+	for _, method := range identity.AMR {
+		if containsString(o.MFAMethods, method) {
+			return true
+		}
+	}
+	return identity.ACR != "" && containsString(o.MFAACR, identity.ACR)
+}
+
+// LoginWithOIDC находит читателя по subject, при необходимости привязывает
+// существующего читателя по подтверждённому email или создаёт нового,
+// синхронизирует флаг Admin и начинает сессию по тем же правилам второго
+// фактора, что и вход по паролю. Локальная проверка пропускается, только если
+// включён TrustIdPMFA и IdP подтвердил многофакторный вход.
+func (s *readerService) LoginWithOIDC(ctx context.Context, identity OIDCIdentity) (*LoginResult, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.LoginWithOIDC")
+	defer span.End()
+
+	reader, err := s.readerRepo.GetReaderByOIDCSubject(ctx, identity.Subject)
+	if err != nil && !errors.Is(err, domainReaders.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		reader, err = s.linkOrProvision(ctx, identity)
+		if err != nil {
+			metrics.FailedLogins.WithLabelValues(metrics.LoginOIDC).Inc()
+			return nil, err
+		}
+	}
+
+	if group := s.auth.OIDC.AdminGroup; group != "" {
+		admin := containsString(identity.Groups, group)
+		if reader.Admin != admin {
+			reader.Admin = admin
+			if err := s.readerRepo.Update(ctx, reader); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if s.auth.OIDC.idpMFA(identity) {
+		return s.issueSession(reader)
+	}
+	return s.startSession(reader)
+}
+
+func (s *readerService) linkOrProvision(ctx context.Context, identity OIDCIdentity) (*domainReaders.Reader, error) {
+	if identity.Email == "" {
+		return nil, ErrOIDCEmailMissing
+	}
+
+	existing, err := s.readerRepo.GetReaderByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		if !identity.EmailVerified {
+			return nil, ErrOIDCEmailNotVerified
+		}
+		if existing.OIDCSubject != "" && existing.OIDCSubject != identity.Subject {
+			return nil, ErrOIDCSubjectConflict
+		}
+		existing.OIDCSubject = identity.Subject
+		if err := s.readerRepo.Update(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	case !errors.Is(err, domainReaders.ErrNotFound):
+		return nil, err
+	}
+
+	if !s.auth.OIDC.AutoProvision {
+		return nil, ErrOIDCReaderNotFound
+	}
+
+	// Локальный пароль случайный: такие читатели входят только через IdP.
+	password, err := randomPassword()
+	if err != nil {
+		return nil, err
+	}
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+	admin := s.auth.OIDC.AdminGroup != "" && containsString(identity.Groups, s.auth.OIDC.AdminGroup)
+	reader, err := domainReaders.NewReader(s.idCounter.GenerateID(), name, "", identity.Email, password, admin)
+	if err != nil {
+		return nil, err
+	}
+	reader.OIDCSubject = identity.Subject
+	if err := s.readerRepo.Create(ctx, reader); err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+func randomPassword() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
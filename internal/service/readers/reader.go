@@ -3,10 +3,13 @@ package readers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/0sokrat0/BookAPI/internal/application/commands"
 	domainReaders "github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
 	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/authtoken"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
 )
 
 type ReaderService interface {
@@ -17,21 +20,43 @@ type ReaderService interface {
 	DeleteReader(ctx context.Context, id int) error
 	ListReaders(ctx context.Context) ([]domainReaders.Reader, error)
 	Authenticate(ctx context.Context, email, password string) (*domainReaders.Reader, error)
+
+	Login(ctx context.Context, email, password string) (*LoginResult, error)
+	CompleteTwoFactorLogin(ctx context.Context, challenge, code string) (*LoginResult, error)
+	EnrollTOTP(ctx context.Context, id int, password string) (*TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, id int, code string) error
+	DisableTOTP(ctx context.Context, id int, password, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, id int, password, code string) ([]string, error)
+	LoginWithOIDC(ctx context.Context, identity OIDCIdentity) (*LoginResult, error)
+}
+
+// AuthOptions содержит параметры входа и двухфакторной аутентификации.
+type AuthOptions struct {
+	Tokens          *authtoken.Manager
+	TokenTTL        time.Duration
+	TOTPIssuer      string
+	RequireAdmin2FA bool
+	OIDC            OIDCOptions
 }
 
 type readerService struct {
 	readerRepo domainReaders.ReaderRepo
 	idCounter  *genid.IDcounter
+	auth       AuthOptions
 }
 
-func NewReaderService(repo domainReaders.ReaderRepo, counter *genid.IDcounter) ReaderService {
+func NewReaderService(repo domainReaders.ReaderRepo, counter *genid.IDcounter, auth AuthOptions) ReaderService {
 	return &readerService{
 		readerRepo: repo,
 		idCounter:  counter,
+		auth:       auth,
 	}
 }
 
 func (s *readerService) CreateReader(ctx context.Context, req commands.CreateReaderRequest) (*domainReaders.Reader, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.CreateReader")
+	defer span.End()
+
 	if req.Name == "" {
 		return nil, fmt.Errorf("name is required")
 	}
@@ -47,14 +72,23 @@ func (s *readerService) CreateReader(ctx context.Context, req commands.CreateRea
 }
 
 func (s *readerService) GetReader(ctx context.Context, id int) (*domainReaders.Reader, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.GetReader")
+	defer span.End()
+
 	return s.readerRepo.GetById(ctx, id)
 }
 
 func (s *readerService) GetReaderByEmail(ctx context.Context, email string) (*domainReaders.Reader, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.GetReaderByEmail")
+	defer span.End()
+
 	return s.readerRepo.GetReaderByEmail(ctx, email)
 }
 
 func (s *readerService) UpdateReader(ctx context.Context, id int, req commands.UpdateReaderRequest) (*domainReaders.Reader, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.UpdateReader")
+	defer span.End()
+
 	existingReader, err := s.readerRepo.GetById(ctx, id)
 	if err != nil {
 		return nil, err
@@ -73,14 +107,23 @@ func (s *readerService) UpdateReader(ctx context.Context, id int, req commands.U
 }
 
 func (s *readerService) DeleteReader(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "ReaderService.DeleteReader")
+	defer span.End()
+
 	return s.readerRepo.Delete(ctx, id)
 }
 
 func (s *readerService) ListReaders(ctx context.Context) ([]domainReaders.Reader, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.ListReaders")
+	defer span.End()
+
 	return s.readerRepo.List(ctx)
 }
 
 func (s *readerService) Authenticate(ctx context.Context, email, password string) (*domainReaders.Reader, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.Authenticate")
+	defer span.End()
+
 	reader, err := s.readerRepo.GetReaderByEmail(ctx, email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve reader: %w", err)
@@ -0,0 +1,260 @@
+package readers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainReaders "github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/pkg/authtoken"
+	"github.com/0sokrat0/BookAPI/pkg/metrics"
+	"github.com/0sokrat0/BookAPI/pkg/totp"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+const (
+	challengeTTL      = 5 * time.Minute
+	totpSkew          = 1
+	recoveryCodeCount = 10
+)
+
+var (
+	ErrInvalidCredentials      = errors.New("invalid email or password")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrNoPendingEnrollment     = errors.New("no pending two-factor enrollment")
+)
+
+// LoginResult — итог шага входа: либо выданный токен сессии,
+// либо вызов на ввод второго фактора.
+type LoginResult struct {
+	Reader            *domainReaders.Reader
+	Token             string
+	ExpiresAt         time.Time
+	TwoFactorRequired bool
+	Challenge         string
+	// TwoFactorEnrollmentRequired — администратору при AUTH_REQUIRE_ADMIN_2FA
+	// выдан токен подключения второго фактора: его принимают только маршруты
+	// /reader/{id}/2fa, после подключения нужно войти заново.
+	TwoFactorEnrollmentRequired bool
+}
+
+// TOTPEnrollment — данные для настройки приложения-аутентификатора.
+type TOTPEnrollment struct {
+	Secret          string   `json:"secret"`
+	ProvisioningURI string   `json:"provisioning_uri"`
+	RecoveryCodes   []string `json:"recovery_codes"`
+}
+
+func (s *readerService) Login(ctx context.Context, email, password string) (*LoginResult, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.Login")
+	defer span.End()
+
+	reader, err := s.readerRepo.GetReaderByEmail(ctx, email)
+	if err != nil || !reader.CheckPassword(password) {
+		metrics.FailedLogins.WithLabelValues(metrics.LoginPassword).Inc()
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.startSession(reader)
+}
+
+// startSession применяет политику второго фактора к читателю, прошедшему
+// первый шаг входа: при включённом TOTP выдаёт challenge, администратору без
+// TOTP при AUTH_REQUIRE_ADMIN_2FA — токен подключения второго фактора.
+func (s *readerService) startSession(reader *domainReaders.Reader) (*LoginResult, error) {
+	if reader.TOTPEnabled {
+		challenge, _, err := s.auth.Tokens.Issue(reader.ID, reader.Admin, authtoken.PurposeTwoFactor, challengeTTL)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{Reader: reader, TwoFactorRequired: true, Challenge: challenge}, nil
+	}
+	if reader.Admin && s.auth.RequireAdmin2FA {
+		token, expiresAt, err := s.auth.Tokens.Issue(reader.ID, false, authtoken.PurposeEnrollment, s.auth.TokenTTL)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{Reader: reader, Token: token, ExpiresAt: expiresAt, TwoFactorEnrollmentRequired: true}, nil
+	}
+	return s.issueSession(reader)
+}
+
+func (s *readerService) CompleteTwoFactorLogin(ctx context.Context, challenge, code string) (*LoginResult, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.CompleteTwoFactorLogin")
+	defer span.End()
+
+	claims, err := s.auth.Tokens.Parse(challenge, authtoken.PurposeTwoFactor)
+	if err != nil {
+		metrics.FailedLogins.WithLabelValues(metrics.LoginTwoFactor).Inc()
+		return nil, err
+	}
+	reader, err := s.readerRepo.GetById(ctx, claims.ReaderID)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if !reader.TOTPEnabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err := s.verifySecondFactor(ctx, reader, code); err != nil {
+		metrics.FailedLogins.WithLabelValues(metrics.LoginTwoFactor).Inc()
+		return nil, err
+	}
+	return s.issueSession(reader)
+}
+
+func (s *readerService) EnrollTOTP(ctx context.Context, id int, password string) (*TOTPEnrollment, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.EnrollTOTP")
+	defer span.End()
+
+	reader, err := s.readerWithPassword(ctx, id, password)
+	if err != nil {
+		return nil, err
+	}
+	if reader.TOTPEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	reader.TOTPSecret = secret
+	reader.TOTPLastStep = 0
+	if err := s.readerRepo.UpdateTOTP(ctx, reader); err != nil {
+		return nil, err
+	}
+	codes, err := s.replaceRecoveryCodes(ctx, reader.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.auth.TOTPIssuer, reader.Email, secret),
+		RecoveryCodes:   codes,
+	}, nil
+}
+
+func (s *readerService) ConfirmTOTP(ctx context.Context, id int, code string) error {
+	ctx, span := tracing.Start(ctx, "ReaderService.ConfirmTOTP")
+	defer span.End()
+
+	reader, err := s.readerRepo.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if reader.TOTPEnabled {
+		return ErrTwoFactorAlreadyEnabled
+	}
+	if !reader.HasPendingTOTP() {
+		return ErrNoPendingEnrollment
+	}
+	step, ok := totp.Validate(reader.TOTPSecret, code, time.Now(), totpSkew)
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+	reader.TOTPEnabled = true
+	reader.TOTPLastStep = step
+	return s.readerRepo.UpdateTOTP(ctx, reader)
+}
+
+func (s *readerService) DisableTOTP(ctx context.Context, id int, password, code string) error {
+	ctx, span := tracing.Start(ctx, "ReaderService.DisableTOTP")
+	defer span.End()
+
+	reader, err := s.readerWithPassword(ctx, id, password)
+	if err != nil {
+		return err
+	}
+	if !reader.TOTPEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+	if err := s.verifySecondFactor(ctx, reader, code); err != nil {
+		return err
+	}
+	reader.TOTPSecret = ""
+	reader.TOTPEnabled = false
+	reader.TOTPLastStep = 0
+	if err := s.readerRepo.UpdateTOTP(ctx, reader); err != nil {
+		return err
+	}
+	return s.readerRepo.ReplaceRecoveryCodes(ctx, reader.ID, nil)
+}
+
+func (s *readerService) RegenerateRecoveryCodes(ctx context.Context, id int, password, code string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "ReaderService.RegenerateRecoveryCodes")
+	defer span.End()
+
+	reader, err := s.readerWithPassword(ctx, id, password)
+	if err != nil {
+		return nil, err
+	}
+	if !reader.TOTPEnabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err := s.verifySecondFactor(ctx, reader, code); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(ctx, reader.ID)
+}
+
+func (s *readerService) issueSession(reader *domainReaders.Reader) (*LoginResult, error) {
+	token, expiresAt, err := s.auth.Tokens.Issue(reader.ID, reader.Admin, authtoken.PurposeSession, s.auth.TokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{Reader: reader, Token: token, ExpiresAt: expiresAt}, nil
+}
+
+func (s *readerService) readerWithPassword(ctx context.Context, id int, password string) (*domainReaders.Reader, error) {
+	reader, err := s.readerRepo.GetById(ctx, id)
+	if err != nil || !reader.CheckPassword(password) {
+		return nil, ErrInvalidCredentials
+	}
+	return reader, nil
+}
+
+// verifySecondFactor принимает либо текущий TOTP-код, либо неиспользованный
+// код восстановления. Уже принятый TOTP-код повторно не засчитывается,
+// в том числе когда его предъявляют параллельно: шаг сдвигается условным
+// обновлением в хранилище.
+func (s *readerService) verifySecondFactor(ctx context.Context, reader *domainReaders.Reader, code string) error {
+	if step, ok := totp.Validate(reader.TOTPSecret, code, time.Now(), totpSkew); ok {
+		advanced, err := s.readerRepo.AdvanceTOTPStep(ctx, reader.ID, step)
+		if err != nil {
+			return err
+		}
+		if !advanced {
+			return ErrInvalidTwoFactorCode
+		}
+		reader.TOTPLastStep = step
+		return nil
+	}
+
+	used, err := s.readerRepo.ConsumeRecoveryCode(ctx, reader.ID, totp.HashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (s *readerService) replaceRecoveryCodes(ctx context.Context, readerID int) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := totp.GenerateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = totp.HashRecoveryCode(code)
+	}
+	if err := s.readerRepo.ReplaceRecoveryCodes(ctx, readerID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
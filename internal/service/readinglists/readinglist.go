@@ -0,0 +1,351 @@
+// Package readinglists управляет списками книг читателей «на потом».
+package readinglists
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/readinglists"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	reservationsvc "github.com/0sokrat0/BookAPI/internal/service/reservations"
+	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+// DefaultLoanPeriod — срок бронирования из списка, если даты не заданы.
+const DefaultLoanPeriod = 14 * 24 * time.Hour
+
+var (
+	// ErrInvalidInput — неверное название, порядок книг или даты.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrForbidden — список принадлежит другому читателю.
+	ErrForbidden = errors.New("reading list belongs to another reader")
+	// ErrNothingAvailable — все книги списка заняты на запрошенный срок.
+	ErrNothingAvailable = errors.New("no book in the list is available")
+)
+
+// Viewer — кто обращается к списку: читатель сессии и признак
+// администратора. Нулевой ReaderID — анонимный запрос.
+type Viewer struct {
+	ReaderID int
+	Admin    bool
+}
+
+// ListedBook — книга списка и её доступность.
+type ListedBook struct {
+	Book books.Book
+	// Available — у книги нет бронирования, пересекающегося с сегодняшним
+	// днём.
+	Available bool
+}
+
+// ListView — список с книгами в его порядке.
+type ListView struct {
+	readinglists.ReadingList
+	Books []ListedBook
+}
+
+// ReadingListService описывает бизнес-логику списков чтения.
+type ReadingListService interface {
+	// CreateList создаёт список читателя; публичный сразу получает ссылку.
+	CreateList(ctx context.Context, readerID int, req commands.CreateReadingListRequest) (*readinglists.ReadingList, error)
+	// GetList возвращает список с книгами. Приватный список видят только
+	// владелец и администраторы, публичный — любой.
+	GetList(ctx context.Context, viewer Viewer, id int) (*ListView, error)
+	// SharedList возвращает публичный список по ссылке.
+	SharedList(ctx context.Context, slug string) (*ListView, error)
+	// ReaderLists возвращает списки читателя по возрастанию ID.
+	ReaderLists(ctx context.Context, readerID int) ([]readinglists.ReadingList, error)
+	// UpdateList меняет название и публичность. Закрытие списка отзывает
+	// ссылку, повторное открытие выдаёт новую.
+	UpdateList(ctx context.Context, readerID, id int, req commands.UpdateReadingListRequest) (*readinglists.ReadingList, error)
+	// DeleteList удаляет список; чужой может удалить только администратор.
+	DeleteList(ctx context.Context, viewer Viewer, id int) error
+
+	// AddBook добавляет книгу в конец списка.
+	AddBook(ctx context.Context, readerID, id, bookID int) (*ListView, error)
+	// RemoveBook убирает книгу из списка.
+	RemoveBook(ctx context.Context, readerID, id, bookID int) (*ListView, error)
+	// ReorderBooks задаёт новый порядок всех книг списка.
+	ReorderBooks(ctx context.Context, readerID, id int, bookIDs []int) (*ListView, error)
+
+	// ReserveFirstAvailable бронирует на владельца списка первую по порядку
+	// книгу, свободную на весь срок. Нулевые даты — с сегодняшнего дня на
+	// DefaultLoanPeriod.
+	ReserveFirstAvailable(ctx context.Context, readerID, id int, startDate, endDate time.Time) (*reservations.Reservation, error)
+}
+
+// BookGetter выдаёт книгу вместе с обложкой и рейтингом (см. сервис books).
+type BookGetter interface {
+	GetBook(ctx context.Context, id int) (*books.Book, error)
+}
+
+// Reserver создаёт бронирование (см. сервис reservations).
+type Reserver interface {
+	CreateReservation(ctx context.Context, viewer reservationsvc.Viewer, req reservationsvc.CreateReservationRequest) (*reservations.Reservation, error)
+}
+
+type readingListService struct {
+	listRepo        readinglists.ReadingListRepo
+	reservationRepo reservations.ReservationRepo
+	books           BookGetter
+	reserver        Reserver
+	idCounter       *genid.IDcounter
+}
+
+// NewReadingListService возвращает реализацию ReadingListService.
+func NewReadingListService(repo readinglists.ReadingListRepo, reservationRepo reservations.ReservationRepo, bookGetter BookGetter, reserver Reserver, counter *genid.IDcounter) ReadingListService {
+	return &readingListService{
+		listRepo:        repo,
+		reservationRepo: reservationRepo,
+		books:           bookGetter,
+		reserver:        reserver,
+		idCounter:       counter,
+	}
+}
+
+// now возвращает текущее время с точностью хранилища — до микросекунд.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// today возвращает начало текущего дня по UTC: бронирования хранят даты.
+func today() time.Time {
+	y, m, d := time.Now().UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// newSlug возвращает случайную часть ссылки на публичный список.
+func newSlug() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// setPublic открывает или закрывает список.
+func setPublic(list *readinglists.ReadingList, public bool) error {
+	if !public {
+		list.Unshare()
+		return nil
+	}
+	if list.Public {
+		return nil
+	}
+	slug, err := newSlug()
+	if err != nil {
+		return fmt.Errorf("failed to generate share slug: %w", err)
+	}
+	list.Share(slug)
+	return nil
+}
+
+func (s *readingListService) CreateList(ctx context.Context, readerID int, req commands.CreateReadingListRequest) (*readinglists.ReadingList, error) {
+	ctx, span := tracing.Start(ctx, "ReadingListService.CreateList")
+	defer span.End()
+
+	list, err := readinglists.NewReadingList(s.idCounter.GenerateID(), readerID, req.Name, now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := setPublic(list, req.Public); err != nil {
+		return nil, err
+	}
+	if err := s.listRepo.Create(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *readingListService) GetList(ctx context.Context, viewer Viewer, id int) (*ListView, error) {
+	ctx, span := tracing.Start(ctx, "ReadingListService.GetList")
+	defer span.End()
+
+	list, err := s.listRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Чужой приватный список выглядит как отсутствующий.
+	if !list.Public && !viewer.Admin && viewer.ReaderID != list.ReaderID {
+		return nil, readinglists.ErrNotFound
+	}
+	return s.view(ctx, list)
+}
+
+func (s *readingListService) SharedList(ctx context.Context, slug string) (*ListView, error) {
+	ctx, span := tracing.Start(ctx, "ReadingListService.SharedList")
+	defer span.End()
+
+	list, err := s.listRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.view(ctx, list)
+}
+
+func (s *readingListService) ReaderLists(ctx context.Context, readerID int) ([]readinglists.ReadingList, error) {
+	ctx, span := tracing.Start(ctx, "ReadingListService.ReaderLists")
+	defer span.End()
+
+	lists, err := s.listRepo.ListByReader(ctx, readerID)
+	if err != nil {
+		return nil, err
+	}
+	if lists == nil {
+		lists = []readinglists.ReadingList{}
+	}
+	return lists, nil
+}
+
+func (s *readingListService) UpdateList(ctx context.Context, readerID, id int, req commands.UpdateReadingListRequest) (*readinglists.ReadingList, error) {
+	ctx, span := tracing.Start(ctx, "ReadingListService.UpdateList")
+	defer span.End()
+
+	return s.listRepo.Modify(ctx, id, func(list *readinglists.ReadingList) error {
+		if list.ReaderID != readerID {
+			return ErrForbidden
+		}
+		if err := list.Rename(req.Name); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		return setPublic(list, req.Public)
+	})
+}
+
+func (s *readingListService) DeleteList(ctx context.Context, viewer Viewer, id int) error {
+	ctx, span := tracing.Start(ctx, "ReadingListService.DeleteList")
+	defer span.End()
+
+	list, err := s.listRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !viewer.Admin && list.ReaderID != viewer.ReaderID {
+		return ErrForbidden
+	}
+	return s.listRepo.Delete(ctx, id)
+}
+
+func (s *readingListService) AddBook(ctx context.Context, readerID, id, bookID int) (*ListView, error) {
+	ctx, span := tracing.Start(ctx, "ReadingListService.AddBook")
+	defer span.End()
+
+	if _, err := s.books.GetBook(ctx, bookID); err != nil {
+		return nil, err
+	}
+	return s.edit(ctx, readerID, id, func(list *readinglists.ReadingList) error {
+		return list.Add(bookID)
+	})
+}
+
+func (s *readingListService) RemoveBook(ctx context.Context, readerID, id, bookID int) (*ListView, error) {
+	ctx, span := tracing.Start(ctx, "ReadingListService.RemoveBook")
+	defer span.End()
+
+	return s.edit(ctx, readerID, id, func(list *readinglists.ReadingList) error {
+		return list.Remove(bookID)
+	})
+}
+
+func (s *readingListService) ReorderBooks(ctx context.Context, readerID, id int, bookIDs []int) (*ListView, error) {
+	ctx, span := tracing.Start(ctx, "ReadingListService.ReorderBooks")
+	defer span.End()
+
+	return s.edit(ctx, readerID, id, func(list *readinglists.ReadingList) error {
+		return list.Reorder(bookIDs)
+	})
+}
+
+// edit применяет apply к своему списку и сохраняет его в одной транзакции,
+// чтобы параллельные правки не затирали друг друга. Ошибки домена, кроме
+// ErrAlreadyListed и ErrNotListed, считаются неверным вводом.
+func (s *readingListService) edit(ctx context.Context, readerID, id int, apply func(*readinglists.ReadingList) error) (*ListView, error) {
+	list, err := s.listRepo.Modify(ctx, id, func(list *readinglists.ReadingList) error {
+		if list.ReaderID != readerID {
+			return ErrForbidden
+		}
+		if err := apply(list); err != nil {
+			if errors.Is(err, readinglists.ErrAlreadyListed) || errors.Is(err, readinglists.ErrNotListed) {
+				return err
+			}
+			return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.view(ctx, list)
+}
+
+func (s *readingListService) ReserveFirstAvailable(ctx context.Context, readerID, id int, startDate, endDate time.Time) (*reservations.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ReadingListService.ReserveFirstAvailable")
+	defer span.End()
+
+	if startDate.IsZero() {
+		startDate = today()
+	}
+	if endDate.IsZero() {
+		endDate = startDate.Add(DefaultLoanPeriod)
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("%w: end date cannot be before start date", ErrInvalidInput)
+	}
+	list, err := s.own(ctx, readerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.BookIDs) == 0 {
+		return nil, fmt.Errorf("%w: the list is empty", ErrNothingAvailable)
+	}
+	res, err := s.reserver.CreateReservation(ctx, reservationsvc.Viewer{ReaderID: list.ReaderID}, reservationsvc.CreateReservationRequest{
+		ID:         s.idCounter.GenerateID(),
+		Candidates: list.BookIDs,
+		Reader:     readers.Reader{ID: list.ReaderID},
+		StartDate:  startDate,
+		EndDate:    endDate,
+	})
+	if errors.Is(err, reservations.ErrNoFreeBook) {
+		return nil, fmt.Errorf("%w: all %d books are reserved for the period", ErrNothingAvailable, len(list.BookIDs))
+	}
+	return res, err
+}
+
+// own возвращает список, если он принадлежит читателю.
+func (s *readingListService) own(ctx context.Context, readerID, id int) (*readinglists.ReadingList, error) {
+	list, err := s.listRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if list.ReaderID != readerID {
+		return nil, ErrForbidden
+	}
+	return list, nil
+}
+
+// view дополняет список книгами и их доступностью на сегодня.
+func (s *readingListService) view(ctx context.Context, list *readinglists.ReadingList) (*ListView, error) {
+	day := today()
+	busy, err := s.reservationRepo.BusyBooks(ctx, list.BookIDs, day, day)
+	if err != nil {
+		return nil, err
+	}
+	view := &ListView{ReadingList: *list, Books: make([]ListedBook, 0, len(list.BookIDs))}
+	for _, bookID := range list.BookIDs {
+		book, err := s.books.GetBook(ctx, bookID)
+		if err != nil {
+			return nil, err
+		}
+		view.Books = append(view.Books, ListedBook{Book: *book, Available: !slices.Contains(busy, bookID)})
+	}
+	return view, nil
+}
@@ -0,0 +1,250 @@
+// Package recommendations подбирает книги по совместным бронированиям:
+// похожие на данную и персональные рекомендации читателю.
+package recommendations
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/recommendations"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+const (
+	// DefaultLimit — сколько книг выдаётся, если limit не задан.
+	DefaultLimit = 10
+	// MaxLimit — наибольший limit.
+	MaxLimit = 50
+)
+
+var (
+	// ErrInvalidInput — limit вне диапазона.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrForbidden — рекомендации другого читателя видят только
+	// администраторы.
+	ErrForbidden = errors.New("recommendations of another reader are not available")
+)
+
+// Viewer — кто запрашивает рекомендации: читатель сессии и признак
+// администратора.
+type Viewer struct {
+	ReaderID int
+	Admin    bool
+}
+
+// Suggestion — рекомендованная книга.
+type Suggestion struct {
+	Book books.Book `json:"book"`
+	// Score — близость к исходной книге или, для читателя, сумма близостей
+	// к книгам, которые он брал.
+	Score float64 `json:"score"`
+	// Readers — сколько читателей брали и исходную книгу, и эту; только у
+	// похожих книг.
+	Readers int `json:"readers,omitempty"`
+	// Because — книги читателя, по которым подобрана эта; только у
+	// персональных рекомендаций.
+	Because []int `json:"because,omitempty"`
+}
+
+// RecommendationService описывает рекомендации книг.
+type RecommendationService interface {
+	// Recompute пересчитывает соседей всех книг по истории бронирований.
+	// Вызывается по расписанию фоновой задачей.
+	Recompute(ctx context.Context) error
+	// SimilarBooks возвращает книги, которые брали читатели книги bookID,
+	// по убыванию близости.
+	SimilarBooks(ctx context.Context, bookID, limit int) ([]Suggestion, error)
+	// ReaderRecommendations возвращает соседей книг из истории читателя,
+	// кроме книг, которые он уже брал. Чужие рекомендации доступны только
+	// администратору.
+	ReaderRecommendations(ctx context.Context, viewer Viewer, readerID, limit int) ([]Suggestion, error)
+}
+
+// BookGetter выдаёт книгу вместе с обложкой и рейтингом (см. сервис books).
+type BookGetter interface {
+	GetBook(ctx context.Context, id int) (*books.Book, error)
+}
+
+type recommendationService struct {
+	similarityRepo  recommendations.SimilarityRepo
+	reservationRepo reservations.ReservationRepo
+	readerRepo      readers.ReaderRepo
+	books           BookGetter
+	topN            int
+}
+
+// NewRecommendationService возвращает реализацию RecommendationService;
+// topN — сколько соседей хранится у каждой книги.
+func NewRecommendationService(repo recommendations.SimilarityRepo, reservationRepo reservations.ReservationRepo, readerRepo readers.ReaderRepo, bookGetter BookGetter, topN int) RecommendationService {
+	return &recommendationService{
+		similarityRepo:  repo,
+		reservationRepo: reservationRepo,
+		readerRepo:      readerRepo,
+		books:           bookGetter,
+		topN:            topN,
+	}
+}
+
+func (s *recommendationService) Recompute(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "RecommendationService.Recompute")
+	defer span.End()
+
+	history := recommendations.NewHistory()
+	err := s.reservationRepo.Iterate(ctx, reservations.Filter{}, func(r *reservations.Reservation) error {
+		history.Add(r.Reader.ID, r.Book.ID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read reservation history: %w", err)
+	}
+	if err := s.similarityRepo.Replace(ctx, history.Neighbours(s.topN)); err != nil {
+		return fmt.Errorf("failed to store book neighbours: %w", err)
+	}
+	return nil
+}
+
+func (s *recommendationService) SimilarBooks(ctx context.Context, bookID, limit int) ([]Suggestion, error) {
+	ctx, span := tracing.Start(ctx, "RecommendationService.SimilarBooks")
+	defer span.End()
+
+	limit, err := checkLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.books.GetBook(ctx, bookID); err != nil {
+		return nil, err
+	}
+	neighbours, err := s.similarityRepo.Neighbours(ctx, []int{bookID})
+	if err != nil {
+		return nil, err
+	}
+	list := neighbours[bookID]
+	suggestions := make([]Suggestion, 0, min(len(list), limit))
+	for _, n := range list {
+		if len(suggestions) == limit {
+			break
+		}
+		suggestion := Suggestion{Score: n.Score, Readers: n.Readers}
+		ok, err := s.loadBook(ctx, n.BookID, &suggestion)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	return suggestions, nil
+}
+
+func (s *recommendationService) ReaderRecommendations(ctx context.Context, viewer Viewer, readerID, limit int) ([]Suggestion, error) {
+	ctx, span := tracing.Start(ctx, "RecommendationService.ReaderRecommendations")
+	defer span.End()
+
+	if !viewer.Admin && viewer.ReaderID != readerID {
+		return nil, ErrForbidden
+	}
+	limit, err := checkLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.readerRepo.GetById(ctx, readerID); err != nil {
+		return nil, err
+	}
+
+	had := make(map[int]struct{})
+	err = s.reservationRepo.Iterate(ctx, reservations.Filter{ReaderID: readerID}, func(r *reservations.Reservation) error {
+		had[r.Book.ID] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(had) == 0 {
+		return []Suggestion{}, nil
+	}
+	ids := make([]int, 0, len(had))
+	for id := range had {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	neighbours, err := s.similarityRepo.Neighbours(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Кандидат набирает близость ко всем книгам читателя, с которыми
+	// его брали.
+	candidates := make(map[int]*Suggestion)
+	for _, id := range ids {
+		for _, n := range neighbours[id] {
+			if _, ok := had[n.BookID]; ok {
+				continue
+			}
+			c := candidates[n.BookID]
+			if c == nil {
+				c = &Suggestion{Book: books.Book{ID: n.BookID}}
+				candidates[n.BookID] = c
+			}
+			c.Score += n.Score
+			c.Because = append(c.Because, id)
+		}
+	}
+	ranked := make([]*Suggestion, 0, len(candidates))
+	for _, c := range candidates {
+		c.Score = math.Round(c.Score*1000) / 1000
+		ranked = append(ranked, c)
+	}
+	slices.SortFunc(ranked, func(a, b *Suggestion) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Book.ID, b.Book.ID)
+	})
+
+	suggestions := make([]Suggestion, 0, min(len(ranked), limit))
+	for _, c := range ranked {
+		if len(suggestions) == limit {
+			break
+		}
+		ok, err := s.loadBook(ctx, c.Book.ID, c)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			suggestions = append(suggestions, *c)
+		}
+	}
+	return suggestions, nil
+}
+
+// loadBook заполняет книгу рекомендации. Книгу, удалённую после пересчёта,
+// пропускает: ok = false.
+func (s *recommendationService) loadBook(ctx context.Context, id int, suggestion *Suggestion) (ok bool, err error) {
+	book, err := s.books.GetBook(ctx, id)
+	if errors.Is(err, books.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	suggestion.Book = *book
+	return true, nil
+}
+
+// checkLimit подставляет DefaultLimit вместо нуля и проверяет диапазон.
+func checkLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultLimit, nil
+	}
+	if limit < 0 || limit > MaxLimit {
+		return 0, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidInput, MaxLimit)
+	}
+	return limit, nil
+}
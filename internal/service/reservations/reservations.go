@@ -2,40 +2,74 @@ package reservations
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
 	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
 	"github.com/0sokrat0/BookAPI/internal/domain/entity/readers"
+	"github.com/0sokrat0/BookAPI/pkg/metrics"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
 )
 
+var (
+	// ErrNoEditionAvailable — у произведения нет издания, свободного на весь
+	// срок бронирования.
+	ErrNoEditionAvailable = errors.New("no edition of the work is available")
+	// ErrInvalidInput — неверный срок бронирования.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrForbidden — бронирование другого читателя; им управляют только
+	// администраторы.
+	ErrForbidden = errors.New("reservation belongs to another reader")
+	// ErrAlreadyReturned — возврат книги по бронированию уже записан.
+	ErrAlreadyReturned = errors.New("book has already been returned")
+)
+
+// Viewer — кто управляет бронированием: читатель сессии и признак
+// администратора. Читатель бронирует только на себя и не раньше
+// сегодняшнего дня; администратор может записать и прошедшую выдачу.
+type Viewer struct {
+	ReaderID int
+	Admin    bool
+}
+
 // ReservationService определяет интерфейс сервиса бронирований.
 type ReservationService interface {
-	CreateReservation(ctx context.Context, req CreateReservationRequest) (*reservations.Reservation, error)
+	CreateReservation(ctx context.Context, viewer Viewer, req CreateReservationRequest) (*reservations.Reservation, error)
 	GetReservationByID(ctx context.Context, id int) (*reservations.Reservation, error)
-	UpdateReservation(ctx context.Context, req UpdateReservationRequest) error
-	DeleteReservation(ctx context.Context, id int) error
+	UpdateReservation(ctx context.Context, viewer Viewer, req UpdateReservationRequest) error
+	DeleteReservation(ctx context.Context, viewer Viewer, id int) error
+	// ReturnReservation записывает возврат книги сегодняшним днём.
+	ReturnReservation(ctx context.Context, id int) (*reservations.Reservation, error)
 	ListReservations(ctx context.Context, startDate, endDate time.Time) ([]reservations.Reservation, error)
+	CountOverdue(ctx context.Context, now time.Time) (int, error)
 }
 
 // reservationService — реализация сервиса бронирований.
 type reservationService struct {
-	repo reservations.ReservationRepo
+	repo     reservations.ReservationRepo
+	bookRepo books.BookRepo
 }
 
 // NewReservationService создаёт новый сервис бронирований.
-func NewReservationService(repo reservations.ReservationRepo) ReservationService {
-	return &reservationService{repo: repo}
+func NewReservationService(repo reservations.ReservationRepo, bookRepo books.BookRepo) ReservationService {
+	return &reservationService{repo: repo, bookRepo: bookRepo}
 }
 
 // CreateReservationRequest содержит данные для создания бронирования.
 type CreateReservationRequest struct {
-	ID        int
-	Book      books.Book
-	Reader    readers.Reader
-	StartDate time.Time
-	EndDate   time.Time
+	ID   int
+	Book books.Book
+	// WorkID — бронирование любого издания произведения; используется,
+	// если Book.ID не задан.
+	WorkID int
+	// Candidates — бронирование первой по порядку свободной из этих книг;
+	// используется, если не заданы ни Book.ID, ни WorkID.
+	Candidates []int
+	Reader     readers.Reader
+	StartDate  time.Time
+	EndDate    time.Time
 }
 
 // UpdateReservationRequest содержит данные для обновления бронирования.
@@ -47,10 +81,39 @@ type UpdateReservationRequest struct {
 	EndDate   time.Time
 }
 
-func (s *reservationService) CreateReservation(ctx context.Context, req CreateReservationRequest) (*reservations.Reservation, error) {
-	// Проверка бизнес-правил может быть добавлена здесь.
-	if req.EndDate.Before(req.StartDate) {
-		return nil, fmt.Errorf("end date cannot be before start date")
+// today возвращает текущую дату по UTC.
+func today() time.Time {
+	y, m, d := time.Now().UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// checkPeriod проверяет срок бронирования. Начало в прошлом допускается
+// только при allowPast: иначе читатель мог бы записать себе завершённое
+// бронирование задним числом.
+func checkPeriod(startDate, endDate time.Time, allowPast bool) error {
+	if endDate.Before(startDate) {
+		return fmt.Errorf("%w: end date cannot be before start date", ErrInvalidInput)
+	}
+	y, m, d := startDate.Date()
+	if !allowPast && time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Before(today()) {
+		return fmt.Errorf("%w: start date cannot be in the past", ErrInvalidInput)
+	}
+	return nil
+}
+
+func (s *reservationService) CreateReservation(ctx context.Context, viewer Viewer, req CreateReservationRequest) (*reservations.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.CreateReservation")
+	defer span.End()
+
+	if !viewer.Admin && req.Reader.ID != viewer.ReaderID {
+		return nil, ErrForbidden
+	}
+	if err := checkPeriod(req.StartDate, req.EndDate, viewer.Admin); err != nil {
+		return nil, err
+	}
+
+	if req.Book.ID == 0 && (req.WorkID != 0 || len(req.Candidates) > 0) {
+		return s.createFirstFree(ctx, req)
 	}
 
 	// Создаём агрегат бронирования через доменную фабрику.
@@ -60,24 +123,119 @@ func (s *reservationService) CreateReservation(ctx context.Context, req CreateRe
 	}
 
 	// Сохраняем бронирование через репозиторий.
-	return s.repo.Create(ctx, res.ID, req.Book, req.Reader, req.StartDate, req.EndDate)
+	created, err := s.repo.Create(ctx, res.ID, req.Book, req.Reader, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	metrics.ReservationsCreated.Inc()
+	return created, nil
+}
+
+// createFirstFree бронирует первую свободную книгу из кандидатов, а для
+// WorkID — издание произведения с наименьшим ID. Выбор и вставку делает
+// репозиторий в одной транзакции, иначе параллельные запросы получили бы
+// одну и ту же книгу.
+func (s *reservationService) createFirstFree(ctx context.Context, req CreateReservationRequest) (*reservations.Reservation, error) {
+	candidates := req.Candidates
+	if req.WorkID != 0 {
+		candidates = nil
+		err := s.bookRepo.Iterate(ctx, books.Filter{WorkID: req.WorkID}, func(b *books.Book) error {
+			candidates = append(candidates, b.ID)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("%w: work %d has no editions", ErrNoEditionAvailable, req.WorkID)
+		}
+	}
+
+	created, err := s.repo.CreateFirstFree(ctx, req.ID, candidates, req.Reader, req.StartDate, req.EndDate)
+	if errors.Is(err, reservations.ErrNoFreeBook) && req.WorkID != 0 {
+		return nil, fmt.Errorf("%w: all %d editions of work %d are reserved", ErrNoEditionAvailable, len(candidates), req.WorkID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	metrics.ReservationsCreated.Inc()
+	return created, nil
 }
 
 func (s *reservationService) GetReservationByID(ctx context.Context, id int) (*reservations.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.GetReservationByID")
+	defer span.End()
+
 	return s.repo.GetById(ctx, id)
 }
 
-func (s *reservationService) UpdateReservation(ctx context.Context, req UpdateReservationRequest) error {
-	if req.EndDate.Before(req.StartDate) {
-		return fmt.Errorf("end date cannot be before start date")
+func (s *reservationService) UpdateReservation(ctx context.Context, viewer Viewer, req UpdateReservationRequest) error {
+	ctx, span := tracing.Start(ctx, "ReservationService.UpdateReservation")
+	defer span.End()
+
+	allowPast := viewer.Admin
+	if !viewer.Admin {
+		existing, err := s.repo.GetById(ctx, req.ID)
+		if err != nil {
+			return err
+		}
+		if existing.Reader.ID != viewer.ReaderID || req.Reader.ID != viewer.ReaderID {
+			return ErrForbidden
+		}
+		// Уже начавшееся бронирование можно продлить, не сдвигая начало.
+		allowPast = req.StartDate.Equal(existing.StartDate)
+	}
+	if err := checkPeriod(req.StartDate, req.EndDate, allowPast); err != nil {
+		return err
 	}
 	return s.repo.Update(ctx, req.ID, req.Book, req.Reader, req.StartDate, req.EndDate)
 }
 
-func (s *reservationService) DeleteReservation(ctx context.Context, id int) error {
+func (s *reservationService) DeleteReservation(ctx context.Context, viewer Viewer, id int) error {
+	ctx, span := tracing.Start(ctx, "ReservationService.DeleteReservation")
+	defer span.End()
+
+	if !viewer.Admin {
+		existing, err := s.repo.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if existing.Reader.ID != viewer.ReaderID {
+			return ErrForbidden
+		}
+	}
 	return s.repo.Delete(ctx, id)
 }
 
+func (s *reservationService) ReturnReservation(ctx context.Context, id int) (*reservations.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.ReturnReservation")
+	defer span.End()
+
+	res, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if res.ReturnedDate != nil {
+		return nil, ErrAlreadyReturned
+	}
+	returned := today()
+	if err := s.repo.MarkReturned(ctx, id, returned); err != nil {
+		return nil, err
+	}
+	res.ReturnedDate = &returned
+	return res, nil
+}
+
 func (s *reservationService) ListReservations(ctx context.Context, startDate, endDate time.Time) ([]reservations.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.ListReservations")
+	defer span.End()
+
 	return s.repo.List(ctx, startDate, endDate)
 }
+
+func (s *reservationService) CountOverdue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.CountOverdue")
+	defer span.End()
+
+	return s.repo.CountOverdue(ctx, now)
+}
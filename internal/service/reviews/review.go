@@ -0,0 +1,293 @@
+// Package reviews управляет отзывами и оценками книг читателями.
+package reviews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reservations"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/reviews"
+	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+var (
+	// ErrInvalidInput — неверная оценка, слишком длинный текст, неизвестный
+	// статус или попытка отметить полезным свой отзыв.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrNotEligible — у читателя нет завершённого бронирования книги.
+	ErrNotEligible = errors.New("only readers who have finished a reservation of the book can review it")
+	// ErrAlreadyReviewed — читатель уже оставил отзыв о книге.
+	ErrAlreadyReviewed = errors.New("reader has already reviewed this book")
+	// ErrForbidden — отзыв принадлежит другому читателю.
+	ErrForbidden = errors.New("review belongs to another reader")
+)
+
+// errFound останавливает обход бронирований на первом подходящем.
+var errFound = errors.New("found")
+
+// now возвращает текущее время с точностью хранилища — до микросекунд,
+// чтобы ответ совпадал с тем, что потом прочитается из базы.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// Viewer — кто обращается к отзыву: читатель сессии и признак
+// администратора. Нулевой ReaderID — анонимный запрос.
+type Viewer struct {
+	ReaderID int
+	Admin    bool
+}
+
+// ReviewService описывает бизнес-логику отзывов.
+type ReviewService interface {
+	// CreateReview сохраняет отзыв читателя о книге, бронирование которой
+	// у него завершилось. Оценка без текста публикуется сразу, отзыв с
+	// текстом ждёт модерации.
+	CreateReview(ctx context.Context, readerID, bookID int, req commands.CreateReviewRequest) (*reviews.Review, error)
+	// GetReview возвращает отзыв; неодобренный видят только автор и
+	// администраторы.
+	GetReview(ctx context.Context, viewer Viewer, id int) (*reviews.Review, error)
+	// UpdateReview меняет свой отзыв; изменённый текст снова проходит
+	// модерацию.
+	UpdateReview(ctx context.Context, readerID, id int, req commands.UpdateReviewRequest) (*reviews.Review, error)
+	// DeleteReview удаляет отзыв; чужой может удалить только администратор.
+	DeleteReview(ctx context.Context, viewer Viewer, id int) error
+	// BookReviews возвращает одобренные отзывы о книге.
+	BookReviews(ctx context.Context, bookID int, sort reviews.Sort) ([]reviews.Review, error)
+	// ListReviews возвращает отзывы по фильтру для модерации.
+	ListReviews(ctx context.Context, filter reviews.Filter) ([]reviews.Review, error)
+	// ModerateReview одобряет или отклоняет отзыв.
+	ModerateReview(ctx context.Context, id int, status reviews.Status) (*reviews.Review, error)
+	// MarkHelpful и UnmarkHelpful ставят и снимают отметку «полезен» на
+	// чужом одобренном отзыве.
+	MarkHelpful(ctx context.Context, readerID, id int) (*reviews.Review, error)
+	UnmarkHelpful(ctx context.Context, readerID, id int) (*reviews.Review, error)
+
+	// LoadRating и LoadRatings дополняют книги сводкой оценок (см. сервис
+	// books).
+	LoadRating(ctx context.Context, book *books.Book) error
+	LoadRatings(ctx context.Context, list []books.Book) error
+}
+
+type reviewService struct {
+	reviewRepo      reviews.ReviewRepo
+	bookRepo        books.BookRepo
+	reservationRepo reservations.ReservationRepo
+	idCounter       *genid.IDcounter
+}
+
+// NewReviewService возвращает реализацию ReviewService.
+func NewReviewService(repo reviews.ReviewRepo, bookRepo books.BookRepo, reservationRepo reservations.ReservationRepo, counter *genid.IDcounter) ReviewService {
+	return &reviewService{
+		reviewRepo:      repo,
+		bookRepo:        bookRepo,
+		reservationRepo: reservationRepo,
+		idCounter:       counter,
+	}
+}
+
+func (s *reviewService) CreateReview(ctx context.Context, readerID, bookID int, req commands.CreateReviewRequest) (*reviews.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.CreateReview")
+	defer span.End()
+
+	review, err := reviews.NewReview(s.idCounter.GenerateID(), bookID, readerID, req.Rating, req.Text, now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+	finished, err := s.hasFinished(ctx, readerID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if !finished {
+		return nil, ErrNotEligible
+	}
+	existing, err := s.reviewRepo.List(ctx, reviews.Filter{BookID: bookID, ReaderID: readerID})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("%w: review %d", ErrAlreadyReviewed, existing[0].ID)
+	}
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// hasFinished сообщает, есть ли у читателя бронирование книги, которое
+// закончилось до сегодняшнего дня или по которому книга уже возвращена.
+func (s *reviewService) hasFinished(ctx context.Context, readerID, bookID int) (bool, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	filter := reservations.Filter{BookID: bookID, ReaderID: readerID}
+	err := s.reservationRepo.Iterate(ctx, filter, func(res *reservations.Reservation) error {
+		if res.ReturnedDate != nil || res.EndDate.Before(today) {
+			return errFound
+		}
+		return nil
+	})
+	if errors.Is(err, errFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (s *reviewService) GetReview(ctx context.Context, viewer Viewer, id int) (*reviews.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GetReview")
+	defer span.End()
+
+	review, err := s.reviewRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Чужой неодобренный отзыв выглядит как отсутствующий.
+	if !review.Visible() && !viewer.Admin && viewer.ReaderID != review.ReaderID {
+		return nil, reviews.ErrNotFound
+	}
+	return review, nil
+}
+
+func (s *reviewService) UpdateReview(ctx context.Context, readerID, id int, req commands.UpdateReviewRequest) (*reviews.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.UpdateReview")
+	defer span.End()
+
+	review, err := s.reviewRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.ReaderID != readerID {
+		return nil, ErrForbidden
+	}
+	if err := review.Edit(req.Rating, req.Text, now()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := s.reviewRepo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, viewer Viewer, id int) error {
+	ctx, span := tracing.Start(ctx, "ReviewService.DeleteReview")
+	defer span.End()
+
+	review, err := s.reviewRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !viewer.Admin && review.ReaderID != viewer.ReaderID {
+		return ErrForbidden
+	}
+	return s.reviewRepo.Delete(ctx, id)
+}
+
+func (s *reviewService) BookReviews(ctx context.Context, bookID int, sort reviews.Sort) ([]reviews.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.BookReviews")
+	defer span.End()
+
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+	return s.list(ctx, reviews.Filter{BookID: bookID, Status: reviews.StatusApproved, Sort: sort})
+}
+
+func (s *reviewService) ListReviews(ctx context.Context, filter reviews.Filter) ([]reviews.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.ListReviews")
+	defer span.End()
+
+	return s.list(ctx, filter)
+}
+
+// list возвращает непустой срез, чтобы пустой список выдавался как [].
+func (s *reviewService) list(ctx context.Context, filter reviews.Filter) ([]reviews.Review, error) {
+	list, err := s.reviewRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = []reviews.Review{}
+	}
+	return list, nil
+}
+
+func (s *reviewService) ModerateReview(ctx context.Context, id int, status reviews.Status) (*reviews.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.ModerateReview")
+	defer span.End()
+
+	if status != reviews.StatusApproved && status != reviews.StatusRejected {
+		return nil, fmt.Errorf("%w: status must be %s or %s", ErrInvalidInput, reviews.StatusApproved, reviews.StatusRejected)
+	}
+	review, err := s.reviewRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	review.Status = status
+	if err := s.reviewRepo.Update(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (s *reviewService) MarkHelpful(ctx context.Context, readerID, id int) (*reviews.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.MarkHelpful")
+	defer span.End()
+
+	return s.vote(ctx, readerID, id, s.reviewRepo.Vote)
+}
+
+func (s *reviewService) UnmarkHelpful(ctx context.Context, readerID, id int) (*reviews.Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.UnmarkHelpful")
+	defer span.End()
+
+	return s.vote(ctx, readerID, id, s.reviewRepo.Unvote)
+}
+
+// vote применяет apply к одобренному чужому отзыву и возвращает его с
+// новым числом отметок.
+func (s *reviewService) vote(ctx context.Context, readerID, id int, apply func(ctx context.Context, reviewID, readerID int) error) (*reviews.Review, error) {
+	review, err := s.reviewRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !review.Visible() {
+		return nil, reviews.ErrNotFound
+	}
+	if review.ReaderID == readerID {
+		return nil, fmt.Errorf("%w: readers cannot vote for their own review", ErrInvalidInput)
+	}
+	if err := apply(ctx, id, readerID); err != nil {
+		return nil, err
+	}
+	return s.reviewRepo.GetByID(ctx, id)
+}
+
+func (s *reviewService) LoadRating(ctx context.Context, book *books.Book) error {
+	rating, err := s.reviewRepo.BookRating(ctx, book.ID)
+	if err != nil {
+		return err
+	}
+	book.Rating = &rating
+	return nil
+}
+
+func (s *reviewService) LoadRatings(ctx context.Context, list []books.Book) error {
+	if len(list) == 0 {
+		return nil
+	}
+	ratings, err := s.reviewRepo.Ratings(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range list {
+		rating := ratings[list[i].ID]
+		list[i].Rating = &rating
+	}
+	return nil
+}
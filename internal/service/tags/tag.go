@@ -0,0 +1,196 @@
+// Package tags управляет свободными тематическими тегами книг.
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/tags"
+	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+// ErrInvalidInput — пустое или слишком длинное имя тега, неверный список
+// тегов для слияния.
+var ErrInvalidInput = errors.New("invalid input")
+
+// TagService описывает бизнес-логику для тегов.
+type TagService interface {
+	// TagBook ставит книге теги по именам. Имена нормализуются, синонимы
+	// заменяются основным тегом, недостающие теги создаются. Возвращает
+	// все теги книги.
+	TagBook(ctx context.Context, bookID int, names []string) ([]tags.Tag, error)
+	UntagBook(ctx context.Context, bookID, tagID int) error
+	BookTags(ctx context.Context, bookID int) ([]tags.Tag, error)
+
+	GetTag(ctx context.Context, id int) (*tags.Tag, error)
+	DeleteTag(ctx context.Context, id int) error
+	// TagCounts возвращает самые частые теги для облака тегов; limit 0 —
+	// все теги с книгами.
+	TagCounts(ctx context.Context, limit int) ([]tags.TagCount, error)
+	// MergeTags сливает синонимы sourceIDs в тег targetID.
+	MergeTags(ctx context.Context, targetID int, sourceIDs []int) (*tags.Tag, error)
+	// ListBooks возвращает книги с тегом.
+	ListBooks(ctx context.Context, id int) ([]books.Book, error)
+}
+
+// BookFinder выдаёт книги по фильтру вместе с жанрами и обложками
+// (см. сервис books).
+type BookFinder interface {
+	FindBooks(ctx context.Context, filter books.Filter) ([]books.Book, error)
+}
+
+type tagService struct {
+	tagRepo   tags.TagRepo
+	bookRepo  books.BookRepo
+	idCounter *genid.IDcounter
+	finder    BookFinder
+}
+
+// NewTagService возвращает реализацию TagService.
+func NewTagService(repo tags.TagRepo, bookRepo books.BookRepo, counter *genid.IDcounter, finder BookFinder) TagService {
+	return &tagService{
+		tagRepo:   repo,
+		bookRepo:  bookRepo,
+		idCounter: counter,
+		finder:    finder,
+	}
+}
+
+func (s *tagService) TagBook(ctx context.Context, bookID int, names []string) ([]tags.Tag, error) {
+	ctx, span := tracing.Start(ctx, "TagService.TagBook")
+	defer span.End()
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: no tags given", ErrInvalidInput)
+	}
+	// Имена проверяются до записи, чтобы неверное имя в конце списка
+	// не оставило книгу помеченной наполовину.
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name, err := tags.Normalize(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		normalized = append(normalized, name)
+	}
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	for _, name := range normalized {
+		tag, err := s.tagRepo.FindByName(ctx, name)
+		if errors.Is(err, tags.ErrNotFound) {
+			tag = &tags.Tag{ID: s.idCounter.GenerateID(), Name: name}
+			err = s.tagRepo.Create(ctx, tag)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := s.tagRepo.AddToBook(ctx, bookID, tag.ID); err != nil {
+			return nil, err
+		}
+	}
+	return s.tagRepo.BookTags(ctx, bookID)
+}
+
+func (s *tagService) UntagBook(ctx context.Context, bookID, tagID int) error {
+	ctx, span := tracing.Start(ctx, "TagService.UntagBook")
+	defer span.End()
+
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return err
+	}
+	return s.tagRepo.RemoveFromBook(ctx, bookID, tagID)
+}
+
+func (s *tagService) BookTags(ctx context.Context, bookID int) ([]tags.Tag, error) {
+	ctx, span := tracing.Start(ctx, "TagService.BookTags")
+	defer span.End()
+
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+	list, err := s.tagRepo.BookTags(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = []tags.Tag{}
+	}
+	return list, nil
+}
+
+func (s *tagService) GetTag(ctx context.Context, id int) (*tags.Tag, error) {
+	ctx, span := tracing.Start(ctx, "TagService.GetTag")
+	defer span.End()
+
+	return s.tagRepo.GetByID(ctx, id)
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "TagService.DeleteTag")
+	defer span.End()
+
+	if _, err := s.tagRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.tagRepo.Delete(ctx, id)
+}
+
+func (s *tagService) TagCounts(ctx context.Context, limit int) ([]tags.TagCount, error) {
+	ctx, span := tracing.Start(ctx, "TagService.TagCounts")
+	defer span.End()
+
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrInvalidInput)
+	}
+	counts, err := s.tagRepo.Counts(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	if counts == nil {
+		counts = []tags.TagCount{}
+	}
+	return counts, nil
+}
+
+func (s *tagService) MergeTags(ctx context.Context, targetID int, sourceIDs []int) (*tags.Tag, error) {
+	ctx, span := tracing.Start(ctx, "TagService.MergeTags")
+	defer span.End()
+
+	if len(sourceIDs) == 0 {
+		return nil, fmt.Errorf("%w: no tags to merge", ErrInvalidInput)
+	}
+	if slices.Contains(sourceIDs, targetID) {
+		return nil, fmt.Errorf("%w: tag %d cannot be merged into itself", ErrInvalidInput, targetID)
+	}
+	if _, err := s.tagRepo.GetByID(ctx, targetID); err != nil {
+		return nil, err
+	}
+	for _, id := range sourceIDs {
+		if _, err := s.tagRepo.GetByID(ctx, id); err != nil {
+			if errors.Is(err, tags.ErrNotFound) {
+				return nil, fmt.Errorf("%w: tag %d not found", ErrInvalidInput, id)
+			}
+			return nil, err
+		}
+	}
+	if err := s.tagRepo.Merge(ctx, targetID, sourceIDs); err != nil {
+		return nil, err
+	}
+	return s.tagRepo.GetByID(ctx, targetID)
+}
+
+func (s *tagService) ListBooks(ctx context.Context, id int) ([]books.Book, error) {
+	ctx, span := tracing.Start(ctx, "TagService.ListBooks")
+	defer span.End()
+
+	if _, err := s.tagRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.finder.FindBooks(ctx, books.Filter{TagID: id})
+}
@@ -0,0 +1,122 @@
+// Package works управляет произведениями — группами изданий одной книги.
+package works
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/internal/application/commands"
+	"github.com/0sokrat0/BookAPI/internal/domain/aggregate/books"
+	"github.com/0sokrat0/BookAPI/internal/domain/entity/works"
+	genid "github.com/0sokrat0/BookAPI/pkg/GenID"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
+)
+
+var (
+	// ErrInvalidInput — пустое название произведения.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrInUse — у произведения есть издания.
+	ErrInUse = errors.New("work is in use")
+)
+
+// WorkService описывает бизнес-логику для произведений.
+type WorkService interface {
+	CreateWork(ctx context.Context, req commands.CreateWorkRequest) (*works.Work, error)
+	GetWork(ctx context.Context, id int) (*works.Work, error)
+	UpdateWork(ctx context.Context, id int, req commands.UpdateWorkRequest) (*works.Work, error)
+	DeleteWork(ctx context.Context, id int) error
+	ListWorks(ctx context.Context) ([]works.Work, error)
+	// ListEditions возвращает издания произведения по возрастанию ID.
+	ListEditions(ctx context.Context, id int) ([]books.Book, error)
+}
+
+// BookFinder выдаёт книги по фильтру вместе с жанрами и обложками
+// (см. сервис books).
+type BookFinder interface {
+	FindBooks(ctx context.Context, filter books.Filter) ([]books.Book, error)
+}
+
+type workService struct {
+	workRepo  works.WorkRepo
+	idCounter *genid.IDcounter
+	finder    BookFinder
+}
+
+// NewWorkService возвращает реализацию WorkService.
+func NewWorkService(repo works.WorkRepo, counter *genid.IDcounter, finder BookFinder) WorkService {
+	return &workService{
+		workRepo:  repo,
+		idCounter: counter,
+		finder:    finder,
+	}
+}
+
+func (s *workService) CreateWork(ctx context.Context, req commands.CreateWorkRequest) (*works.Work, error) {
+	ctx, span := tracing.Start(ctx, "WorkService.CreateWork")
+	defer span.End()
+
+	work, err := works.NewWork(s.idCounter.GenerateID(), req.Title)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := s.workRepo.Create(ctx, work); err != nil {
+		return nil, err
+	}
+	return work, nil
+}
+
+func (s *workService) GetWork(ctx context.Context, id int) (*works.Work, error) {
+	ctx, span := tracing.Start(ctx, "WorkService.GetWork")
+	defer span.End()
+
+	return s.workRepo.GetByID(ctx, id)
+}
+
+func (s *workService) UpdateWork(ctx context.Context, id int, req commands.UpdateWorkRequest) (*works.Work, error) {
+	ctx, span := tracing.Start(ctx, "WorkService.UpdateWork")
+	defer span.End()
+
+	if _, err := s.workRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	work, err := works.NewWork(id, req.Title)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := s.workRepo.Update(ctx, work); err != nil {
+		return nil, err
+	}
+	return work, nil
+}
+
+func (s *workService) DeleteWork(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "WorkService.DeleteWork")
+	defer span.End()
+
+	editions, err := s.ListEditions(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(editions) > 0 {
+		return fmt.Errorf("%w: work %d has %d editions", ErrInUse, id, len(editions))
+	}
+	return s.workRepo.Delete(ctx, id)
+}
+
+func (s *workService) ListWorks(ctx context.Context) ([]works.Work, error) {
+	ctx, span := tracing.Start(ctx, "WorkService.ListWorks")
+	defer span.End()
+
+	return s.workRepo.List(ctx)
+}
+
+func (s *workService) ListEditions(ctx context.Context, id int) ([]books.Book, error) {
+	ctx, span := tracing.Start(ctx, "WorkService.ListEditions")
+	defer span.End()
+
+	if _, err := s.workRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.finder.FindBooks(ctx, books.Filter{WorkID: id})
+}
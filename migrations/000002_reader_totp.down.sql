@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS reader_recovery_codes;
+
+ALTER TABLE readers
+    DROP COLUMN IF EXISTS totp_last_step,
+    DROP COLUMN IF EXISTS totp_enabled,
+    DROP COLUMN IF EXISTS totp_secret;
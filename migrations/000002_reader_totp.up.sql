@@ -0,0 +1,13 @@
+-- Двухфакторная аутентификация читателей (TOTP)
+ALTER TABLE readers
+    ADD COLUMN totp_secret VARCHAR NOT NULL DEFAULT '',
+    ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN totp_last_step BIGINT NOT NULL DEFAULT 0;
+
+-- Хэши одноразовых кодов восстановления
+CREATE TABLE reader_recovery_codes (
+    reader_id INT NOT NULL,
+    code_hash VARCHAR NOT NULL,
+    PRIMARY KEY (reader_id, code_hash),
+    FOREIGN KEY (reader_id) REFERENCES readers(id) ON DELETE CASCADE
+);
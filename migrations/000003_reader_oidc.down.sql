@@ -0,0 +1 @@
+ALTER TABLE readers DROP COLUMN IF EXISTS oidc_subject;
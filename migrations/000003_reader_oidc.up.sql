@@ -0,0 +1,2 @@
+-- Привязка читателя к субъекту внешнего провайдера OpenID Connect
+ALTER TABLE readers ADD COLUMN oidc_subject VARCHAR UNIQUE;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS books_isbn_normalized_idx;
@@ -0,0 +1,9 @@
+-- Обложки книг: изображения лежат в хранилище файлов, здесь — их версия
+CREATE TABLE book_covers (
+    book_id INT PRIMARY KEY REFERENCES books(id) ON DELETE CASCADE,
+    version VARCHAR NOT NULL,
+    content_type VARCHAR NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    thumbnail_widths INT[] NOT NULL DEFAULT '{}'
+);
@@ -0,0 +1,16 @@
+-- Возвращаем текстовое поле: книге достаётся название первого по ID жанра
+ALTER TABLE books ADD COLUMN genre VARCHAR;
+
+UPDATE books b
+SET genre = (
+    SELECT g.name
+    FROM book_genres bg
+    JOIN genres g ON g.id = bg.genre_id
+    WHERE bg.book_id = b.id
+    ORDER BY g.id
+    LIMIT 1
+);
+
+DROP TABLE IF EXISTS book_genres;
+DROP TABLE IF EXISTS genre_names;
+DROP TABLE IF EXISTS genres;
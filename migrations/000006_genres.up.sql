@@ -0,0 +1,47 @@
+-- Иерархия жанров вместо текстового поля books.genre
+CREATE TABLE genres (
+    id INT PRIMARY KEY,
+    parent_id INT REFERENCES genres(id),
+    name VARCHAR NOT NULL
+);
+
+-- Локализованные названия жанров
+CREATE TABLE genre_names (
+    genre_id INT NOT NULL REFERENCES genres(id) ON DELETE CASCADE,
+    lang VARCHAR NOT NULL,
+    name VARCHAR NOT NULL,
+    PRIMARY KEY (genre_id, lang)
+);
+
+CREATE TABLE book_genres (
+    book_id INT NOT NULL REFERENCES books(id),
+    genre_id INT NOT NULL REFERENCES genres(id),
+    PRIMARY KEY (book_id, genre_id)
+);
+CREATE INDEX book_genres_genre_id_idx ON book_genres (genre_id);
+
+-- Переносим различающиеся без учёта регистра и пробелов по краям значения
+-- в жанры верхнего уровня. ID продолжают общий счётчик приложения.
+INSERT INTO genres (id, name)
+SELECT base.max_id + row_number() OVER (ORDER BY g.name), g.name
+FROM (
+    SELECT DISTINCT ON (lower(btrim(genre))) btrim(genre) AS name
+    FROM books
+    WHERE btrim(COALESCE(genre, '')) <> ''
+    ORDER BY lower(btrim(genre)), btrim(genre)
+) AS g,
+(
+    SELECT COALESCE(MAX(id), 0) AS max_id FROM (
+        SELECT MAX(id) AS id FROM books
+        UNION ALL SELECT MAX(id) FROM authors
+        UNION ALL SELECT MAX(id) FROM readers
+        UNION ALL SELECT MAX(id) FROM reservations
+    ) AS ids
+) AS base;
+
+INSERT INTO book_genres (book_id, genre_id)
+SELECT b.id, g.id
+FROM books b
+JOIN genres g ON lower(g.name) = lower(btrim(b.genre));
+
+ALTER TABLE books DROP COLUMN genre;
@@ -0,0 +1,19 @@
+-- Свободные тематические теги книг. Имена хранятся нормализованными.
+CREATE TABLE tags (
+    id INT PRIMARY KEY,
+    name VARCHAR NOT NULL UNIQUE
+);
+
+-- Синонимы — имена тегов, слитых в другой тег
+CREATE TABLE tag_aliases (
+    alias VARCHAR PRIMARY KEY,
+    tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE
+);
+CREATE INDEX tag_aliases_tag_id_idx ON tag_aliases (tag_id);
+
+CREATE TABLE book_tags (
+    book_id INT NOT NULL REFERENCES books(id),
+    tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, tag_id)
+);
+CREATE INDEX book_tags_tag_id_idx ON book_tags (tag_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS books_work_id_idx;
+ALTER TABLE books
+    DROP COLUMN IF EXISTS work_id,
+    DROP COLUMN IF EXISTS publisher,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS translator;
+DROP TABLE IF EXISTS works;
@@ -0,0 +1,11 @@
+-- Возвращаем текстовое поле с названием издательства
+ALTER TABLE books ADD COLUMN publisher VARCHAR NOT NULL DEFAULT '';
+
+UPDATE books b
+SET publisher = p.name
+FROM publishers p
+WHERE p.id = b.publisher_id;
+
+DROP INDEX IF EXISTS books_publisher_id_idx;
+ALTER TABLE books DROP COLUMN IF EXISTS publisher_id;
+DROP TABLE IF EXISTS publishers;
@@ -0,0 +1,39 @@
+-- Издательства вместо текстового поля books.publisher
+CREATE TABLE publishers (
+    id INT PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    city VARCHAR NOT NULL DEFAULT '',
+    country VARCHAR NOT NULL DEFAULT ''
+);
+
+ALTER TABLE books ADD COLUMN publisher_id INT REFERENCES publishers(id);
+CREATE INDEX books_publisher_id_idx ON books (publisher_id);
+
+-- Переносим различающиеся без учёта регистра и пробелов по краям названия
+-- в издательства. ID продолжают общий счётчик приложения.
+INSERT INTO publishers (id, name)
+SELECT base.max_id + row_number() OVER (ORDER BY p.name), p.name
+FROM (
+    SELECT DISTINCT ON (lower(btrim(publisher))) btrim(publisher) AS name
+    FROM books
+    WHERE btrim(publisher) <> ''
+    ORDER BY lower(btrim(publisher)), btrim(publisher)
+) AS p,
+(
+    SELECT COALESCE(MAX(id), 0) AS max_id FROM (
+        SELECT MAX(id) AS id FROM books
+        UNION ALL SELECT MAX(id) FROM authors
+        UNION ALL SELECT MAX(id) FROM readers
+        UNION ALL SELECT MAX(id) FROM reservations
+        UNION ALL SELECT MAX(id) FROM genres
+        UNION ALL SELECT MAX(id) FROM tags
+        UNION ALL SELECT MAX(id) FROM works
+    ) AS ids
+) AS base;
+
+UPDATE books b
+SET publisher_id = p.id
+FROM publishers p
+WHERE lower(p.name) = lower(btrim(b.publisher));
+
+ALTER TABLE books DROP COLUMN publisher;
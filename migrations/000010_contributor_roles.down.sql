@@ -0,0 +1,11 @@
+-- Без ролей каждый участник снова становится автором; из нескольких ролей
+-- одного человека остаётся первая по порядку.
+DELETE FROM book_authors a
+USING book_authors b
+WHERE a.book_id = b.book_id AND a.author_id = b.author_id
+  AND (a.position, a.role) > (b.position, b.role);
+
+ALTER TABLE book_authors DROP CONSTRAINT book_authors_pkey;
+ALTER TABLE book_authors DROP COLUMN position;
+ALTER TABLE book_authors DROP COLUMN role;
+ALTER TABLE book_authors ADD PRIMARY KEY (book_id, author_id);
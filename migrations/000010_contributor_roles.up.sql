@@ -0,0 +1,18 @@
+-- Роль и порядок участника книги: один автор может быть и автором, и
+-- иллюстратором, поэтому роль входит в первичный ключ.
+ALTER TABLE book_authors ADD COLUMN role VARCHAR NOT NULL DEFAULT 'author'
+    CHECK (role IN ('author', 'translator', 'editor', 'illustrator'));
+ALTER TABLE book_authors ADD COLUMN position INT NOT NULL DEFAULT 0;
+
+-- Прежний порядок не хранился; нумеруем существующих авторов по ID.
+UPDATE book_authors ba
+SET position = o.position
+FROM (
+    SELECT book_id, author_id,
+           row_number() OVER (PARTITION BY book_id ORDER BY author_id) - 1 AS position
+    FROM book_authors
+) AS o
+WHERE ba.book_id = o.book_id AND ba.author_id = o.author_id;
+
+ALTER TABLE book_authors DROP CONSTRAINT book_authors_pkey;
+ALTER TABLE book_authors ADD PRIMARY KEY (book_id, author_id, role);
@@ -0,0 +1,12 @@
+-- Даты жизни и биография автора
+ALTER TABLE authors ADD COLUMN birth_date DATE;
+ALTER TABLE authors ADD COLUMN death_date DATE;
+ALTER TABLE authors ADD COLUMN bio TEXT NOT NULL DEFAULT '';
+
+-- Синонимы имени: транслитерации, инициалы, псевдонимы и имена слитых
+-- дубликатов. У разных авторов синонимы могут совпадать.
+CREATE TABLE author_aliases (
+    author_id INT NOT NULL REFERENCES authors(id) ON DELETE CASCADE,
+    alias VARCHAR NOT NULL,
+    PRIMARY KEY (author_id, alias)
+);
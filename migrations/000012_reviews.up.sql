@@ -0,0 +1,23 @@
+-- Отзывы читателей о книгах: оценка 1–5 и необязательный текст. Читатель
+-- оставляет о книге один отзыв; в рейтинг входят только одобренные.
+CREATE TABLE reviews (
+    id INT PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    reader_id INT NOT NULL REFERENCES readers(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body TEXT NOT NULL DEFAULT '',
+    status VARCHAR NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (book_id, reader_id)
+);
+CREATE INDEX reviews_reader_id_idx ON reviews (reader_id);
+CREATE INDEX reviews_status_idx ON reviews (status);
+
+-- Отметки «отзыв полезен», по одной от читателя
+CREATE TABLE review_votes (
+    review_id INT NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    reader_id INT NOT NULL REFERENCES readers(id) ON DELETE CASCADE,
+    PRIMARY KEY (review_id, reader_id)
+);
+CREATE INDEX review_votes_reader_id_idx ON review_votes (reader_id);
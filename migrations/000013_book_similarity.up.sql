@@ -0,0 +1,11 @@
+-- Соседи книг по совместным бронированиям; таблица целиком пересчитывается
+-- фоновой задачей. position — место соседа в списке книги, начиная с 1.
+CREATE TABLE book_similarity (
+    book_id INT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    similar_book_id INT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    readers INT NOT NULL,
+    PRIMARY KEY (book_id, similar_book_id)
+);
+CREATE INDEX book_similarity_similar_book_id_idx ON book_similarity (similar_book_id);
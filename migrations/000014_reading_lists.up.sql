@@ -0,0 +1,19 @@
+-- Списки книг читателей «на потом». slug задан только у публичных списков
+-- и служит ссылкой для общего доступа.
+CREATE TABLE reading_lists (
+    id INT PRIMARY KEY,
+    reader_id INT NOT NULL REFERENCES readers(id) ON DELETE CASCADE,
+    name VARCHAR NOT NULL,
+    slug VARCHAR UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX reading_lists_reader_id_idx ON reading_lists (reader_id);
+
+-- Книги списка; position задаёт порядок, начиная с 1
+CREATE TABLE reading_list_books (
+    list_id INT NOT NULL REFERENCES reading_lists(id) ON DELETE CASCADE,
+    book_id INT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    PRIMARY KEY (list_id, book_id)
+);
+CREATE INDEX reading_list_books_book_id_idx ON reading_list_books (book_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS reservations_unreturned_end_date_idx;
+ALTER TABLE reservations DROP COLUMN returned_date;
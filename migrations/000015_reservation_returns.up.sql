@@ -0,0 +1,4 @@
+-- Дата возврата книги; NULL — книга ещё у читателя. Просроченными считаются
+-- только невозвращённые бронирования.
+ALTER TABLE reservations ADD COLUMN returned_date DATE;
+CREATE INDEX reservations_unreturned_end_date_idx ON reservations (end_date) WHERE returned_date IS NULL;
@@ -0,0 +1,45 @@
+-- Создание таблицы books
+CREATE TABLE books (
+    id INTEGER PRIMARY KEY,
+    title TEXT NOT NULL,
+    year INTEGER,
+    isbn TEXT,
+    genre TEXT
+);
+
+-- Создание таблицы authors
+CREATE TABLE authors (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    country TEXT
+);
+
+-- Создание таблицы readers
+CREATE TABLE readers (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    phone TEXT,
+    email TEXT,
+    password TEXT,
+    admin BOOLEAN
+);
+
+-- Создание таблицы book_authors (связующая таблица между books и authors)
+CREATE TABLE book_authors (
+    book_id INTEGER NOT NULL,
+    author_id INTEGER NOT NULL,
+    PRIMARY KEY (book_id, author_id),
+    FOREIGN KEY (book_id) REFERENCES books(id),
+    FOREIGN KEY (author_id) REFERENCES authors(id)
+);
+
+-- Создание таблицы reservations; даты хранятся как текст YYYY-MM-DD
+CREATE TABLE reservations (
+    id INTEGER PRIMARY KEY,
+    book_id INTEGER NOT NULL,
+    reader_id INTEGER NOT NULL,
+    start_date TEXT,
+    end_date TEXT,
+    FOREIGN KEY (book_id) REFERENCES books(id),
+    FOREIGN KEY (reader_id) REFERENCES readers(id)
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS reader_recovery_codes;
+ALTER TABLE readers DROP COLUMN totp_last_step;
+ALTER TABLE readers DROP COLUMN totp_enabled;
+ALTER TABLE readers DROP COLUMN totp_secret;
@@ -0,0 +1,12 @@
+-- Двухфакторная аутентификация читателей (TOTP)
+ALTER TABLE readers ADD COLUMN totp_secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE readers ADD COLUMN totp_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE readers ADD COLUMN totp_last_step INTEGER NOT NULL DEFAULT 0;
+
+-- Хэши одноразовых кодов восстановления
+CREATE TABLE reader_recovery_codes (
+    reader_id INTEGER NOT NULL,
+    code_hash TEXT NOT NULL,
+    PRIMARY KEY (reader_id, code_hash),
+    FOREIGN KEY (reader_id) REFERENCES readers(id) ON DELETE CASCADE
+);
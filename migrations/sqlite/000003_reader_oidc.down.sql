@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS readers_oidc_subject_key;
+ALTER TABLE readers DROP COLUMN oidc_subject;
@@ -0,0 +1,4 @@
+-- Привязка читателя к субъекту внешнего провайдера OpenID Connect.
+-- SQLite не добавляет UNIQUE-колонки через ALTER TABLE, поэтому отдельный индекс.
+ALTER TABLE readers ADD COLUMN oidc_subject TEXT;
+CREATE UNIQUE INDEX readers_oidc_subject_key ON readers (oidc_subject);
@@ -0,0 +1,2 @@
+-- Индекс для поиска книги по нормализованному ISBN при импорте
+CREATE INDEX books_isbn_normalized_idx ON books ((upper(replace(replace(isbn, '-', ''), ' ', ''))));
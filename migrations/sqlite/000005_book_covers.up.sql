@@ -0,0 +1,10 @@
+-- Обложки книг; ширины миниатюр хранятся через запятую
+CREATE TABLE book_covers (
+    book_id INTEGER PRIMARY KEY,
+    version TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    thumbnail_widths TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
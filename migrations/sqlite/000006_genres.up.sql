@@ -0,0 +1,53 @@
+-- Иерархия жанров вместо текстового поля books.genre
+CREATE TABLE genres (
+    id INTEGER PRIMARY KEY,
+    parent_id INTEGER,
+    name TEXT NOT NULL,
+    FOREIGN KEY (parent_id) REFERENCES genres(id)
+);
+
+-- Локализованные названия жанров
+CREATE TABLE genre_names (
+    genre_id INTEGER NOT NULL,
+    lang TEXT NOT NULL,
+    name TEXT NOT NULL,
+    PRIMARY KEY (genre_id, lang),
+    FOREIGN KEY (genre_id) REFERENCES genres(id) ON DELETE CASCADE
+);
+
+CREATE TABLE book_genres (
+    book_id INTEGER NOT NULL,
+    genre_id INTEGER NOT NULL,
+    PRIMARY KEY (book_id, genre_id),
+    FOREIGN KEY (book_id) REFERENCES books(id),
+    FOREIGN KEY (genre_id) REFERENCES genres(id)
+);
+CREATE INDEX book_genres_genre_id_idx ON book_genres (genre_id);
+
+-- Переносим различающиеся значения в жанры верхнего уровня. lower() в SQLite
+-- меняет регистр только латиницы, поэтому варианты кириллических названий,
+-- отличающиеся регистром, станут разными жанрами. ID продолжают общий
+-- счётчик приложения.
+INSERT INTO genres (id, name)
+SELECT base.max_id + row_number() OVER (ORDER BY g.name), g.name
+FROM (
+    SELECT MIN(trim(genre)) AS name
+    FROM books
+    WHERE trim(COALESCE(genre, '')) <> ''
+    GROUP BY lower(trim(genre))
+) AS g,
+(
+    SELECT COALESCE(MAX(id), 0) AS max_id FROM (
+        SELECT MAX(id) AS id FROM books
+        UNION ALL SELECT MAX(id) FROM authors
+        UNION ALL SELECT MAX(id) FROM readers
+        UNION ALL SELECT MAX(id) FROM reservations
+    )
+) AS base;
+
+INSERT INTO book_genres (book_id, genre_id)
+SELECT b.id, g.id
+FROM books b
+JOIN genres g ON lower(g.name) = lower(trim(b.genre));
+
+ALTER TABLE books DROP COLUMN genre;
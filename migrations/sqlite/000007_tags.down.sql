@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS book_tags;
+DROP TABLE IF EXISTS tag_aliases;
+DROP TABLE IF EXISTS tags;
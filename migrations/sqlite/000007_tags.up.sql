@@ -0,0 +1,22 @@
+-- Свободные тематические теги книг. Имена хранятся нормализованными.
+CREATE TABLE tags (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE
+);
+
+-- Синонимы — имена тегов, слитых в другой тег
+CREATE TABLE tag_aliases (
+    alias TEXT PRIMARY KEY,
+    tag_id INTEGER NOT NULL,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+CREATE INDEX tag_aliases_tag_id_idx ON tag_aliases (tag_id);
+
+CREATE TABLE book_tags (
+    book_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    PRIMARY KEY (book_id, tag_id),
+    FOREIGN KEY (book_id) REFERENCES books(id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+CREATE INDEX book_tags_tag_id_idx ON book_tags (tag_id);
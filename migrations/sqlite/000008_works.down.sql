@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS books_work_id_idx;
+ALTER TABLE books DROP COLUMN work_id;
+ALTER TABLE books DROP COLUMN publisher;
+ALTER TABLE books DROP COLUMN language;
+ALTER TABLE books DROP COLUMN translator;
+DROP TABLE IF EXISTS works;
@@ -0,0 +1,37 @@
+-- Произведения: книги становятся изданиями произведения
+CREATE TABLE works (
+    id INTEGER PRIMARY KEY,
+    title TEXT NOT NULL
+);
+
+ALTER TABLE books ADD COLUMN work_id INTEGER REFERENCES works(id);
+ALTER TABLE books ADD COLUMN publisher TEXT NOT NULL DEFAULT '';
+ALTER TABLE books ADD COLUMN language TEXT NOT NULL DEFAULT '';
+ALTER TABLE books ADD COLUMN translator TEXT NOT NULL DEFAULT '';
+CREATE INDEX books_work_id_idx ON books (work_id);
+
+-- Каждая существующая книга становится единственным изданием своего
+-- произведения. ID продолжают общий счётчик приложения.
+CREATE TEMPORARY TABLE book_works AS
+SELECT b.id AS book_id, b.title, base.max_id + row_number() OVER (ORDER BY b.id) AS work_id
+FROM books b,
+(
+    SELECT COALESCE(MAX(id), 0) AS max_id FROM (
+        SELECT MAX(id) AS id FROM books
+        UNION ALL SELECT MAX(id) FROM authors
+        UNION ALL SELECT MAX(id) FROM readers
+        UNION ALL SELECT MAX(id) FROM reservations
+        UNION ALL SELECT MAX(id) FROM genres
+        UNION ALL SELECT MAX(id) FROM tags
+    )
+) AS base;
+
+INSERT INTO works (id, title)
+SELECT work_id, title FROM book_works;
+
+UPDATE books
+SET work_id = bw.work_id
+FROM book_works bw
+WHERE bw.book_id = books.id;
+
+DROP TABLE book_works;
@@ -0,0 +1,9 @@
+-- Возвращаем текстовое поле с названием издательства
+ALTER TABLE books ADD COLUMN publisher TEXT NOT NULL DEFAULT '';
+
+UPDATE books
+SET publisher = COALESCE((SELECT p.name FROM publishers p WHERE p.id = books.publisher_id), '');
+
+DROP INDEX IF EXISTS books_publisher_id_idx;
+ALTER TABLE books DROP COLUMN publisher_id;
+DROP TABLE IF EXISTS publishers;
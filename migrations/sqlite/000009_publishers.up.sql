@@ -0,0 +1,41 @@
+-- Издательства вместо текстового поля books.publisher
+CREATE TABLE publishers (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    city TEXT NOT NULL DEFAULT '',
+    country TEXT NOT NULL DEFAULT ''
+);
+
+ALTER TABLE books ADD COLUMN publisher_id INTEGER REFERENCES publishers(id);
+CREATE INDEX books_publisher_id_idx ON books (publisher_id);
+
+-- Переносим различающиеся названия в издательства. lower() в SQLite меняет
+-- регистр только латиницы, поэтому кириллические названия, отличающиеся
+-- регистром, станут разными издательствами. ID продолжают общий счётчик
+-- приложения.
+INSERT INTO publishers (id, name)
+SELECT base.max_id + row_number() OVER (ORDER BY p.name), p.name
+FROM (
+    SELECT MIN(trim(publisher)) AS name
+    FROM books
+    WHERE trim(publisher) <> ''
+    GROUP BY lower(trim(publisher))
+) AS p,
+(
+    SELECT COALESCE(MAX(id), 0) AS max_id FROM (
+        SELECT MAX(id) AS id FROM books
+        UNION ALL SELECT MAX(id) FROM authors
+        UNION ALL SELECT MAX(id) FROM readers
+        UNION ALL SELECT MAX(id) FROM reservations
+        UNION ALL SELECT MAX(id) FROM genres
+        UNION ALL SELECT MAX(id) FROM tags
+        UNION ALL SELECT MAX(id) FROM works
+    )
+) AS base;
+
+UPDATE books
+SET publisher_id = (
+    SELECT p.id FROM publishers p WHERE lower(p.name) = lower(trim(books.publisher))
+);
+
+ALTER TABLE books DROP COLUMN publisher;
@@ -0,0 +1,15 @@
+-- Без ролей каждый участник снова становится автором; из нескольких ролей
+-- одного человека остаётся первая по порядку.
+CREATE TABLE book_authors_old (
+    book_id INTEGER NOT NULL,
+    author_id INTEGER NOT NULL,
+    PRIMARY KEY (book_id, author_id),
+    FOREIGN KEY (book_id) REFERENCES books(id),
+    FOREIGN KEY (author_id) REFERENCES authors(id)
+);
+
+INSERT OR IGNORE INTO book_authors_old (book_id, author_id)
+SELECT book_id, author_id FROM book_authors ORDER BY book_id, position;
+
+DROP TABLE book_authors;
+ALTER TABLE book_authors_old RENAME TO book_authors;
@@ -0,0 +1,22 @@
+-- Роль и порядок участника книги: один автор может быть и автором, и
+-- иллюстратором, поэтому роль входит в первичный ключ. SQLite не меняет
+-- первичный ключ на месте, поэтому таблица пересоздаётся.
+CREATE TABLE book_authors_new (
+    book_id INTEGER NOT NULL,
+    author_id INTEGER NOT NULL,
+    role TEXT NOT NULL DEFAULT 'author'
+        CHECK (role IN ('author', 'translator', 'editor', 'illustrator')),
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (book_id, author_id, role),
+    FOREIGN KEY (book_id) REFERENCES books(id),
+    FOREIGN KEY (author_id) REFERENCES authors(id)
+);
+
+-- Прежний порядок не хранился; нумеруем существующих авторов по ID.
+INSERT INTO book_authors_new (book_id, author_id, role, position)
+SELECT book_id, author_id, 'author',
+       row_number() OVER (PARTITION BY book_id ORDER BY author_id) - 1
+FROM book_authors;
+
+DROP TABLE book_authors;
+ALTER TABLE book_authors_new RENAME TO book_authors;
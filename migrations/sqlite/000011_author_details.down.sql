@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS author_aliases;
+ALTER TABLE authors DROP COLUMN bio;
+ALTER TABLE authors DROP COLUMN death_date;
+ALTER TABLE authors DROP COLUMN birth_date;
@@ -0,0 +1,13 @@
+-- Даты жизни (YYYY-MM-DD) и биография автора
+ALTER TABLE authors ADD COLUMN birth_date TEXT;
+ALTER TABLE authors ADD COLUMN death_date TEXT;
+ALTER TABLE authors ADD COLUMN bio TEXT NOT NULL DEFAULT '';
+
+-- Синонимы имени: транслитерации, инициалы, псевдонимы и имена слитых
+-- дубликатов. У разных авторов синонимы могут совпадать.
+CREATE TABLE author_aliases (
+    author_id INTEGER NOT NULL,
+    alias TEXT NOT NULL,
+    PRIMARY KEY (author_id, alias),
+    FOREIGN KEY (author_id) REFERENCES authors(id) ON DELETE CASCADE
+);
@@ -0,0 +1,29 @@
+-- Отзывы читателей о книгах: оценка 1–5 и необязательный текст. Читатель
+-- оставляет о книге один отзыв; в рейтинг входят только одобренные.
+-- Время хранится в UTC в формате фиксированной ширины, поэтому строки
+-- сортируются как моменты времени.
+CREATE TABLE reviews (
+    id INTEGER PRIMARY KEY,
+    book_id INTEGER NOT NULL,
+    reader_id INTEGER NOT NULL,
+    rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    UNIQUE (book_id, reader_id),
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+    FOREIGN KEY (reader_id) REFERENCES readers(id) ON DELETE CASCADE
+);
+CREATE INDEX reviews_reader_id_idx ON reviews (reader_id);
+CREATE INDEX reviews_status_idx ON reviews (status);
+
+-- Отметки «отзыв полезен», по одной от читателя
+CREATE TABLE review_votes (
+    review_id INTEGER NOT NULL,
+    reader_id INTEGER NOT NULL,
+    PRIMARY KEY (review_id, reader_id),
+    FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+    FOREIGN KEY (reader_id) REFERENCES readers(id) ON DELETE CASCADE
+);
+CREATE INDEX review_votes_reader_id_idx ON review_votes (reader_id);
@@ -0,0 +1,13 @@
+-- Соседи книг по совместным бронированиям; таблица целиком пересчитывается
+-- фоновой задачей. position — место соседа в списке книги, начиная с 1.
+CREATE TABLE book_similarity (
+    book_id INTEGER NOT NULL,
+    similar_book_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    score REAL NOT NULL,
+    readers INTEGER NOT NULL,
+    PRIMARY KEY (book_id, similar_book_id),
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+    FOREIGN KEY (similar_book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+CREATE INDEX book_similarity_similar_book_id_idx ON book_similarity (similar_book_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reading_list_books;
+DROP TABLE IF EXISTS reading_lists;
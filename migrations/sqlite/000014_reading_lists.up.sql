@@ -0,0 +1,22 @@
+-- Списки книг читателей «на потом». slug задан только у публичных списков
+-- и служит ссылкой для общего доступа.
+CREATE TABLE reading_lists (
+    id INTEGER PRIMARY KEY,
+    reader_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    slug TEXT UNIQUE,
+    created_at TEXT NOT NULL,
+    FOREIGN KEY (reader_id) REFERENCES readers(id) ON DELETE CASCADE
+);
+CREATE INDEX reading_lists_reader_id_idx ON reading_lists (reader_id);
+
+-- Книги списка; position задаёт порядок, начиная с 1
+CREATE TABLE reading_list_books (
+    list_id INTEGER NOT NULL,
+    book_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (list_id, book_id),
+    FOREIGN KEY (list_id) REFERENCES reading_lists(id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE
+);
+CREATE INDEX reading_list_books_book_id_idx ON reading_list_books (book_id);
@@ -0,0 +1,10 @@
+// Package sqlite содержит миграции схемы для хранилища SQLite.
+// Версии совпадают с миграциями Postgres, отличается только диалект.
+package sqlite
+
+import "embed"
+
+// FS — файлы миграций в формате golang-migrate: NNNNNN_name.{up,down}.sql.
+//
+//go:embed *.sql
+var FS embed.FS
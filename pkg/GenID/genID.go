@@ -13,3 +13,14 @@ func NewCounter(initial int64) *IDcounter {
 func (i *IDcounter) GenerateID() int {
 	return int(atomic.AddInt64(&i.id, 1))
 }
+
+// Advance сдвигает счётчик так, чтобы следующий ID был больше min.
+// Используется при старте, чтобы не выдавать ID, уже занятые в базе.
+func (i *IDcounter) Advance(min int64) {
+	for {
+		cur := atomic.LoadInt64(&i.id)
+		if cur >= min || atomic.CompareAndSwapInt64(&i.id, cur, min) {
+			return
+		}
+	}
+}
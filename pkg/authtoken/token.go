@@ -0,0 +1,97 @@
+package authtoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Назначения токенов: сессия после входа, промежуточный вызов второго фактора
+// и сессия администратора, которому нужно подключить второй фактор.
+const (
+	PurposeSession    = "session"
+	PurposeTwoFactor  = "2fa"
+	PurposeEnrollment = "2fa-enroll"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+// Claims — содержимое подписанного токена.
+type Claims struct {
+	ReaderID  int    `json:"sub"`
+	Admin     bool   `json:"adm"`
+	Purpose   string `json:"pur"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Manager выпускает и проверяет токены, подписанные HMAC-SHA256.
+type Manager struct {
+	secret []byte
+}
+
+// NewManager создаёт менеджер токенов. Если секрет пуст, генерируется
+// случайный ключ — выданные токены перестанут действовать после перезапуска.
+func NewManager(secret string) (*Manager, error) {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate token secret: %w", err)
+		}
+	}
+	return &Manager{secret: key}, nil
+}
+
+// Issue подписывает токен с указанным назначением и временем жизни.
+func (m *Manager) Issue(readerID int, admin bool, purpose string, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(ttl)
+	payload, err := json.Marshal(Claims{
+		ReaderID:  readerID,
+		Admin:     admin,
+		Purpose:   purpose,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	return body + "." + m.sign(body), expiresAt, nil
+}
+
+// Parse проверяет подпись, срок действия и назначение токена.
+func (m *Manager) Parse(token, purpose string) (*Claims, error) {
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(m.sign(body))) {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.Purpose != purpose {
+		return nil, ErrInvalidToken
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+	return &claims, nil
+}
+
+func (m *Manager) sign(body string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
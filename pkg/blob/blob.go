@@ -0,0 +1,38 @@
+// Package blob хранит двоичные объекты (изображения и т. п.) по ключу:
+// в локальной файловой системе, в S3-совместимом хранилище или в памяти.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNotFound — объекта с таким ключом нет.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore хранит объекты по ключу вида "a/b/c". Объекты небольшие
+// и передаются в Put целиком: так S3 получает подписанный хеш содержимого.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get открывает объект для чтения; вызывающий закрывает reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет объект; отсутствие объекта ошибкой не считается.
+	Delete(ctx context.Context, key string) error
+}
+
+// validateKey отклоняет пустые сегменты, "." и "..", чтобы ключ
+// не выходил за пределы каталога или бакета.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return fmt.Errorf("blob: invalid key %q", key)
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." || strings.ContainsAny(seg, "\\\x00") {
+			return fmt.Errorf("blob: invalid key %q", key)
+		}
+	}
+	return nil
+}
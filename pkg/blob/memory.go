@@ -0,0 +1,47 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// Memory хранит объекты в памяти процесса; подходит для хранилища
+// memory и разработки.
+type Memory struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
+func NewMemory() *Memory {
+	return &Memory{objects: make(map[string][]byte)}
+}
+
+func (m *Memory) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = bytes.Clone(data)
+	return nil
+}
+
+func (m *Memory) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	// Put заменяет срез целиком, поэтому читать его без копии безопасно.
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+	return nil
+}
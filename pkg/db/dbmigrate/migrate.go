@@ -0,0 +1,188 @@
+// Package dbmigrate применяет встроенные SQL-миграции golang-migrate
+// и сообщает состояние схемы. Драйвер базы и способ блокировки задаёт
+// пакет конкретной СУБД.
+package dbmigrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// ErrNoMigrations возвращается, когда в бинарник не встроено ни одной миграции.
+var ErrNoMigrations = errors.New("no embedded migrations")
+
+// MigrationInfo описывает одну встроенную миграцию.
+type MigrationInfo struct {
+	Version    uint
+	Identifier string
+	Applied    bool
+}
+
+// Status — состояние схемы относительно встроенных миграций.
+type Status struct {
+	Current    uint
+	Dirty      bool
+	Latest     uint
+	Migrations []MigrationInfo
+}
+
+// LockFunc выполняет fn, удерживая блокировку миграций всей операции.
+type LockFunc func(ctx context.Context, fn func() error) error
+
+// Migrator применяет миграции из fsys к одной базе.
+type Migrator struct {
+	m    *migrate.Migrate
+	fsys fs.FS
+	lock LockFunc
+}
+
+// New готовит миграции из fsys для уже открытого драйвера базы.
+// lock может быть nil, если базу не делят несколько процессов.
+func New(ctx context.Context, fsys fs.FS, dbName string, driver database.Driver, lock LockFunc) (*Migrator, error) {
+	src, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, dbName, driver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	m.Log = migrateLogger{lg: logger.FromContext(ctx)}
+	if lock == nil {
+		lock = func(ctx context.Context, fn func() error) error { return fn() }
+	}
+	return &Migrator{m: m, fsys: fsys, lock: lock}, nil
+}
+
+// Up применяет все неприменённые миграции.
+func (mg *Migrator) Up(ctx context.Context) error {
+	return mg.run(ctx, "up", mg.m.Up)
+}
+
+// Down откатывает n последних миграций.
+func (mg *Migrator) Down(ctx context.Context, n int) error {
+	if n < 1 {
+		return fmt.Errorf("down: number of steps must be positive, got %d", n)
+	}
+	return mg.run(ctx, "down", func() error { return mg.m.Steps(-n) })
+}
+
+// Goto переводит схему на указанную версию вверх или вниз.
+func (mg *Migrator) Goto(ctx context.Context, version uint) error {
+	return mg.run(ctx, "goto", func() error { return mg.m.Migrate(version) })
+}
+
+// Force записывает версию без выполнения миграций и снимает признак dirty.
+// Нужен после ручного исправления упавшей миграции; -1 означает «нет версии».
+func (mg *Migrator) Force(ctx context.Context, version int) error {
+	return mg.run(ctx, "force", func() error { return mg.m.Force(version) })
+}
+
+// Status сравнивает версию схемы со встроенными миграциями.
+func (mg *Migrator) Status(ctx context.Context) (*Status, error) {
+	list, err := Embedded(mg.fsys)
+	if err != nil {
+		return nil, err
+	}
+	version, dirty, err := mg.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	status := &Status{Current: version, Dirty: dirty, Migrations: list}
+	for i := range status.Migrations {
+		status.Migrations[i].Applied = err == nil && status.Migrations[i].Version <= version
+	}
+	status.Latest = list[len(list)-1].Version
+	return status, nil
+}
+
+// Close освобождает соединение, открытое golang-migrate.
+func (mg *Migrator) Close() error {
+	srcErr, dbErr := mg.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// run выполняет операцию под блокировкой. Отмена ctx останавливает
+// миграции после текущего шага.
+func (mg *Migrator) run(ctx context.Context, op string, fn func() error) error {
+	lg := logger.FromContext(ctx)
+
+	err := mg.lock(ctx, func() error {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				mg.m.GracefulStop <- true
+			case <-done:
+			}
+		}()
+		return fn()
+	})
+
+	var dirty migrate.ErrDirty
+	switch {
+	case err == nil:
+		lg.Infof("migrate %s: done", op)
+		return nil
+	case errors.Is(err, migrate.ErrNoChange):
+		lg.Infof("migrate %s: no change", op)
+		return nil
+	case errors.As(err, &dirty):
+		return fmt.Errorf("%s: database is dirty at version %d; fix the schema manually and run `migrate force %d`: %w",
+			op, dirty.Version, dirty.Version, err)
+	default:
+		return fmt.Errorf("%s: %w", op, err)
+	}
+}
+
+// Embedded перечисляет миграции из fsys по возрастанию версии.
+func Embedded(fsys fs.FS) ([]MigrationInfo, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var list []MigrationInfo
+	for _, e := range entries {
+		m, err := source.DefaultParse(e.Name())
+		if err != nil || m.Direction != source.Up {
+			continue
+		}
+		list = append(list, MigrationInfo{Version: m.Version, Identifier: m.Identifier})
+	}
+	if len(list) == 0 {
+		return nil, ErrNoMigrations
+	}
+	return list, nil
+}
+
+// Latest возвращает версию последней миграции из fsys.
+func Latest(fsys fs.FS) (uint, error) {
+	list, err := Embedded(fsys)
+	if err != nil {
+		return 0, err
+	}
+	return list[len(list)-1].Version, nil
+}
+
+// migrateLogger передаёт сообщения golang-migrate в логгер приложения.
+type migrateLogger struct {
+	lg *logger.Logger
+}
+
+func (l migrateLogger) Printf(format string, v ...interface{}) {
+	l.lg.Infof(strings.TrimSuffix(format, "\n"), v...)
+}
+
+func (l migrateLogger) Verbose() bool {
+	return false
+}
@@ -4,13 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 	"sync"
 
 	"github.com/0sokrat0/BookAPI/internal/config"
 	"github.com/0sokrat0/BookAPI/pkg/logger"
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/0sokrat0/BookAPI/pkg/tracing"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
@@ -19,6 +19,8 @@ import (
 type Postgres struct {
 	// Экспортируем поле, чтобы можно было получить доступ к *pgxpool.Pool.
 	DB *pgxpool.Pool
+
+	migrateURL string
 }
 
 var (
@@ -27,76 +29,106 @@ var (
 )
 
 func NewPG(ctx context.Context, cfg *config.Config) (*Postgres, error) {
+	var err error
+	pgOnce.Do(func() {
+		pgInstance, err = Connect(ctx, databaseURL(cfg), cfg.Database.MaxConn, cfg.Database.MinConn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pgInstance, nil
+}
+
+// Connect создаёт пул по строке подключения postgres://. В отличие от NewPG
+// не кеширует экземпляр, поэтому подходит для тестов и утилит.
+func Connect(ctx context.Context, dsn string, maxConns, minConns int32) (*Postgres, error) {
 	lg := logger.FromContext(ctx)
 	if lg == nil {
 		return nil, errors.New("logger not found in context")
 	}
 
-	var err error
-
-	connString := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?pool_max_conns=%d&pool_min_conns=%d",
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name,
-		cfg.Database.MaxConn,
-		cfg.Database.MinConn,
-	)
-
-	pgOnce.Do(func() {
-		var db *pgxpool.Pool
-		db, err = pgxpool.New(ctx, connString)
-		if err != nil {
-			lg.Errorf("error creating pg pool", zap.Error(err))
-			return
-		}
-
-		pgInstance = &Postgres{DB: db}
-	})
-
+	poolCfg, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
+		lg.Errorf("error parsing pg config", zap.Error(err))
 		return nil, err
 	}
+	poolCfg.MaxConns = maxConns
+	poolCfg.MinConns = minConns
+	poolCfg.ConnConfig.Tracer = tracing.NewPgxTracer()
 
-	migrateString := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name,
-	)
-
-	m, err := migrate.New(
-		"file:///root/migrations",
-		migrateString)
+	db, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
-		lg.Errorf("unable to create migration", zap.Error(err))
+		lg.Errorf("error creating pg pool", zap.Error(err))
 		return nil, err
 	}
 
-	err = m.Up()
-	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		if err.Error() == "Dirty database version 1. Fix and force version." {
-			lg.Warn("Database is dirty, forcing version 1...")
-			if forceErr := m.Force(1); forceErr != nil {
-				lg.Errorf("Failed to force version: %v", forceErr)
-				return nil, forceErr
-			}
-
-			err = m.Up()
-			if err != nil && !errors.Is(err, migrate.ErrNoChange) {
-				lg.Errorf("Unable to migrate database after forcing version: %v", err)
-				return nil, err
-			}
-		} else {
-			lg.Errorf("unable to migrate database", zap.Error(err))
-			return nil, err
-		}
+	lg.Info("Pools created successfully")
+	return &Postgres{DB: db, migrateURL: dsn}, nil
+}
+
+// databaseURL собирает строку подключения из конфигурации; пароль и
+// остальные части экранируются.
+func databaseURL(cfg *config.Config) string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(cfg.Database.User, cfg.Database.Password),
+		Host:   cfg.Database.Host + ":" + strconv.Itoa(int(cfg.Database.Port)),
+		Path:   "/" + cfg.Database.Name,
+	}
+	q := url.Values{}
+	q.Set("sslmode", cfg.Database.SSLMode)
+	if cfg.Database.Schema != "" && cfg.Database.Schema != "public" {
+		q.Set("search_path", cfg.Database.Schema)
 	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
 
-	lg.Info("Pools created successfully")
-	return pgInstance, nil
+// MigrationVersion возвращает текущую версию схемы и признак незавершённой миграции.
+func (pg *Postgres) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := pg.DB.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
+
+// maxIDQuery находит наибольший ID среди таблиц, ключи которых выдаёт
+// общий счётчик приложения.
+const maxIDQuery = `
+	SELECT COALESCE(MAX(id), 0) FROM (
+		SELECT MAX(id) AS id FROM books
+		UNION ALL SELECT MAX(id) FROM authors
+		UNION ALL SELECT MAX(id) FROM readers
+		UNION ALL SELECT MAX(id) FROM reservations
+		UNION ALL SELECT MAX(id) FROM genres
+		UNION ALL SELECT MAX(id) FROM tags
+		UNION ALL SELECT MAX(id) FROM works
+		UNION ALL SELECT MAX(id) FROM publishers
+		UNION ALL SELECT MAX(id) FROM reviews
+		UNION ALL SELECT MAX(id) FROM reading_lists
+	) AS ids`
+
+// MaxID возвращает наибольший занятый ID; счётчик ID продолжает с него
+// после перезапуска.
+func (pg *Postgres) MaxID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := pg.DB.QueryRow(ctx, maxIDQuery).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to read max id: %w", err)
+	}
+	return id, nil
+}
+
+// Stats возвращает сведения о пуле соединений для проверки готовности.
+func (pg *Postgres) Stats() map[string]interface{} {
+	stat := pg.DB.Stat()
+	return map[string]interface{}{
+		"total_connections":    stat.TotalConns(),
+		"idle_connections":     stat.IdleConns(),
+		"acquired_connections": stat.AcquiredConns(),
+	}
 }
 
 func (pg *Postgres) Ping(ctx context.Context) error {
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0sokrat0/BookAPI/migrations"
+	"github.com/0sokrat0/BookAPI/pkg/db/dbmigrate"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/golang-migrate/migrate/v4/database"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+)
+
+// migrationLockID — ключ advisory lock, под которым выполняются миграции.
+// Отличается от ключа, который берёт сам golang-migrate на время одного шага:
+// наш лок держится всю операцию, включая проверку версии.
+const migrationLockID int64 = 0x626f6f6b617069 // "bookapi"
+
+// NewMigrator готовит встроенные миграции Postgres. Каждая операция
+// выполняется под advisory lock, поэтому несколько экземпляров
+// не мигрируют одновременно.
+func (pg *Postgres) NewMigrator(ctx context.Context) (*dbmigrate.Migrator, error) {
+	driver, err := database.Open(pg.migrateURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database for migrations: %w", err)
+	}
+	return dbmigrate.New(ctx, migrations.FS, "postgres", driver, pg.withMigrationLock)
+}
+
+// LatestMigration возвращает версию последней встроенной миграции Postgres.
+func (pg *Postgres) LatestMigration() (uint, error) {
+	return dbmigrate.Latest(migrations.FS)
+}
+
+func (pg *Postgres) withMigrationLock(ctx context.Context, fn func() error) error {
+	lg := logger.FromContext(ctx)
+
+	conn, err := pg.DB.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	lg.Debugf("waiting for migration lock %d", migrationLockID)
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			lg.Errorf("failed to release migration lock: %v", err)
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DBTX — общие методы *pgxpool.Pool и pgx.Tx. Репозитории принимают DBTX,
+// поэтому одинаково работают с пулом и внутри транзакции; Begin внутри
+// транзакции создаёт точку сохранения.
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
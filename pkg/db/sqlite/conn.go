@@ -0,0 +1,129 @@
+// Package sqlite открывает локальную базу SQLite и применяет её миграции.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/0sokrat0/BookAPI/internal/config"
+	sqlitemigrations "github.com/0sokrat0/BookAPI/migrations/sqlite"
+	"github.com/0sokrat0/BookAPI/pkg/db/dbmigrate"
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// SQLite — подключение к файлу базы.
+type SQLite struct {
+	DB *sql.DB
+
+	dsn string
+}
+
+// New открывает (и при необходимости создаёт) файл базы из cfg.Database.Path.
+// Внешние ключи включаются на каждом соединении, запись ждёт освобождения
+// блокировки до 5 секунд, а транзакции сразу берут блокировку на запись,
+// чтобы параллельные запросы не получали SQLITE_BUSY посреди транзакции.
+func New(ctx context.Context, cfg *config.Config) (*SQLite, error) {
+	lg := logger.FromContext(ctx)
+
+	q := url.Values{}
+	q.Add("_pragma", "foreign_keys(1)")
+	q.Add("_pragma", "busy_timeout(5000)")
+	q.Add("_pragma", "journal_mode(WAL)")
+	q.Set("_txlock", "immediate")
+	dsn := "file:" + cfg.Database.Path + "?" + q.Encode()
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", cfg.Database.Path, err)
+	}
+	lg.Infof("SQLite database opened: %s", cfg.Database.Path)
+	return &SQLite{DB: db, dsn: dsn}, nil
+}
+
+// NewMigrator готовит встроенные миграции SQLite. Файл базы принадлежит
+// одному процессу, поэтому межпроцессная блокировка не нужна. Мигратор
+// работает через отдельное подключение: golang-migrate закрывает его в Close.
+func (s *SQLite) NewMigrator(ctx context.Context) (*dbmigrate.Migrator, error) {
+	db, err := sql.Open("sqlite", s.dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database for migrations: %w", err)
+	}
+	driver, err := migratesqlite.WithInstance(db, &migratesqlite.Config{})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open database for migrations: %w", err)
+	}
+	return dbmigrate.New(ctx, sqlitemigrations.FS, "sqlite", driver, nil)
+}
+
+// MigrationVersion возвращает текущую версию схемы и признак незавершённой миграции.
+func (s *SQLite) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := s.DB.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
+
+// LatestMigration возвращает версию последней встроенной миграции SQLite.
+func (s *SQLite) LatestMigration() (uint, error) {
+	return dbmigrate.Latest(sqlitemigrations.FS)
+}
+
+// maxIDQuery находит наибольший ID среди таблиц, ключи которых выдаёт
+// общий счётчик приложения.
+const maxIDQuery = `
+	SELECT COALESCE(MAX(id), 0) FROM (
+		SELECT MAX(id) AS id FROM books
+		UNION ALL SELECT MAX(id) FROM authors
+		UNION ALL SELECT MAX(id) FROM readers
+		UNION ALL SELECT MAX(id) FROM reservations
+		UNION ALL SELECT MAX(id) FROM genres
+		UNION ALL SELECT MAX(id) FROM tags
+		UNION ALL SELECT MAX(id) FROM works
+		UNION ALL SELECT MAX(id) FROM publishers
+		UNION ALL SELECT MAX(id) FROM reviews
+		UNION ALL SELECT MAX(id) FROM reading_lists
+	) AS ids`
+
+// MaxID возвращает наибольший занятый ID; счётчик ID продолжает с него
+// после перезапуска.
+func (s *SQLite) MaxID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := s.DB.QueryRowContext(ctx, maxIDQuery).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to read max id: %w", err)
+	}
+	return id, nil
+}
+
+// Stats возвращает сведения о пуле соединений для проверки готовности.
+func (s *SQLite) Stats() map[string]interface{} {
+	stat := s.DB.Stats()
+	return map[string]interface{}{
+		"open_connections":   stat.OpenConnections,
+		"idle_connections":   stat.Idle,
+		"in_use_connections": stat.InUse,
+	}
+}
+
+func (s *SQLite) Ping(ctx context.Context) error {
+	return s.DB.PingContext(ctx)
+}
+
+func (s *SQLite) Close() {
+	s.DB.Close()
+}
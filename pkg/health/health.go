@@ -0,0 +1,113 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Статусы проверок.
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// ErrDraining возвращается, пока сервер выводится из балансировки перед остановкой.
+var ErrDraining = errors.New("server is shutting down")
+
+// CheckFunc проверяет одну зависимость. Details попадают в JSON-ответ.
+type CheckFunc func(ctx context.Context) (details map[string]interface{}, err error)
+
+// CheckResult — результат одной проверки.
+type CheckResult struct {
+	Status   string                 `json:"status"`
+	Error    string                 `json:"error,omitempty"`
+	Duration string                 `json:"duration"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report — сводный результат проверок готовности.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Checker хранит проверки готовности и признак вывода сервера из балансировки.
+type Checker struct {
+	mu       sync.RWMutex
+	checks   []namedCheck
+	draining atomic.Bool
+}
+
+// NewChecker создаёт пустой набор проверок.
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Add регистрирует проверку зависимости.
+func (c *Checker) Add(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// SetDraining переводит готовность в состояние отказа, чтобы оркестратор
+// перестал направлять трафик до остановки сервера.
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+// Draining сообщает, идёт ли остановка сервера.
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Run выполняет все проверки параллельно.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := make([]namedCheck, len(c.checks))
+	copy(checks, c.checks)
+	c.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks)+1)}
+	if c.Draining() {
+		report.Status = StatusFail
+		report.Checks["shutdown"] = CheckResult{Status: StatusFail, Error: ErrDraining.Error(), Duration: "0s"}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+			start := time.Now()
+			details, err := nc.check(ctx)
+			result := CheckResult{
+				Status:   StatusOK,
+				Duration: time.Since(start).String(),
+				Details:  details,
+			}
+			if err != nil {
+				result.Status = StatusFail
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[nc.name] = result
+			if err != nil {
+				report.Status = StatusFail
+			}
+		}(nc)
+	}
+	wg.Wait()
+	return report
+}
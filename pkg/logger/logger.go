@@ -24,17 +24,21 @@ type ctxKey string
 
 const loggerCtxKey ctxKey = "logger"
 
-// NewLogger создает логгер в зависимости от режима (prod/dev)
+// NewLogger создает логгер по настройкам: формат вывода (console/json)
+// и минимальный уровень задаются независимо от окружения приложения.
 func NewLogger(cfg *config.Config) *Logger {
 	once.Do(func() {
-		var l *zap.Logger
-		var err error
+		level, err := zapcore.ParseLevel(cfg.Logger.Level)
+		if err != nil {
+			panic(err)
+		}
 
-		if cfg.Logger.Level == "production" {
-			l, err = zap.NewProduction()
+		var config zap.Config
+		if cfg.Logger.Format == "json" {
+			config = zap.NewProductionConfig()
 		} else {
 			// Настраиваем цветной вывод для VS Code
-			config := zap.NewDevelopmentConfig()
+			config = zap.NewDevelopmentConfig()
 			config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // Цвета уровней логов
 			config.EncoderConfig.EncodeCaller = zapcore.ShortCallerEncoder      // Короткий путь к файлу
 			config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder        // Человеческое время
@@ -43,10 +47,10 @@ func NewLogger(cfg *config.Config) *Logger {
 			// Добавляем цветной вывод в терминал
 			config.EncoderConfig.ConsoleSeparator = " | "
 			config.EncoderConfig.EncodeDuration = zapcore.StringDurationEncoder
-
-			l, err = config.Build(zap.WithCaller(true)) // Включаем caller (пути файлов)
 		}
+		config.Level = zap.NewAtomicLevelAt(level)
 
+		l, err := config.Build(zap.WithCaller(true)) // Включаем caller (пути файлов)
 		if err != nil {
 			panic(err)
 		}
@@ -73,3 +77,8 @@ func FromContext(ctx context.Context) *Logger {
 func WithLogger(ctx context.Context, l *Logger) context.Context {
 	return context.WithValue(ctx, loggerCtxKey, l)
 }
+
+// With возвращает дочерний логгер с дополнительными полями.
+func (l *Logger) With(args ...interface{}) *Logger {
+	return &Logger{l.SugaredLogger.With(args...)}
+}
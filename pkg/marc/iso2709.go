@@ -0,0 +1,212 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	recordTerminator  = 0x1D
+	fieldTerminator   = 0x1E
+	subfieldDelimiter = 0x1F
+
+	leaderLen   = 24
+	dirEntryLen = 12
+	// maxRecordLen — предел длины записи: под неё в маркере пять цифр.
+	maxRecordLen = 99999
+	// maxFieldLen — предел длины поля: под неё в справочнике четыре цифры.
+	maxFieldLen = 9999
+)
+
+// Reader читает записи ISO 2709 по одной.
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read возвращает следующую запись или io.EOF. Ошибка с ErrInvalidRecord
+// означает, что повреждённая запись пропущена и можно читать дальше.
+// Переводы строк между записями, которые добавляют некоторые выгрузки,
+// игнорируются.
+func (r *Reader) Read() (*Record, error) {
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if b != '\n' && b != '\r' {
+			break
+		}
+	}
+	if err := r.r.UnreadByte(); err != nil {
+		return nil, err
+	}
+
+	head, err := r.r.Peek(5)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	length, convErr := strconv.Atoi(string(head))
+	if convErr != nil || length < leaderLen+1 {
+		// Без длины границу записи находим по терминатору.
+		if err := r.skipRecord(); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: bad record length %q", ErrInvalidRecord, head)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: truncated record", ErrInvalidRecord)
+		}
+		return nil, err
+	}
+	if data[length-1] != recordTerminator {
+		// Длина в маркере неверна; дочитываем до терминатора, чтобы
+		// следующий вызов начал с новой записи.
+		if err := r.skipRecord(); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: record length does not match terminator", ErrInvalidRecord)
+	}
+	return parseRecord(data)
+}
+
+// skipRecord пропускает всё до конца текущей записи.
+func (r *Reader) skipRecord() error {
+	for {
+		_, err := r.r.ReadSlice(recordTerminator)
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return err
+		}
+	}
+}
+
+func parseRecord(data []byte) (*Record, error) {
+	invalid := func(format string, args ...any) (*Record, error) {
+		return nil, fmt.Errorf("%w: "+format, append([]any{ErrInvalidRecord}, args...)...)
+	}
+	rec := &Record{Leader: string(data[:leaderLen])}
+	base, err := strconv.Atoi(string(data[12:17]))
+	if err != nil || base <= leaderLen || base > len(data) || data[base-1] != fieldTerminator {
+		return invalid("bad base address %q", data[12:17])
+	}
+	dir := data[leaderLen : base-1]
+	if len(dir)%dirEntryLen != 0 {
+		return invalid("directory length %d is not a multiple of %d", len(dir), dirEntryLen)
+	}
+	body := data[base : len(data)-1]
+
+	for ; len(dir) > 0; dir = dir[dirEntryLen:] {
+		tag := string(dir[:3])
+		length, err1 := strconv.Atoi(string(dir[3:7]))
+		start, err2 := strconv.Atoi(string(dir[7:12]))
+		if err1 != nil || err2 != nil || length < 1 || start < 0 || start+length > len(body) {
+			return invalid("bad directory entry for tag %s", tag)
+		}
+		field := bytes.TrimSuffix(body[start:start+length], []byte{fieldTerminator})
+
+		if isControlTag(tag) {
+			rec.ControlFields = append(rec.ControlFields, ControlField{Tag: tag, Value: string(field)})
+			continue
+		}
+		if len(field) < 2 {
+			return invalid("field %s has no indicators", tag)
+		}
+		df := DataField{Tag: tag, Ind1: string(field[0]), Ind2: string(field[1])}
+		// Всё до первого разделителя — мусор между индикаторами и подполями.
+		chunks := bytes.Split(field[2:], []byte{subfieldDelimiter})
+		for _, chunk := range chunks[1:] {
+			if len(chunk) == 0 {
+				continue
+			}
+			df.Subfields = append(df.Subfields, Subfield{Code: string(chunk[0]), Value: string(chunk[1:])})
+		}
+		rec.DataFields = append(rec.DataFields, df)
+	}
+	return rec, nil
+}
+
+// Writer пишет записи ISO 2709.
+type Writer struct {
+	w io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write кодирует запись. Длина записи, базовый адрес и длины в справочнике
+// вычисляются заново; остальные позиции маркера берутся из rec.Leader.
+func (w *Writer) Write(rec *Record) error {
+	var dir, body bytes.Buffer
+	addField := func(tag string, data []byte) error {
+		if len(tag) != 3 {
+			return fmt.Errorf("marc: bad tag %q", tag)
+		}
+		if len(data)+1 > maxFieldLen {
+			return fmt.Errorf("marc: field %s is longer than %d bytes", tag, maxFieldLen)
+		}
+		fmt.Fprintf(&dir, "%s%04d%05d", tag, len(data)+1, body.Len())
+		body.Write(data)
+		body.WriteByte(fieldTerminator)
+		return nil
+	}
+
+	for _, f := range rec.ControlFields {
+		if err := addField(f.Tag, []byte(f.Value)); err != nil {
+			return err
+		}
+	}
+	var field bytes.Buffer
+	for _, f := range rec.DataFields {
+		field.Reset()
+		field.WriteByte(indicator(f.Ind1))
+		field.WriteByte(indicator(f.Ind2))
+		for _, sf := range f.Subfields {
+			field.WriteByte(subfieldDelimiter)
+			field.WriteString(sf.Code)
+			field.WriteString(sf.Value)
+		}
+		if err := addField(f.Tag, field.Bytes()); err != nil {
+			return err
+		}
+	}
+	dir.WriteByte(fieldTerminator)
+
+	base := leaderLen + dir.Len()
+	total := base + body.Len() + 1
+	if total > maxRecordLen {
+		return fmt.Errorf("marc: record is longer than %d bytes", maxRecordLen)
+	}
+	leader := []byte(DefaultLeader)
+	copy(leader, rec.Leader)
+	copy(leader[0:5], fmt.Sprintf("%05d", total))
+	copy(leader[10:12], "22")
+	copy(leader[12:17], fmt.Sprintf("%05d", base))
+	copy(leader[20:24], "4500")
+
+	out := make([]byte, 0, total)
+	out = append(out, leader...)
+	out = append(out, dir.Bytes()...)
+	out = append(out, body.Bytes()...)
+	out = append(out, recordTerminator)
+	_, err := w.w.Write(out)
+	return err
+}
+
+// indicator возвращает индикатор или пробел, если он не задан.
+func indicator(s string) byte {
+	if s == "" {
+		return ' '
+	}
+	return s[0]
+}
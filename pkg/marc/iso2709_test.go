@@ -0,0 +1,178 @@
+package marc_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/0sokrat0/BookAPI/pkg/marc"
+)
+
+func sampleRecords() []*marc.Record {
+	first := marc.NewRecord()
+	first.AddControlField("001", "42")
+	first.AddDataField("020", ' ', ' ', "a", "9785170000000")
+	first.AddDataField("100", '1', ' ', "a", "Толстой, Лев")
+	first.AddDataField("245", '1', '0', "a", "Война и мир", "b", "роман")
+	first.AddDataField("264", ' ', '1', "c", "1869")
+
+	second := marc.NewRecord()
+	second.AddControlField("001", "43")
+	second.AddDataField("245", '0', '0', "a", "Untitled")
+	return []*marc.Record{first, second}
+}
+
+// encode пишет записи в ISO 2709.
+func encode(t *testing.T, recs ...*marc.Record) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := marc.NewWriter(&buf)
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	return buf.Bytes()
+}
+
+// readAll читает записи до io.EOF; ошибки повреждённых записей собираются
+// отдельно.
+func readAll(t *testing.T, data []byte) ([]*marc.Record, []error) {
+	t.Helper()
+	r := marc.NewReader(bytes.NewReader(data))
+	var recs []*marc.Record
+	var invalid []error
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return recs, invalid
+		}
+		if errors.Is(err, marc.ErrInvalidRecord) {
+			invalid = append(invalid, err)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		recs = append(recs, rec)
+	}
+}
+
+// sameFields сравнивает записи без маркера: длина и базовый адрес в нём
+// проставляются при записи.
+func sameFields(t *testing.T, got, want *marc.Record) {
+	t.Helper()
+	if !reflect.DeepEqual(got.ControlFields, want.ControlFields) {
+		t.Errorf("control fields = %+v, want %+v", got.ControlFields, want.ControlFields)
+	}
+	if !reflect.DeepEqual(got.DataFields, want.DataFields) {
+		t.Errorf("data fields = %+v, want %+v", got.DataFields, want.DataFields)
+	}
+}
+
+func TestISO2709RoundTrip(t *testing.T) {
+	want := sampleRecords()
+	// Перевод строки между записями оставляют некоторые выгрузки.
+	data := append(encode(t, want[0]), '\n')
+	data = append(data, encode(t, want[1])...)
+
+	got, invalid := readAll(t, data)
+	if len(invalid) != 0 {
+		t.Fatalf("invalid records: %v", invalid)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("read %d records, want %d", len(got), len(want))
+	}
+	for i := range want {
+		sameFields(t, got[i], want[i])
+	}
+	if len(got[0].Leader) != 24 || got[0].Leader[5:10] != marc.DefaultLeader[5:10] {
+		t.Errorf("leader = %q", got[0].Leader)
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	want := sampleRecords()
+	var buf bytes.Buffer
+	w := marc.NewXMLWriter(&buf)
+	for _, rec := range want {
+		if err := w.Write(rec); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	r := marc.NewXMLReader(&buf)
+	for i := range want {
+		got, err := r.Read()
+		if err != nil {
+			t.Fatalf("read record %d: %v", i, err)
+		}
+		sameFields(t, got, want[i])
+	}
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		t.Fatalf("read after last record: %v, want io.EOF", err)
+	}
+}
+
+func TestISO2709Malformed(t *testing.T) {
+	valid := encode(t, sampleRecords()[1])
+	// Справочник начинается сразу после 24 байт маркера: тег, длина поля
+	// (4 цифры), начало поля (5 цифр).
+	const entry = 24
+	corrupt := func(f func(b []byte) []byte) []byte {
+		return f(bytes.Clone(valid))
+	}
+
+	// Повреждённая запись пропускается, следующая читается; у обрезанной
+	// записи следующей нет.
+	cases := map[string]struct {
+		data      []byte
+		wantValid int
+	}{
+		"negative field start": {corrupt(func(b []byte) []byte {
+			copy(b[entry+7:entry+12], "-0001")
+			return b
+		}), 1},
+		"field past body": {corrupt(func(b []byte) []byte {
+			copy(b[entry+7:entry+12], "99999")
+			return b
+		}), 1},
+		"negative field length": {corrupt(func(b []byte) []byte {
+			copy(b[entry+3:entry+7], "-001")
+			return b
+		}), 1},
+		"bad base address": {corrupt(func(b []byte) []byte {
+			copy(b[12:17], "-0001")
+			return b
+		}), 1},
+		"bad record length": {corrupt(func(b []byte) []byte {
+			copy(b[0:5], "abcde")
+			return b
+		}), 1},
+		"length does not match terminator": {corrupt(func(b []byte) []byte {
+			copy(b[0:5], "00030")
+			return b
+		}), 1},
+		"truncated": {valid[:len(valid)-5], 0},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stream := bytes.Clone(tc.data)
+			if tc.wantValid > 0 {
+				stream = append(stream, valid...)
+			}
+			got, invalid := readAll(t, stream)
+			if len(invalid) != 1 {
+				t.Fatalf("invalid records = %v, want exactly one", invalid)
+			}
+			if len(got) != tc.wantValid {
+				t.Fatalf("read %d valid records, want %d", len(got), tc.wantValid)
+			}
+		})
+	}
+}
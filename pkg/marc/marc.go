@@ -0,0 +1,89 @@
+// Package marc читает и пишет библиографические записи MARC 21 в бинарном
+// формате ISO 2709 и в MARCXML. Пакет не знает о смысле полей: сопоставление
+// с книгами выполняет вызывающий код.
+package marc
+
+import "errors"
+
+// ErrInvalidRecord — запись повреждена. Читатель уже перешёл к следующей
+// записи, поэтому чтение можно продолжать.
+var ErrInvalidRecord = errors.New("invalid marc record")
+
+// DefaultLeader — маркер новой библиографической записи монографии в UTF-8.
+// Длина записи и базовый адрес проставляются при записи в ISO 2709.
+const DefaultLeader = "00000nam a2200000 i 4500"
+
+// Record — запись MARC. Управляющие поля (001–009) хранятся отдельно
+// от полей данных, порядок полей внутри групп сохраняется.
+type Record struct {
+	Leader        string         `xml:"leader"`
+	ControlFields []ControlField `xml:"controlfield"`
+	DataFields    []DataField    `xml:"datafield"`
+}
+
+// ControlField — управляющее поле без индикаторов и подполей.
+type ControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+// DataField — поле данных с двумя индикаторами и подполями.
+type DataField struct {
+	Tag       string     `xml:"tag,attr"`
+	Ind1      string     `xml:"ind1,attr"`
+	Ind2      string     `xml:"ind2,attr"`
+	Subfields []Subfield `xml:"subfield"`
+}
+
+// Subfield — подполе с однобуквенным кодом.
+type Subfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// NewRecord создаёт пустую запись с DefaultLeader.
+func NewRecord() *Record {
+	return &Record{Leader: DefaultLeader}
+}
+
+// AddControlField добавляет управляющее поле.
+func (r *Record) AddControlField(tag, value string) {
+	r.ControlFields = append(r.ControlFields, ControlField{Tag: tag, Value: value})
+}
+
+// AddDataField добавляет поле данных; subfields — пары код, значение.
+// Подполя с пустым значением пропускаются.
+func (r *Record) AddDataField(tag string, ind1, ind2 byte, subfields ...string) {
+	field := DataField{Tag: tag, Ind1: string(ind1), Ind2: string(ind2)}
+	for i := 0; i+1 < len(subfields); i += 2 {
+		if subfields[i+1] != "" {
+			field.Subfields = append(field.Subfields, Subfield{Code: subfields[i], Value: subfields[i+1]})
+		}
+	}
+	r.DataFields = append(r.DataFields, field)
+}
+
+// ControlField возвращает значение первого управляющего поля tag.
+func (r *Record) ControlField(tag string) string {
+	for _, f := range r.ControlFields {
+		if f.Tag == tag {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// Subfield возвращает значение первого подполя code.
+func (f *DataField) Subfield(code string) string {
+	for _, sf := range f.Subfields {
+		if sf.Code == code {
+			return sf.Value
+		}
+	}
+	return ""
+}
+
+// isControlTag сообщает, что tag — управляющее поле (00X).
+func isControlTag(tag string) bool {
+	return len(tag) == 3 && tag[0] == '0' && tag[1] == '0'
+}
@@ -0,0 +1,95 @@
+package marc
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// Namespace — пространство имён MARCXML.
+const Namespace = "http://www.loc.gov/MARC21/slim"
+
+// XMLReader читает записи MARCXML по одной: и <collection> с записями,
+// и одиночную <record>.
+type XMLReader struct {
+	d *xml.Decoder
+}
+
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{d: xml.NewDecoder(r)}
+}
+
+// Read возвращает следующую запись или io.EOF. Ошибка разбора XML фатальна:
+// продолжить с середины документа нельзя.
+func (r *XMLReader) Read() (*Record, error) {
+	for {
+		tok, err := r.d.Token()
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+		var rec Record
+		if err := r.d.DecodeElement(&rec, &start); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		return &rec, nil
+	}
+}
+
+// XMLWriter пишет записи внутри <collection>; Close закрывает документ.
+type XMLWriter struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	started bool
+}
+
+func NewXMLWriter(w io.Writer) *XMLWriter {
+	return &XMLWriter{w: w, enc: xml.NewEncoder(w)}
+}
+
+func (w *XMLWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	if _, err := io.WriteString(w.w, xml.Header+`<collection xmlns="`+Namespace+`">`+"\n"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Write кодирует запись. Пустой маркер заменяется DefaultLeader.
+func (w *XMLWriter) Write(rec *Record) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if rec.Leader == "" {
+		copied := *rec
+		copied.Leader = DefaultLeader
+		rec = &copied
+	}
+	// Пространство имён наследуется от <collection>.
+	if err := w.enc.EncodeElement(rec, xml.StartElement{Name: xml.Name{Local: "record"}}); err != nil {
+		return err
+	}
+	if err := w.enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.w, "\n")
+	return err
+}
+
+// Close дописывает </collection>; пустая коллекция тоже корректный документ.
+func (w *XMLWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w.w, "</collection>\n")
+	return err
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "bookapi"
+
+// Registry — реестр метрик приложения, отдаваемый на /metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "HTTP requests currently being served.",
+	})
+
+	// ReservationsCreated считает успешно созданные бронирования.
+	ReservationsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_created_total",
+		Help:      "Reservations created.",
+	})
+
+	// OverdueLoans — число невозвращённых бронирований с истёкшим сроком,
+	// обновляется фоновой задачей.
+	OverdueLoans = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "loans_overdue",
+		Help:      "Reservations whose end date has passed and whose book has not been returned.",
+	})
+
+	// FailedLogins считает неудачные попытки входа по способу входа.
+	FailedLogins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "failed_logins_total",
+		Help:      "Failed login attempts by method (password, two_factor, oidc).",
+	}, []string{"method"})
+)
+
+// Способы входа для метки FailedLogins.
+const (
+	LoginPassword  = "password"
+	LoginTwoFactor = "two_factor"
+	LoginOIDC      = "oidc"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		httpInFlight,
+		ReservationsCreated,
+		OverdueLoans,
+		FailedLogins,
+	)
+}
+
+// unmatchedRoute — метка для запросов, не попавших ни в один маршрут,
+// чтобы произвольные URL не раздували число временных рядов.
+const unmatchedRoute = "unmatched"
+
+// Middleware собирает число и длительность запросов по шаблону маршрута.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		httpInFlight.Inc()
+		defer httpInFlight.Dec()
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			if fe, ok := err.(*fiber.Error); ok {
+				status = fe.Code
+			}
+		}
+		// Если ни один маршрут не подошёл, текущим остаётся последний middleware,
+		// смонтированный на "/" (собственного корневого маршрута у API нет).
+		route := c.Route().Path
+		if route == "/" && status == fiber.StatusNotFound {
+			route = unmatchedRoute
+		}
+
+		code := strconv.Itoa(status)
+		httpRequests.WithLabelValues(c.Method(), route, code).Inc()
+		httpDuration.WithLabelValues(c.Method(), route, code).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// Handler отдаёт метрики в формате Prometheus.
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
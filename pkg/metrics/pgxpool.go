@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector снимает статистику пула pgx в момент опроса.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquired     *prometheus.Desc
+	idle         *prometheus.Desc
+	total        *prometheus.Desc
+	max          *prometheus.Desc
+	acquires     *prometheus.Desc
+	waits        *prometheus.Desc
+	waitDuration *prometheus.Desc
+	canceled     *prometheus.Desc
+}
+
+// RegisterPool добавляет в реестр метрики пула соединений PostgreSQL.
+func RegisterPool(pool *pgxpool.Pool) error {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+	return Registry.Register(&poolCollector{
+		pool:         pool,
+		acquired:     desc("acquired_connections", "Connections currently acquired from the pool."),
+		idle:         desc("idle_connections", "Idle connections in the pool."),
+		total:        desc("total_connections", "Total connections in the pool."),
+		max:          desc("max_connections", "Maximum pool size."),
+		acquires:     desc("acquires_total", "Successful connection acquisitions."),
+		waits:        desc("empty_acquires_total", "Acquisitions that had to wait for a connection."),
+		waitDuration: desc("acquire_duration_seconds_total", "Total time spent acquiring connections."),
+		canceled:     desc("canceled_acquires_total", "Acquisitions canceled by the caller's context."),
+	})
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquired
+	ch <- c.idle
+	ch <- c.total
+	ch <- c.max
+	ch <- c.acquires
+	ch <- c.waits
+	ch <- c.waitDuration
+	ch <- c.canceled
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.waits, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceled, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
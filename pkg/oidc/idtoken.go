@@ -0,0 +1,316 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha512" // регистрирует SHA-384/512 для RS384/RS512
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Claims — проверенные утверждения ID token.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// AMR — методы аутентификации (RFC 8176), ACR — её уровень.
+	AMR []string
+	ACR string
+	Raw map[string]interface{}
+}
+
+// StringList возвращает значение утверждения как список строк
+// (IdP передают группы и массивом, и одной строкой).
+func (c *Claims) StringList(name string) []string {
+	switch v := c.Raw[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type keySet struct {
+	keys map[string]crypto.PublicKey
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// VerifyIDToken проверяет подпись (RS256 или ES256), issuer, audience,
+// срок действия и nonce.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawToken, nonce string) (*Claims, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed id token")
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed id token header: %w", err)
+	}
+
+	key, err := p.publicKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("malformed id token signature")
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("malformed id token payload: %w", err)
+	}
+	if err := p.validateClaims(raw, nonce); err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{Raw: raw}
+	claims.Subject, _ = raw["sub"].(string)
+	claims.Email, _ = raw["email"].(string)
+	claims.Name, _ = raw["name"].(string)
+	if claims.Name == "" {
+		claims.Name, _ = raw["preferred_username"].(string)
+	}
+	claims.AMR = claims.StringList("amr")
+	claims.ACR, _ = raw["acr"].(string)
+	switch v := raw["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = v
+	case string:
+		claims.EmailVerified = v == "true"
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+	return claims, nil
+}
+
+func (p *Provider) validateClaims(raw map[string]interface{}, nonce string) error {
+	iss, _ := raw["iss"].(string)
+	if strings.TrimRight(iss, "/") != p.cfg.IssuerURL {
+		return fmt.Errorf("id token issuer mismatch: %q", iss)
+	}
+
+	var audiences []string
+	switch v := raw["aud"].(type) {
+	case string:
+		audiences = []string{v}
+	case []interface{}:
+		for _, a := range v {
+			if s, ok := a.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+	}
+	found := false
+	for _, a := range audiences {
+		if a == p.cfg.ClientID {
+			found = true
+		}
+	}
+	if !found {
+		return errors.New("id token audience mismatch")
+	}
+	if len(audiences) > 1 {
+		if azp, _ := raw["azp"].(string); azp != p.cfg.ClientID {
+			return errors.New("id token authorized party mismatch")
+		}
+	}
+
+	now := time.Now()
+	exp, ok := raw["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(clockLeeway)) {
+		return errors.New("id token expired")
+	}
+	if iat, ok := raw["iat"].(float64); ok && time.Unix(int64(iat), 0).After(now.Add(clockLeeway)) {
+		return errors.New("id token issued in the future")
+	}
+	if got, _ := raw["nonce"].(string); got != nonce {
+		return errors.New("id token nonce mismatch")
+	}
+	return nil
+}
+
+// publicKey ищет ключ по kid; при промахе JWKS перечитывается один раз,
+// чтобы подхватить ротацию ключей на стороне IdP.
+func (p *Provider) publicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	keys := p.keys
+	p.mu.Unlock()
+	if keys != nil {
+		if key, ok := keys.lookup(kid); ok {
+			return key, nil
+		}
+	}
+
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	if key, ok := keys.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("no signing key for kid %q", kid)
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) (*keySet, error) {
+	disc, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, disc.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.doJSON(req, &doc); err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	set := &keySet{keys: make(map[string]crypto.PublicKey)}
+	for _, k := range doc.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		set.keys[k.Kid] = key
+	}
+	return set, nil
+}
+
+func (s *keySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if key, ok := s.keys[kid]; ok {
+		return key, true
+	}
+	// Токен без kid допустим, если у IdP единственный ключ.
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, signature []byte) error {
+	digest := func(h crypto.Hash) []byte {
+		hasher := h.New()
+		hasher.Write([]byte(signed))
+		return hasher.Sum(nil)
+	}
+	switch alg {
+	case "RS256", "RS384", "RS512":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("id token key type mismatch")
+		}
+		h := map[string]crypto.Hash{"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512}[alg]
+		if err := rsa.VerifyPKCS1v15(pub, h, digest(h), signature); err != nil {
+			return errors.New("invalid id token signature")
+		}
+		return nil
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(signature) != 64 {
+			return errors.New("id token key type mismatch")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(pub, digest(crypto.SHA256), r, s) {
+			return errors.New("invalid id token signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported id token algorithm %q", alg)
+	}
+}
+
+func decodeSegment(seg string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,268 @@
+package oidc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownState = errors.New("unknown or expired oidc state")
+	ErrNotEnabled   = errors.New("oidc login is not configured")
+)
+
+// Config — параметры клиента OpenID Connect.
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// StateKey — ключ HMAC для cookie с параметрами начатого входа. У всех
+	// реплик он должен совпадать; без ключа берётся случайный, и вход,
+	// начатый на одной реплике, не завершится на другой.
+	StateKey []byte
+}
+
+// Discovery — нужная часть документа .well-known/openid-configuration.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// StateCookie — имя cookie, в которой браузер хранит параметры начатого
+// входа до возврата с IdP. Она привязывает ответ IdP к браузеру, начавшему
+// вход, и защищает от подмены входа (login CSRF).
+const StateCookie = "bookapi_oidc_state"
+
+// pendingAuth — параметры начатого входа, подписанные StateKey.
+type pendingAuth struct {
+	State     string `json:"s"`
+	Nonce     string `json:"n"`
+	Verifier  string `json:"v"`
+	ExpiresAt int64  `json:"e"`
+}
+
+// Provider реализует authorization code flow с PKCE.
+// Документ discovery и ключи JWKS загружаются лениво при первом обращении,
+// поэтому сервер стартует, даже если IdP временно недоступен.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *Discovery
+	keys      *keySet
+}
+
+const (
+	// StateTTL — срок, за который вход нужно завершить на IdP.
+	StateTTL     = 10 * time.Minute
+	clockLeeway  = time.Minute
+	maxBodyBytes = 1 << 20
+)
+
+// NewProvider создаёт клиента OIDC.
+func NewProvider(cfg Config, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	cfg.IssuerURL = strings.TrimRight(cfg.IssuerURL, "/")
+	if len(cfg.StateKey) == 0 {
+		cfg.StateKey = make([]byte, 32)
+		rand.Read(cfg.StateKey)
+	}
+	return &Provider{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+// AuthCodeURL начинает вход: возвращает адрес страницы авторизации IdP и
+// значение cookie StateCookie с подписанными state, nonce и PKCE verifier.
+func (p *Provider) AuthCodeURL(ctx context.Context) (authURL, cookie string, err error) {
+	disc, err := p.Discover(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	var pending pendingAuth
+	for _, v := range []*string{&pending.State, &pending.Nonce, &pending.Verifier} {
+		if *v, err = randomString(); err != nil {
+			return "", "", err
+		}
+	}
+	pending.ExpiresAt = time.Now().Add(StateTTL).Unix()
+	cookie, err = p.signState(pending)
+	if err != nil {
+		return "", "", err
+	}
+
+	challenge := sha256.Sum256([]byte(pending.Verifier))
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.cfg.ClientID)
+	params.Set("redirect_uri", p.cfg.RedirectURL)
+	params.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	params.Set("state", pending.State)
+	params.Set("nonce", pending.Nonce)
+	params.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	params.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(disc.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return disc.AuthorizationEndpoint + sep + params.Encode(), cookie, nil
+}
+
+// Exchange проверяет, что state из ответа IdP совпадает с cookie браузера,
+// обменивает код авторизации на токены и проверяет ID token.
+func (p *Provider) Exchange(ctx context.Context, cookie, state, code string) (*Claims, error) {
+	pending, err := p.openState(cookie)
+	if err != nil || !hmac.Equal([]byte(pending.State), []byte(state)) {
+		return nil, ErrUnknownState
+	}
+
+	disc, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", pending.Verifier)
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, disc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := p.doJSON(req, &tokens); err != nil {
+		return nil, fmt.Errorf("token exchange failed: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+	return p.VerifyIDToken(ctx, tokens.IDToken, pending.Nonce)
+}
+
+// Discover загружает и кэширует документ discovery.
+func (p *Provider) Discover(ctx context.Context) (*Discovery, error) {
+	if p.cfg.IssuerURL == "" || p.cfg.ClientID == "" {
+		return nil, ErrNotEnabled
+	}
+	p.mu.Lock()
+	cached := p.discovery
+	p.mu.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.IssuerURL+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var disc Discovery
+	if err := p.doJSON(req, &disc); err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	if strings.TrimRight(disc.Issuer, "/") != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("oidc discovery issuer mismatch: %q", disc.Issuer)
+	}
+	if disc.AuthorizationEndpoint == "" || disc.TokenEndpoint == "" || disc.JWKSURI == "" {
+		return nil, errors.New("oidc discovery document is incomplete")
+	}
+
+	p.mu.Lock()
+	p.discovery = &disc
+	p.mu.Unlock()
+	return &disc, nil
+}
+
+func (p *Provider) doJSON(req *http.Request, out interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
+
+// signState кодирует параметры входа как payload.signature в base64url.
+func (p *Provider) signState(pending pendingAuth) (string, error) {
+	payload, err := json.Marshal(pending)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(p.stateMAC(encoded)), nil
+}
+
+// openState проверяет подпись и срок cookie и возвращает параметры входа.
+func (p *Provider) openState(cookie string) (*pendingAuth, error) {
+	encoded, sig, ok := strings.Cut(cookie, ".")
+	if !ok {
+		return nil, ErrUnknownState
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, p.stateMAC(encoded)) {
+		return nil, ErrUnknownState
+	}
+	var pending pendingAuth
+	if err := decodeSegment(encoded, &pending); err != nil {
+		return nil, ErrUnknownState
+	}
+	if pending.State == "" || time.Now().After(time.Unix(pending.ExpiresAt, 0)) {
+		return nil, ErrUnknownState
+	}
+	return &pending, nil
+}
+
+func (p *Provider) stateMAC(encoded string) []byte {
+	mac := hmac.New(sha256.New, p.cfg.StateKey)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+func randomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
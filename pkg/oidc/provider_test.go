@@ -0,0 +1,214 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/pkg/oidc"
+)
+
+const clientID = "bookapi"
+
+// stubIdP — минимальный провайдер: discovery, JWKS и token endpoint.
+// Страницу авторизации заменяет authorize: она выдаёт код для запроса,
+// как если бы пользователь вошёл на IdP.
+type stubIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+type authorization struct {
+	nonce     string
+	challenge string
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &stubIdP{t: t, key: key, codes: make(map[string]authorization)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"keys": []map[string]string{{
+			"kid": "k1",
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize проверяет адрес авторизации и возвращает код и state.
+func (idp *stubIdP) authorize(authURL string) (code, state string) {
+	idp.t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	q := u.Query()
+	if u.Path != "/authorize" || q.Get("client_id") != clientID || q.Get("code_challenge_method") != "S256" {
+		idp.t.Fatalf("unexpected authorization url %s", authURL)
+	}
+	code = "code-" + q.Get("state")
+	idp.mu.Lock()
+	idp.codes[code] = authorization{nonce: q.Get("nonce"), challenge: q.Get("code_challenge")}
+	idp.mu.Unlock()
+	return code, q.Get("state")
+}
+
+func (idp *stubIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	idp.mu.Lock()
+	auth, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(verifier[:]) != auth.challenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	now := time.Now()
+	writeJSON(w, map[string]string{"id_token": idp.sign(map[string]interface{}{
+		"iss":            idp.server.URL,
+		"aud":            clientID,
+		"sub":            "user-1",
+		"email":          "reader@example.com",
+		"email_verified": true,
+		"amr":            []string{"pwd", "otp"},
+		"nonce":          auth.nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Minute).Unix(),
+	})})
+}
+
+func (idp *stubIdP) sign(claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, digest[:])
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func (idp *stubIdP) provider(stateKey string) *oidc.Provider {
+	return oidc.NewProvider(oidc.Config{
+		IssuerURL:   idp.server.URL,
+		ClientID:    clientID,
+		RedirectURL: "http://localhost/auth/oidc/callback",
+		Scopes:      []string{"openid", "email"},
+		StateKey:    []byte(stateKey),
+	}, idp.server.Client())
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestProviderLogin(t *testing.T) {
+	idp := newStubIdP(t)
+	ctx := context.Background()
+	started := idp.provider("replica-key")
+	// Вход завершает другая реплика с тем же ключом.
+	finished := idp.provider("replica-key")
+
+	authURL, cookie, err := started.AuthCodeURL(ctx)
+	if err != nil {
+		t.Fatalf("auth code url: %v", err)
+	}
+	code, state := idp.authorize(authURL)
+	claims, err := finished.Exchange(ctx, cookie, state, code)
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if claims.Subject != "user-1" || claims.Email != "reader@example.com" || !claims.EmailVerified {
+		t.Fatalf("claims = %+v", claims)
+	}
+	if strings.Join(claims.AMR, ",") != "pwd,otp" {
+		t.Fatalf("amr = %v", claims.AMR)
+	}
+}
+
+func TestProviderRejectsForeignState(t *testing.T) {
+	idp := newStubIdP(t)
+	ctx := context.Background()
+	provider := idp.provider("replica-key")
+
+	// Жертва начала свой вход; атакующий подсовывает ей код и state своего.
+	_, victimCookie, err := provider.AuthCodeURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attackerURL, attackerCookie, err := provider.AuthCodeURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, state := idp.authorize(attackerURL)
+
+	payload, sig, _ := strings.Cut(attackerCookie, ".")
+	_, otherCookie, err := idp.provider("other-key").AuthCodeURL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]string{
+		"no cookie":         "",
+		"other login":       victimCookie,
+		"tampered payload":  payload + "x." + sig,
+		"tampered sig":      payload + ".AAAA",
+		"signed by another": otherCookie,
+	}
+	for name, cookie := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := provider.Exchange(ctx, cookie, state, code); !errors.Is(err, oidc.ErrUnknownState) {
+				t.Fatalf("exchange error = %v, want ErrUnknownState", err)
+			}
+		})
+	}
+
+	// Код не израсходован отклонёнными попытками: владелец cookie входит.
+	if _, err := provider.Exchange(ctx, attackerCookie, state, code); err != nil {
+		t.Fatalf("exchange with matching cookie: %v", err)
+	}
+}
@@ -9,6 +9,7 @@ type BaseResponse struct {
 
 // ErrorResponse — формат ответа в случае ошибки
 type ErrorResponse struct {
-	Code    int    `json:"code" example:"400"`
-	Message string `json:"message" example:"Bad Request"`
+	Code      int    `json:"code" example:"400"`
+	Message   string `json:"message" example:"Bad Request"`
+	RequestID string `json:"request_id,omitempty" example:"4f1c2a9e0b7d4e3f8a6b5c4d3e2f1a0b"`
 }
@@ -0,0 +1,35 @@
+package totp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	recoveryCodeLen  = 10
+	recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// GenerateRecoveryCode создаёт одноразовый код восстановления вида
+// xxxxx-xxxxx без похожих друг на друга символов.
+func GenerateRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = recoveryAlphabet[int(b)%len(recoveryAlphabet)]
+	}
+	half := recoveryCodeLen / 2
+	return string(buf[:half]) + "-" + string(buf[half:]), nil
+}
+
+// HashRecoveryCode нормализует ввод (регистр, дефисы, пробелы) и возвращает SHA-256.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,103 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0sokrat0/BookAPI/pkg/totp"
+)
+
+// rfcSecret — ключ SHA-1 из приложения B RFC 6238 в кодировке base32.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// Векторы RFC 6238 даны для восьми цифр; шестизначный код — их последние
+// шесть цифр.
+func TestCodeAtRFC6238(t *testing.T) {
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, v := range vectors {
+		step := totp.Step(time.Unix(v.unix, 0))
+		got, err := totp.CodeAt(rfcSecret, step)
+		if err != nil {
+			t.Fatalf("code at %d: %v", v.unix, err)
+		}
+		if want := v.code[len(v.code)-totp.Digits:]; got != want {
+			t.Errorf("code at %d = %s, want %s", v.unix, got, want)
+		}
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	current := totp.Step(now)
+	codeAt := func(step int64) string {
+		code, err := totp.CodeAt(rfcSecret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return code
+	}
+
+	// Соседние шаги принимаются и возвращают свой номер шага.
+	for _, step := range []int64{current - 1, current, current + 1} {
+		got, ok := totp.Validate(rfcSecret, codeAt(step), now, 1)
+		if !ok || got != step {
+			t.Errorf("step %d: validate = %d, %v", step, got, ok)
+		}
+	}
+	// Шаги за пределами окна отклоняются.
+	for _, step := range []int64{current - 2, current + 2} {
+		if _, ok := totp.Validate(rfcSecret, codeAt(step), now, 1); ok {
+			t.Errorf("step %d accepted outside the skew window", step)
+		}
+	}
+	if _, ok := totp.Validate(rfcSecret, codeAt(current+1), now, 0); ok {
+		t.Error("next step accepted with zero skew")
+	}
+	if _, ok := totp.Validate(rfcSecret, " "+codeAt(current)+" ", now, 0); !ok {
+		t.Error("surrounding spaces rejected")
+	}
+	if _, ok := totp.Validate(rfcSecret, "12345", now, 1); ok {
+		t.Error("short code accepted")
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 20 {
+		code, err := totp.GenerateRecoveryCode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, second, ok := strings.Cut(code, "-")
+		if !ok || len(first) != 5 || len(second) != 5 || strings.ContainsAny(first+second, "01ilo") {
+			t.Fatalf("malformed recovery code %q", code)
+		}
+		if seen[code] {
+			t.Fatalf("recovery code %q repeated", code)
+		}
+		seen[code] = true
+	}
+
+	// Хэш не зависит от регистра, дефисов и пробелов, которые вводит читатель.
+	want := totp.HashRecoveryCode("abcde-fghjk")
+	for _, input := range []string{"abcdefghjk", "ABCDE-FGHJK", " abcde fghjk "} {
+		if got := totp.HashRecoveryCode(input); got != want {
+			t.Errorf("hash of %q differs from the canonical form", input)
+		}
+	}
+	if totp.HashRecoveryCode("abcde-fghjm") == want {
+		t.Error("different codes share a hash")
+	}
+}
@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"strings"
+
+	"github.com/0sokrat0/BookAPI/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware открывает серверный спан на каждый запрос, продолжая трассу из
+// заголовка traceparent, и кладёт в контекст логгер с полями trace_id и span_id.
+// Должен подключаться после middleware, добавляющего логгер в контекст.
+func Middleware() fiber.Handler {
+	tracer := otel.Tracer(instrumentationName)
+	return func(c *fiber.Ctx) error {
+		headers := propagation.MapCarrier{}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			headers[strings.ToLower(string(key))] = string(value)
+		})
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headers)
+
+		ctx, span := tracer.Start(ctx, "HTTP "+c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+				semconv.ClientAddress(c.IP()),
+			),
+		)
+		defer span.End()
+
+		if sc := span.SpanContext(); sc.IsValid() {
+			lg := logger.FromContext(ctx).With(
+				"trace_id", sc.TraceID().String(),
+				"span_id", sc.SpanID().String(),
+			)
+			ctx = logger.WithLogger(ctx, lg)
+		}
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			if fe, ok := err.(*fiber.Error); ok {
+				status = fe.Code
+			}
+			span.RecordError(err)
+		}
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, fiber.ErrInternalServerError.Message)
+		}
+		return err
+	}
+}